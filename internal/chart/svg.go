@@ -0,0 +1,161 @@
+// Package chart renders small, dependency-free SVG charts for reports.
+package chart
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	cellSize    = 12
+	cellGap     = 2
+	labelWidth  = 30
+	labelHeight = 16
+)
+
+// heatmapPalette runs from empty to busiest, matching the terminal heatmap shades.
+var heatmapPalette = []string{"#ebedf0", "#c6e48b", "#7bc96f", "#239a3b", "#196127"}
+
+// HeatmapCell is one day in a calendar heatmap.
+type HeatmapCell struct {
+	Date  time.Time
+	Value int
+}
+
+// Point is one labelled sample on a line chart.
+type Point struct {
+	Label string
+	Value int
+}
+
+// Heatmap writes a week-per-column calendar heatmap. Cells are expected in
+// chronological order; rows run Monday to Sunday.
+func Heatmap(w io.Writer, title string, cells []HeatmapCell) error {
+	if len(cells) == 0 {
+		return writeEmpty(w, title)
+	}
+
+	max := 0
+	for _, cell := range cells {
+		if cell.Value > max {
+			max = cell.Value
+		}
+	}
+
+	first := cells[0].Date
+	firstMonday := first.AddDate(0, 0, -weekdayOffset(first))
+	weeks := daysBetween(firstMonday, cells[len(cells)-1].Date)/7 + 1
+
+	width := labelWidth + weeks*(cellSize+cellGap)
+	height := labelHeight*2 + 7*(cellSize+cellGap)
+
+	var b strings.Builder
+	writeHeader(&b, width, height, title)
+	for i, day := range []string{"Mon", "Wed", "Fri"} {
+		y := labelHeight*2 + (i*2)*(cellSize+cellGap) + cellSize - 2
+		fmt.Fprintf(&b, `<text x="0" y="%d" font-size="9" fill="#767676">%s</text>`+"\n", y, day)
+	}
+	for _, cell := range cells {
+		days := daysBetween(firstMonday, cell.Date)
+		x := labelWidth + (days/7)*(cellSize+cellGap)
+		y := labelHeight*2 + weekdayOffset(cell.Date)*(cellSize+cellGap)
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"><title>%s: %d</title></rect>`+"\n",
+			x, y, cellSize, cellSize, heatmapPalette[Level(cell.Value, max, len(heatmapPalette))],
+			cell.Date.Format("2006-01-02"), cell.Value)
+	}
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Line writes a simple line chart with one labelled point per sample.
+func Line(w io.Writer, title string, points []Point) error {
+	if len(points) == 0 {
+		return writeEmpty(w, title)
+	}
+
+	const (
+		plotWidth  = 480
+		plotHeight = 160
+		margin     = 32
+	)
+	max := 1
+	for _, point := range points {
+		if point.Value > max {
+			max = point.Value
+		}
+	}
+
+	step := 0.0
+	if len(points) > 1 {
+		step = float64(plotWidth) / float64(len(points)-1)
+	}
+	coords := make([]string, len(points))
+	for i, point := range points {
+		x := float64(margin) + step*float64(i)
+		y := float64(margin+plotHeight) - float64(point.Value)/float64(max)*plotHeight
+		coords[i] = fmt.Sprintf("%.1f,%.1f", x, y)
+	}
+
+	width := plotWidth + margin*2
+	height := plotHeight + margin*2 + labelHeight
+
+	var b strings.Builder
+	writeHeader(&b, width, height, title)
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#999"/>`+"\n", margin, margin+plotHeight, margin+plotWidth, margin+plotHeight)
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#999"/>`+"\n", margin, margin, margin, margin+plotHeight)
+	fmt.Fprintf(&b, `<text x="4" y="%d" font-size="10" fill="#767676">%d</text>`+"\n", margin+4, max)
+	fmt.Fprintf(&b, `<polyline fill="none" stroke="#239a3b" stroke-width="2" points="%s"/>`+"\n", strings.Join(coords, " "))
+	for i, point := range points {
+		xy := strings.Split(coords[i], ",")
+		fmt.Fprintf(&b, `<circle cx="%s" cy="%s" r="3" fill="#196127"><title>%s: %d</title></circle>`+"\n",
+			xy[0], xy[1], html.EscapeString(point.Label), point.Value)
+	}
+	fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="10" fill="#767676">%s</text>`+"\n", margin, height-4, html.EscapeString(points[0].Label))
+	fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="10" fill="#767676" text-anchor="end">%s</text>`+"\n", margin+plotWidth, height-4, html.EscapeString(points[len(points)-1].Label))
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Level buckets value into one of levels shades, reserving zero for empty days.
+func Level(value, max, levels int) int {
+	if value <= 0 || max <= 0 || levels < 2 {
+		return 0
+	}
+	level := (value*(levels-1) + max - 1) / max
+	if level >= levels {
+		level = levels - 1
+	}
+	return level
+}
+
+// daysBetween counts calendar days from a to b, rebuilding both in UTC so a
+// daylight saving change between them does not shorten a day.
+func daysBetween(a, b time.Time) int {
+	from := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func weekdayOffset(date time.Time) int {
+	return (int(date.Weekday()) + 6) % 7
+}
+
+func writeHeader(b *strings.Builder, width, height int, title string) {
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n", width, height, width, height)
+	fmt.Fprintf(b, `<text x="0" y="12" font-size="12" font-weight="bold">%s</text>`+"\n", html.EscapeString(title))
+}
+
+func writeEmpty(w io.Writer, title string) error {
+	var b strings.Builder
+	writeHeader(&b, 240, 40, title)
+	b.WriteString(`<text x="0" y="32" font-size="11" fill="#767676">No data</text>` + "\n</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
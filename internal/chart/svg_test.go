@@ -0,0 +1,80 @@
+package chart
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeatmapPlacesCellsByWeekday(t *testing.T) {
+	monday := time.Date(2025, time.November, 10, 0, 0, 0, 0, time.UTC)
+	cells := []HeatmapCell{
+		{Date: monday, Value: 0},
+		{Date: monday.AddDate(0, 0, 1), Value: 4},
+		{Date: monday.AddDate(0, 0, 7), Value: 2},
+	}
+
+	var b strings.Builder
+	if err := Heatmap(&b, "Done <weekly>", cells); err != nil {
+		t.Fatalf("Heatmap: %v", err)
+	}
+	svg := b.String()
+
+	for _, want := range []string{
+		`<svg xmlns="http://www.w3.org/2000/svg"`,
+		"Done &lt;weekly&gt;",
+		`<rect x="30" y="32" width="12" height="12" rx="2" fill="#ebedf0"><title>2025-11-10: 0</title>`,
+		`<rect x="30" y="46" width="12" height="12" rx="2" fill="#196127"><title>2025-11-11: 4</title>`,
+		`<rect x="44" y="32" width="12" height="12" rx="2" fill="#7bc96f"><title>2025-11-17: 2</title>`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Fatalf("svg missing %q:\n%s", want, svg)
+		}
+	}
+}
+
+func TestHeatmapCountsDaysAcrossDaylightSaving(t *testing.T) {
+	zone, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	// Clocks spring forward on Sunday 2025-03-09, so the day is 23 hours long.
+	monday := time.Date(2025, time.March, 3, 0, 0, 0, 0, zone)
+	var cells []HeatmapCell
+	for i := range 14 {
+		cells = append(cells, HeatmapCell{Date: monday.AddDate(0, 0, i), Value: 1})
+	}
+
+	var b strings.Builder
+	if err := Heatmap(&b, "Done", cells); err != nil {
+		t.Fatalf("Heatmap: %v", err)
+	}
+	svg := b.String()
+
+	for _, want := range []string{
+		`<svg xmlns="http://www.w3.org/2000/svg" width="58"`,
+		`<rect x="44" y="32" width="12" height="12" rx="2" fill="#196127"><title>2025-03-10: 1</title>`,
+		`<rect x="44" y="116" width="12" height="12" rx="2" fill="#196127"><title>2025-03-16: 1</title>`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Fatalf("svg missing %q:\n%s", want, svg)
+		}
+	}
+}
+
+func TestLineAndLevel(t *testing.T) {
+	var b strings.Builder
+	if err := Line(&b, "Open todos", []Point{{Label: "a", Value: 2}, {Label: "b", Value: 0}}); err != nil {
+		t.Fatalf("Line: %v", err)
+	}
+	if !strings.Contains(b.String(), `points="32.0,32.0 512.0,192.0"`) {
+		t.Fatalf("unexpected polyline:\n%s", b.String())
+	}
+
+	if got := Level(0, 5, 5); got != 0 {
+		t.Fatalf("Level(0) = %d, want 0", got)
+	}
+	if got := Level(5, 5, 5); got != 4 {
+		t.Fatalf("Level(max) = %d, want 4", got)
+	}
+}
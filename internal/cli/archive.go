@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+)
+
+func newArchiveCommand(manager *files.Manager) *cobra.Command {
+	var (
+		olderThanFlag int
+		dryRunFlag    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "archive",
+		Short: "Compress old month files into the archive directory.",
+		Long: "archive gzips every month file older than --older-than months into <base>/archive/ and removes\n" +
+			"the original. Archived months stay readable by every command; writing to one restores it first.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if olderThanFlag < 1 {
+				return fmt.Errorf("--older-than must be at least 1")
+			}
+			now := time.Now().In(logZone())
+			cutoff := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -olderThanFlag, 0)
+
+			months, err := manager.LiveMonths()
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			archived := 0
+			for _, month := range months {
+				if !month.Before(cutoff) {
+					continue
+				}
+				if dryRunFlag {
+					fmt.Fprintf(out, "Would archive %s\n", month.Format("2006-01"))
+				} else {
+					if err := manager.ArchiveMonth(month); err != nil {
+						return fmt.Errorf("archive %s: %w", month.Format("2006-01"), err)
+					}
+					fmt.Fprintf(out, "Archived %s\n", month.Format("2006-01"))
+				}
+				archived++
+			}
+
+			if archived == 0 {
+				fmt.Fprintf(out, "No month files older than %s.\n", cutoff.Format("2006-01"))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&olderThanFlag, "older-than", 3, "Archive months that ended more than this many months ago")
+	cmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "List the months that would be archived")
+
+	return cmd
+}
@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestArchiveCommandCompressesOldMonths(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	old := time.Now().AddDate(0, -6, 0).Format("2006-01") + "-15"
+	recent := time.Now().Format("2006-01-02")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", old, "--time", "09:00", "Old work")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", recent, "--time", "09:00", "New work")
+
+	out := executeCommand(t, newArchiveCommand(mgr), "--dry-run")
+	assertContains(t, out, "Would archive "+old[:7])
+	assertNotContains(t, out, recent[:7])
+
+	out = executeCommand(t, newArchiveCommand(mgr))
+	assertContains(t, out, "Archived "+old[:7])
+	oldDate := mustParseDate(t, old)
+	if _, err := os.Stat(mgr.MonthPath(oldDate)); !os.IsNotExist(err) {
+		t.Fatalf("old month file still present: %v", err)
+	}
+
+	list := executeCommand(t, newJumpCommand(ctx, mgr), old)
+	assertContains(t, list, "[done] 09:00 Old work")
+
+	out = executeCommand(t, newArchiveCommand(mgr))
+	assertContains(t, out, "No month files older than")
+}
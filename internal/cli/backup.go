@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+)
+
+func newBackupCommand(manager *files.Manager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "List or restore the copies kept before month files are rewritten.",
+		Long: "With backups = N in the config file, every write first copies the month file into <base>/backups/,\n" +
+			"keeping the N most recent copies of each file. backup list shows them and backup restore puts one\n" +
+			"back; the file it replaces is backed up too, so a restore can be reverted the same way.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	var monthFlag string
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "Show saved copies, newest first.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			backups, err := manager.Backups()
+			if err != nil {
+				return err
+			}
+			if monthFlag != "" {
+				var matching []files.Backup
+				for _, backup := range backups {
+					if filepath.Base(backup.Source) == monthFlag+".md" {
+						matching = append(matching, backup)
+					}
+				}
+				backups = matching
+			}
+
+			if jsonRequested(cmd) {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(backups)
+			}
+			out := cmd.OutOrStdout()
+			if len(backups) == 0 {
+				if settings.Backups == 0 {
+					fmt.Fprintln(out, "No backups. Set backups = N in the config file to keep N copies of each month file.")
+				} else {
+					fmt.Fprintln(out, "No backups yet.")
+				}
+				return nil
+			}
+			for _, backup := range backups {
+				fmt.Fprintf(out, "%s  %s\n", backup.Time.Format("2006-01-02 15:04:05"), backup.Name)
+			}
+			return nil
+		},
+	}
+	list.Flags().StringVar(&monthFlag, "month", "", "Only list copies of this month file (YYYY-MM)")
+
+	cmd.AddCommand(
+		list,
+		&cobra.Command{
+			Use:   "restore <backup>",
+			Short: "Replace a month file with a saved copy from backup list.",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				backup, err := manager.RestoreBackup(args[0])
+				if err != nil {
+					return err
+				}
+				name := backup.Source
+				if rel, err := filepath.Rel(manager.BasePath(), backup.Source); err == nil {
+					name = filepath.ToSlash(rel)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Restored %s from the copy taken %s\n", name, backup.Time.Format("2006-01-02 15:04:05"))
+				return nil
+			},
+		},
+	)
+
+	return cmd
+}
@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestBackupListAndRestore(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	out := executeCommand(t, newBackupCommand(mgr), "list")
+	assertContains(t, out, "No backups.")
+
+	mgr.KeepBackups(3)
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-16", "--time", "09:00", "First")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-16", "--time", "10:00", "Second")
+
+	out = executeCommand(t, newBackupCommand(mgr), "list", "--month", "2025-11")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 backups, got:\n%s", out)
+	}
+	assertContains(t, lines[0], "2025/2025-11.md.")
+	assertNotContains(t, executeCommand(t, newBackupCommand(mgr), "list", "--month", "2025-10"), "2025-11.md")
+
+	// The newest copy was taken before "Second" was added.
+	name := strings.Fields(lines[0])[2]
+	out = executeCommand(t, newBackupCommand(mgr), "restore", name)
+	assertContains(t, out, "Restored 2025/2025-11.md from the copy taken")
+
+	out = executeCommand(t, newJumpCommand(ctx, mgr), "2025-11-16")
+	assertContains(t, out, "First")
+	assertNotContains(t, out, "Second")
+}
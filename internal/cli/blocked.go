@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// blockedTodo is an open entry still waiting for some of its after:
+// dependencies.
+type blockedTodo struct {
+	Date    string               `json:"date"`
+	Index   int                  `json:"index"`
+	Entry   logbook.Entry        `json:"entry"`
+	Waiting []logbook.Dependency `json:"waiting"`
+}
+
+func newBlockedCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		dateFlag     string
+		lookbackFlag int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "blocked",
+		Short: "List open entries whose dependencies are not done yet.",
+		Long: "An after:YYYY-MM-DD#N token makes an entry wait for the Nth entry of that day, and keeps following\n" +
+			"that entry as its day is reordered. blocked lists the open entries written within --lookback days\n" +
+			"up to --date (default today) that still wait for an entry that is neither done nor cancelled, or\n" +
+			"that no longer exists, along with what they wait for.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if lookbackFlag < 0 {
+				return fmt.Errorf("--lookback must not be negative")
+			}
+			date, err := resolveDate(dateFlag)
+			if err != nil {
+				return err
+			}
+			reader := logbook.NewReader(manager)
+			sections, err := reader.SectionsBetween(ctx, date.AddDate(0, 0, -lookbackFlag), date)
+			if err != nil {
+				return err
+			}
+
+			scope := activeContext(cmd)
+			todos := []blockedTodo{}
+			for _, section := range sections {
+				for i, entry := range section.Entries {
+					if !entry.Status.Open() || len(entry.After) == 0 || !logbook.MatchesContext(entry, scope) {
+						continue
+					}
+					deps, err := reader.Dependencies(ctx, entry)
+					if err != nil {
+						return err
+					}
+					if waiting := logbook.Pending(deps); len(waiting) > 0 {
+						todos = append(todos, blockedTodo{Date: section.Date.Format("2006-01-02"), Index: i + 1, Entry: entry, Waiting: waiting})
+					}
+				}
+			}
+			if jsonRequested(cmd) {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(todos)
+			}
+
+			out := cmd.OutOrStdout()
+			if len(todos) == 0 {
+				fmt.Fprintln(out, "Nothing is blocked.")
+				return nil
+			}
+			fmt.Fprintf(out, "Blocked entries (%d)\n", len(todos))
+			for _, todo := range todos {
+				entry := todo.Entry
+				entry.After = nil
+				fmt.Fprintf(out, "- %s #%d: %s\n", todo.Date, todo.Index, formatEntry(entry))
+				for _, dep := range todo.Waiting {
+					if dep.Entry == nil {
+						fmt.Fprintf(out, "    waiting on %s: no such entry\n", dep.Ref)
+						continue
+					}
+					fmt.Fprintf(out, "    waiting on %s: %s\n", dep.Ref, formatEntry(*dep.Entry))
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Last day to look at in YYYY-MM-DD (default: today)")
+	cmd.Flags().IntVar(&lookbackFlag, "lookback", 30, "How many days back to gather open entries from")
+
+	return cmd
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestBlockedCommandListsUnfinishedDependencies(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-10", "--time", "09:00", "Ship build")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-10", "--time", "10:00", "Write notes")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-11", "--time", "09:00", "Deploy", "after:2025-11-10#1", "after:2025-11-10#2")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-11", "--time", "10:00", "Announce", "after:2025-11-10#2")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-09", "--time", "09:00", "Book room")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-11", "--time", "11:00", "Retro", "after:2025-11-09#1")
+	executeCommand(t, newDeleteCommand(ctx, mgr), "--date", "2025-11-09", "1")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-09", "--time", "10:00", "Unrelated")
+
+	out := executeCommand(t, newBlockedCommand(ctx, mgr), "--date", "2025-11-12")
+	assertContains(t, out, "Blocked entries (2)\n")
+	assertContains(t, out, "- 2025-11-11 #1: [todo] 09:00 Deploy\n    waiting on 2025-11-10#1: [todo] 09:00 Ship build\n")
+	assertContains(t, out, "- 2025-11-11 #3: [todo] 11:00 Retro\n    waiting on 2025-11-09#1: no such entry\n")
+	assertNotContains(t, out, "Announce")
+	assertNotContains(t, out, "Write notes")
+
+	executeCommand(t, newDoneCommand(ctx, mgr), "--date", "2025-11-10", "1")
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "blocked", "--date", "2025-11-12", "--json")
+	var todos []blockedTodo
+	if err := json.Unmarshal([]byte(out), &todos); err != nil {
+		t.Fatalf("Unmarshal: %v\n%s", err, out)
+	}
+	if len(todos) != 1 || todos[0].Index != 3 || todos[0].Waiting[0].Entry != nil {
+		t.Fatalf("unexpected blocked entries: %+v", todos)
+	}
+}
+
+func TestBlockedCommandFollowsDependenciesAcrossInserts(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-10", "--time", "09:00", "Deploy")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-11", "--time", "09:00", "Announce", "after:2025-11-10#1")
+	executeCommand(t, NewRootCommand(ctx, mgr), "log", "--sort", "--date", "2025-11-10", "--time", "08:00", "Coffee")
+
+	out := executeCommand(t, newBlockedCommand(ctx, mgr), "--date", "2025-11-11")
+	assertContains(t, out, "- 2025-11-11 #1: [todo] 09:00 Announce\n    waiting on 2025-11-10#2: [todo] 09:00 Deploy\n")
+
+	cmd := newTodoCommand(ctx, mgr)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--date", "2025-11-11", "Later", "after:2025-11-10#7"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "no such entry") {
+		t.Fatalf("expected an after: reference to no entry to be refused, got %v", err)
+	}
+}
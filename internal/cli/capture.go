@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// readClipboard is swapped out in tests so they do not depend on a system clipboard.
+var readClipboard = clipboard.ReadAll
+
+func newCaptureCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		dateFlag      string
+		fromClipboard bool
+		todoFlag      bool
+		doneFlag      bool
+		daemonFlag    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "capture [text ...]",
+		Short: "Capture an entry from the clipboard or free-form text.",
+		Long: "capture parses free-form text with the same tokens as the TUI prompt (@HH:MM, !todo|!done, #tags)\n" +
+			"and appends it to the target date. Use --from-clipboard to read the text from the system clipboard.\n\n" +
+			"capture --daemon keeps kerja running on a unix socket (KERJA_CAPTURE_SOCKET, or kerja-capture-<uid>.sock\n" +
+			"in XDG_RUNTIME_DIR or the temp directory). While it runs, kerja capture with text and only --date,\n" +
+			"--todo, or --done hands the text to it once the config is read and the logbook found, skipping the\n" +
+			"rest of start-up, so a desktop hotkey bound to kerja capture returns at once. The daemon only takes\n" +
+			"captures for the logbook it writes to. It reads the config when it starts; restart it after changes.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if daemonFlag {
+				if len(args) > 0 || fromClipboard || todoFlag || doneFlag || dateFlag != "" {
+					return fmt.Errorf("--daemon takes no text or other flags")
+				}
+				return runCaptureDaemon(ctx, cmd, manager)
+			}
+			if todoFlag && doneFlag {
+				return fmt.Errorf("--todo and --done are mutually exclusive")
+			}
+
+			input := strings.Join(args, " ")
+			if fromClipboard {
+				if len(args) > 0 {
+					return fmt.Errorf("text arguments cannot be combined with --from-clipboard")
+				}
+				value, err := readClipboard()
+				if err != nil {
+					return fmt.Errorf("read clipboard: %w", err)
+				}
+				input = value
+			}
+			status := ""
+			if todoFlag {
+				status = "todo"
+			}
+			if doneFlag {
+				status = "done"
+			}
+			entry, err := captureEntry(ctx, newWriter(cmd, manager), input, dateFlag, status)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Captured %s\n", formatEntry(entry))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Target date in YYYY-MM-DD (default: today)")
+	cmd.Flags().BoolVar(&fromClipboard, "from-clipboard", false, "Read the entry text from the system clipboard")
+	cmd.Flags().BoolVar(&todoFlag, "todo", false, "Capture as a todo entry (default: config default_status unless !done is present)")
+	cmd.Flags().BoolVar(&doneFlag, "done", false, "Capture as a done entry")
+	cmd.Flags().BoolVar(&daemonFlag, "daemon", false, "Listen on a unix socket for fast captures until interrupted")
+
+	return cmd
+}
+
+func newQuickCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	return &cobra.Command{
+		Use:   "q <text ...>",
+		Short: "Quick-add a todo, or a done entry with a leading x.",
+		Long: "q appends an entry to today with as little typing as possible, for shell aliases and launchers.\n" +
+			"The text accepts the same tokens as capture (@HH:MM, @-15m, !status, #tags, ref: links). A leading\n" +
+			"x marks the entry done, so `kerja q x fix build #ci` logs finished work; otherwise it is a todo.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input := strings.TrimSpace(strings.Join(args, " "))
+			status := logbook.StatusTodo
+			if rest, ok := strings.CutPrefix(input, "x "); ok {
+				input, status = rest, logbook.StatusDone
+			}
+
+			date, err := resolveDate("")
+			if err != nil {
+				return err
+			}
+			entry, err := tokenEntry(input, date, status)
+			if err != nil {
+				return err
+			}
+			if err := newWriter(cmd, manager).Append(ctx, date, entry); err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), formatEntry(entry))
+			return nil
+		},
+	}
+}
+
+// captureEntry appends input, in the TUI prompt syntax, to the day named by
+// dateFlag (default today). status, when set, wins over a !status token.
+func captureEntry(ctx context.Context, writer *logbook.Writer, input, dateFlag, status string) (logbook.Entry, error) {
+	if strings.TrimSpace(input) == "" {
+		return logbook.Entry{}, fmt.Errorf("text is required")
+	}
+	date, err := resolveDate(dateFlag)
+	if err != nil {
+		return logbook.Entry{}, err
+	}
+	defaultStatus, err := parseStatusFlag(settings.DefaultStatus, logbook.StatusTodo)
+	if err != nil {
+		return logbook.Entry{}, err
+	}
+	entry, err := tokenEntry(input, date, defaultStatus)
+	if err != nil {
+		return logbook.Entry{}, err
+	}
+	if status != "" {
+		if entry.Status, err = logbook.ParseStatus(status); err != nil {
+			return logbook.Entry{}, err
+		}
+	}
+	if err := writer.Append(ctx, date, entry); err != nil {
+		return logbook.Entry{}, err
+	}
+	return entry, nil
+}
+
+// tokenEntry builds an entry stamped now on date from a line in the TUI
+// prompt syntax, with status unless the line carries a !status token.
+func tokenEntry(input string, date time.Time, status logbook.Status) (logbook.Entry, error) {
+	entryTime, err := resolveTime(date, "")
+	if err != nil {
+		return logbook.Entry{}, err
+	}
+	parsed, err := logbook.ParseTokens(input, date)
+	if err != nil {
+		return logbook.Entry{}, err
+	}
+	if parsed.Text == "" && len(parsed.Tags) == 0 {
+		return logbook.Entry{}, fmt.Errorf("text is required")
+	}
+	return parsed.Entry(entryTime, status), nil
+}
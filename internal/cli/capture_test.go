@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func TestCaptureCommandFromClipboardParsesTokens(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	original := readClipboard
+	readClipboard = func() (string, error) {
+		return "PROJ-123 Fix login\nredirect @13:05 !done #bug", nil
+	}
+	t.Cleanup(func() { readClipboard = original })
+
+	out := executeCommand(t, newCaptureCommand(ctx, mgr), "--date", "2025-11-06", "--from-clipboard")
+	assertContains(t, out, "Captured [done] 13:05 PROJ-123 Fix login redirect (#bug)")
+
+	section, err := logbook.NewReader(mgr).Section(ctx, mustParseDate(t, "2025-11-06"))
+	if err != nil {
+		t.Fatalf("Section: %v", err)
+	}
+	if len(section.Entries) != 1 || section.Entries[0].Status != logbook.StatusDone {
+		t.Fatalf("unexpected entries: %#v", section.Entries)
+	}
+}
+
+func TestCaptureCommandStatusFlagOverridesToken(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	out := executeCommand(t, newCaptureCommand(ctx, mgr), "--date", "2025-11-06", "--todo", "Follow up !done @09:00")
+	assertContains(t, out, "Captured [todo] 09:00 Follow up")
+}
+
+func TestQuickCommandInfersStatusFromLeadingX(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	out := executeCommand(t, newQuickCommand(ctx, mgr), "x fix build @08:30 #ci")
+	assertContains(t, out, "[done] 08:30 fix build (#ci)")
+	out = executeCommand(t, newQuickCommand(ctx, mgr), "xcode", "upgrade", "@09:00")
+	assertContains(t, out, "[todo] 09:00 xcode upgrade")
+
+	today, err := resolveDate("")
+	if err != nil {
+		t.Fatalf("resolveDate: %v", err)
+	}
+	section, err := logbook.NewReader(mgr).Section(ctx, today)
+	if err != nil {
+		t.Fatalf("Section: %v", err)
+	}
+	if len(section.Entries) != 2 || section.Entries[0].Status != logbook.StatusDone || section.Entries[1].Status != logbook.StatusTodo {
+		t.Fatalf("unexpected entries: %#v", section.Entries)
+	}
+}
+
+func TestCaptureDaemonAppendsSentEntries(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	mgr := newTempManager(t)
+	t.Setenv("KERJA_CAPTURE_SOCKET", filepath.Join(t.TempDir(), "capture.sock"))
+
+	args := []string{"capture", "--date", "2025-11-06", "--done", "Ship login @09:00 #work"}
+	if sent, _ := sendCapture(args, mgr.BasePath(), &bytes.Buffer{}); sent {
+		t.Fatalf("sendCapture reached a daemon that is not running")
+	}
+
+	daemon := newCaptureCommand(ctx, mgr)
+	daemon.SetOut(&bytes.Buffer{})
+	daemon.SetArgs([]string{"--daemon"})
+	stopped := make(chan error, 1)
+	go func() { stopped <- daemon.Execute() }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-stopped; err != nil {
+			t.Errorf("daemon: %v", err)
+		}
+	})
+
+	var out bytes.Buffer
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		sent, err := sendCapture(args, mgr.BasePath(), &out)
+		if err != nil {
+			t.Fatalf("sendCapture: %v", err)
+		}
+		if sent {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("daemon never started listening")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assertContains(t, out.String(), "Captured [done] 09:00 Ship login (#work)")
+
+	section, err := logbook.NewReader(mgr).Section(ctx, mustParseDate(t, "2025-11-06"))
+	if err != nil {
+		t.Fatalf("Section: %v", err)
+	}
+	if len(section.Entries) != 1 || section.Entries[0].Text != "Ship login" {
+		t.Fatalf("unexpected entries: %#v", section.Entries)
+	}
+
+	if _, err := sendCapture([]string{"capture", "--date", "someday", "Later"}, mgr.BasePath(), &out); err == nil {
+		t.Fatalf("expected the daemon to reject a bad date")
+	}
+	if sent, _ := sendCapture([]string{"capture", "--from-clipboard"}, mgr.BasePath(), &out); sent {
+		t.Fatalf("--from-clipboard must be left to the full command")
+	}
+	if sent, err := sendCapture([]string{"capture", "Elsewhere"}, t.TempDir(), &out); sent || err != nil {
+		t.Fatalf("a capture for another logbook must be left to the full command, got sent=%v err=%v", sent, err)
+	}
+	if section, _ := logbook.NewReader(mgr).Section(ctx, time.Now()); len(section.Entries) != 0 {
+		t.Fatalf("the daemon wrote a capture meant for another logbook: %#v", section.Entries)
+	}
+}
+
+func TestCaptureDaemonKeepsFilesThatAreNotSockets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capture.sock")
+	t.Setenv("KERJA_CAPTURE_SOCKET", path)
+	if err := os.WriteFile(path, []byte("keep"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	daemon := newCaptureCommand(context.Background(), newTempManager(t))
+	daemon.SetOut(io.Discard)
+	daemon.SetErr(io.Discard)
+	daemon.SetArgs([]string{"--daemon"})
+	if err := daemon.Execute(); err == nil || !strings.Contains(err.Error(), "not a socket") {
+		t.Fatalf("expected the daemon to refuse the path, got %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "keep" {
+		t.Fatalf("the file was touched: %q, %v", data, err)
+	}
+	if sent, _ := sendCapture([]string{"capture", "Hello"}, "", &bytes.Buffer{}); sent {
+		t.Fatalf("sendCapture wrote to a file that is not a socket")
+	}
+}
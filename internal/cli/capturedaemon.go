@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// captureTimeout bounds one exchange with the capture daemon.
+const captureTimeout = 5 * time.Second
+
+// captureRequest is what kerja capture sends the daemon; Status is "todo"
+// or "done" when --todo or --done was passed. Base is the logbook the client
+// resolved, which the daemon must be writing to.
+type captureRequest struct {
+	Base   string `json:"base"`
+	Text   string `json:"text"`
+	Date   string `json:"date,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+// captureResponse carries the line kerja capture prints, or why the entry
+// was not added. Refused means the daemon writes to another logbook and
+// left the entry to the client.
+type captureResponse struct {
+	Output  string `json:"output,omitempty"`
+	Error   string `json:"error,omitempty"`
+	Refused bool   `json:"refused,omitempty"`
+}
+
+// captureSocketPath is where capture --daemon listens: KERJA_CAPTURE_SOCKET
+// when set, otherwise kerja-capture-<uid>.sock in XDG_RUNTIME_DIR or the
+// temp directory. It reads only the environment, so daemons for different
+// logbooks share it; the client has loaded the config by the time it dials,
+// and the daemon refuses captures for a logbook other than its own.
+func captureSocketPath() string {
+	if path := strings.TrimSpace(os.Getenv("KERJA_CAPTURE_SOCKET")); path != "" {
+		return path
+	}
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, fmt.Sprintf("kerja-capture-%d.sock", os.Getuid()))
+}
+
+// runCaptureDaemon appends the entries sent to the capture socket, one
+// connection at a time so writes never race, until interrupted.
+func runCaptureDaemon(ctx context.Context, cmd *cobra.Command, manager *files.Manager) error {
+	path := captureSocketPath()
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return fmt.Errorf("a capture daemon is already listening on %s", path)
+	}
+	// Nothing answers, so a leftover socket is from a daemon that was killed.
+	// Anything else at the path is not ours to remove.
+	if _, err := os.Lstat(path); err == nil {
+		if !ownSocket(path) {
+			return fmt.Errorf("%s exists and is not a socket of yours; remove it or set KERJA_CAPTURE_SOCKET", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("remove stale socket: %w", err)
+		}
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("capture daemon: %w", err)
+	}
+	defer listener.Close()
+	if err := os.Chmod(path, 0o600); err != nil {
+		return fmt.Errorf("capture daemon: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	writer := newWriter(cmd, manager)
+	fmt.Fprintf(cmd.OutOrStdout(), "Capturing into %s on %s (Ctrl+C to stop).\n", manager.BasePath(), path)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("capture daemon: %w", err)
+		}
+		if err := serveCapture(ctx, writer, manager.BasePath(), conn); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: %v\n", err)
+		}
+	}
+}
+
+// serveCapture answers one client. Errors adding the entry go back to the
+// client; only a broken connection is reported to the caller. A client that
+// resolved another logbook than base is refused.
+func serveCapture(ctx context.Context, writer *logbook.Writer, base string, conn net.Conn) error {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(captureTimeout))
+
+	var (
+		req  captureRequest
+		resp captureResponse
+	)
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		return fmt.Errorf("read capture request: %w", err)
+	}
+	if req.Base != base {
+		resp.Refused = true
+	} else if entry, err := captureEntry(ctx, writer, req.Text, req.Date, req.Status); err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Output = fmt.Sprintf("Captured %s\n", formatEntry(entry))
+	}
+	if err := json.NewEncoder(conn).Encode(resp); err != nil {
+		return fmt.Errorf("answer capture request: %w", err)
+	}
+	return nil
+}
+
+// sendCapture hands `capture <text>` to a daemon writing to the logbook at
+// base and reports whether it did. Anything the daemon cannot take, such as
+// --from-clipboard or --help, a socket owned by another user, a daemon on
+// another logbook, and any failure to reach it, is left to the full command.
+func sendCapture(args []string, base string, out io.Writer) (bool, error) {
+	req, ok := parseCaptureArgs(args)
+	if !ok {
+		return false, nil
+	}
+	req.Base = base
+	path := captureSocketPath()
+	if !ownSocket(path) {
+		return false, nil
+	}
+	conn, err := net.DialTimeout("unix", path, captureTimeout)
+	if err != nil {
+		return false, nil
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(captureTimeout))
+
+	// Once the request is sent the entry may have been written, so a
+	// failure from here on is reported rather than retried in-process.
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return true, fmt.Errorf("capture daemon: %w", err)
+	}
+	var resp captureResponse
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return true, fmt.Errorf("capture daemon: %w", err)
+	}
+	if resp.Refused {
+		return false, nil
+	}
+	if resp.Error != "" {
+		return true, errors.New(resp.Error)
+	}
+	fmt.Fprint(out, resp.Output)
+	return true, nil
+}
+
+// ownSocket reports whether path is a socket owned by the current user, so
+// captures never go to, and stale files are never removed for, a socket
+// someone else planted in a shared directory.
+func ownSocket(path string) bool {
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&os.ModeSocket == 0 {
+		return false
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	return ok && int(stat.Uid) == os.Getuid()
+}
+
+// parseCaptureArgs reads the command line of a capture the daemon can take:
+// text with at most --date, --todo, and --done.
+func parseCaptureArgs(args []string) (captureRequest, bool) {
+	if len(args) < 2 || args[0] != "capture" {
+		return captureRequest{}, false
+	}
+	var (
+		req   captureRequest
+		words []string
+	)
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			words = append(words, args[i+1:]...)
+			i = len(args)
+		case arg == "--todo" || arg == "--done":
+			if req.Status != "" {
+				return captureRequest{}, false
+			}
+			req.Status = strings.TrimPrefix(arg, "--")
+		case arg == "--date" && i+1 < len(args):
+			i++
+			req.Date = args[i]
+		case strings.HasPrefix(arg, "--date="):
+			req.Date = strings.TrimPrefix(arg, "--date=")
+		case strings.HasPrefix(arg, "-") && arg != "-":
+			return captureRequest{}, false
+		default:
+			words = append(words, arg)
+		}
+	}
+	req.Text = strings.Join(words, " ")
+	if strings.TrimSpace(req.Text) == "" {
+		return captureRequest{}, false
+	}
+	return req, true
+}
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/chart"
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func newHeatmapCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		dateFlag  string
+		weeksFlag int
+		svgFlag   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "heatmap",
+		Short: "Show completed entries per day as a calendar heatmap.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			end, err := resolveDate(dateFlag)
+			if err != nil {
+				return err
+			}
+			if weeksFlag <= 0 {
+				weeksFlag = 1
+			}
+			start, _ := weekBounds(end.AddDate(0, 0, -7*(weeksFlag-1)))
+
+			sections, err := logbook.NewReader(manager).SectionsBetween(ctx, start, end)
+			if err != nil {
+				return err
+			}
+			done := make(map[string]int)
+			for _, section := range sections {
+				done[section.Date.Format("2006-01-02")] = len(section.Entries) - section.OpenCount()
+			}
+
+			var cells []chart.HeatmapCell
+			for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+				cells = append(cells, chart.HeatmapCell{Date: day, Value: done[day.Format("2006-01-02")]})
+			}
+
+			if svgFlag != "" {
+				title := fmt.Sprintf("Completed entries %s", formatRange(start, end))
+				return writeSVG(cmd, svgFlag, func(f *os.File) error { return chart.Heatmap(f, title, cells) })
+			}
+			printHeatmap(cmd, cells)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Last day to include in YYYY-MM-DD (default: today)")
+	cmd.Flags().IntVar(&weeksFlag, "weeks", 12, "Number of weeks to include")
+	cmd.Flags().StringVar(&svgFlag, "svg", "", "Write the heatmap as an SVG file instead of printing it")
+
+	return cmd
+}
+
+func newBurndownCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		dateFlag string
+		daysFlag int
+		svgFlag  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "burndown",
+		Short: "Show open todos remaining at the end of each day.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			end, err := resolveDate(dateFlag)
+			if err != nil {
+				return err
+			}
+			if daysFlag <= 0 {
+				daysFlag = 1
+			}
+			start := end.AddDate(0, 0, -(daysFlag - 1))
+
+			sections, err := logbook.NewReader(manager).SectionsBetween(ctx, start, end)
+			if err != nil {
+				return err
+			}
+			open := make(map[string]int)
+			for _, section := range sections {
+				open[section.Date.Format("2006-01-02")] = section.OpenCount()
+			}
+
+			var points []chart.Point
+			for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+				key := day.Format("2006-01-02")
+				points = append(points, chart.Point{Label: key, Value: open[key]})
+			}
+
+			if svgFlag != "" {
+				title := fmt.Sprintf("Open todos %s", formatRange(start, end))
+				return writeSVG(cmd, svgFlag, func(f *os.File) error { return chart.Line(f, title, points) })
+			}
+			printBurndown(cmd, points)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Last day to include in YYYY-MM-DD (default: today)")
+	cmd.Flags().IntVar(&daysFlag, "days", 14, "Number of days to include ending on the target date")
+	cmd.Flags().StringVar(&svgFlag, "svg", "", "Write the chart as an SVG file instead of printing it")
+
+	return cmd
+}
+
+func writeSVG(cmd *cobra.Command, path string, render func(*os.File) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create svg: %w", err)
+	}
+	if err := render(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s\n", path)
+	return nil
+}
+
+func printHeatmap(cmd *cobra.Command, cells []chart.HeatmapCell) {
+	max := 0
+	for _, cell := range cells {
+		if cell.Value > max {
+			max = cell.Value
+		}
+	}
+
+	shades := glyphsFor(cmd).shades
+	rows := make([][]rune, 7)
+	for _, cell := range cells {
+		row := (int(cell.Date.Weekday()) + 6) % 7
+		rows[row] = append(rows[row], shades[chart.Level(cell.Value, max, len(shades))])
+	}
+
+	out := cmd.OutOrStdout()
+	for i, label := range []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"} {
+		fmt.Fprintf(out, "%s %s\n", label, string(rows[i]))
+	}
+	fmt.Fprintf(out, "max %d done/day\n", max)
+}
+
+func printBurndown(cmd *cobra.Command, points []chart.Point) {
+	out := cmd.OutOrStdout()
+	bar := string(glyphsFor(cmd).bar)
+	for _, point := range points {
+		fmt.Fprintf(out, "%s %3d %s\n", point.Label, point.Value, strings.Repeat(bar, point.Value))
+	}
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHeatmapAndBurndownCommands(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-11", "Ship")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-11", "Review")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-12", "Plan")
+
+	out := executeCommand(t, newHeatmapCommand(ctx, mgr), "--date", "2025-11-12", "--weeks", "1")
+	assertContains(t, out, "Mon ·\nTue █\nWed ·\nThu \n")
+	assertContains(t, out, "max 2 done/day")
+
+	burn := executeCommand(t, newBurndownCommand(ctx, mgr), "--date", "2025-11-12", "--days", "2")
+	assertContains(t, burn, "2025-11-11   0 \n2025-11-12   1 █\n")
+
+	svgPath := filepath.Join(t.TempDir(), "burndown.svg")
+	svgOut := executeCommand(t, newBurndownCommand(ctx, mgr), "--date", "2025-11-12", "--days", "2", "--svg", svgPath)
+	assertContains(t, svgOut, "Wrote "+svgPath)
+	data, err := os.ReadFile(svgPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "<svg") {
+		t.Fatalf("unexpected svg: %q", data)
+	}
+}
+
+func TestPlainOutputUsesASCII(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-11", "--time", "09:00-10:30", "Ship")
+
+	out := executeCommand(t, NewRootCommand(ctx, mgr), "heatmap", "--plain", "--date", "2025-11-12", "--weeks", "1")
+	assertContains(t, out, "Mon .\nTue #\nWed .\nThu \n")
+
+	t.Setenv("NO_COLOR", "1")
+	list := executeCommand(t, newListCommand(ctx, mgr), "--date", "2025-11-11")
+	assertContains(t, list, "2025-11-11 - 1/1 done, 100% - 1h30m tracked")
+	burn := executeCommand(t, newBurndownCommand(ctx, mgr), "--date", "2025-11-12", "--days", "2")
+	assertNotContains(t, burn, "█")
+	if got := truncateRunes("Review the platform doc", 10, asciiText.ellipsis); got != "Review..." {
+		t.Fatalf("truncateRunes = %q", got)
+	}
+}
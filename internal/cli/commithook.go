@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/gitsync"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func newHooksCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hooks",
+		Short: "Install hooks that log work done in other tools.",
+		Long: "hooks install git adds a post-commit hook to the git repository in the current directory, so every\n" +
+			"commit is logged as a done entry with the commit subject, tagged with the repository name. Amended\n" +
+			"commits are logged again. hooks uninstall git removes it.",
+		Args: cobra.NoArgs,
+	}
+	cmd.AddCommand(newHooksInstallCommand(), newHooksUninstallCommand(), newGitCommitHookCommand(ctx, manager))
+	return cmd
+}
+
+func newHooksInstallCommand() *cobra.Command {
+	var forceFlag bool
+
+	cmd := &cobra.Command{
+		Use:       "install git",
+		Short:     "Log each commit in this git repository as a done entry.",
+		Args:      hookKind,
+		ValidArgs: []string{"git"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			path, err := gitsync.InstallHook(dir, forceFlag)
+			if errors.Is(err, gitsync.ErrForeignHook) {
+				return fmt.Errorf("%s: %w; pass --force to replace it", path, err)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Installed %s\n", path)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&forceFlag, "force", false, "Replace a post-commit hook kerja did not install")
+	return cmd
+}
+
+func newHooksUninstallCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:       "uninstall git",
+		Short:     "Stop logging commits in this git repository.",
+		Args:      hookKind,
+		ValidArgs: []string{"git"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			path, removed, err := gitsync.UninstallHook(dir)
+			if errors.Is(err, gitsync.ErrForeignHook) {
+				return fmt.Errorf("%s: %w; leaving it in place", path, err)
+			}
+			if err != nil {
+				return err
+			}
+			if !removed {
+				fmt.Fprintf(cmd.OutOrStdout(), "No kerja hook at %s\n", path)
+				return nil
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed %s\n", path)
+			return nil
+		},
+	}
+}
+
+// newGitCommitHookCommand is what the installed post-commit hook runs, from
+// the root of the repository that was committed to.
+func newGitCommitHookCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	return &cobra.Command{
+		Use:    "git-commit",
+		Short:  "Log the latest commit of the current repository as a done entry.",
+		Hidden: true,
+		Args:   cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			subject, tag, err := gitsync.LastCommit(dir)
+			if err != nil || subject == "" {
+				return err
+			}
+			date, err := resolveDate("")
+			if err != nil {
+				return err
+			}
+			entryTime, err := resolveTime(date, "")
+			if err != nil {
+				return err
+			}
+			entry := logbook.Entry{Status: logbook.StatusDone, Time: entryTime, Text: commitText(subject)}
+			if logbook.ValidTag(tag) == nil {
+				entry.Tags = []string{tag}
+			}
+			if err := newWriter(cmd, manager).Append(ctx, date, entry); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "kerja: logged %s\n", formatEntry(entry))
+			return nil
+		},
+	}
+}
+
+// commitText keeps a commit subject whole as entry text. A word starting with
+// # would begin the entry's tags and cut off the rest of the subject, so a
+// reference such as #42 is written as (#42).
+func commitText(subject string) string {
+	words := strings.Fields(subject)
+	for i, word := range words {
+		if strings.HasPrefix(word, "#") {
+			words[i] = "(" + word + ")"
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// hookKind accepts the one kind of hook kerja installs.
+func hookKind(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 || args[0] != "git" {
+		return fmt.Errorf("expected the hook kind: git")
+	}
+	return nil
+}
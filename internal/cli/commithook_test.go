@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func TestHooksInstallGitLogsCommits(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "kerja")
+	t.Setenv("GIT_AUTHOR_EMAIL", "kerja@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "kerja")
+	t.Setenv("GIT_COMMITTER_EMAIL", "kerja@example.com")
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+
+	repo := filepath.Join(t.TempDir(), "Billing")
+	if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	t.Chdir(repo)
+
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	out := executeCommand(t, newHooksCommand(ctx, mgr), "install", "git")
+	assertContains(t, out, filepath.Join(".git", "hooks", "post-commit"))
+
+	// Commit without running hooks, then run what the hook runs.
+	if out, err := exec.Command("git", "-c", "core.hooksPath=/dev/null", "commit", "-q", "--allow-empty", "-m", "Add invoice export").CombinedOutput(); err != nil {
+		t.Fatalf("git commit: %v\n%s", err, out)
+	}
+	out = executeCommand(t, newHooksCommand(ctx, mgr), "git-commit")
+	assertContains(t, out, "[done]")
+	assertContains(t, out, "Add invoice export (#billing)")
+
+	if out, err := exec.Command("git", "-c", "core.hooksPath=/dev/null", "commit", "-q", "--allow-empty", "-m", "Merge pull request #42 from user/branch").CombinedOutput(); err != nil {
+		t.Fatalf("git commit: %v\n%s", err, out)
+	}
+	executeCommand(t, newHooksCommand(ctx, mgr), "git-commit")
+
+	out = executeCommand(t, newTodayCommand(ctx, mgr))
+	assertContains(t, out, "Add invoice export")
+	section, err := logbook.NewReader(mgr).Section(ctx, time.Now())
+	if err != nil || len(section.Entries) != 2 {
+		t.Fatalf("Section = %+v, %v", section, err)
+	}
+	if merge := section.Entries[1]; merge.Text != "Merge pull request (#42) from user/branch" || len(merge.Tags) != 1 || merge.Tags[0] != "billing" {
+		t.Fatalf("merge entry read back as %+v", merge)
+	}
+
+	out = executeCommand(t, newHooksCommand(ctx, mgr), "uninstall", "git")
+	assertContains(t, out, "Removed")
+}
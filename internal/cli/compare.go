@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func newCompareCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		weekFlag   bool
+		outputJSON bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "compare <from-date> <to-date>",
+		Short: "Compare two days (or weeks) of entries.",
+		Long: "compare matches entries by text between two dates, each entry with at most one on the other side,\n" +
+			"and reports items completed in both, todos carried over, finished items open again, new items, and\n" +
+			"dropped todos. With --week each date expands to its Monday-Sunday week.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, err := resolveDate(args[0])
+			if err != nil {
+				return err
+			}
+			to, err := resolveDate(args[1])
+			if err != nil {
+				return err
+			}
+
+			fromStart, fromEnd := from, from
+			toStart, toEnd := to, to
+			if weekFlag {
+				fromStart, fromEnd = weekBounds(from)
+				toStart, toEnd = weekBounds(to)
+			}
+
+			reader := logbook.NewReader(manager)
+			before, err := reader.SectionsBetween(ctx, fromStart, fromEnd)
+			if err != nil {
+				return err
+			}
+			after, err := reader.SectionsBetween(ctx, toStart, toEnd)
+			if err != nil {
+				return err
+			}
+
+			result := compareSections(before, after)
+			result.From = formatRange(fromStart, fromEnd)
+			result.To = formatRange(toStart, toEnd)
+
+			if outputJSON {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(result)
+			}
+			printComparison(cmd, result)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&weekFlag, "week", false, "Compare the weeks containing each date")
+	cmd.Flags().BoolVar(&outputJSON, "json", false, "Emit the comparison as JSON")
+
+	return cmd
+}
+
+type comparison struct {
+	From          string          `json:"from"`
+	To            string          `json:"to"`
+	CompletedBoth []logbook.Entry `json:"completed_both"`
+	CarriedOver   []logbook.Entry `json:"carried_over"`
+	Reopened      []logbook.Entry `json:"reopened"`
+	New           []logbook.Entry `json:"new"`
+	Dropped       []logbook.Entry `json:"dropped"`
+}
+
+// compareSections pairs entries across both ranges by normalised text, in
+// order, so an entry written twice pairs with up to two later ones. Entries
+// closed in the earlier range and not open again are finished work and are
+// not reported.
+func compareSections(before, after []logbook.DateSection) comparison {
+	var later []logbook.Entry
+	byKey := make(map[string][]int)
+	for _, section := range after {
+		for _, entry := range section.Entries {
+			key := compareKey(entry)
+			byKey[key] = append(byKey[key], len(later))
+			later = append(later, entry)
+		}
+	}
+
+	result := comparison{
+		CompletedBoth: []logbook.Entry{},
+		CarriedOver:   []logbook.Entry{},
+		Reopened:      []logbook.Entry{},
+		New:           []logbook.Entry{},
+		Dropped:       []logbook.Entry{},
+	}
+	matched := make([]bool, len(later))
+	for _, section := range before {
+		for _, entry := range section.Entries {
+			key := compareKey(entry)
+			queue := byKey[key]
+			if len(queue) == 0 {
+				if entry.Status.Open() {
+					result.Dropped = append(result.Dropped, entry)
+				}
+				continue
+			}
+			byKey[key] = queue[1:]
+			matched[queue[0]] = true
+			match := later[queue[0]]
+			switch {
+			case entry.Status.Open():
+				result.CarriedOver = append(result.CarriedOver, match)
+			case match.Status.Open():
+				result.Reopened = append(result.Reopened, match)
+			case entry.Status == logbook.StatusDone && match.Status == logbook.StatusDone:
+				result.CompletedBoth = append(result.CompletedBoth, match)
+			}
+		}
+	}
+
+	for i, entry := range later {
+		if !matched[i] {
+			result.New = append(result.New, entry)
+		}
+	}
+	return result
+}
+
+func compareKey(entry logbook.Entry) string {
+	return strings.ToLower(strings.Join(strings.Fields(entry.Text), " "))
+}
+
+// weekBounds returns the Monday and Sunday surrounding date.
+func weekBounds(date time.Time) (time.Time, time.Time) {
+	offset := (int(date.Weekday()) + 6) % 7
+	start := date.AddDate(0, 0, -offset)
+	return start, start.AddDate(0, 0, 6)
+}
+
+func formatRange(start, end time.Time) string {
+	if sameDate(start, end) {
+		return start.Format("2006-01-02")
+	}
+	return start.Format("2006-01-02") + ".." + end.Format("2006-01-02")
+}
+
+func sameDate(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
+
+func printComparison(cmd *cobra.Command, result comparison) {
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Comparing %s %s %s\n", result.From, glyphsFor(cmd).arrow, result.To)
+
+	groups := []struct {
+		title   string
+		entries []logbook.Entry
+	}{
+		{"Completed in both", result.CompletedBoth},
+		{"Carried over", result.CarriedOver},
+		{"Reopened", result.Reopened},
+		{"New", result.New},
+		{"Dropped", result.Dropped},
+	}
+	for _, group := range groups {
+		fmt.Fprintf(out, "\n%s (%d)\n", group.title, len(group.entries))
+		if len(group.entries) == 0 {
+			fmt.Fprintln(out, "(none)")
+			continue
+		}
+		for _, entry := range group.entries {
+			fmt.Fprintf(out, "- %s %s\n", entry.Time.Format("2006-01-02"), formatEntry(entry))
+		}
+	}
+}
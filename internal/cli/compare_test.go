@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestCompareCommandClassifiesEntries(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-10", "--time", "09:00", "Daily standup")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-10", "--time", "10:00", "Write RFC")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-10", "--time", "11:00", "Fix flaky test")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-10", "--time", "12:00", "Ship hotfix")
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-11", "--time", "09:00", "daily  standup")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-11", "--time", "10:30", "Write RFC")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-11", "--time", "14:00", "Plan sprint")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-11", "--time", "15:00", "Ship hotfix")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-11", "--time", "16:00", "Daily standup")
+
+	out := executeCommand(t, newCompareCommand(ctx, mgr), "2025-11-10", "2025-11-11")
+	assertContains(t, out, "Comparing 2025-11-10 → 2025-11-11")
+	assertContains(t, out, "Completed in both (1)\n- 2025-11-11 [done] 09:00 daily  standup")
+	assertContains(t, out, "Carried over (1)\n- 2025-11-11 [done] 10:30 Write RFC")
+	assertContains(t, out, "Reopened (1)\n- 2025-11-11 [todo] 15:00 Ship hotfix")
+	assertContains(t, out, "New (2)\n- 2025-11-11 [todo] 14:00 Plan sprint\n- 2025-11-11 [done] 16:00 Daily standup")
+	assertContains(t, out, "Dropped (1)\n- 2025-11-10 [todo] 11:00 Fix flaky test")
+
+	jsonOut := executeCommand(t, newCompareCommand(ctx, mgr), "--week", "--json", "2025-11-10", "2025-11-17")
+	var result comparison
+	if err := json.Unmarshal([]byte(jsonOut), &result); err != nil {
+		t.Fatalf("json.Unmarshal: %v\n%s", err, jsonOut)
+	}
+	if result.From != "2025-11-10..2025-11-16" || result.To != "2025-11-17..2025-11-23" {
+		t.Fatalf("unexpected ranges: %q %q", result.From, result.To)
+	}
+	if len(result.Dropped) != 4 || len(result.New) != 0 {
+		t.Fatalf("unexpected week comparison: %+v", result)
+	}
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// recentDateCount is how many days back --date completion offers.
+const recentDateCount = 14
+
+// tagArgCommands take free-form text where #tags can be completed.
+var tagArgCommands = map[string]bool{
+	"log":     true,
+	"todo":    true,
+	"edit":    true,
+	"capture": true,
+	"search":  true,
+}
+
+func newCompletionCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "completion bash|zsh|fish|powershell",
+		Short: "Generate a shell completion script.",
+		Long: "completion prints a completion script for the given shell. For example:\n\n" +
+			"  bash:       source <(kerja completion bash)\n" +
+			"  zsh:        kerja completion zsh > \"${fpath[1]}/_kerja\"\n" +
+			"  fish:       kerja completion fish > ~/.config/fish/completions/kerja.fish\n" +
+			"  powershell: kerja completion powershell | Out-String | Invoke-Expression\n\n" +
+			"Completions include recent dates for --date, status names for --status, and #tags from the current month.",
+		Args:                  cobra.ExactValidArgs(1),
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := cmd.Root()
+			out := cmd.OutOrStdout()
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(out, true)
+			case "zsh":
+				return root.GenZshCompletion(out)
+			case "fish":
+				return root.GenFishCompletion(out, true)
+			case "powershell":
+				return root.GenPowerShellCompletionWithDesc(out)
+			default:
+				return fmt.Errorf("unsupported shell %q", args[0])
+			}
+		},
+	}
+
+	return cmd
+}
+
+// registerCompletions wires dynamic completion for shared flags and tag
+// arguments across every subcommand of root.
+func registerCompletions(ctx context.Context, root *cobra.Command, manager *files.Manager) {
+	for _, cmd := range root.Commands() {
+		for _, name := range []string{"date", "to"} {
+			if cmd.Flags().Lookup(name) != nil {
+				_ = cmd.RegisterFlagCompletionFunc(name, completeDates)
+			}
+		}
+		if cmd.Flags().Lookup("template") != nil {
+			_ = cmd.RegisterFlagCompletionFunc("template", completeTemplates(manager))
+		}
+		if cmd.Flags().Lookup("status") != nil {
+			_ = cmd.RegisterFlagCompletionFunc("status", cobra.FixedCompletions(logbook.StatusNames(), cobra.ShellCompDirectiveNoFileComp))
+		}
+		if tagArgCommands[cmd.Name()] && cmd.ValidArgsFunction == nil {
+			cmd.ValidArgsFunction = completeTags(ctx, manager)
+		}
+	}
+}
+
+// completeDates offers today and the preceding days, newest first.
+func completeDates(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	today, _ := resolveDate("")
+	var dates []string
+	for i := 0; i < recentDateCount; i++ {
+		day := today.AddDate(0, 0, -i)
+		label := day.Format("Monday")
+		switch i {
+		case 0:
+			label = "today"
+		case 1:
+			label = "yesterday"
+		}
+		value := day.Format("2006-01-02")
+		if strings.HasPrefix(value, toComplete) {
+			dates = append(dates, value+"\t"+label)
+		}
+	}
+	return dates, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// completeTags suggests #tags used so far this month once the word starts with #.
+func completeTags(ctx context.Context, manager *files.Manager) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if !strings.HasPrefix(toComplete, "#") {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		tags, err := monthTags(ctx, manager, time.Now())
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		var matches []string
+		for _, tag := range tags {
+			if strings.HasPrefix("#"+tag, toComplete) {
+				matches = append(matches, "#"+tag)
+			}
+		}
+		return matches, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// monthTags lists the distinct tags in the month file containing date.
+func monthTags(ctx context.Context, manager *files.Manager, date time.Time) ([]string, error) {
+	start := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	sections, err := logbook.NewReader(manager).SectionsBetween(ctx, start, start.AddDate(0, 1, -1))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var tags []string
+	for _, section := range sections {
+		for _, entry := range section.Entries {
+			for _, tag := range entry.Tags {
+				if !seen[tag] {
+					seen[tag] = true
+					tags = append(tags, tag)
+				}
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCompletionCommandAndDynamicCompletions(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	today := time.Now().Format("2006-01-02")
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", today, "--time", "09:00", "Ship", "#release", "#backend")
+
+	out := executeCommand(t, NewRootCommand(ctx, mgr), "completion", "bash")
+	assertContains(t, out, "bash completion V2 for kerja")
+
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "__complete", "todo", "#re")
+	assertContains(t, out, "#release\n")
+	assertNotContains(t, out, "#backend")
+
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "__complete", "list", "--date", "")
+	assertContains(t, out, today+"\ttoday\n")
+
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "__complete", "edit", "--status", "")
+	assertContains(t, out, "todo\ndone\n")
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/config"
+)
+
+func newConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Read and update settings in the kerja config file.",
+		Long:  "config manages ~/.kerja/config.toml (or $KERJA_CONFIG). Run `kerja config list` to see every key.",
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "get <key>",
+			Short: "Print the effective value of a setting.",
+			Long:  "get prints the value commands use: the config file's, unless an environment variable such as\nKERJA_HOME or KERJA_WIP_LIMIT overrides it.",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				cfg, err := effectiveConfig()
+				if err != nil {
+					return err
+				}
+				value, err := cfg.Get(args[0])
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), value)
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "set <key> <value>",
+			Short: "Validate and store a setting.",
+			Args:  cobra.ExactArgs(2),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				path, err := config.Path()
+				if err != nil {
+					return err
+				}
+				if err := config.SetInFile(path, args[0], args[1]); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Set %s = %s in %s\n", args[0], args[1], path)
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "list",
+			Short: "Show every setting with its effective value.",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				path, err := config.Path()
+				if err != nil {
+					return err
+				}
+				cfg, err := config.LoadFile(path)
+				if err != nil {
+					return err
+				}
+				cfg, err = cfg.Effective()
+				if err != nil {
+					return err
+				}
+				out := cmd.OutOrStdout()
+				fmt.Fprintf(out, "# %s\n", path)
+				for _, key := range config.Keys() {
+					value, _ := cfg.Get(key)
+					note := config.Describe(key)
+					if name, ok := config.EnvOverride(key); ok {
+						note += fmt.Sprintf(" (from %s)", name)
+					}
+					fmt.Fprintf(out, "%s = %q  # %s\n", key, value, note)
+				}
+				return nil
+			},
+		},
+	)
+
+	return cmd
+}
+
+// effectiveConfig loads the config file with environment overrides applied.
+func effectiveConfig() (config.Config, error) {
+	path, err := config.Path()
+	if err != nil {
+		return config.Config{}, err
+	}
+	cfg, err := config.LoadFile(path)
+	if err != nil {
+		return config.Config{}, err
+	}
+	return cfg.Effective()
+}
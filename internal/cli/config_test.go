@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigCommandSetGetList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	t.Setenv("KERJA_CONFIG", path)
+
+	out := executeCommand(t, newConfigCommand(), "set", "time_format", "12h")
+	assertContains(t, out, "Set time_format = 12h in "+path)
+
+	out = executeCommand(t, newConfigCommand(), "get", "time_format")
+	if out != "12h\n" {
+		t.Fatalf("get output = %q, want %q", out, "12h\n")
+	}
+
+	out = executeCommand(t, newConfigCommand(), "list")
+	assertContains(t, out, `default_status = "todo"`)
+	assertContains(t, out, `time_format = "12h"`)
+
+	home := t.TempDir()
+	t.Setenv("KERJA_HOME", home)
+	t.Setenv("KERJA_WIP_LIMIT", "3")
+	executeCommand(t, newConfigCommand(), "set", "wip_limit", "5")
+	if out := executeCommand(t, newConfigCommand(), "get", "wip_limit"); out != "3\n" {
+		t.Fatalf("get wip_limit = %q, want the KERJA_WIP_LIMIT value", out)
+	}
+	if out := executeCommand(t, newConfigCommand(), "get", "base_path"); out != home+"\n" {
+		t.Fatalf("get base_path = %q, want the KERJA_HOME value", out)
+	}
+	out = executeCommand(t, newConfigCommand(), "list")
+	assertContains(t, out, "(from KERJA_WIP_LIMIT)")
+}
+
+func TestFormatEntryHonorsTwelveHourSetting(t *testing.T) {
+	original := settings
+	t.Cleanup(func() { settings = original })
+	settings.TimeFormat = "12h"
+
+	out := executeCommand(t, newLogCommand(t.Context(), newTempManager(t)), "--date", "2025-11-15", "--time", "14:05", "Demo")
+	assertContains(t, out, "[done] 2:05PM Demo")
+
+	out = executeCommand(t, newLogCommand(t.Context(), newTempManager(t)), "--date", "2025-11-15", "--time", "9:45pm-10:30pm", "Retro")
+	assertContains(t, out, "[done] 9:45PM-10:30PM (45m) Retro")
+}
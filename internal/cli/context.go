@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/config"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func newContextCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "context",
+		Short: "Show, set, or clear the tags every view is limited to.",
+		Long: "context stores tags in the config file so today, prev, next, jump, list, and the TUI only show\n" +
+			"entries carrying one of them until the context is cleared. Entry numbers stay those of the whole\n" +
+			"day, and --no-context shows everything for a single command.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tags := logbook.ParseContext(settings.Context)
+			if len(tags) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No context set.")
+				return nil
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Context: %s\n", formatContext(tags))
+			return nil
+		},
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "set <#tag>...",
+			Short: "Limit views to entries with any of the given tags.",
+			Args:  cobra.MinimumNArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				tags := logbook.ParseContext(strings.Join(args, " "))
+				if len(tags) == 0 {
+					return fmt.Errorf("context needs at least one #tag")
+				}
+				if err := storeContext(strings.Join(tags, ",")); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Context set to %s\n", formatContext(tags))
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "clear",
+			Short: "Show every entry again.",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if err := storeContext(""); err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), "Context cleared.")
+				return nil
+			},
+		},
+	)
+
+	return cmd
+}
+
+// storeContext saves value as the context key and applies it to this run.
+func storeContext(value string) error {
+	path, err := config.Path()
+	if err != nil {
+		return err
+	}
+	if err := config.SetInFile(path, "context", value); err != nil {
+		return err
+	}
+	settings.Context = value
+	return nil
+}
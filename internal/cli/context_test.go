@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestContextLimitsViewsUntilCleared(t *testing.T) {
+	ctx := t.Context()
+	mgr := newTempManager(t)
+	path := filepath.Join(t.TempDir(), "config.toml")
+	t.Setenv("KERJA_CONFIG", path)
+	original := settings
+	t.Cleanup(func() { settings = original })
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-15", "Plan sprint", "#work")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-15", "Buy milk", "#home")
+
+	out := executeCommand(t, newContextCommand(), "set", "#Work")
+	assertContains(t, out, "Context set to #Work")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	assertContains(t, string(data), `context = "Work"`)
+
+	out = executeCommand(t, newTodayCommand(ctx, mgr), "--date", "2025-11-15")
+	assertContains(t, out, "context #Work")
+	assertContains(t, out, "1. [todo]")
+	assertNotContains(t, out, "Buy milk")
+
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "list", "--date", "2025-11-15", "--no-context")
+	assertContains(t, out, "2. [todo]")
+	assertContains(t, out, "Buy milk")
+
+	executeCommand(t, newContextCommand(), "clear")
+	out = executeCommand(t, newContextCommand())
+	assertContains(t, out, "No context set.")
+	out = executeCommand(t, newJumpCommand(ctx, mgr), "2025-11-15")
+	assertContains(t, out, "Buy milk")
+}
+
+func TestTimeZoneIsRecordedInNewMonths(t *testing.T) {
+	ctx := t.Context()
+	mgr := newTempManager(t)
+	original := settings
+	t.Cleanup(func() { settings = original })
+	settings.TimeZone = "Asia/Tokyo"
+	mgr.SetTimeZone(logZone())
+
+	date, err := resolveDate("")
+	if err != nil {
+		t.Fatalf("resolveDate: %v", err)
+	}
+	if date.Location().String() != "Asia/Tokyo" {
+		t.Fatalf("today reckoned in %s, want Asia/Tokyo", date.Location())
+	}
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-15", "--time", "09:00", "Land in Tokyo")
+	data, err := os.ReadFile(filepath.Join(mgr.BasePath(), "2025", "2025-11.md"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	assertContains(t, string(data), "# November 2025\n<!-- time_zone: Asia/Tokyo -->\n\n## 2025-11-15\n")
+
+	out := executeCommand(t, newJumpCommand(ctx, mgr), "2025-11-15")
+	assertContains(t, out, "09:00 Land in Tokyo")
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// monthCheck is the doctor result for one month file.
+type monthCheck struct {
+	Month    string            `json:"month"`
+	Path     string            `json:"path"`
+	Problems []logbook.Problem `json:"problems"`
+	Repaired bool              `json:"repaired,omitempty"`
+	// Settled counts the future-dated entries --future changed.
+	Settled int `json:"settled,omitempty"`
+}
+
+func newDoctorCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		monthFlag  string
+		fixFlag    bool
+		futureFlag string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check month files for formatting problems and optionally repair them.",
+		Long: "doctor validates every month file (or just --month): the month header is present, date headings\n" +
+			"are sorted and unique, and every line is an entry, a note, or a heading. Problems are listed with\n" +
+			"line numbers. --fix rewrites the files in canonical form; lines it cannot parse are kept as they are.\n" +
+			"Done or cancelled entries dated after the current time are flagged too, as they usually come from a\n" +
+			"mistyped --date or --time: --future=today moves them to today, no later than now, and --future=tag\n" +
+			"tags them #future to fix by hand. A repair can be reverted with `kerja undo`.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if manager.Daily() {
+				return fmt.Errorf("doctor: %w", files.ErrDailyLayout)
+			}
+			var future logbook.FutureFix
+			if futureFlag != "" {
+				var err error
+				if future, err = logbook.ParseFutureFix(futureFlag); err != nil {
+					return fmt.Errorf("invalid --future %q (expected today or tag)", futureFlag)
+				}
+			}
+
+			var months []time.Time
+			if monthFlag != "" {
+				month, err := time.ParseInLocation("2006-01", monthFlag, logZone())
+				if err != nil {
+					return fmt.Errorf("invalid --month %q (expected YYYY-MM)", monthFlag)
+				}
+				months = []time.Time{month}
+			} else {
+				var err error
+				months, err = manager.LiveMonths()
+				if err != nil {
+					return err
+				}
+			}
+
+			reader := logbook.NewReader(manager)
+			writer := newWriter(cmd, manager)
+			checks := make([]monthCheck, 0, len(months))
+			for _, month := range months {
+				check := monthCheck{Month: month.Format("2006-01"), Path: manager.MonthPath(month)}
+				problems, err := reader.Check(ctx, month)
+				if err != nil {
+					return fmt.Errorf("check %s: %w", check.Month, err)
+				}
+				if fixFlag {
+					if check.Repaired, err = writer.Repair(ctx, month); err != nil {
+						return fmt.Errorf("repair %s: %w", check.Month, err)
+					}
+				}
+				if future != 0 {
+					if check.Settled, err = writer.FixFuture(ctx, month, future); err != nil {
+						return fmt.Errorf("settle future entries in %s: %w", check.Month, err)
+					}
+				}
+				check.Problems = problems
+				checks = append(checks, check)
+			}
+
+			if jsonRequested(cmd) {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(checks)
+			}
+			printDoctor(cmd, checks, fixFlag, future)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&monthFlag, "month", "", "Check only this month (YYYY-MM)")
+	cmd.Flags().BoolVar(&fixFlag, "fix", false, "Rewrite files in canonical form, fixing what can be fixed")
+	cmd.Flags().StringVar(&futureFlag, "future", "", "Settle closed entries dated after now: today (move to today) or tag (tag #future)")
+
+	return cmd
+}
+
+func printDoctor(cmd *cobra.Command, checks []monthCheck, fixed bool, future logbook.FutureFix) {
+	out := cmd.OutOrStdout()
+	total, fixable := 0, 0
+	for _, check := range checks {
+		for _, problem := range check.Problems {
+			total++
+			suffix := ""
+			switch {
+			case problem.Future && future == logbook.FutureToday:
+				suffix = " (moved to today)"
+			case problem.Future && future == logbook.FutureTag:
+				suffix = " (tagged #" + logbook.FutureTagName + ")"
+			case problem.Future:
+				suffix = " (settle with --future=today or --future=tag)"
+			}
+			if problem.Fixable {
+				fixable++
+				suffix = " (fixable)"
+				if fixed {
+					suffix = " (fixed)"
+				}
+			}
+			fmt.Fprintf(out, "%s:%d: %s%s\n", check.Path, problem.Line, problem.Message, suffix)
+		}
+		if check.Repaired && len(check.Problems) == 0 {
+			fmt.Fprintf(out, "%s: normalized formatting\n", check.Path)
+		}
+	}
+
+	switch {
+	case total == 0:
+		fmt.Fprintf(out, "Checked %d month file%s: no problems found.\n", len(checks), sSuffix(len(checks)))
+	case fixed:
+		fmt.Fprintf(out, "Checked %d month file%s: %d problem%s, %d fixed.\n", len(checks), sSuffix(len(checks)), total, sSuffix(total), fixable)
+	default:
+		fmt.Fprintf(out, "Checked %d month file%s: %d problem%s, %d fixable with --fix.\n", len(checks), sSuffix(len(checks)), total, sSuffix(total), fixable)
+	}
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func TestDoctorReportsAndFixesMonthFiles(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-10-03", "--time", "09:00", "Healthy month")
+	path := mgr.MonthPath(mustParseDate(t, "2025-11-01"))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	broken := "# November 2025\n\n## 2025-11-04\n- [x] [09:00] Later\n\n## 2025-11-02\n- [ ] [10:00]   Earlier\nstray text\n"
+	if err := os.WriteFile(path, []byte(broken), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	out := executeCommand(t, newDoctorCommand(ctx, mgr))
+	assertContains(t, out, path+":6: section 2025-11-02 is out of order (after 2025-11-04) (fixable)")
+	assertContains(t, out, path+":7: entry is not in canonical form (fixable)")
+	assertContains(t, out, path+`:8: unparseable line "stray text"`)
+	assertContains(t, out, "Checked 2 month files: 3 problems, 2 fixable with --fix.")
+
+	out = executeCommand(t, newDoctorCommand(ctx, mgr), "--month", "2025-11", "--fix")
+	assertContains(t, out, "(fixed)")
+	assertContains(t, out, "3 problems, 2 fixed.")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	want := "# November 2025\n\n## 2025-11-02\n- [ ] [10:00] Earlier\nstray text\n\n## 2025-11-04\n- [x] [09:00] Later\n"
+	if string(data) != want {
+		t.Fatalf("repaired file =\n%s", data)
+	}
+
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "doctor", "--json")
+	var checks []monthCheck
+	if err := json.Unmarshal([]byte(out), &checks); err != nil {
+		t.Fatalf("Unmarshal: %v\n%s", err, out)
+	}
+	if len(checks) != 2 || len(checks[0].Problems) != 0 || len(checks[1].Problems) != 1 || checks[1].Problems[0].Line != 5 {
+		t.Fatalf("unexpected checks: %+v", checks)
+	}
+
+	section, err := logbook.NewReader(mgr).Section(ctx, time.Date(2025, time.November, 2, 0, 0, 0, 0, time.Local))
+	if err != nil || len(section.Entries) != 1 {
+		t.Fatalf("Section after repair = %+v, %v", section, err)
+	}
+}
+
+func TestDoctorSettlesFutureEntries(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	future := time.Now().AddDate(1, 0, 0).Format("2006-01-02")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", future, "--time", "09:00", "Wrong year")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", future, "--time", "10:00", "Planned")
+
+	out := executeCommand(t, newDoctorCommand(ctx, mgr))
+	assertContains(t, out, "section "+future+" is dated after today but holds 1 closed entry (settle with --future=today or --future=tag)")
+
+	out = executeCommand(t, newDoctorCommand(ctx, mgr), "--future", "today")
+	assertContains(t, out, "(moved to today)")
+
+	today, err := logbook.NewReader(mgr).Section(ctx, time.Now())
+	if err != nil || len(today.Entries) != 1 || today.Entries[0].Text != "Wrong year" {
+		t.Fatalf("today after --future=today = %+v, %v", today, err)
+	}
+	out = executeCommand(t, newDoctorCommand(ctx, mgr))
+	assertNotContains(t, out, "dated after today")
+}
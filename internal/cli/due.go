@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// dueTodo is an open entry with a deadline, wherever it was written.
+type dueTodo struct {
+	Due     string        `json:"due"`
+	Overdue bool          `json:"overdue"`
+	Date    string        `json:"date"`
+	Index   int           `json:"index"`
+	Entry   logbook.Entry `json:"entry"`
+}
+
+func newDueCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		dateFlag     string
+		daysFlag     int
+		lookbackFlag int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "due",
+		Short: "List open entries by deadline.",
+		Long: "due gathers the open entries carrying a due:YYYY-MM-DD token, written within --lookback days before\n" +
+			"--date (default today) or on the days after it, and lists those due within --days of --date,\n" +
+			"soonest deadline first. Entries past their deadline are marked overdue. Entries are numbered as on\n" +
+			"the day they were written, for use with kerja toggle --date and friends.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if daysFlag < 0 {
+				return fmt.Errorf("--days must not be negative")
+			}
+			if lookbackFlag < 0 {
+				return fmt.Errorf("--lookback must not be negative")
+			}
+			date, err := resolveDate(dateFlag)
+			if err != nil {
+				return err
+			}
+			until := date.AddDate(0, 0, daysFlag)
+			sections, err := logbook.NewReader(manager).SectionsBetween(ctx, date.AddDate(0, 0, -lookbackFlag), until)
+			if err != nil {
+				return err
+			}
+
+			todos := findDueTodos(sections, activeContext(cmd), date, until)
+			if jsonRequested(cmd) {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(todos)
+			}
+
+			out := cmd.OutOrStdout()
+			if len(todos) == 0 {
+				fmt.Fprintf(out, "Nothing due by %s.\n", until.Format("2006-01-02"))
+				return nil
+			}
+			fmt.Fprintf(out, "Due by %s (%d)\n", until.Format("2006-01-02"), len(todos))
+			for _, todo := range todos {
+				entry := todo.Entry
+				entry.Due = time.Time{}
+				fmt.Fprintf(out, "- %s  %-12s %s #%d: %s\n", todo.Due, dueLabel(todo.Entry.Due, date), todo.Date, todo.Index, formatEntry(entry))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Reference date in YYYY-MM-DD (default: today)")
+	cmd.Flags().IntVar(&daysFlag, "days", 14, "How many days ahead of --date to include deadlines for")
+	cmd.Flags().IntVar(&lookbackFlag, "lookback", 90, "How many days back to gather entries from")
+
+	return cmd
+}
+
+// findDueTodos returns the open entries matching context that are due by
+// until, ordered by deadline and then by where they were written. Overdue is
+// judged against date.
+func findDueTodos(sections []logbook.DateSection, context []string, date, until time.Time) []dueTodo {
+	todos := []dueTodo{}
+	for _, section := range sections {
+		for i, entry := range section.Entries {
+			if entry.Due.IsZero() || !entry.Status.Open() || !logbook.MatchesContext(entry, context) {
+				continue
+			}
+			if entry.Due.After(until) {
+				continue
+			}
+			todos = append(todos, dueTodo{
+				Due:     entry.Due.Format("2006-01-02"),
+				Overdue: entry.Overdue(date),
+				Date:    section.Date.Format("2006-01-02"),
+				Index:   i + 1,
+				Entry:   entry,
+			})
+		}
+	}
+	sort.SliceStable(todos, func(i, j int) bool {
+		return todos[i].Due < todos[j].Due
+	})
+	return todos
+}
+
+// dueLabel says how far a deadline is from date, in whole days.
+func dueLabel(due, date time.Time) string {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, due.Location())
+	days := int(math.Round(due.Sub(day).Hours() / 24))
+	switch {
+	case days < 0:
+		return fmt.Sprintf("overdue %dd", -days)
+	case days == 0:
+		return "due today"
+	default:
+		return fmt.Sprintf("in %dd", days)
+	}
+}
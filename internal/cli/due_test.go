@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestDueCommandListsDeadlinesAcrossDates(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-03", "--time", "09:00", "Send report", "due:2025-11-10", "#ops")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-10", "--time", "09:00", "No deadline")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-10", "--time", "10:00", "Renew passport due:2025-11-14")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-10", "--time", "11:00", "Filed taxes due:2025-11-11")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-11", "--time", "09:00", "Book venue due:2025-11-12")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-11", "--time", "10:00", "Plan offsite due:2025-12-20")
+
+	out := executeCommand(t, newDueCommand(ctx, mgr), "--date", "2025-11-12", "--days", "7")
+	assertContains(t, out, "Due by 2025-11-19 (3)\n")
+	assertContains(t, out, "- 2025-11-10  overdue 2d   2025-11-03 #1: [todo] 09:00 Send report (#ops)\n")
+	assertContains(t, out, "- 2025-11-12  due today    2025-11-11 #1: [todo] 09:00 Book venue\n")
+	assertContains(t, out, "- 2025-11-14  in 2d        2025-11-10 #2: [todo] 10:00 Renew passport\n")
+	assertNotContains(t, out, "No deadline")
+	assertNotContains(t, out, "Filed taxes")
+	assertNotContains(t, out, "Plan offsite")
+
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "due", "--date", "2025-11-12", "--days", "60", "--json")
+	var todos []dueTodo
+	if err := json.Unmarshal([]byte(out), &todos); err != nil {
+		t.Fatalf("Unmarshal: %v\n%s", err, out)
+	}
+	if len(todos) != 4 || !todos[0].Overdue || todos[3].Due != "2025-12-20" || todos[3].Entry.Due.IsZero() {
+		t.Fatalf("unexpected due todos: %+v", todos)
+	}
+
+	out = executeCommand(t, newDueCommand(ctx, mgr), "--date", "2025-11-01", "--days", "3")
+	assertContains(t, out, "Nothing due by 2025-11-04.")
+}
+
+func TestEditReplacesDue(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-10", "--time", "09:00", "Send report", "due:2025-11-12")
+	executeCommand(t, newEditCommand(ctx, mgr), "1", "--date", "2025-11-10", "Send report", "due:2025-11-14")
+	out := executeCommand(t, newListCommand(ctx, mgr), "--date", "2025-11-10", "--days", "1")
+	assertContains(t, out, "Send report (due 2025-11-14, overdue)")
+}
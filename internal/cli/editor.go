@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/editor"
+	"github.com/faizmokh/kerja/internal/files"
+)
+
+// runEditor opens path in the user's editor and waits for it to exit. Tests
+// replace it to simulate edits without a terminal.
+var runEditor = func(path string) error {
+	return runEditorAt(path, 0)
+}
+
+// runEditorAt opens path in the user's editor with the cursor on line when
+// the editor supports it, and waits for it to exit. Tests replace it.
+var runEditorAt = func(path string, line int) error {
+	cmd := editor.CommandAt(path, line)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("run editor %q: %w", editor.Name(), err)
+	}
+	return nil
+}
+
+// captureWithEditor opens a template seeded with initial and returns the
+// first non-comment line plus any remaining lines as notes.
+func captureWithEditor(initial string) (string, []string, error) {
+	path, err := editor.Scratch(initial)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := runEditor(path); err != nil {
+		os.Remove(path)
+		return "", nil, err
+	}
+	return editor.ReadScratch(path)
+}
+
+func newOpenCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var dateFlag string
+
+	cmd := &cobra.Command{
+		Use:   "open",
+		Short: "Edit the month file in $EDITOR at a day's heading.",
+		Long: "open launches $VISUAL or $EDITOR on the month file holding --date, with the cursor on that day's\n" +
+			"heading for editors that accept a line (vi, nano, emacs, VS Code, Sublime Text, and others). The\n" +
+			"heading is added first when the day has no section yet. Encrypted logbooks cannot be edited raw.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if manager.Encrypted() {
+				return fmt.Errorf("month files are encrypted; edit entries with kerja edit or the TUI instead")
+			}
+			date, err := resolveDate(dateFlag)
+			if err != nil {
+				return err
+			}
+
+			path, line, err := newWriter(cmd, manager).EnsureSection(ctx, date)
+			if err != nil {
+				return err
+			}
+			return runEditorAt(path, line)
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Day to open in YYYY-MM-DD (default: today)")
+
+	return cmd
+}
@@ -2,8 +2,11 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -13,8 +16,10 @@ import (
 
 func newLogCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
 	var (
-		dateFlag string
-		timeFlag string
+		dateFlag     string
+		timeFlag     string
+		editorFlag   bool
+		templateFlag string
 	)
 
 	cmd := &cobra.Command{
@@ -22,7 +27,7 @@ func newLogCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
 		Short: "Record a completed entry for today.",
 		Long:  "log appends a done entry under the target date. Tags can be provided inline via #tag syntax.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if len(args) == 0 {
+			if len(args) == 0 && !editorFlag && templateFlag == "" {
 				return fmt.Errorf("text is required")
 			}
 
@@ -31,24 +36,47 @@ func newLogCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
 				return err
 			}
 
-			entryTime, err := resolveTime(date, timeFlag)
+			entryTime, endTime, err := resolveTimeRange(date, timeFlag)
 			if err != nil {
 				return err
 			}
 
-			text, tags := parseTextAndTags(args)
-			if text == "" {
-				return fmt.Errorf("text is required")
+			var notes []string
+			if editorFlag {
+				first, editorNotes, err := captureWithEditor(strings.Join(args, " "))
+				if err != nil {
+					return err
+				}
+				args = strings.Fields(first)
+				notes = editorNotes
 			}
 
-			entry := logbook.Entry{
-				Status: logbook.StatusDone,
-				Time:   entryTime,
-				Text:   text,
-				Tags:   tags,
+			args, relative, hasRelative, err := takeRelativeTime(args, date)
+			if err != nil {
+				return err
+			}
+			if hasRelative {
+				if cmd.Flags().Changed("time") {
+					return fmt.Errorf("use either --time or an @ time, not both")
+				}
+				entryTime, endTime = relative, time.Time{}
+			}
+
+			entry := parseEntryArgs(args)
+			entry.Status = logbook.StatusDone
+			entry.Time, entry.End = entryTime, endTime
+			entry.Notes = notes
+			if templateFlag != "" {
+				entry, err = expandTemplate(manager, templateFlag, date, entry, cmd.Flags().Changed("time") || hasRelative)
+				if err != nil {
+					return err
+				}
+			}
+			if entry.Text == "" {
+				return fmt.Errorf("text is required")
 			}
 
-			writer := logbook.NewWriter(manager)
+			writer := newWriter(cmd, manager)
 			if err := writer.Append(ctx, date, entry); err != nil {
 				return err
 			}
@@ -59,23 +87,30 @@ func newLogCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&dateFlag, "date", "", "Target date in YYYY-MM-DD (default: today)")
-	cmd.Flags().StringVar(&timeFlag, "time", "", "Timestamp in HH:MM (default: current time)")
+	cmd.Flags().StringVar(&timeFlag, "time", "", "Timestamp in HH:MM or 9:45pm, range HH:MM-HH:MM, or relative now, +30m, -1h (default: current time)")
+	cmd.Flags().BoolVar(&editorFlag, "editor", false, "Compose the entry in $EDITOR; extra lines become notes")
+	cmd.Flags().StringVar(&templateFlag, "template", "", "Start from a snippet in snippets.md; extra text and #tags are appended")
 
 	return cmd
 }
 
 func newTodoCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
 	var (
-		dateFlag string
-		timeFlag string
+		dateFlag     string
+		timeFlag     string
+		editorFlag   bool
+		templateFlag string
+		forceFlag    bool
+		limitFlag    int
 	)
 
 	cmd := &cobra.Command{
 		Use:   "todo [text ... #tags]",
 		Short: "Capture a todo entry for today.",
-		Long:  "todo appends an open item under the target date. Tags can be provided inline via #tag syntax.",
+		Long: "todo appends an open item under the target date. Tags can be provided inline via #tag syntax,\n" +
+			"and a due:YYYY-MM-DD token sets a deadline listed by kerja due.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if len(args) == 0 {
+			if len(args) == 0 && !editorFlag && templateFlag == "" {
 				return fmt.Errorf("text is required")
 			}
 
@@ -84,24 +119,58 @@ func newTodoCommand(ctx context.Context, manager *files.Manager) *cobra.Command
 				return err
 			}
 
-			entryTime, err := resolveTime(date, timeFlag)
+			entryTime, endTime, err := resolveTimeRange(date, timeFlag)
 			if err != nil {
 				return err
 			}
 
-			text, tags := parseTextAndTags(args)
-			if text == "" {
+			var notes []string
+			if editorFlag {
+				first, editorNotes, err := captureWithEditor(strings.Join(args, " "))
+				if err != nil {
+					return err
+				}
+				args = strings.Fields(first)
+				notes = editorNotes
+			}
+
+			args, relative, hasRelative, err := takeRelativeTime(args, date)
+			if err != nil {
+				return err
+			}
+			if hasRelative {
+				if cmd.Flags().Changed("time") {
+					return fmt.Errorf("use either --time or an @ time, not both")
+				}
+				entryTime, endTime = relative, time.Time{}
+			}
+
+			entry := parseEntryArgs(args)
+			entry.Status = logbook.StatusTodo
+			entry.Time, entry.End = entryTime, endTime
+			entry.Notes = notes
+			if templateFlag != "" {
+				entry, err = expandTemplate(manager, templateFlag, date, entry, cmd.Flags().Changed("time") || hasRelative)
+				if err != nil {
+					return err
+				}
+			}
+			if entry.Text == "" {
 				return fmt.Errorf("text is required")
 			}
 
-			entry := logbook.Entry{
-				Status: logbook.StatusTodo,
-				Time:   entryTime,
-				Text:   text,
-				Tags:   tags,
+			limit := limitFlag
+			if !cmd.Flags().Changed("wip-limit") {
+				limit, err = files.ResolveWIPLimit(settings.WIPLimit)
+				if err != nil {
+					return err
+				}
+			}
+			if err := checkWIPLimit(ctx, cmd, manager, date, limit, forceFlag); err != nil {
+				return err
 			}
 
-			writer := logbook.NewWriter(manager)
+			writer := newWriter(cmd, manager)
 			if err := writer.Append(ctx, date, entry); err != nil {
 				return err
 			}
@@ -112,7 +181,11 @@ func newTodoCommand(ctx context.Context, manager *files.Manager) *cobra.Command
 	}
 
 	cmd.Flags().StringVar(&dateFlag, "date", "", "Target date in YYYY-MM-DD (default: today)")
-	cmd.Flags().StringVar(&timeFlag, "time", "", "Timestamp in HH:MM (default: current time)")
+	cmd.Flags().StringVar(&timeFlag, "time", "", "Timestamp in HH:MM or 9:45pm, range HH:MM-HH:MM, or relative now, +30m, -1h (default: current time)")
+	cmd.Flags().BoolVar(&editorFlag, "editor", false, "Compose the entry in $EDITOR; extra lines become notes")
+	cmd.Flags().StringVar(&templateFlag, "template", "", "Start from a snippet in snippets.md; extra text and #tags are appended")
+	cmd.Flags().BoolVar(&forceFlag, "force", false, "Add the todo even when the daily WIP limit is reached")
+	cmd.Flags().IntVar(&limitFlag, "wip-limit", 0, "Maximum open todos per day (default: $KERJA_WIP_LIMIT, 0 disables)")
 
 	return cmd
 }
@@ -121,27 +194,81 @@ func newToggleCommand(ctx context.Context, manager *files.Manager) *cobra.Comman
 	var dateFlag string
 
 	cmd := &cobra.Command{
-		Use:   "toggle <index>",
-		Short: "Flip the status of an entry by index.",
-		Args:  cobra.ExactArgs(1),
+		Use:   "toggle <index>...",
+		Short: "Advance the status of one or more entries by index.",
+		Long: "toggle moves every listed index one step through todo → in-progress → done → todo in a single write.\n" +
+			"Blocked and cancelled entries reopen as todo.",
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			index, err := strconv.Atoi(args[0])
-			if err != nil || index <= 0 {
-				return fmt.Errorf("index must be a positive integer")
+			day, args, err := splitDatedIndexes(dateFlag, args)
+			if err != nil {
+				return err
+			}
+			indexes, err := parseIndexes(args)
+			if err != nil {
+				return err
 			}
 
-			date, err := resolveDate(dateFlag)
+			date, err := resolveDate(day)
+			if err != nil {
+				return err
+			}
+
+			writer := newWriter(cmd, manager)
+			entries, err := writer.ToggleMany(ctx, date, indexes)
+			if err != nil {
+				return err
+			}
+
+			for i, entry := range entries {
+				fmt.Fprintf(cmd.OutOrStdout(), "Toggled entry %d: %s\n", indexes[i], formatEntry(entry))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Target date in YYYY-MM-DD (default: today)")
+
+	return cmd
+}
+
+func newPinCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var dateFlag string
+
+	cmd := &cobra.Command{
+		Use:   "pin <index>...",
+		Short: "Pin or unpin one or more entries by index.",
+		Long: "pin flips the pin on every listed index in a single write. Pinned entries are listed first\n" +
+			"in today, list, and the TUI, whatever their time, and carry a * before their text in the file.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			day, args, err := splitDatedIndexes(dateFlag, args)
+			if err != nil {
+				return err
+			}
+			indexes, err := parseIndexes(args)
+			if err != nil {
+				return err
+			}
+
+			date, err := resolveDate(day)
 			if err != nil {
 				return err
 			}
 
-			writer := logbook.NewWriter(manager)
-			entry, err := writer.Toggle(ctx, date, index)
+			writer := newWriter(cmd, manager)
+			entries, err := writer.TogglePinMany(ctx, date, indexes)
 			if err != nil {
 				return err
 			}
 
-			fmt.Fprintf(cmd.OutOrStdout(), "Toggled entry %d: %s\n", index, formatEntry(entry))
+			for i, entry := range entries {
+				verb := "Pinned"
+				if !entry.Pinned {
+					verb = "Unpinned"
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s entry %d: %s\n", verb, indexes[i], formatEntry(entry))
+			}
 			return nil
 		},
 	}
@@ -155,27 +282,238 @@ func newDeleteCommand(ctx context.Context, manager *files.Manager) *cobra.Comman
 	var dateFlag string
 
 	cmd := &cobra.Command{
-		Use:   "delete <index>",
-		Short: "Remove an entry by index.",
-		Args:  cobra.ExactArgs(1),
+		Use:   "delete <index>...",
+		Short: "Remove one or more entries by index.",
+		Long:  "delete removes every listed index in a single write; indexes refer to the list before deletion.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			day, args, err := splitDatedIndexes(dateFlag, args)
+			if err != nil {
+				return err
+			}
+			indexes, err := parseIndexes(args)
+			if err != nil {
+				return err
+			}
+
+			date, err := resolveDate(day)
+			if err != nil {
+				return err
+			}
+
+			writer := newWriter(cmd, manager)
+			entries, err := writer.DeleteMany(ctx, date, indexes)
+			if err != nil {
+				return err
+			}
+
+			for i, entry := range entries {
+				fmt.Fprintf(cmd.OutOrStdout(), "Deleted entry %d: %s\n", indexes[i], formatEntry(entry))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Target date in YYYY-MM-DD (default: today)")
+
+	return cmd
+}
+
+func newMoveCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		dateFlag string
+		toFlag   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "move <index> --to <date>",
+		Short: "Move an entry to another date.",
+		Long: "move removes the entry from --date and appends it to --to, keeping its status, time, tags, and notes.\n" +
+			"Both days are updated as one change, so a single undo reverts the move.",
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			index, err := strconv.Atoi(args[0])
 			if err != nil || index <= 0 {
 				return fmt.Errorf("index must be a positive integer")
 			}
+			if toFlag == "" {
+				return fmt.Errorf("--to is required")
+			}
+
+			from, err := resolveDate(dateFlag)
+			if err != nil {
+				return err
+			}
+			to, err := resolveDate(toFlag)
+			if err != nil {
+				return err
+			}
+
+			entry, err := newWriter(cmd, manager).Move(ctx, from, index, to)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Moved entry %d from %s to %s: %s\n", index, from.Format("2006-01-02"), to.Format("2006-01-02"), formatEntry(entry))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Date the entry is on in YYYY-MM-DD (default: today)")
+	cmd.Flags().StringVar(&toFlag, "to", "", "Destination date in YYYY-MM-DD")
+
+	return cmd
+}
+
+func newDupCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		dateFlag       string
+		toFlag         string
+		keepStatusFlag bool
+		keepTimeFlag   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "dup <index>",
+		Short: "Clone an entry as a new todo.",
+		Long: "dup copies the entry's text, tags, and notes into a new entry on --to (default: the same day).\n" +
+			"The copy is a todo stamped with the current time unless --keep-status or --keep-time is set.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			index, err := strconv.Atoi(args[0])
+			if err != nil || index <= 0 {
+				return fmt.Errorf("index must be a positive integer")
+			}
+
+			from, err := resolveDate(dateFlag)
+			if err != nil {
+				return err
+			}
+			to := from
+			if toFlag != "" {
+				if to, err = resolveDate(toFlag); err != nil {
+					return err
+				}
+			}
+
+			section, err := logbook.NewReader(manager).Section(ctx, from)
+			if err != nil {
+				return err
+			}
+			if index > len(section.Entries) {
+				return logbook.ErrInvalidIndex
+			}
+
+			entry := cloneEntry(section.Entries[index-1])
+			if !keepStatusFlag {
+				entry.Status = logbook.StatusTodo
+			}
+			start := time.Date(to.Year(), to.Month(), to.Day(), entry.Time.Hour(), entry.Time.Minute(), 0, 0, to.Location())
+			if !keepTimeFlag {
+				if start, err = resolveTime(to, ""); err != nil {
+					return err
+				}
+			}
+			entry = entry.Reschedule(start)
+
+			if err := newWriter(cmd, manager).Append(ctx, to, entry); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Duplicated entry %d to %s: %s\n", index, to.Format("2006-01-02"), formatEntry(entry))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Date the entry is on in YYYY-MM-DD (default: today)")
+	cmd.Flags().StringVar(&toFlag, "to", "", "Date to add the copy to in YYYY-MM-DD (default: --date)")
+	cmd.Flags().BoolVar(&keepStatusFlag, "keep-status", false, "Keep the original status instead of resetting to todo")
+	cmd.Flags().BoolVar(&keepTimeFlag, "keep-time", false, "Keep the original clock time instead of the current time")
+
+	return cmd
+}
+
+// cloneEntry copies entry so the tags and notes of the copy can change
+// independently of the original.
+func cloneEntry(entry logbook.Entry) logbook.Entry {
+	entry.Tags = append([]string(nil), entry.Tags...)
+	entry.Notes = append([]string(nil), entry.Notes...)
+	return entry
+}
+
+func newReorderCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var dateFlag string
+
+	cmd := &cobra.Command{
+		Use:   "reorder <from> <to>",
+		Short: "Move an entry to another position within its day.",
+		Long:  "reorder moves the entry at index <from> so it ends up at index <to>, carrying its notes along.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, err := strconv.Atoi(args[0])
+			if err != nil || from <= 0 {
+				return fmt.Errorf("from must be a positive integer")
+			}
+			to, err := strconv.Atoi(args[1])
+			if err != nil || to <= 0 {
+				return fmt.Errorf("to must be a positive integer")
+			}
 
 			date, err := resolveDate(dateFlag)
 			if err != nil {
 				return err
 			}
 
-			writer := logbook.NewWriter(manager)
-			entry, err := writer.Delete(ctx, date, index)
+			entry, err := newWriter(cmd, manager).Reorder(ctx, date, from, to)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Moved entry %d to position %d: %s\n", from, to, formatEntry(entry))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Target date in YYYY-MM-DD (default: today)")
+
+	return cmd
+}
+
+func newDoneCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var dateFlag string
+
+	cmd := &cobra.Command{
+		Use:   "done <index>...",
+		Short: "Mark one or more entries as done by index.",
+		Long:  "done marks every listed index as done in a single write; entries already done are left as they are.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			day, args, err := splitDatedIndexes(dateFlag, args)
+			if err != nil {
+				return err
+			}
+			indexes, err := parseIndexes(args)
+			if err != nil {
+				return err
+			}
+
+			date, err := resolveDate(day)
+			if err != nil {
+				return err
+			}
+
+			writer := newWriter(cmd, manager)
+			entries, err := writer.EditMany(ctx, date, indexes, func(entry logbook.Entry) logbook.Entry {
+				entry.Status = logbook.StatusDone
+				return entry
+			})
 			if err != nil {
 				return err
 			}
 
-			fmt.Fprintf(cmd.OutOrStdout(), "Deleted entry %d: %s\n", index, formatEntry(entry))
+			for i, entry := range entries {
+				fmt.Fprintf(cmd.OutOrStdout(), "Done entry %d: %s\n", indexes[i], formatEntry(entry))
+			}
 			return nil
 		},
 	}
@@ -193,17 +531,31 @@ func newEditCommand(ctx context.Context, manager *files.Manager) *cobra.Command
 	)
 
 	cmd := &cobra.Command{
-		Use:   "edit <index> [text ... #tags]",
+		Use:   "edit <index>... [text ... #tags]",
 		Short: "Modify an entry by index.",
-		Args:  cobra.MinimumNArgs(1),
+		Long: "edit updates the text, tags, time, or status of an entry. Pass several indexes with --time or\n" +
+			"--status (for example `kerja edit 1 3 5 --status done`) to update them all in a single write.",
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			index, err := strconv.Atoi(args[0])
+			if len(args) > 1 && (timeFlag != "" || statusFlag != "") {
+				if day, plain, err := splitDatedIndexes(dateFlag, args); err == nil {
+					if indexes, err := parseIndexes(plain); err == nil {
+						return editMany(ctx, cmd, manager, day, timeFlag, statusFlag, indexes)
+					}
+				}
+			}
+
+			day, first, err := splitDatedIndexes(dateFlag, args[:1])
+			if err != nil {
+				return err
+			}
+			index, err := strconv.Atoi(first[0])
 			if err != nil || index <= 0 {
 				return fmt.Errorf("index must be a positive integer")
 			}
 			textArgs := args[1:]
 
-			date, err := resolveDate(dateFlag)
+			date, err := resolveDate(day)
 			if err != nil {
 				return err
 			}
@@ -222,21 +574,23 @@ func newEditCommand(ctx context.Context, manager *files.Manager) *cobra.Command
 			updated := current
 
 			if len(textArgs) > 0 {
-				text, tags := parseTextAndTags(textArgs)
-				if text != "" {
-					updated.Text = text
-				} else {
-					updated.Text = ""
+				parsed := parseEntryArgs(textArgs)
+				updated.Text = parsed.Text
+				updated.Tags = parsed.Tags
+				updated.Links = parsed.Links
+				updated.After = parsed.After
+				if parsed.Author != "" {
+					updated.Author = parsed.Author
 				}
-				updated.Tags = tags
+				updated.Due = parsed.Due
 			}
 
 			if timeFlag != "" {
-				entryTime, err := resolveTime(date, timeFlag)
+				entryTime, endTime, err := resolveTimeRange(date, timeFlag)
 				if err != nil {
 					return err
 				}
-				updated.Time = entryTime
+				updated = retime(updated, entryTime, endTime)
 			}
 
 			if statusFlag != "" {
@@ -247,7 +601,7 @@ func newEditCommand(ctx context.Context, manager *files.Manager) *cobra.Command
 				updated.Status = status
 			}
 
-			writer := logbook.NewWriter(manager)
+			writer := newWriter(cmd, manager)
 			if err := writer.Edit(ctx, date, index, updated); err != nil {
 				return err
 			}
@@ -258,8 +612,71 @@ func newEditCommand(ctx context.Context, manager *files.Manager) *cobra.Command
 	}
 
 	cmd.Flags().StringVar(&dateFlag, "date", "", "Target date in YYYY-MM-DD (default: today)")
-	cmd.Flags().StringVar(&timeFlag, "time", "", "Timestamp in HH:MM (default: unchanged)")
-	cmd.Flags().StringVar(&statusFlag, "status", "", "todo or done (default: unchanged)")
+	cmd.Flags().StringVar(&timeFlag, "time", "", "Timestamp in HH:MM or 9:45pm, or range HH:MM-HH:MM (default: unchanged)")
+	cmd.Flags().StringVar(&statusFlag, "status", "", "todo, done, in-progress, blocked, or cancelled (default: unchanged)")
 
 	return cmd
 }
+
+// editMany applies --time and --status to several entries at once.
+func editMany(ctx context.Context, cmd *cobra.Command, manager *files.Manager, dateFlag, timeFlag, statusFlag string, indexes []int) error {
+	date, err := resolveDate(dateFlag)
+	if err != nil {
+		return err
+	}
+	var entryTime, endTime time.Time
+	if timeFlag != "" {
+		if entryTime, endTime, err = resolveTimeRange(date, timeFlag); err != nil {
+			return err
+		}
+	}
+	if statusFlag != "" {
+		if _, err := parseStatusFlag(statusFlag, logbook.StatusTodo); err != nil {
+			return err
+		}
+	}
+
+	entries, err := newWriter(cmd, manager).EditMany(ctx, date, indexes, func(entry logbook.Entry) logbook.Entry {
+		if timeFlag != "" {
+			entry = retime(entry, entryTime, endTime)
+		}
+		if statusFlag != "" {
+			entry.Status, _ = parseStatusFlag(statusFlag, entry.Status)
+		}
+		return entry
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, entry := range entries {
+		fmt.Fprintf(cmd.OutOrStdout(), "Updated entry %d: %s\n", indexes[i], formatEntry(entry))
+	}
+	return nil
+}
+
+// checkWIPLimit refuses to add another todo once the day already holds limit
+// open items, unless force is set, in which case it only warns.
+func checkWIPLimit(ctx context.Context, cmd *cobra.Command, manager *files.Manager, date time.Time, limit int, force bool) error {
+	if limit <= 0 {
+		return nil
+	}
+
+	section, err := logbook.NewReader(manager).Section(ctx, date)
+	if err != nil {
+		if errors.Is(err, logbook.ErrSectionNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	open := section.OpenCount()
+	if open < limit {
+		return nil
+	}
+	if !force {
+		return fmt.Errorf("WIP limit reached: %d open todos (limit %d); finish something first or pass --force", open, limit)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "warning: WIP limit exceeded: %d open todos (limit %d)\n", open+1, limit)
+	return nil
+}
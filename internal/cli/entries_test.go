@@ -3,6 +3,9 @@ package cli
 import (
 	"bytes"
 	"context"
+	"errors"
+	"io"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -101,6 +104,13 @@ func TestToggleCommandFlipsStatus(t *testing.T) {
 	if err := cmd.Execute(); err != nil {
 		t.Fatalf("Execute: %v", err)
 	}
+	if got := buf.String(); !strings.Contains(got, "[in-progress]") {
+		t.Fatalf("unexpected first toggle output: %q", got)
+	}
+	buf.Reset()
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("second Execute: %v", err)
+	}
 
 	reader := logbook.NewReader(mgr)
 	section, err := reader.Section(context.Background(), date)
@@ -224,3 +234,332 @@ func TestToggleCommandInvalidIndex(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestToggleCommandRefusesDatedIndexOnAnotherDay(t *testing.T) {
+	mgr := newTempManager(t)
+
+	cmd := newToggleCommand(context.Background(), mgr)
+	cmd.SetArgs([]string{"--date", "2025-11-17", "2025-11-18#1"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "2025-11-18#1 is not on 2025-11-17") {
+		t.Fatalf("expected day mismatch error, got %v", err)
+	}
+}
+
+func TestTodoCommandEditorCapturesNotes(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	original := runEditor
+	runEditor = func(path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(string(data), "Plan migration\n") {
+			t.Fatalf("template not seeded with args: %q", data)
+		}
+		content := "Plan migration #infra\nCheck replica lag\n\n# comment\nSchedule window\n"
+		return os.WriteFile(path, []byte(content), 0o600)
+	}
+	t.Cleanup(func() { runEditor = original })
+
+	out := executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-08", "--time", "10:00", "--editor", "Plan", "migration")
+	assertContains(t, out, "Added todo [todo] 10:00 Plan migration (#infra)")
+
+	section, err := logbook.NewReader(mgr).Section(ctx, mustParseDate(t, "2025-11-08"))
+	if err != nil {
+		t.Fatalf("Section: %v", err)
+	}
+	notes := section.Entries[0].Notes
+	if len(notes) != 2 || notes[0] != "Check replica lag" || notes[1] != "Schedule window" {
+		t.Fatalf("notes = %#v", notes)
+	}
+}
+
+func TestTodoCommandEnforcesWIPLimit(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	t.Setenv("KERJA_WIP_LIMIT", "2")
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-09", "First")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-09", "Second")
+
+	cmd := newTodoCommand(ctx, mgr)
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"--date", "2025-11-09", "Third"})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "WIP limit reached: 2 open todos (limit 2)") {
+		t.Fatalf("expected WIP limit error, got %v", err)
+	}
+
+	out := executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-09", "--force", "Third")
+	assertContains(t, out, "warning: WIP limit exceeded: 3 open todos (limit 2)")
+	assertContains(t, out, "Added todo")
+
+	// Done entries do not count towards the limit, and the flag overrides the env.
+	executeCommand(t, newDoneCommand(ctx, mgr), "--date", "2025-11-09", "1")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-09", "--wip-limit", "3", "Fourth")
+}
+
+func TestMoveCommandMovesEntry(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-16", "--time", "11:00", "Carry me", "#ops")
+	out := executeCommand(t, newMoveCommand(ctx, mgr), "--date", "2025-11-16", "--to", "2025-11-17", "1")
+	assertContains(t, out, "Moved entry 1 from 2025-11-16 to 2025-11-17")
+
+	out = executeCommand(t, newJumpCommand(ctx, mgr), "2025-11-17")
+	assertContains(t, out, "Carry me")
+	out = executeCommand(t, newJumpCommand(ctx, mgr), "2025-11-16")
+	assertNotContains(t, out, "Carry me")
+
+	cmd := newMoveCommand(ctx, mgr)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--date", "2025-11-17", "1"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--to is required") {
+		t.Fatalf("expected --to error, got %v", err)
+	}
+}
+
+func TestReorderCommand(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-16", "--time", "09:00", "Alpha")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-16", "--time", "10:00", "Beta")
+	out := executeCommand(t, newReorderCommand(ctx, mgr), "--date", "2025-11-16", "2", "1")
+	assertContains(t, out, "Moved entry 2 to position 1")
+
+	out = executeCommand(t, newJumpCommand(ctx, mgr), "2025-11-16")
+	if strings.Index(out, "Beta") > strings.Index(out, "Alpha") {
+		t.Fatalf("Beta should now come first:\n%s", out)
+	}
+}
+
+func TestSortFlagKeepsDayInTimeOrder(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, NewRootCommand(ctx, mgr), "todo", "--date", "2025-11-16", "--time", "11:00", "Late")
+	executeCommand(t, NewRootCommand(ctx, mgr), "log", "--sort", "--date", "2025-11-16", "--time", "09:00", "Early")
+
+	out := executeCommand(t, newJumpCommand(ctx, mgr), "2025-11-16")
+	if strings.Index(out, "Early") > strings.Index(out, "Late") {
+		t.Fatalf("Early should come first with --sort:\n%s", out)
+	}
+
+	executeCommand(t, NewRootCommand(ctx, mgr), "log", "--date", "2025-11-16", "--time", "08:00", "Unsorted")
+	out = executeCommand(t, newJumpCommand(ctx, mgr), "2025-11-16")
+	if strings.Index(out, "Unsorted") < strings.Index(out, "Late") {
+		t.Fatalf("Unsorted should stay last without --sort:\n%s", out)
+	}
+}
+
+func TestShowDiffPrintsChangedLines(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, NewRootCommand(ctx, mgr), "todo", "--date", "2025-11-16", "--time", "09:00", "Draft plan")
+	out := executeCommand(t, NewRootCommand(ctx, mgr), "done", "--show-diff", "--date", "2025-11-16", "1")
+	assertContains(t, out, "--- a/2025/2025-11.md\n+++ b/2025/2025-11.md\n")
+	assertContains(t, out, "@@ -1,4 +1,4 @@")
+	assertContains(t, out, "-- [ ] [09:00] Draft plan\n+- [x] [09:00] Draft plan\n")
+
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "undo", "--show-diff")
+	assertContains(t, out, "-- [x] [09:00] Draft plan\n+- [ ] [09:00] Draft plan\n")
+
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "done", "--date", "2025-11-16", "1")
+	assertNotContains(t, out, "+++")
+}
+
+func TestEntryCommandsAcceptSeveralIndexes(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	for _, text := range []string{"Alpha", "Beta", "Gamma", "Delta"} {
+		executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-16", "--time", "09:00", text)
+	}
+
+	out := executeCommand(t, newDoneCommand(ctx, mgr), "--date", "2025-11-16", "3", "1")
+	assertContains(t, out, "Done entry 1: [done] 09:00 Alpha")
+	assertContains(t, out, "Done entry 3: [done] 09:00 Gamma")
+
+	out = executeCommand(t, newToggleCommand(ctx, mgr), "--date", "2025-11-16", "1", "2")
+	assertContains(t, out, "Toggled entry 1: [todo] 09:00 Alpha")
+	assertContains(t, out, "Toggled entry 2: [in-progress] 09:00 Beta")
+
+	out = executeCommand(t, newEditCommand(ctx, mgr), "--date", "2025-11-16", "--time", "14:00", "2", "4")
+	assertContains(t, out, "Updated entry 2: [in-progress] 14:00 Beta")
+	assertContains(t, out, "Updated entry 4: [todo] 14:00 Delta")
+
+	out = executeCommand(t, newDeleteCommand(ctx, mgr), "--date", "2025-11-16", "4", "1")
+	assertContains(t, out, "Deleted entry 1")
+	assertContains(t, out, "Deleted entry 4")
+
+	out = executeCommand(t, newJumpCommand(ctx, mgr), "2025-11-16")
+	assertNotContains(t, out, "Alpha")
+	assertNotContains(t, out, "Delta")
+	assertContains(t, out, "Beta")
+	assertContains(t, out, "Gamma")
+
+	cmd := newDeleteCommand(ctx, mgr)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--date", "2025-11-16", "1", "9"})
+	if err := cmd.Execute(); err == nil {
+		t.Fatalf("expected error for out-of-range index")
+	}
+	out = executeCommand(t, newJumpCommand(ctx, mgr), "2025-11-16")
+	assertContains(t, out, "Beta")
+}
+
+func TestDupCommandClonesEntry(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-16", "--time", "09:00", "Rotate keys", "#ops")
+
+	out := executeCommand(t, newDupCommand(ctx, mgr), "--date", "2025-11-16", "--to", "2025-11-20", "1")
+	assertContains(t, out, "Duplicated entry 1 to 2025-11-20: [todo]")
+	assertContains(t, out, "Rotate keys (#ops)")
+
+	out = executeCommand(t, newDupCommand(ctx, mgr), "--date", "2025-11-16", "--keep-status", "--keep-time", "1")
+	assertContains(t, out, "Duplicated entry 1 to 2025-11-16: [done] 09:00 Rotate keys (#ops)")
+
+	section, err := logbook.NewReader(mgr).Section(ctx, mustParseDate(t, "2025-11-16"))
+	if err != nil {
+		t.Fatalf("Section: %v", err)
+	}
+	if len(section.Entries) != 2 {
+		t.Fatalf("expected original plus copy, got %d entries", len(section.Entries))
+	}
+
+	cmd := newDupCommand(ctx, mgr)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--date", "2025-11-16", "5"})
+	if err := cmd.Execute(); !errors.Is(err, logbook.ErrInvalidIndex) {
+		t.Fatalf("expected ErrInvalidIndex, got %v", err)
+	}
+}
+
+func TestLogCommandRecordsLinks(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	out := executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-15", "--time", "09:00", "Fix", "login", "ref:https://jira.example.com/AUTH-12", "#bug")
+	assertContains(t, out, "Fix login ref:https://jira.example.com/AUTH-12 (#bug)")
+
+	list := executeCommand(t, newListCommand(ctx, mgr), "--date", "2025-11-15")
+	assertContains(t, list, "ref:https://jira.example.com/AUTH-12")
+
+	data, err := os.ReadFile(mgr.MonthPath(mustParseDate(t, "2025-11-15")))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	assertContains(t, string(data), "- [x] [09:00] Fix login ref:https://jira.example.com/AUTH-12 #bug")
+
+	executeCommand(t, newEditCommand(ctx, mgr), "--date", "2025-11-15", "1", "Fix", "login", "ref:2025-11-14#1")
+	section, err := logbook.NewReader(mgr).Section(ctx, mustParseDate(t, "2025-11-15"))
+	if err != nil {
+		t.Fatalf("Section: %v", err)
+	}
+	if links := section.Entries[0].Links; len(links) != 1 || links[0] != "2025-11-14#1" {
+		t.Fatalf("links = %v", links)
+	}
+}
+
+func TestLogCommandAcceptsRelativeTime(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	before := time.Now().Add(-time.Hour).Truncate(time.Minute)
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-12", "Standup", "@-1h", "#team")
+	after := time.Now().Add(-time.Hour)
+
+	section, err := logbook.NewReader(mgr).Section(ctx, mustParseDate(t, "2025-11-12"))
+	if err != nil || len(section.Entries) != 1 {
+		t.Fatalf("Section = %+v, %v", section, err)
+	}
+	entry := section.Entries[0]
+	if entry.Text != "Standup" || len(entry.Tags) != 1 {
+		t.Fatalf("entry = %+v", entry)
+	}
+	clock := func(t time.Time) int { return t.Hour()*60 + t.Minute() }
+	if got := clock(entry.Time); got != clock(before) && got != clock(after) {
+		t.Fatalf("time = %s, want an hour before now", entry.Time.Format("15:04"))
+	}
+
+	cmd := newTodoCommand(ctx, mgr)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--time", "09:00", "Review", "@now"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "not both") {
+		t.Fatalf("expected --time and @now to conflict, got %v", err)
+	}
+}
+
+func TestPinCommandListsPinnedEntriesFirst(t *testing.T) {
+	mgr := newTempManager(t)
+	writer := logbook.NewWriter(mgr)
+
+	date := time.Date(2025, 11, 14, 0, 0, 0, 0, time.Local)
+	for i, text := range []string{"Standup", "Ship release"} {
+		if err := writer.Append(context.Background(), date, logbook.Entry{
+			Status: logbook.StatusTodo,
+			Time:   time.Date(2025, 11, 14, 9+i, 0, 0, 0, time.Local),
+			Text:   text,
+		}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	output := executeCommand(t, newPinCommand(context.Background(), mgr), "--date", "2025-11-14", "2")
+	assertContains(t, output, "Pinned entry 2: [todo] 10:00 * Ship release")
+
+	output = executeCommand(t, newListCommand(context.Background(), mgr), "--date", "2025-11-14")
+	if pinned, other := strings.Index(output, "2. [todo] 10:00 * Ship release"), strings.Index(output, "1. [todo] 09:00 Standup"); pinned < 0 || other < 0 || pinned > other {
+		t.Fatalf("pinned entry not listed first: %q", output)
+	}
+
+	output = executeCommand(t, newPinCommand(context.Background(), mgr), "--date", "2025-11-14", "2")
+	assertContains(t, output, "Unpinned entry 2: [todo] 10:00 Ship release")
+}
+
+func TestOpenCommandJumpsToDayHeading(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-03", "--time", "09:00", "Plan sprint")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-10", "--time", "09:00", "Review PRs")
+
+	var openedPath string
+	var openedLine int
+	original := runEditorAt
+	runEditorAt = func(path string, line int) error {
+		openedPath, openedLine = path, line
+		return nil
+	}
+	t.Cleanup(func() { runEditorAt = original })
+
+	for _, day := range []string{"2025-11-10", "2025-11-12"} {
+		executeCommand(t, newOpenCommand(ctx, mgr), "--date", day)
+		if openedPath != mgr.MonthPath(mustParseDate(t, day)) {
+			t.Fatalf("opened %q, want the November file", openedPath)
+		}
+		data, err := os.ReadFile(openedPath)
+		if err != nil {
+			t.Fatalf("ReadFile: %v", err)
+		}
+		lines := strings.Split(string(data), "\n")
+		if openedLine < 1 || lines[openedLine-1] != "## "+day {
+			t.Fatalf("line %d of %q is not the %s heading", openedLine, data, day)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/export"
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func newExportCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		formatFlag     string
+		outputFlag     string
+		durationFlag   time.Duration
+		dateFlag       string
+		weekFlag       bool
+		monthFlag      bool
+		fromFlag       string
+		toFlag         string
+		tagFlag        string
+		excludeTagFlag string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export [format]",
+		Short: "Export entries over a range of days for other tools.",
+		Long: "export writes the last 7 days ending on --date by default; use --month or --from/--to for other ranges.\n" +
+			"--format ics emits one calendar event per entry, starting at the entry time and lasting for a\n" +
+			"~duration annotation in its text (for example ~1h30m) or --duration. --format todotxt emits one\n" +
+			"todo.txt task per entry, with tags as +projects and pinned entries at priority (A). The format may\n" +
+			"also be given as an argument, as in kerja export todotxt. --tag keeps only entries with one of the\n" +
+			"comma-separated tags, and --exclude-tag leaves out entries with any of its tags.",
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: []string{formatICS, formatTodoTxt},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				if cmd.Flags().Changed("format") && formatFlag != args[0] {
+					return fmt.Errorf("format given as both %q and --format %q", args[0], formatFlag)
+				}
+				formatFlag = args[0]
+			}
+			if err := validateFormat(formatFlag, formatICS, formatTodoTxt); err != nil {
+				return err
+			}
+			start, end, err := resolveSummaryRange(dateFlag, weekFlag, monthFlag, fromFlag, toFlag)
+			if err != nil {
+				return err
+			}
+
+			sections, err := logbook.NewReader(manager).SectionsBetween(ctx, start, end)
+			if err != nil {
+				return err
+			}
+			sections = byTags(sections, logbook.ParseContext(tagFlag), logbook.ParseContext(excludeTagFlag))
+
+			render := func(w io.Writer) error {
+				if formatFlag == formatTodoTxt {
+					return export.TodoTxt(w, sections)
+				}
+				return export.ICS(w, sections, export.ICSOptions{DefaultDuration: durationFlag})
+			}
+			if outputFlag == "" || outputFlag == "-" {
+				return render(cmd.OutOrStdout())
+			}
+
+			file, err := os.Create(outputFlag)
+			if err != nil {
+				return fmt.Errorf("create %s: %w", outputFlag, err)
+			}
+			if err := render(file); err != nil {
+				file.Close()
+				return err
+			}
+			if err := file.Close(); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Wrote %d entr%s to %s\n", countEntries(sections), pluralSuffix(countEntries(sections)), outputFlag)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&formatFlag, "format", formatICS, "Output format: ics|todotxt")
+	cmd.Flags().StringVarP(&outputFlag, "output", "o", "", "Write to this file instead of stdout")
+	cmd.Flags().DurationVar(&durationFlag, "duration", 30*time.Minute, "Event length for entries without a ~duration annotation")
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Reference date in YYYY-MM-DD (default: today)")
+	cmd.Flags().BoolVar(&weekFlag, "week", false, "Export the 7 days ending on the reference date (default)")
+	cmd.Flags().BoolVar(&monthFlag, "month", false, "Export the calendar month containing the reference date")
+	cmd.Flags().StringVar(&fromFlag, "from", "", "First day of a custom range in YYYY-MM-DD")
+	cmd.Flags().StringVar(&toFlag, "to", "", "Last day of a custom range in YYYY-MM-DD (default: reference date)")
+	cmd.Flags().StringVar(&tagFlag, "tag", "", "Only export entries with one of these comma-separated tags")
+	cmd.Flags().StringVar(&excludeTagFlag, "exclude-tag", "", "Leave out entries with any of these comma-separated tags")
+
+	return cmd
+}
+
+func countEntries(sections []logbook.DateSection) int {
+	total := 0
+	for _, section := range sections {
+		total += len(section.Entries)
+	}
+	return total
+}
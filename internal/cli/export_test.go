@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportCommandICS(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-04", "--time", "09:00", "Standup ~15m", "#team")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-06", "--time", "13:00", "Write report")
+
+	out := executeCommand(t, newExportCommand(ctx, mgr), "--format", "ics", "--from", "2025-11-01", "--to", "2025-11-07")
+	assertContains(t, out, "BEGIN:VCALENDAR")
+	assertContains(t, out, "SUMMARY:Standup")
+	assertContains(t, out, "SUMMARY:Write report")
+	if strings.Count(out, "BEGIN:VEVENT") != 2 {
+		t.Fatalf("expected 2 events:\n%s", out)
+	}
+
+	path := filepath.Join(t.TempDir(), "log.ics")
+	out = executeCommand(t, newExportCommand(ctx, mgr), "--date", "2025-11-04", "-o", path)
+	assertContains(t, out, "Wrote 1 entry to "+path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	assertContains(t, string(data), "CATEGORIES:team")
+}
+
+func TestExportTodoTxtRoundTripsThroughImport(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-04", "--time", "09:00", "Ship login", "#web")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-05", "--time", "13:00", "*", "Write report", "#docs")
+
+	out := executeCommand(t, newExportCommand(ctx, mgr), "todotxt", "--from", "2025-11-01", "--to", "2025-11-07")
+	want := "x 2025-11-04 2025-11-04 Ship login +web\n(A) 2025-11-05 Write report +docs\n"
+	if out != want {
+		t.Fatalf("todo.txt export = %q, want %q", out, want)
+	}
+
+	path := filepath.Join(t.TempDir(), "todo.txt")
+	if err := os.WriteFile(path, []byte(out+"Call @phone +client\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	other := newTempManager(t)
+	out = executeCommand(t, newImportCommand(ctx, other), "--format", "todotxt", "--date", "2025-11-06", "--quiet", path)
+	assertContains(t, out, "Imported 3 entries across 1 month.")
+	list := executeCommand(t, newListCommand(ctx, other), "--date", "2025-11-06", "--days", "3")
+	assertContains(t, list, "[done] 00:00 Ship login (#web)")
+	assertContains(t, list, "[todo] 00:00 * Write report (#docs)")
+	assertContains(t, list, "[todo] 00:00 Call (#phone, #client)")
+}
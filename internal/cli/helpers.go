@@ -1,22 +1,29 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/locale"
 	"github.com/faizmokh/kerja/internal/logbook"
 )
 
 func resolveDate(dateFlag string) (time.Time, error) {
 	if dateFlag == "" {
-		now := time.Now().In(time.Local)
+		now := time.Now().In(logZone())
 		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()), nil
 	}
 
-	parsed, err := time.ParseInLocation("2006-01-02", dateFlag, time.Local)
+	parsed, err := time.ParseInLocation("2006-01-02", dateFlag, logZone())
 	if err != nil {
 		return time.Time{}, fmt.Errorf("parse date: %w", err)
 	}
@@ -29,15 +36,129 @@ func resolveTime(date time.Time, timeFlag string) (time.Time, error) {
 		return time.Date(date.Year(), date.Month(), date.Day(), now.Hour(), now.Minute(), 0, 0, date.Location()), nil
 	}
 
-	parsed, err := time.ParseInLocation("15:04", timeFlag, date.Location())
+	parsed, end, err := logbook.ParseClockRange(timeFlag, date)
+	if err == nil && !end.IsZero() {
+		err = fmt.Errorf("invalid time %q (expected a single time, not a range)", timeFlag)
+	}
 	if err != nil {
 		return time.Time{}, fmt.Errorf("parse time: %w", err)
 	}
+	return parsed, nil
+}
+
+// resolveTimeRange is resolveTime for flags that also accept an
+// HH:MM-HH:MM range or a time relative to now such as now, +30m, or -1h.
+// end is zero unless a range was given.
+func resolveTimeRange(date time.Time, timeFlag string) (time.Time, time.Time, error) {
+	if timeFlag == "" {
+		start, err := resolveTime(date, "")
+		return start, time.Time{}, err
+	}
+	if start, ok, err := logbook.ParseRelativeClock(timeFlag, date); ok {
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("parse time: %w", err)
+		}
+		return start, time.Time{}, nil
+	}
+	start, end, err := logbook.ParseClockRange(timeFlag, date)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("parse time: %w", err)
+	}
+	return start, end, nil
+}
 
-	return time.Date(date.Year(), date.Month(), date.Day(), parsed.Hour(), parsed.Minute(), 0, 0, date.Location()), nil
+// retime applies a resolved --time to entry. A single time moves a ranged
+// entry without changing its duration; a range replaces both ends.
+func retime(entry logbook.Entry, start, end time.Time) logbook.Entry {
+	if end.IsZero() {
+		return entry.Reschedule(start)
+	}
+	entry.Time = start
+	entry.End = end
+	return entry
 }
 
-func parseTextAndTags(args []string) (string, []string) {
+// formatDuration renders d as 1h30m, 2h, or 45m.
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours := int(d / time.Hour)
+	minutes := int((d % time.Hour) / time.Minute)
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%02dm", hours, minutes)
+	}
+}
+
+// logZone returns the configured time_zone, or the system zone when unset.
+// "Today" and "now" are reckoned in it.
+func logZone() *time.Location {
+	if settings.TimeZone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(settings.TimeZone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// displayLocale returns the configured locale for day and month names. The
+// config file is validated on load, so a bad value only comes from tests
+// poking settings and falls back to English.
+func displayLocale() locale.Locale {
+	names, err := locale.Parse(settings.Locale)
+	if err != nil {
+		return locale.English
+	}
+	return names
+}
+
+// workCalendar returns the configured weekend and holidays. The config
+// file is validated on load, so a bad value only comes from tests poking
+// settings and is treated as no days off.
+func workCalendar() logbook.Calendar {
+	calendar, _ := logbook.ParseCalendar(settings.Weekend, settings.Holidays)
+	return calendar
+}
+
+// clockLayout returns the time layout for the configured 12h/24h style.
+func clockLayout() string {
+	if settings.TimeFormat == "12h" {
+		return "3:04PM"
+	}
+	return "15:04"
+}
+
+func formatClock(t time.Time) string {
+	return t.Format(clockLayout())
+}
+
+// takeRelativeTime removes @now, @+30m, and @-1h style arguments from args
+// and resolves the last one on date. ok is false when there was none.
+func takeRelativeTime(args []string, date time.Time) (rest []string, when time.Time, ok bool, err error) {
+	for _, arg := range args {
+		if value, found := strings.CutPrefix(arg, "@"); found {
+			parsed, relative, parseErr := logbook.ParseRelativeClock(value, date)
+			if relative {
+				if parseErr != nil {
+					return nil, time.Time{}, false, parseErr
+				}
+				when, ok = parsed, true
+				continue
+			}
+		}
+		rest = append(rest, arg)
+	}
+	return rest, when, ok, nil
+}
+
+// parseEntryArgs reads the text, #tags, ref: links, after: dependencies,
+// ~author, and due: deadline from the words given to log, todo, and edit.
+func parseEntryArgs(args []string) logbook.Entry {
 	var (
 		textParts []string
 		tags      []string
@@ -51,14 +172,15 @@ func parseTextAndTags(args []string) (string, []string) {
 		textParts = append(textParts, arg)
 	}
 
-	return strings.TrimSpace(strings.Join(textParts, " ")), tags
+	text, links := logbook.SplitLinks(strings.TrimSpace(strings.Join(textParts, " ")))
+	text, after := logbook.SplitAfter(text)
+	text, author := logbook.SplitAuthor(text)
+	text, due := logbook.SplitDue(text, logZone())
+	return logbook.Entry{Text: text, Tags: tags, Links: links, After: after, Author: author, Due: due}
 }
 
 func formatEntry(entry logbook.Entry) string {
-	status := "todo"
-	if entry.Status == logbook.StatusDone {
-		status = "done"
-	}
+	status := entry.Status.String()
 
 	builder := strings.Builder{}
 	builder.Grow(32 + len(entry.Text) + len(entry.Tags)*6)
@@ -66,13 +188,47 @@ func formatEntry(entry logbook.Entry) string {
 	builder.WriteString("[")
 	builder.WriteString(status)
 	builder.WriteString("] ")
-	builder.WriteString(entry.Time.Format("15:04"))
+	builder.WriteString(formatClock(entry.Time))
+	if duration := entry.Duration(); duration > 0 {
+		builder.WriteString("-")
+		builder.WriteString(formatClock(entry.End))
+		builder.WriteString(" (")
+		builder.WriteString(formatDuration(duration))
+		builder.WriteString(")")
+	}
 
+	if entry.Pinned {
+		builder.WriteString(" *")
+	}
 	if entry.Text != "" {
 		builder.WriteString(" ")
 		builder.WriteString(entry.Text)
 	}
 
+	for _, link := range entry.Links {
+		builder.WriteString(" ")
+		builder.WriteString(logbook.LinkPrefix)
+		builder.WriteString(link)
+	}
+	for _, after := range entry.After {
+		builder.WriteString(" ")
+		builder.WriteString(logbook.AfterPrefix)
+		builder.WriteString(logbook.AfterRef(after))
+	}
+	if entry.Author != "" {
+		builder.WriteString(" ")
+		builder.WriteString(logbook.AuthorPrefix)
+		builder.WriteString(entry.Author)
+	}
+	if !entry.Due.IsZero() {
+		builder.WriteString(" (due ")
+		builder.WriteString(entry.Due.Format("2006-01-02"))
+		if entry.Overdue(time.Now().In(logZone())) {
+			builder.WriteString(", overdue")
+		}
+		builder.WriteString(")")
+	}
+
 	if len(entry.Tags) > 0 {
 		builder.WriteString(" (")
 		for i, tag := range entry.Tags {
@@ -88,45 +244,196 @@ func formatEntry(entry logbook.Entry) string {
 	return builder.String()
 }
 
+// parseIndexes converts positional index arguments into 1-based entry indexes,
+// sorted and without repeats to match the order the writer reports them in.
+func parseIndexes(args []string) ([]int, error) {
+	indexes := make([]int, 0, len(args))
+	for _, arg := range args {
+		index, err := strconv.Atoi(arg)
+		if err != nil || index <= 0 {
+			return nil, fmt.Errorf("index must be a positive integer")
+		}
+		indexes = append(indexes, index)
+	}
+	slices.Sort(indexes)
+	return slices.Compact(indexes), nil
+}
+
+// splitDatedIndexes lets index arguments name their day as YYYY-MM-DD#N, the
+// form script-filter items carry in arg, and returns the day to use in place
+// of --date with the arguments reduced to plain indexes. Every dated argument
+// must agree with --date and with each other.
+func splitDatedIndexes(dateFlag string, args []string) (string, []string, error) {
+	day := dateFlag
+	indexes := make([]string, len(args))
+	for i, arg := range args {
+		argDay, index, ok := strings.Cut(arg, "#")
+		if !ok {
+			indexes[i] = arg
+			continue
+		}
+		if day != "" && argDay != day {
+			return "", nil, fmt.Errorf("%s is not on %s", arg, day)
+		}
+		day, indexes[i] = argDay, index
+	}
+	return day, indexes, nil
+}
+
 func parseStatusFlag(value string, current logbook.Status) (logbook.Status, error) {
 	if value == "" {
 		return current, nil
 	}
 
-	switch strings.ToLower(value) {
-	case "todo":
-		return logbook.StatusTodo, nil
-	case "done":
-		return logbook.StatusDone, nil
-	default:
-		return current, fmt.Errorf("invalid status %q (expected todo|done)", value)
+	status, err := logbook.ParseStatus(value)
+	if err != nil {
+		return current, err
 	}
+	return status, nil
 }
 
 func printMissingSection(cmd *cobra.Command, date time.Time) {
 	fmt.Fprintf(cmd.OutOrStdout(), "No entries for %s\n", date.Format("2006-01-02"))
 }
 
-func printSection(cmd *cobra.Command, section logbook.DateSection) error {
+// printSection prints the entries of section that belong to scope (every
+// entry when it is empty) and satisfy match when it is not nil, leaving out
+// closed ones when openOnly is set. Entries keep their position in the whole
+// day as their number, so toggle, edit, and delete still find them. The
+// progress counts closed entries hidden by openOnly too.
+func printSection(cmd *cobra.Command, section logbook.DateSection, scope []string, match func(logbook.Entry) bool, openOnly bool) error {
+	show := func(entry logbook.Entry) bool {
+		return logbook.MatchesContext(entry, scope) && (match == nil || match(entry))
+	}
 	out := cmd.OutOrStdout()
-	fmt.Fprintf(out, "%s\n", section.Date.Format("2006-01-02"))
+	fmt.Fprint(out, section.Date.Format("2006-01-02"))
+	if progress := formatProgress(keepEntries(section, show)); progress != "" {
+		fmt.Fprint(out, glyphsFor(cmd).sep+progress)
+	}
+	if tracked := section.TrackedDuration(); tracked > 0 {
+		fmt.Fprintf(out, "%s%s tracked", glyphsFor(cmd).sep, formatDuration(tracked))
+	}
+	if len(scope) > 0 {
+		fmt.Fprintf(out, "%scontext %s", glyphsFor(cmd).sep, formatContext(scope))
+	}
+	fmt.Fprintln(out)
 	if len(section.Entries) == 0 {
 		fmt.Fprintln(out, "(no entries)")
 		return nil
 	}
 
-	for i, entry := range section.Entries {
+	shown, closed := 0, 0
+	for _, i := range section.DisplayOrder() {
+		entry := section.Entries[i]
+		if !show(entry) {
+			continue
+		}
+		if openOnly && !entry.Status.Open() {
+			closed++
+			continue
+		}
+		shown++
 		fmt.Fprintf(out, "%d. %s\n", i+1, formatEntry(entry))
+		for _, note := range entry.Notes {
+			fmt.Fprintf(out, "   %s\n", note)
+		}
+	}
+	switch {
+	case shown == 0 && closed > 0:
+		fmt.Fprintf(out, "(nothing left to do; %d closed hidden)\n", closed)
+	case shown == 0:
+		fmt.Fprintf(out, "(no entries in context; %d hidden)\n", len(section.Entries))
+	case closed > 0:
+		fmt.Fprintf(out, "(%d closed hidden)\n", closed)
 	}
 	return nil
 }
 
-func printSections(cmd *cobra.Command, sections []logbook.DateSection) error {
+// keepEntries returns a copy of section holding only the entries keep
+// accepts.
+func keepEntries(section logbook.DateSection, keep func(logbook.Entry) bool) logbook.DateSection {
+	kept := logbook.DateSection{Date: section.Date}
+	for _, entry := range section.Entries {
+		if keep(entry) {
+			kept.Entries = append(kept.Entries, entry)
+		}
+	}
+	return kept
+}
+
+// formatProgress renders how much of section is done, such as
+// "3/7 done, 43%", or "" when it has nothing to do.
+func formatProgress(section logbook.DateSection) string {
+	done, total := section.Progress()
+	if total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d done, %d%%", done, total, (done*100+total/2)/total)
+}
+
+// jsonRequested reports whether --json was set, either on the command itself or
+// through the root command's persistent flag.
+func jsonRequested(cmd *cobra.Command) bool {
+	flag := cmd.Flags().Lookup("json")
+	return flag != nil && flag.Value.String() == "true"
+}
+
+// textGlyphs are the decorative characters in CLI output.
+type textGlyphs struct {
+	sep, arrow, ellipsis string
+	bar                  rune
+	shades               []rune
+}
+
+var (
+	unicodeText = textGlyphs{sep: " · ", arrow: "→", ellipsis: "…", bar: '█', shades: []rune{'·', '░', '▒', '▓', '█'}}
+	asciiText   = textGlyphs{sep: " - ", arrow: "->", ellipsis: "...", bar: '#', shades: []rune{'.', ':', '+', '*', '#'}}
+)
+
+// plainRequested reports whether --plain was passed or NO_COLOR is set to a
+// non-empty value (see https://no-color.org).
+func plainRequested(cmd *cobra.Command) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return true
+	}
+	flag := cmd.Flags().Lookup("plain")
+	return flag != nil && flag.Value.String() == "true"
+}
+
+// glyphsFor returns the ASCII glyphs in plain mode and Unicode otherwise.
+func glyphsFor(cmd *cobra.Command) textGlyphs {
+	if plainRequested(cmd) {
+		return asciiText
+	}
+	return unicodeText
+}
+
+// printSectionsJSON writes sections as JSON: a single object when one is
+// given, an array otherwise. Missing entries encode as [] rather than null.
+func printSectionsJSON(cmd *cobra.Command, sections []logbook.DateSection, single bool) error {
+	for i := range sections {
+		if sections[i].Entries == nil {
+			sections[i].Entries = []logbook.Entry{}
+		}
+	}
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	if single && len(sections) == 1 {
+		return enc.Encode(sections[0])
+	}
+	if sections == nil {
+		sections = []logbook.DateSection{}
+	}
+	return enc.Encode(sections)
+}
+
+// printSections prints each of sections with printSection.
+func printSections(cmd *cobra.Command, sections []logbook.DateSection, scope []string, match func(logbook.Entry) bool) error {
 	if len(sections) == 0 {
 		return nil
 	}
 	for i, section := range sections {
-		if err := printSection(cmd, section); err != nil {
+		if err := printSection(cmd, section, scope, match, false); err != nil {
 			return err
 		}
 		if i < len(sections)-1 {
@@ -135,3 +442,110 @@ func printSections(cmd *cobra.Command, sections []logbook.DateSection) error {
 	}
 	return nil
 }
+
+// sortRequested reports whether day sections should be kept in time order:
+// --sort when given, otherwise sort_entries = "time" from the config.
+func sortRequested(cmd *cobra.Command) bool {
+	if flag := cmd.Flags().Lookup("sort"); flag != nil && flag.Changed {
+		return flag.Value.String() == "true"
+	}
+	return settings.SortEntries == "time"
+}
+
+// diffRequested reports whether --show-diff or show_diff asks for a diff of
+// every change.
+func diffRequested(cmd *cobra.Command) bool {
+	if flag := cmd.Flags().Lookup("show-diff"); flag != nil && flag.Changed {
+		return flag.Value.String() == "true"
+	}
+	return settings.ShowDiff
+}
+
+// newWriter returns a writer honoring sortRequested, diffRequested,
+// search_index, author, and webhook_url.
+func newWriter(cmd *cobra.Command, manager *files.Manager) *logbook.Writer {
+	w := logbook.NewWriter(manager).SortByTime(sortRequested(cmd)).MaintainIndex(settings.SearchIndex).StampAuthor(settings.Author)
+	if diffRequested(cmd) {
+		// Keep --json output parseable by sending the diff to stderr.
+		out := cmd.OutOrStdout()
+		if jsonRequested(cmd) {
+			out = cmd.ErrOrStderr()
+		}
+		w.OnWrite(func(path string, before, after []string) {
+			name := path
+			if rel, err := filepath.Rel(manager.BasePath(), path); err == nil {
+				name = filepath.ToSlash(rel)
+			}
+			fmt.Fprint(out, logbook.Diff(name, before, after))
+		})
+	}
+	if send := entryWebhook(func(err error) { fmt.Fprintf(cmd.ErrOrStderr(), "warning: %v\n", err) }); send != nil {
+		w.OnChange(send)
+	}
+	return w
+}
+
+// activeContext returns the tags of the configured context, or nil when
+// --no-context is passed.
+func activeContext(cmd *cobra.Command) []string {
+	if flag := cmd.Flags().Lookup("no-context"); flag != nil && flag.Value.String() == "true" {
+		return nil
+	}
+	return logbook.ParseContext(settings.Context)
+}
+
+// withinContext returns copies of sections holding only entries in scope.
+func withinContext(sections []logbook.DateSection, scope []string) []logbook.DateSection {
+	if len(scope) == 0 {
+		return sections
+	}
+	filtered := make([]logbook.DateSection, len(sections))
+	for i, section := range sections {
+		filtered[i] = logbook.DateSection{Date: section.Date}
+		for _, entry := range section.Entries {
+			if logbook.MatchesContext(entry, scope) {
+				filtered[i].Entries = append(filtered[i].Entries, entry)
+			}
+		}
+	}
+	return filtered
+}
+
+// byTags returns copies of the sections holding only the entries tagMatch
+// accepts, dropping sections left empty.
+func byTags(sections []logbook.DateSection, include, exclude []string) []logbook.DateSection {
+	return byMatch(sections, tagMatch(include, exclude))
+}
+
+// byMatch returns copies of the sections holding only the entries match
+// accepts, dropping sections left empty; a nil match keeps everything. The
+// copies number their entries afresh, so listings that print indexes pass
+// match to the printer instead.
+func byMatch(sections []logbook.DateSection, match func(logbook.Entry) bool) []logbook.DateSection {
+	if match == nil {
+		return sections
+	}
+	var filtered []logbook.DateSection
+	for _, section := range sections {
+		if kept := keepEntries(section, match); len(kept.Entries) > 0 {
+			filtered = append(filtered, kept)
+		}
+	}
+	return filtered
+}
+
+// tagMatch accepts entries that carry one of the include tags, when any are
+// given, and none of the exclude tags. Tags match without regard to case. It
+// returns nil when both are empty.
+func tagMatch(include, exclude []string) func(logbook.Entry) bool {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil
+	}
+	return func(entry logbook.Entry) bool {
+		return logbook.MatchesContext(entry, include) && (len(exclude) == 0 || !logbook.MatchesContext(entry, exclude))
+	}
+}
+
+func formatContext(scope []string) string {
+	return "#" + strings.Join(scope, " #")
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func newHistoryCommand(manager *files.Manager) *cobra.Command {
+	var (
+		dateFlag  string
+		limitFlag int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show what changed in the logbook and when.",
+		Long: "Every add, edit, toggle, delete, move, and undo appends the entries it changed, as they were\n" +
+			"before and after, to history.jsonl beneath the logbook root. history prints the most recent\n" +
+			"changes first; --date keeps only the changes to entries on that day. Unlike undo, the history is\n" +
+			"never pruned. Encrypted logbooks keep no history.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limitFlag < 0 {
+				return fmt.Errorf("--limit must be zero or more")
+			}
+			records, err := logbook.NewHistory(manager.BasePath()).Records()
+			if err != nil {
+				return err
+			}
+			if dateFlag != "" {
+				date, err := resolveDate(dateFlag)
+				if err != nil {
+					return err
+				}
+				records = historyOn(records, date.Format("2006-01-02"))
+			}
+			slices.Reverse(records)
+			if limitFlag > 0 && len(records) > limitFlag {
+				records = records[:limitFlag]
+			}
+
+			if jsonRequested(cmd) {
+				if records == nil {
+					records = []logbook.HistoryRecord{}
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(records)
+			}
+			out := cmd.OutOrStdout()
+			if len(records) == 0 {
+				fmt.Fprintln(out, "No changes recorded.")
+				return nil
+			}
+			arrow := glyphsFor(cmd).arrow
+			for _, record := range records {
+				fmt.Fprintf(out, "%s  %s\n", record.Time.Local().Format("2006-01-02 15:04:05"), record.Op)
+				for _, change := range record.Changes {
+					ref := logbook.EntryRef{Date: change.Date, Index: change.Index}
+					switch change.Kind {
+					case logbook.ChangeCreate:
+						fmt.Fprintf(out, "  created %s: %s\n", ref, formatEntry(*change.After))
+					case logbook.ChangeDelete:
+						fmt.Fprintf(out, "  deleted %s: %s\n", ref, formatEntry(*change.Before))
+					case logbook.ChangeToggle:
+						fmt.Fprintf(out, "  toggled %s: %s %s %s\n", ref, change.Before.Status, arrow, formatEntry(*change.After))
+					default:
+						fmt.Fprintf(out, "  edited %s: %s %s %s\n", ref, formatEntry(*change.Before), arrow, formatEntry(*change.After))
+					}
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Only show changes to entries on this day (YYYY-MM-DD)")
+	cmd.Flags().IntVar(&limitFlag, "limit", 20, "Show at most this many changes (0 for all)")
+
+	return cmd
+}
+
+// historyOn keeps the records that changed entries on day (YYYY-MM-DD),
+// each with only those changes.
+func historyOn(records []logbook.HistoryRecord, day string) []logbook.HistoryRecord {
+	var kept []logbook.HistoryRecord
+	for _, record := range records {
+		var changes []logbook.EntryChange
+		for _, change := range record.Changes {
+			if change.Date.Format("2006-01-02") == day {
+				changes = append(changes, change)
+			}
+		}
+		if len(changes) > 0 {
+			record.Changes = changes
+			kept = append(kept, record)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+	"github.com/faizmokh/kerja/internal/logging"
+)
+
+// runHook runs command through the shell in dir with KERJA_HOME set to it,
+// passing its output to out and its errors to the command's stderr.
+func runHook(cmd *cobra.Command, out io.Writer, dir, command string) error {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	hook := exec.Command(shell, flag, command)
+	hook.Dir = dir
+	hook.Env = append(os.Environ(), "KERJA_HOME="+dir)
+	hook.Stdout = out
+	hook.Stderr = cmd.ErrOrStderr()
+	logging.L().Info("run hook", "command", command, "dir", dir)
+	if err := hook.Run(); err != nil {
+		return fmt.Errorf("run %q: %w", command, err)
+	}
+	return nil
+}
+
+func newPushCommand(manager *files.Manager) *cobra.Command {
+	return newHookCommand(manager, "push", "push_command", "Copy the logbook to a remote with the configured push_command.", func() string { return settings.PushCommand })
+}
+
+func newPullCommand(manager *files.Manager) *cobra.Command {
+	return newHookCommand(manager, "pull", "pull_command", "Fetch the logbook from a remote with the configured pull_command.", func() string { return settings.PullCommand })
+}
+
+// newHookCommand builds kerja push or kerja pull, which run the shell
+// command set in key.
+func newHookCommand(manager *files.Manager, use, key, short string, command func() string) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long: fmt.Sprintf("%s runs the %s config setting through the shell in the logbook directory, with\n"+
+			"KERJA_HOME set to it, so any tool such as rclone or rsync can sync the logbook to Dropbox, S3,\n"+
+			"or elsewhere. Set auto_sync = true to pull before every command and push after any that changes the\n"+
+			"logbook, including TUI sessions.", use, key),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if command() == "" {
+				return fmt.Errorf("%s is not set; add it with kerja config set %s \"<command>\"", key, key)
+			}
+			return runHook(cmd, cmd.OutOrStdout(), manager.BasePath(), command())
+		},
+	}
+}
+
+// noAutoSync lists commands that never trigger auto_sync: they do not touch
+// the logbook or sync it themselves.
+var noAutoSync = []string{"push", "pull", "sync", "config", "completion", "version", "help", "install", "uninstall", "__complete", "__completeNoDesc"}
+
+// journalMark identifies the newest undo record before a command ran, so
+// syncAfter can tell whether the command wrote.
+var journalMark string
+
+// syncBefore runs pull_command ahead of the command when auto_sync is on.
+// Hook output goes to stderr so it never mixes with --json output.
+func syncBefore(cmd *cobra.Command, manager *files.Manager) error {
+	if !autoSyncs(cmd) {
+		return nil
+	}
+	if settings.PullCommand != "" {
+		if err := runHook(cmd, cmd.ErrOrStderr(), manager.BasePath(), settings.PullCommand); err != nil {
+			return fmt.Errorf("auto_sync: %w", err)
+		}
+	}
+	journalMark = latestChange(manager)
+	return nil
+}
+
+// syncAfter runs push_command when auto_sync is on and the command changed
+// the logbook, whether through the CLI or the TUI.
+func syncAfter(cmd *cobra.Command, manager *files.Manager) error {
+	if !autoSyncs(cmd) || settings.PushCommand == "" || latestChange(manager) == journalMark {
+		return nil
+	}
+	if err := runHook(cmd, cmd.ErrOrStderr(), manager.BasePath(), settings.PushCommand); err != nil {
+		return fmt.Errorf("auto_sync: %w", err)
+	}
+	return nil
+}
+
+func autoSyncs(cmd *cobra.Command) bool {
+	if !settings.AutoSync {
+		return false
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		if slices.Contains(noAutoSync, c.Name()) {
+			return false
+		}
+	}
+	return true
+}
+
+// latestChange summarizes the undo journal: its length and newest change.
+func latestChange(manager *files.Manager) string {
+	changes, err := logbook.NewJournal(manager.BasePath()).Changes()
+	if err != nil || len(changes) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d %s", len(changes), changes[0].Time.Format("2006-01-02T15:04:05.000000000"))
+}
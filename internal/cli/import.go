@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/importer"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func newImportCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		quietFlag    bool
+		progressFlag int
+		formatFlag   string
+		dateFlag     string
+		dryRunFlag   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import entries from a kerja logbook, a Markdown task list, a CSV file, or todo.txt.",
+		Long: "import streams entries from the file and writes each month as soon as the file moves past it, so\n" +
+			"a file ordered by date rewrites every month file once.\n" +
+			"Progress is reported on stderr, followed by a per-month summary.\n\n" +
+			"Formats:\n" +
+			"  kerja     a kerja month file (default)\n" +
+			"  markdown  checkbox task lists; headings containing YYYY-MM-DD set the day, otherwise --date is used\n" +
+			"  csv       a header row with date (required), time, status, text, and tags columns\n" +
+			"  todotxt   todo.txt tasks; x marks done, the completion or creation date sets the day (otherwise\n" +
+			"            --date), a priority pins the entry, and +project and @context become tags\n\n" +
+			"Use --dry-run to print the entries that would be written without touching the logbook.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateFormat(formatFlag, formatKerja, formatMarkdown, formatCSV, formatTodoTxt); err != nil {
+				return err
+			}
+			date, err := resolveDate(dateFlag)
+			if err != nil {
+				return err
+			}
+
+			file, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("open import file: %w", err)
+			}
+			defer file.Close()
+
+			opts := importer.Options{ProgressEvery: progressFlag}
+			if !quietFlag {
+				opts.Progress = func(read int) {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Read %d entries...\n", read)
+				}
+			}
+
+			src := newImportSource(formatFlag, file, date)
+			if dryRunFlag {
+				entries, err := importer.Read(ctx, src, opts)
+				if err != nil {
+					return err
+				}
+				return printImportPreview(cmd, entries)
+			}
+
+			writer := newWriter(cmd, manager)
+			summary, err := importer.Run(ctx, src, writer, opts)
+			if err != nil {
+				return err
+			}
+
+			printImportSummary(cmd, summary)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&quietFlag, "quiet", false, "Suppress progress output")
+	cmd.Flags().IntVar(&progressFlag, "progress-every", 500, "Report progress after this many entries")
+	cmd.Flags().StringVar(&formatFlag, "format", formatKerja, "Input format: kerja|markdown|csv|todotxt")
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Day for Markdown tasks without a dated heading and undated todo.txt tasks in YYYY-MM-DD (default: today)")
+	cmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Show what would be written without changing the logbook")
+
+	return cmd
+}
+
+func newImportSource(format string, r io.Reader, date time.Time) importer.Source {
+	switch format {
+	case formatMarkdown:
+		return importer.NewMarkdownSource(r, date)
+	case formatCSV:
+		return importer.NewCSVSource(r, date.Location())
+	case formatTodoTxt:
+		return importer.NewTodoTxtSource(r, date)
+	default:
+		return importer.NewLogbookSource(r)
+	}
+}
+
+// printImportPreview groups entries into the date sections they would be
+// appended to, in date order, followed by the per-month totals.
+func printImportPreview(cmd *cobra.Command, entries []logbook.Entry) error {
+	out := cmd.OutOrStdout()
+	if len(entries) == 0 {
+		fmt.Fprintln(out, "No entries to import.")
+		return nil
+	}
+
+	byDate := make(map[string]*logbook.DateSection)
+	var sections []*logbook.DateSection
+	for _, entry := range entries {
+		key := entry.Time.Format("2006-01-02")
+		section, ok := byDate[key]
+		if !ok {
+			section = &logbook.DateSection{Date: time.Date(entry.Time.Year(), entry.Time.Month(), entry.Time.Day(), 0, 0, 0, 0, entry.Time.Location())}
+			byDate[key] = section
+			sections = append(sections, section)
+		}
+		section.Entries = append(section.Entries, entry)
+	}
+	sort.SliceStable(sections, func(i, j int) bool { return sections[i].Date.Before(sections[j].Date) })
+
+	ordered := make([]logbook.DateSection, len(sections))
+	for i, section := range sections {
+		ordered[i] = *section
+	}
+	if err := printSections(cmd, ordered, nil, nil); err != nil {
+		return err
+	}
+
+	summary := importer.Summarize(entries)
+	fmt.Fprintf(out, "\nDry run: would import %d entr%s across %d month%s.\n", len(entries), pluralSuffix(len(entries)), len(summary), sSuffix(len(summary)))
+	return nil
+}
+
+func printImportSummary(cmd *cobra.Command, summary []importer.MonthSummary) {
+	out := cmd.OutOrStdout()
+	if len(summary) == 0 {
+		fmt.Fprintln(out, "No entries imported.")
+		return
+	}
+	total := 0
+	for _, month := range summary {
+		fmt.Fprintf(out, "%s: %d entr%s\n", month.Month, month.Entries, pluralSuffix(month.Entries))
+		total += month.Entries
+	}
+	fmt.Fprintf(out, "Imported %d entr%s across %d month%s.\n", total, pluralSuffix(total), len(summary), sSuffix(len(summary)))
+}
+
+func pluralSuffix(count int) string {
+	if count == 1 {
+		return "y"
+	}
+	return "ies"
+}
+
+func sSuffix(count int) string {
+	if count == 1 {
+		return ""
+	}
+	return "s"
+}
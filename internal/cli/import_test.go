@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestImportCommandPrintsMonthSummary(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	source := filepath.Join(t.TempDir(), "archive.md")
+	content := "## 2025-08-01\n- [x] [09:00] Old task\n\n## 2025-09-02\n- [ ] [10:00] Older todo\n- [x] [11:00] Done\n"
+	if err := os.WriteFile(source, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	out := executeCommand(t, newImportCommand(ctx, mgr), source)
+	assertContains(t, out, "Read 3 entries...")
+	assertContains(t, out, "2025-08: 1 entry\n2025-09: 2 entries\nImported 3 entries across 2 months.")
+
+	list := executeCommand(t, newListCommand(ctx, mgr), "--date", "2025-09-02")
+	assertContains(t, list, "[todo] 10:00 Older todo")
+}
+
+func TestImportCommandMarkdownDryRunAndCSV(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	dir := t.TempDir()
+
+	markdown := filepath.Join(dir, "tasks.md")
+	content := "- [ ] Loose task #inbox\n\n### Tuesday 2025-11-11\n- [x] 09:15 Review PR #code\n- plain note\n"
+	if err := os.WriteFile(markdown, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	out := executeCommand(t, newImportCommand(ctx, mgr), "--format", "markdown", "--date", "2025-11-10", "--dry-run", "--quiet", markdown)
+	assertContains(t, out, "2025-11-10 · 0/1 done, 0%\n1. [todo] 00:00 Loose task (#inbox)")
+	assertContains(t, out, "2025-11-11 · 1/1 done, 100%\n1. [done] 09:15 Review PR (#code)")
+	assertContains(t, out, "Dry run: would import 2 entries across 1 month.")
+	assertNotContains(t, out, "plain note")
+	if list := executeCommand(t, newListCommand(ctx, mgr), "--date", "2025-11-11", "--days", "2"); list != "No entries between 2025-11-10 and 2025-11-11\n" {
+		t.Fatalf("dry run wrote entries:\n%s", list)
+	}
+
+	csvPath := filepath.Join(dir, "tasks.csv")
+	csvContent := "date,time,status,text,tags\n2025-11-12,08:00,done,Deploy,\"ops, #release\"\n"
+	if err := os.WriteFile(csvPath, []byte(csvContent), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	out = executeCommand(t, newImportCommand(ctx, mgr), "--format", "csv", "--quiet", csvPath)
+	assertContains(t, out, "Imported 1 entry across 1 month.")
+	list := executeCommand(t, newJumpCommand(ctx, mgr), "2025-11-12")
+	assertContains(t, list, "[done] 08:00 Deploy (#ops, #release)")
+}
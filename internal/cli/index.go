@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func newIndexCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	return &cobra.Command{
+		Use:   "index",
+		Short: "Rebuild the search index.",
+		Long: "With search_index = true, every change updates the index under index/ beneath the logbook root, and\n" +
+			"search --all or --from/--to skips months it shows cannot match. Months edited outside kerja are read\n" +
+			"in full until they are indexed again; index rebuilds the whole index from the live month files.\n" +
+			"Archived months are never indexed, and encrypted logbooks and daily notes cannot be.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			index := logbook.NewIndex(manager.BasePath())
+			count, err := index.Rebuild(ctx, manager)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Indexed %d month files in %s\n", count, index.Path())
+			if !settings.SearchIndex {
+				fmt.Fprintln(cmd.OutOrStdout(), "search_index is off, so searches ignore the index; turn it on with kerja config set search_index true")
+			}
+			return nil
+		},
+	}
+}
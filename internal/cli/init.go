@@ -0,0 +1,290 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/config"
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/gitsync"
+)
+
+// initAnswers are the choices kerja init writes. Flags preset them; the
+// rest are asked for unless --yes accepts the defaults.
+type initAnswers struct {
+	dir        string
+	theme      string
+	timeFormat string
+	git        bool
+	remote     string
+}
+
+func newInitCommand() *cobra.Command {
+	var (
+		answers initAnswers
+		yesFlag bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Set up the logbook directory, git sync, theme, and time format.",
+		Long: "init asks where the logbook lives, whether to sync it with git, and which theme and time format\n" +
+			"to use, then writes the config file and creates the current month file. Existing settings are\n" +
+			"offered as the defaults, so init can be rerun to change them. Flags answer a question up front;\n" +
+			"--yes accepts the defaults for the rest without prompting. Running kerja for the first time from\n" +
+			"a terminal starts init before the TUI.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			preset := map[string]bool{
+				"dir":         flags.Changed("dir"),
+				"theme":       flags.Changed("theme"),
+				"time-format": flags.Changed("time-format"),
+				"git":         flags.Changed("git"),
+				"remote":      flags.Changed("remote"),
+			}
+			_, err := runInit(cmd, answers, preset, yesFlag)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&answers.dir, "dir", "", "Logbook directory (default: base_path or ~/.kerja)")
+	cmd.Flags().StringVar(&answers.theme, "theme", "", "TUI color theme: default, light, or mono")
+	cmd.Flags().StringVar(&answers.timeFormat, "time-format", "", "Clock style for displayed times: 24h or 12h")
+	cmd.Flags().BoolVar(&answers.git, "git", false, "Initialize the logbook directory as a git repository")
+	cmd.Flags().StringVar(&answers.remote, "remote", "", "Git remote URL to sync with as origin (implies --git)")
+	cmd.Flags().BoolVarP(&yesFlag, "yes", "y", false, "Accept the defaults instead of prompting")
+
+	return cmd
+}
+
+// runInit asks for every answer not in preset, writes the config file, and
+// creates the logbook. It returns the manager for the new logbook.
+func runInit(cmd *cobra.Command, answers initAnswers, preset map[string]bool, yes bool) (*files.Manager, error) {
+	path, err := config.Path()
+	if err != nil {
+		return nil, err
+	}
+	current, err := config.LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	out := cmd.OutOrStdout()
+	ask := &initPrompt{out: out, in: bufio.NewReader(cmd.InOrStdin()), yes: yes}
+
+	fromEnv := strings.TrimSpace(os.Getenv("KERJA_HOME")) != ""
+	if fromEnv {
+		if answers.dir, err = files.ResolveBasePath(); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(out, "Logbook directory: %s (from KERJA_HOME)\n", answers.dir)
+		preset["dir"] = true
+	}
+	if !preset["dir"] {
+		def := current.BasePath
+		if def == "" {
+			if def, err = files.ResolveBasePath(); err != nil {
+				return nil, err
+			}
+		}
+		if answers.dir, err = ask.value("Logbook directory", def, "base_path"); err != nil {
+			return nil, err
+		}
+	}
+	if !preset["theme"] {
+		if answers.theme, err = ask.value("Theme (default, light, mono)", current.Theme, "theme"); err != nil {
+			return nil, err
+		}
+	}
+	if !preset["time-format"] {
+		if answers.timeFormat, err = ask.value("Time format (24h, 12h)", current.TimeFormat, "time_format"); err != nil {
+			return nil, err
+		}
+	}
+	dir, err := expandDir(answers.dir)
+	if err != nil {
+		return nil, err
+	}
+	if answers.remote != "" {
+		answers.git = true
+	}
+	if !preset["git"] && !preset["remote"] {
+		_, openErr := gitsync.Open(dir)
+		if answers.git, err = ask.confirm("Sync the logbook with git?", openErr == nil); err != nil {
+			return nil, err
+		}
+	}
+	if answers.git && !preset["remote"] {
+		if answers.remote, err = ask.value("Git remote URL (blank to skip)", "", ""); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate every answer before writing anything.
+	check := config.Default()
+	for key, value := range map[string]string{"theme": answers.theme, "time_format": answers.timeFormat} {
+		if err := check.Set(key, value); err != nil {
+			return nil, err
+		}
+	}
+	if !fromEnv {
+		defaultDir, err := files.ResolveBasePath()
+		if err != nil {
+			return nil, err
+		}
+		if current.BasePath != "" || filepath.Clean(dir) != filepath.Clean(defaultDir) {
+			if err := config.SetInFile(path, "base_path", answers.dir); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := config.SetInFile(path, "theme", answers.theme); err != nil {
+		return nil, err
+	}
+	if err := config.SetInFile(path, "time_format", answers.timeFormat); err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(out, "Wrote %s\n", path)
+
+	cfg, err := config.LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	settings = cfg
+	manager, err := cfg.Manager()
+	if err != nil {
+		return nil, err
+	}
+	monthPath, err := manager.EnsureMonthFile(time.Now().In(logZone()))
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(out, "Created %s\n", monthPath)
+
+	if answers.git {
+		repo, err := gitsync.Init(manager.BasePath())
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(out, "Initialized git repository in %s\n", repo.Dir())
+		if answers.remote != "" {
+			if err := repo.SetRemote(gitsync.DefaultRemote, answers.remote); err != nil {
+				return nil, err
+			}
+			fmt.Fprintf(out, "Set remote %s to %s; run `kerja sync` to push.\n", gitsync.DefaultRemote, answers.remote)
+		}
+	}
+	return manager, nil
+}
+
+// needsSetup reports whether this is a first run from a terminal: no config
+// file and no logbook directory yet.
+func needsSetup(cmd *cobra.Command, manager *files.Manager) bool {
+	path, err := config.Path()
+	if err != nil {
+		return false
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		return false
+	}
+	if _, err := os.Stat(manager.BasePath()); !errors.Is(err, os.ErrNotExist) {
+		return false
+	}
+	return isTerminal(cmd.InOrStdin())
+}
+
+func expandDir(dir string) (string, error) {
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, strings.TrimPrefix(dir, "~"))
+	}
+	return filepath.Abs(dir)
+}
+
+// initPrompt asks init's questions, or takes every default when yes is set.
+type initPrompt struct {
+	out io.Writer
+	in  *bufio.Reader
+	yes bool
+}
+
+// value asks question until the answer is a valid setting for key, returning
+// def for a blank answer. An empty key accepts anything.
+func (p *initPrompt) value(question, def, key string) (string, error) {
+	if p.yes {
+		return def, nil
+	}
+	for {
+		if def != "" {
+			fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+		} else {
+			fmt.Fprintf(p.out, "%s: ", question)
+		}
+		answer, err := p.readLine()
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			answer = def
+		}
+		if key == "" {
+			return answer, nil
+		}
+		check := config.Default()
+		if err := check.Set(key, answer); err != nil {
+			fmt.Fprintf(p.out, "  %v\n", err)
+			continue
+		}
+		return answer, nil
+	}
+}
+
+// confirm asks a yes/no question, returning def for a blank answer.
+func (p *initPrompt) confirm(question string, def bool) (bool, error) {
+	if p.yes {
+		return def, nil
+	}
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	for {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, hint)
+		answer, err := p.readLine()
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(p.out, "  Answer y or n.")
+	}
+}
+
+func (p *initPrompt) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		if errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("init aborted: input closed")
+		}
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
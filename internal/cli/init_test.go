@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/config"
+)
+
+func TestInitPromptsAndWritesConfig(t *testing.T) {
+	original := settings
+	t.Cleanup(func() { settings = original })
+	root := t.TempDir()
+	configPath := filepath.Join(root, "config.toml")
+	logbook := filepath.Join(root, "notes")
+	t.Setenv("KERJA_CONFIG", configPath)
+	t.Setenv("KERJA_HOME", "")
+	os.Unsetenv("KERJA_HOME")
+
+	cmd := newInitCommand()
+	cmd.SetIn(strings.NewReader(logbook + "\npurple\nmono\n12h\nn\n"))
+	out := executeCommand(t, cmd)
+
+	assertContains(t, out, "Logbook directory [")
+	assertContains(t, out, "expected one of default|light|mono")
+	assertContains(t, out, "Sync the logbook with git? [y/N]")
+	assertContains(t, out, "Wrote "+configPath)
+
+	cfg, err := config.LoadFile(configPath)
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	if cfg.BasePath != logbook || cfg.Theme != "mono" || cfg.TimeFormat != "12h" {
+		t.Fatalf("config = %+v", cfg)
+	}
+	month := filepath.Join(logbook, time.Now().Format("2006"), time.Now().Format("2006-01")+".md")
+	if _, err := os.Stat(month); err != nil {
+		t.Fatalf("current month file missing: %v", err)
+	}
+	assertContains(t, out, "Created "+month)
+	if _, err := os.Stat(filepath.Join(logbook, ".git")); err == nil {
+		t.Fatal("declining git sync should not create a repository")
+	}
+}
+
+func TestInitWithFlagsSetsUpGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	original := settings
+	t.Cleanup(func() { settings = original })
+	root := t.TempDir()
+	configPath := filepath.Join(root, "config.toml")
+	logbook := filepath.Join(root, "logbook")
+	t.Setenv("KERJA_CONFIG", configPath)
+	t.Setenv("KERJA_HOME", logbook)
+
+	out := executeCommand(t, newInitCommand(), "--yes", "--theme", "light", "--remote", "git@example.com:me/logbook.git")
+	assertContains(t, out, "Logbook directory: "+logbook+" (from KERJA_HOME)")
+	assertContains(t, out, "Initialized git repository in "+logbook)
+	assertContains(t, out, "Set remote origin to git@example.com:me/logbook.git")
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if strings.Contains(string(data), "base_path") {
+		t.Fatalf("KERJA_HOME should keep base_path out of the config:\n%s", data)
+	}
+	assertContains(t, string(data), `theme = "light"`)
+	assertContains(t, string(data), `time_format = "24h"`)
+	if _, err := os.Stat(filepath.Join(logbook, ".gitignore")); err != nil {
+		t.Fatalf(".gitignore missing: %v", err)
+	}
+}
@@ -44,11 +44,16 @@ func TestCLIWorkflowEndToEnd(t *testing.T) {
 	assertContains(t, listOut, "Write integration tests")
 	assertContains(t, listOut, "Ship patch")
 
-	// 4. Toggle the todo to done.
+	// 4. Toggle the todo through in-progress to done.
 	toggleOut := executeCommand(t, newToggleCommand(ctx, mgr),
 		"--date", date,
 		"1",
 	)
+	assertContains(t, toggleOut, "Toggled entry 1: [in-progress]")
+	toggleOut = executeCommand(t, newToggleCommand(ctx, mgr),
+		"--date", date,
+		"1",
+	)
 	assertContains(t, toggleOut, "Toggled entry 1: [done]")
 
 	// 5. Edit the second entry's text, time, status, and tags.
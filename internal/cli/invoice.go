@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/invoice"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func newInvoiceCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		rateFlag       float64
+		tagFlag        string
+		excludeTagFlag string
+		monthFlag      string
+		fromFlag       string
+		toFlag         string
+		formatFlag     string
+		titleFlag      string
+		outFlag        string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "invoice",
+		Short: "Draft an invoice of tracked hours at an hourly rate.",
+		Long: "invoice sums the tracked time of each day in --month (default this month) or --from/--to and prices it at\n" +
+			"--rate per hour, one itemized line per day. An entry's time is its range (--time 09:00-10:30), or else a\n" +
+			"~1h30m annotation in its text; entries with neither are left off with a warning. --tag keeps only\n" +
+			"entries with one of the comma-separated tags, as in kerja invoice --tag billable --rate 120\n" +
+			"--month 2025-11, and --exclude-tag leaves out entries with any of its tags. The draft is Markdown, or\n" +
+			"CSV with --format csv.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateFormat(formatFlag, formatMarkdown, formatCSV); err != nil {
+				return err
+			}
+			if rateFlag <= 0 {
+				return fmt.Errorf("--rate must be a positive hourly rate")
+			}
+			start, end, err := resolveInvoiceRange(monthFlag, fromFlag, toFlag)
+			if err != nil {
+				return err
+			}
+
+			sections, err := logbook.NewReader(manager).SectionsBetween(ctx, start, end)
+			if err != nil {
+				return err
+			}
+			sections = byTags(sections, logbook.ParseContext(tagFlag), logbook.ParseContext(excludeTagFlag))
+
+			title := titleFlag
+			if title == "" {
+				title = "Invoice draft for " + start.Format("January 2006")
+				if fromFlag != "" {
+					title = "Invoice draft for " + formatRange(start, end)
+				}
+			}
+			inv := invoice.Build(title, start, end, rateFlag, sections)
+			if inv.Untimed > 0 {
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: %d entr%s without a time range or ~duration left off the invoice\n", inv.Untimed, pluralSuffix(inv.Untimed))
+			}
+
+			render := func(w io.Writer) error {
+				if formatFlag == formatCSV {
+					return invoice.CSV(w, inv)
+				}
+				return invoice.Markdown(w, inv)
+			}
+			if outFlag == "" || outFlag == "-" {
+				return render(cmd.OutOrStdout())
+			}
+
+			file, err := os.Create(outFlag)
+			if err != nil {
+				return fmt.Errorf("create %s: %w", outFlag, err)
+			}
+			if err := render(file); err != nil {
+				file.Close()
+				return err
+			}
+			if err := file.Close(); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Wrote invoice for %s (%.2f hours, %.2f) to %s\n", formatRange(start, end), float64(inv.Minutes)/60, inv.Amount, outFlag)
+			return nil
+		},
+	}
+
+	cmd.Flags().Float64Var(&rateFlag, "rate", 0, "Hourly rate to bill (required)")
+	cmd.Flags().StringVar(&tagFlag, "tag", "", "Only bill entries with one of these comma-separated tags")
+	cmd.Flags().StringVar(&excludeTagFlag, "exclude-tag", "", "Leave out entries with any of these comma-separated tags")
+	cmd.Flags().StringVar(&monthFlag, "month", "", "Month to bill in YYYY-MM (default: this month)")
+	cmd.Flags().StringVar(&fromFlag, "from", "", "First day of a custom range in YYYY-MM-DD")
+	cmd.Flags().StringVar(&toFlag, "to", "", "Last day of a custom range in YYYY-MM-DD (default: today)")
+	cmd.Flags().StringVar(&formatFlag, "format", formatMarkdown, "Output format: markdown or csv")
+	cmd.Flags().StringVar(&titleFlag, "title", "", "Invoice heading (default: Invoice draft for the period)")
+	cmd.Flags().StringVarP(&outFlag, "out", "o", "", "Write the invoice to this file instead of stdout")
+
+	return cmd
+}
+
+// resolveInvoiceRange returns the calendar month given as YYYY-MM, a
+// --from/--to range, or the current month when neither is set.
+func resolveInvoiceRange(month, from, to string) (time.Time, time.Time, error) {
+	if month != "" && (from != "" || to != "") {
+		return time.Time{}, time.Time{}, fmt.Errorf("--month and --from/--to are mutually exclusive")
+	}
+	if from != "" || to != "" {
+		return resolveSummaryRange("", false, false, from, to)
+	}
+	start, err := resolveDate("")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if month != "" {
+		if start, err = time.ParseInLocation("2006-01", month, logZone()); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --month %q (expected YYYY-MM)", month)
+		}
+	}
+	start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location())
+	return start, start.AddDate(0, 1, -1), nil
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInvoiceCommandBillsTaggedHours(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-03", "--time", "09:00-10:30", "Ship login", "#billable")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-03", "Review PR ~30m", "#billable")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-04", "--time", "13:00-14:00", "Gym", "#personal")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-05", "Untimed call", "#billable")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-12-01", "--time", "09:00-10:00", "Next month", "#billable")
+
+	cmd := newInvoiceCommand(ctx, mgr)
+	var out, errOut bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	cmd.SetArgs([]string{"--tag", "billable", "--rate", "120", "--month", "2025-11"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("invoice: %v", err)
+	}
+	assertContains(t, out.String(), "# Invoice draft for November 2025\n")
+	assertContains(t, out.String(), "| 2025-11-03 | Ship login; Review PR | 2.00 | 240.00 |\n")
+	assertContains(t, out.String(), "| **Total** | | **2.00** | **240.00** |\n")
+	assertNotContains(t, out.String(), "Gym")
+	assertNotContains(t, out.String(), "Next month")
+	assertContains(t, errOut.String(), "warning: 1 entry without a time range or ~duration left off the invoice")
+
+	path := filepath.Join(t.TempDir(), "invoice.csv")
+	got := executeCommand(t, newInvoiceCommand(ctx, mgr), "--rate", "100", "--from", "2025-11-01", "--to", "2025-11-30", "--exclude-tag", "billable", "--format", "csv", "-o", path)
+	assertContains(t, got, "Wrote invoice for 2025-11-01..2025-11-30 (1.00 hours, 100.00) to "+path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if want := "date,work,hours,amount\n2025-11-04,Gym,1.00,100.00\ntotal,,1.00,100.00\n"; string(data) != want {
+		t.Fatalf("csv = %q, want %q", data, want)
+	}
+
+	for _, args := range [][]string{{"--month", "2025-11"}, {"--rate", "50", "--month", "Nov"}, {"--rate", "50", "--month", "2025-11", "--from", "2025-11-01"}} {
+		cmd := newInvoiceCommand(ctx, mgr)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err == nil {
+			t.Fatalf("invoice %v: expected an error", args)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/config"
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logging"
+)
+
+// logFile is the --log-file being written, closed by closeLog.
+var logFile *os.File
+
+// setupLogging turns on tracing when --verbose, --debug, --log-file, or
+// KERJA_DEBUG asks for it, then records where the logbook was found. Records
+// go to stderr, or to the end of --log-file.
+func setupLogging(cmd *cobra.Command, manager *files.Manager) error {
+	closeLog()
+	level, enabled := logLevel(cmd)
+	path, _ := cmd.Flags().GetString("log-file")
+	if !enabled && path == "" {
+		logging.Set(nil)
+		return nil
+	}
+
+	out := cmd.ErrOrStderr()
+	if path != "" {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return fmt.Errorf("open log file: %w", err)
+		}
+		logFile, out = file, file
+	}
+	logging.Set(slog.New(slog.NewTextHandler(out, &slog.HandlerOptions{Level: level})))
+
+	configPath, _ := config.Path()
+	layout := "monthly"
+	if manager.Daily() {
+		layout = "daily"
+	}
+	logging.L().Info("resolve logbook", "command", cmd.CommandPath(), "base", manager.BasePath(), "config", configPath,
+		"layout", layout, "encrypted", manager.Encrypted(), "zone", manager.TimeZone().String())
+	return nil
+}
+
+// logLevel returns the most detailed level asked for: Debug for --debug or
+// a true KERJA_DEBUG, Info for --verbose. enabled is false for neither.
+func logLevel(cmd *cobra.Command) (level slog.Level, enabled bool) {
+	if debug, _ := cmd.Flags().GetBool("debug"); debug {
+		return slog.LevelDebug, true
+	}
+	if debug, err := strconv.ParseBool(os.Getenv("KERJA_DEBUG")); err == nil && debug {
+		return slog.LevelDebug, true
+	}
+	if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
+		return slog.LevelInfo, true
+	}
+	return slog.LevelInfo, false
+}
+
+// closeLog stops tracing and closes the --log-file, if any.
+func closeLog() {
+	logging.Set(nil)
+	if logFile != nil {
+		logFile.Close()
+		logFile = nil
+	}
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDebugTracesResolutionParsingAndWrites(t *testing.T) {
+	t.Cleanup(closeLog)
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	month := filepath.Join(mgr.BasePath(), "2025", "2025-11.md")
+	if err := os.MkdirAll(filepath.Dir(month), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(month, []byte("# November 2025\n\n## 2025-11-04\n- [x] [09:00] Shipped\n- [x] 10:00 Broken line\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	logPath := filepath.Join(t.TempDir(), "kerja.log")
+	out := executeCommand(t, NewRootCommand(ctx, mgr), "jump", "2025-11-04", "--debug", "--log-file", logPath)
+	assertNotContains(t, out, "level=")
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "log", "--date", "2025-11-04", "--time", "11:00", "Wrote docs", "--verbose", "--log-file", logPath)
+	assertNotContains(t, out, "level=")
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	log := string(data)
+	assertContains(t, log, `msg="resolve logbook" command="kerja jump" base=`+mgr.BasePath())
+	assertContains(t, log, `level=DEBUG msg="skip line that is not an entry" line=5 text="- [x] 10:00 Broken line"`)
+	assertContains(t, log, `msg="parsed month" month=2025-11 sections=1`)
+	assertContains(t, log, `msg="commit change" op="append to 2025-11-04" files=1`)
+	assertContains(t, log, `msg="write file" path=`+month)
+
+	// --verbose leaves parse steps out.
+	if err := os.Truncate(logPath, 0); err != nil {
+		t.Fatalf("Truncate: %v", err)
+	}
+	executeCommand(t, NewRootCommand(ctx, mgr), "jump", "2025-11-04", "--verbose", "--log-file", logPath)
+	data, _ = os.ReadFile(logPath)
+	assertContains(t, string(data), `msg="read month file"`)
+	assertNotContains(t, string(data), "level=DEBUG")
+
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "jump", "2025-11-04")
+	assertNotContains(t, out, "level=")
+}
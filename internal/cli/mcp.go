@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+	"github.com/faizmokh/kerja/internal/mcp"
+	"github.com/faizmokh/kerja/internal/version"
+)
+
+// mcpSearchLimit caps the results search returns when no limit is given, so
+// a broad term does not flood the assistant's context.
+const mcpSearchLimit = 50
+
+func newMCPCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	return &cobra.Command{
+		Use:   "mcp",
+		Short: "Serve the logbook to AI assistants as Model Context Protocol tools.",
+		Long: "mcp speaks the Model Context Protocol over stdin and stdout, so assistants such as Claude Desktop can\n" +
+			"read and append to the logbook with structured calls. Register `kerja mcp` as a stdio server in the\n" +
+			"assistant's settings. It offers three tools:\n\n" +
+			"  get_day     a day's entries, shaped like kerja today --json\n" +
+			"  add_entry   add an entry written as in the TUI prompt (@HH:MM, !status, #tags, ref: links)\n" +
+			"  search      entries matching any of the terms, like kerja search --json\n\n" +
+			"Writes honor the same settings as the CLI, such as author and webhook_url.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			server := mcp.NewServer("kerja", version.Version, mcpTools(cmd, manager)...)
+			return server.Serve(ctx, cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+}
+
+func mcpTools(cmd *cobra.Command, manager *files.Manager) []mcp.Tool {
+	// stdout carries the protocol, so --show-diff output is dropped.
+	writer := newWriter(cmd, manager).OnWrite(nil)
+	dateSchema := map[string]any{"type": "string", "description": "Day as YYYY-MM-DD; defaults to today"}
+	return []mcp.Tool{
+		{
+			Name:        "get_day",
+			Description: "List the entries logged on a day, in order, with their status, time, text, and tags.",
+			InputSchema: map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"date": dateSchema},
+			},
+			Call: func(ctx context.Context, raw json.RawMessage) (any, error) {
+				var args struct {
+					Date string `json:"date"`
+				}
+				if err := json.Unmarshal(raw, &args); err != nil {
+					return nil, err
+				}
+				date, err := resolveDate(args.Date)
+				if err != nil {
+					return nil, err
+				}
+				section, err := logbook.NewReader(manager).Section(ctx, date)
+				if errors.Is(err, logbook.ErrSectionNotFound) {
+					section, err = logbook.DateSection{Date: date}, nil
+				}
+				if err != nil {
+					return nil, err
+				}
+				if section.Entries == nil {
+					section.Entries = []logbook.Entry{}
+				}
+				return section, nil
+			},
+		},
+		{
+			Name: "add_entry",
+			Description: "Add an entry to a day's log. It is a todo stamped with the current time unless the text " +
+				"says otherwise: @HH:MM or @HH:MM-HH:MM sets the time, !done or !in-progress the status, #tag adds " +
+				"tags, and ref:URL attaches links.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"text": map[string]any{"type": "string", "description": "Entry text, e.g. \"Ship login !done #work @09:30\""},
+					"date": dateSchema,
+				},
+				"required": []string{"text"},
+			},
+			Call: func(ctx context.Context, raw json.RawMessage) (any, error) {
+				var args struct {
+					Text string `json:"text"`
+					Date string `json:"date"`
+				}
+				if err := json.Unmarshal(raw, &args); err != nil {
+					return nil, err
+				}
+				date, err := resolveDate(args.Date)
+				if err != nil {
+					return nil, err
+				}
+				entry, err := tokenEntry(args.Text, date, logbook.StatusTodo)
+				if err != nil {
+					return nil, err
+				}
+				if err := writer.Append(ctx, date, entry); err != nil {
+					return nil, err
+				}
+				return map[string]any{"date": date.Format("2006-01-02"), "entry": entry}, nil
+			},
+		},
+		{
+			Name: "search",
+			Description: "Find entries whose text or tags contain any of the terms, case-insensitively, oldest " +
+				"first. A term starting with # matches tags only. Searches every month unless from or to is given.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"terms": map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "minItems": 1},
+					"from":  map[string]any{"type": "string", "description": "First day to search, YYYY-MM-DD"},
+					"to":    map[string]any{"type": "string", "description": "Last day to search, YYYY-MM-DD"},
+					"limit": map[string]any{"type": "integer", "description": fmt.Sprintf("Most results to return (default %d)", mcpSearchLimit)},
+				},
+				"required": []string{"terms"},
+			},
+			Call: func(ctx context.Context, raw json.RawMessage) (any, error) {
+				var args struct {
+					Terms []string `json:"terms"`
+					From  string   `json:"from"`
+					To    string   `json:"to"`
+					Limit int      `json:"limit"`
+				}
+				if err := json.Unmarshal(raw, &args); err != nil {
+					return nil, err
+				}
+				if len(args.Terms) == 0 {
+					return nil, errors.New("terms is required")
+				}
+				if args.Limit <= 0 {
+					args.Limit = mcpSearchLimit
+				}
+				match, _, err := newSearchMatcher(args.Terms, searchOptions{})
+				if err != nil {
+					return nil, err
+				}
+				scope, err := resolveSearchScope(manager, "", args.From == "" && args.To == "", args.From, args.To)
+				if err != nil {
+					return nil, err
+				}
+				type result struct {
+					Date  string        `json:"date"`
+					Index int           `json:"index"`
+					Entry logbook.Entry `json:"entry"`
+				}
+				results := []result{}
+				err = scope.search(ctx, logbook.NewReader(manager), match, func(res searchResult) {
+					if len(results) < args.Limit {
+						results = append(results, result{res.section.Date.Format("2006-01-02"), res.index + 1, res.entry})
+					}
+				})
+				return results, err
+			},
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMCPToolsReadAddAndSearch(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-04", "--time", "09:00", "Ship login #work")
+
+	requests := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18"}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"add_entry","arguments":{"date":"2025-11-04","text":"Review PR !done #work @10:30"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"get_day","arguments":{"date":"2025-11-04"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"search","arguments":{"terms":["review"]}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"add_entry","arguments":{"text":" "}}}`,
+	}, "\n")
+	cmd := newMCPCommand(ctx, mgr)
+	var out bytes.Buffer
+	cmd.SetIn(strings.NewReader(requests + "\n"))
+	cmd.SetOut(&out)
+	cmd.SetArgs(nil)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("mcp: %v", err)
+	}
+
+	var texts []string
+	var errs []bool
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var resp struct {
+			Result struct {
+				Content []struct{ Text string }
+				IsError bool
+			}
+		}
+		if err := dec.Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(resp.Result.Content) > 0 {
+			texts = append(texts, resp.Result.Content[0].Text)
+			errs = append(errs, resp.Result.IsError)
+		}
+	}
+	if len(texts) != 4 {
+		t.Fatalf("got %d tool results: %q", len(texts), texts)
+	}
+	assertContains(t, texts[0], `"status": "done"`)
+	assertContains(t, texts[1], `"text": "Ship login"`)
+	assertContains(t, texts[1], `"text": "Review PR"`)
+	assertContains(t, texts[2], `"index": 2`)
+	assertNotContains(t, texts[2], "Ship login")
+	if !errs[3] || texts[3] != "text is required" {
+		t.Fatalf("empty add_entry = %q, isError %v", texts[3], errs[3])
+	}
+}
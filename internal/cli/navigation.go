@@ -5,8 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/spf13/cobra"
 
@@ -64,7 +70,7 @@ func newJumpCommand(ctx context.Context, manager *files.Manager) *cobra.Command
 		Short: "Show entries for the specified date.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			target, err := time.ParseInLocation("2006-01-02", args[0], time.Local)
+			target, err := time.ParseInLocation("2006-01-02", args[0], logZone())
 			if err != nil {
 				return fmt.Errorf("parse date: %w", err)
 			}
@@ -78,15 +84,26 @@ func newJumpCommand(ctx context.Context, manager *files.Manager) *cobra.Command
 
 func newListCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
 	var (
-		dateFlag string
-		daysFlag int
-		weekFlag bool
+		dateFlag       string
+		daysFlag       int
+		weekFlag       bool
+		workdays       bool
+		formatFlag     string
+		authorFlag     string
+		tagFlag        string
+		excludeTagFlag string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List entries across a range of days.",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateFormat(formatFlag, formatText, formatJSON, formatOneline); err != nil {
+				return err
+			}
+			if jsonRequested(cmd) {
+				formatFlag = formatJSON
+			}
 			date, err := resolveDate(dateFlag)
 			if err != nil {
 				return err
@@ -106,20 +123,59 @@ func newListCommand(ctx context.Context, manager *files.Manager) *cobra.Command
 			if err != nil {
 				return err
 			}
+			if workdays {
+				calendar := workCalendar()
+				sections = slices.DeleteFunc(sections, func(section logbook.DateSection) bool {
+					return !calendar.Workday(section.Date)
+				})
+			}
+			var author string
+			if authorFlag != "" {
+				if author, err = logbook.ParseAuthor(authorFlag); err != nil {
+					return err
+				}
+			}
+			// Days stay whole so entries keep their index in the day; only
+			// days with nothing to show are dropped.
+			match := tagMatch(logbook.ParseContext(tagFlag), logbook.ParseContext(excludeTagFlag))
+			if author != "" {
+				tags := match
+				match = func(entry logbook.Entry) bool {
+					return logbook.MatchesAuthor(entry, author) && (tags == nil || tags(entry))
+				}
+			}
+			if match != nil {
+				sections = slices.DeleteFunc(sections, func(section logbook.DateSection) bool {
+					return !slices.ContainsFunc(section.Entries, match)
+				})
+			}
 
+			scope := activeContext(cmd)
+			switch formatFlag {
+			case formatJSON:
+				return printSectionsJSON(cmd, withinContext(byMatch(sections, match), scope), false)
+			case formatOneline:
+				printSectionsOneline(cmd.OutOrStdout(), sections, scope, match)
+				return nil
+			}
 			if len(sections) == 0 {
 				fmt.Fprintf(cmd.OutOrStdout(), "No entries between %s and %s\n",
 					start.Format("2006-01-02"), date.Format("2006-01-02"))
 				return nil
 			}
 
-			return printSections(cmd, sections)
+			return printSections(cmd, sections, scope, match)
 		},
 	}
 
 	cmd.Flags().StringVar(&dateFlag, "date", "", "End date in YYYY-MM-DD (default: today)")
 	cmd.Flags().IntVar(&daysFlag, "days", 0, "Number of days to include ending on target date")
 	cmd.Flags().BoolVar(&weekFlag, "week", false, "Shortcut for --days=7")
+	cmd.Flags().BoolVar(&workdays, "workdays", false, "Skip weekend days and holidays (see the weekend and holidays settings)")
+	cmd.Flags().StringVar(&authorFlag, "author", "", "Only entries credited to this author (~name) in a shared logbook")
+	cmd.Flags().StringVar(&tagFlag, "tag", "", "Only list entries with one of these comma-separated tags")
+	cmd.Flags().StringVar(&excludeTagFlag, "exclude-tag", "", "Leave out entries with any of these comma-separated tags")
+	cmd.Flags().StringVar(&formatFlag, "format", formatText, "Output format: text, json, or oneline (tab-separated)")
 
 	return cmd
 }
@@ -130,36 +186,104 @@ func newSearchCommand(ctx context.Context, manager *files.Manager) *cobra.Comman
 		caseSensitive bool
 		outputJSON    bool
 		includeText   bool
+		regexFlag     bool
+		allTermsFlag  bool
+		formatFlag    string
+		allFlag       bool
+		fromFlag      string
+		toFlag        string
+		interactive   bool
 	)
 
 	cmd := &cobra.Command{
-		Use:   "search <term>",
+		Use:   "search <term>...",
 		Short: "Search entries by text or tag within the month.",
-		Args:  cobra.ExactArgs(1),
+		Long: "search matches entries in the month containing --date. --all scans every month file, including\n" +
+			"archived ones, and --from/--to limit the scan to a date range. Several month files are read at once,\n" +
+			"and text results are printed in date order as each month finishes. With search_index = true, months the\n" +
+			"index shows cannot match are skipped.\n\n" +
+			"With several terms an entry matches when any term does, or every term with --all-terms. --regex\n" +
+			"treats each term as a regular expression; a leading # still restricts it to tags.\n\n" +
+			"--interactive lists the matches in a picker: Enter prints the chosen entry in full and o opens\n" +
+			"the TUI on its day with it selected. --format oneline streams one tab-separated line per match.",
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			term := strings.TrimSpace(args[0])
-			if term == "" {
-				return fmt.Errorf("term is required")
+			if err := validateFormat(formatFlag, formatText, formatJSON, formatOneline, formatScriptFilter); err != nil {
+				return err
 			}
-			date, err := resolveDate(dateFlag)
+			if outputJSON {
+				formatFlag = formatJSON
+			}
+			if interactive && formatFlag != formatText {
+				return fmt.Errorf("--interactive cannot be combined with --json or --format %s", formatFlag)
+			}
+
+			opts := searchOptions{
+				caseSensitive: caseSensitive,
+				includeText:   includeText,
+				regex:         regexFlag,
+				allTerms:      allTermsFlag,
+			}
+			match, label, err := newSearchMatcher(args, opts)
+			if err != nil {
+				return err
+			}
+			scope, err := resolveSearchScope(manager, dateFlag, allFlag, fromFlag, toFlag)
 			if err != nil {
 				return err
 			}
+			if may := indexPredicate(args, opts); settings.SearchIndex && may != nil && len(scope.months) > 1 {
+				scope.months, err = logbook.NewIndex(manager.BasePath()).Candidates(manager, scope.months, may)
+				if err != nil {
+					return err
+				}
+			}
 
-			startOfMonth := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
-			endOfMonth := startOfMonth.AddDate(0, 1, -1)
+			bar := scanProgress(cmd)
+			reader := logbook.NewReader(manager).OnProgress(bar.update)
+			if formatFlag == formatOneline {
+				out := cmd.OutOrStdout()
+				err := scope.search(ctx, reader, match, func(res searchResult) {
+					bar.clear()
+					printOneline(out, res.section.Date, res.index+1, res.entry)
+				})
+				bar.clear()
+				return err
+			}
+			if formatFlag == formatText && !interactive {
+				out := cmd.OutOrStdout()
+				fmt.Fprintf(out, "Results for %s in %s\n", label, scope.label)
+				found := 0
+				err := scope.search(ctx, reader, match, func(res searchResult) {
+					found++
+					bar.clear()
+					printSearchResult(out, res)
+				})
+				bar.clear()
+				if err != nil {
+					return err
+				}
+				if found == 0 {
+					fmt.Fprintln(out, "(no matches)")
+				}
+				return nil
+			}
 
-			reader := logbook.NewReader(manager)
-			sections, err := reader.SectionsBetween(ctx, startOfMonth, endOfMonth)
+			var results []searchResult
+			err = scope.search(ctx, reader, match, func(res searchResult) {
+				results = append(results, res)
+			})
+			bar.clear()
 			if err != nil {
 				return err
 			}
-
-			results := filterSectionsByTerm(sections, term, caseSensitive, includeText)
-			if outputJSON {
-				return printSearchResultsJSON(cmd, results)
+			if interactive {
+				return pickSearchResult(ctx, cmd, manager, fmt.Sprintf("Results for %s in %s", label, scope.label), results)
+			}
+			if formatFlag == formatScriptFilter {
+				return printSearchResultsScriptFilter(cmd, results)
 			}
-			return printSearchResultsText(cmd, term, startOfMonth, results)
+			return printSearchResultsJSON(cmd, results)
 		},
 	}
 
@@ -167,20 +291,123 @@ func newSearchCommand(ctx context.Context, manager *files.Manager) *cobra.Comman
 	cmd.Flags().BoolVar(&caseSensitive, "case-sensitive", false, "Match term with case sensitivity")
 	cmd.Flags().BoolVar(&outputJSON, "json", false, "Emit results as JSON objects")
 	cmd.Flags().BoolVar(&includeText, "include-text", false, "Include body text when matching tag-only searches")
+	cmd.Flags().BoolVar(&regexFlag, "regex", false, "Treat each term as a regular expression")
+	cmd.Flags().BoolVar(&allTermsFlag, "all-terms", false, "Require every term to match instead of any")
+	cmd.Flags().StringVar(&formatFlag, "format", formatText, "Output format: text, json, oneline (tab-separated), or script-filter (Alfred/Raycast JSON)")
+	cmd.Flags().BoolVar(&allFlag, "all", false, "Search every month, including archived ones")
+	cmd.Flags().StringVar(&fromFlag, "from", "", "Search from this date in YYYY-MM-DD (across months)")
+	cmd.Flags().StringVar(&toFlag, "to", "", "Search up to this date in YYYY-MM-DD (default with --from: today)")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Pick a match to show in full or open in the TUI")
 
 	return cmd
 }
 
+// searchScope is the set of months a search reads and the dates it keeps.
+type searchScope struct {
+	months   []time.Time
+	from, to time.Time
+	label    string
+}
+
+// resolveSearchScope picks the month containing dateFlag, every month with
+// --all, or the months overlapping --from/--to. Multi-month scopes only list
+// months that have a file so the scan never creates empty ones.
+func resolveSearchScope(manager *files.Manager, dateFlag string, all bool, fromFlag, toFlag string) (searchScope, error) {
+	if !all && fromFlag == "" && toFlag == "" {
+		date, err := resolveDate(dateFlag)
+		if err != nil {
+			return searchScope{}, err
+		}
+		start := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+		return searchScope{
+			months: []time.Time{start},
+			from:   start,
+			to:     start.AddDate(0, 1, -1),
+			label:  start.Format("2006-01"),
+		}, nil
+	}
+	if all && (fromFlag != "" || toFlag != "") {
+		return searchScope{}, fmt.Errorf("--all cannot be combined with --from/--to")
+	}
+
+	scope := searchScope{label: "all months"}
+	if !all {
+		var err error
+		if fromFlag != "" {
+			if scope.from, err = resolveDate(fromFlag); err != nil {
+				return searchScope{}, err
+			}
+		}
+		if scope.to, err = resolveDate(toFlag); err != nil {
+			return searchScope{}, err
+		}
+		if scope.to.Before(scope.from) {
+			return searchScope{}, fmt.Errorf("--to must not be before --from")
+		}
+		scope.label = fmt.Sprintf("%s..%s", formatOptionalDate(scope.from, "start"), scope.to.Format("2006-01-02"))
+	}
+
+	months, err := manager.Months()
+	if err != nil {
+		return searchScope{}, err
+	}
+	for _, month := range months {
+		last := month.AddDate(0, 1, -1)
+		if !scope.from.IsZero() && last.Before(scope.from) || !scope.to.IsZero() && month.After(scope.to) {
+			continue
+		}
+		scope.months = append(scope.months, month)
+	}
+	return scope, nil
+}
+
+func formatOptionalDate(date time.Time, fallback string) string {
+	if date.IsZero() {
+		return fallback
+	}
+	return date.Format("2006-01-02")
+}
+
+// search reads one month at a time, oldest first, and emits each match in
+// date order before moving on.
+func (s searchScope) search(ctx context.Context, reader *logbook.Reader, match func(logbook.Entry) bool, emit func(searchResult)) error {
+	from, to := s.from.Format("2006-01-02"), s.to.Format("2006-01-02")
+	return reader.ScanMonths(ctx, s.months, func(month time.Time, sections []logbook.DateSection) error {
+		sort.SliceStable(sections, func(i, j int) bool { return sections[i].Date.Before(sections[j].Date) })
+
+		// Section dates are parsed in UTC, so compare calendar days.
+		kept := sections[:0]
+		for _, section := range sections {
+			day := section.Date.Format("2006-01-02")
+			if !s.from.IsZero() && day < from || !s.to.IsZero() && day > to {
+				continue
+			}
+			kept = append(kept, section)
+		}
+		for _, res := range filterSections(kept, match) {
+			emit(res)
+		}
+		return nil
+	})
+}
+
 func displaySection(ctx context.Context, cmd *cobra.Command, reader *logbook.Reader, date time.Time) error {
 	section, err := reader.Section(ctx, date)
 	if err != nil {
-		if errors.Is(err, logbook.ErrSectionNotFound) {
-			printMissingSection(cmd, date)
-			return nil
+		if !errors.Is(err, logbook.ErrSectionNotFound) {
+			return err
 		}
-		return err
+		if jsonRequested(cmd) {
+			return printSectionsJSON(cmd, []logbook.DateSection{{Date: date}}, true)
+		}
+		printMissingSection(cmd, date)
+		return nil
 	}
-	return printSection(cmd, section)
+	scope := activeContext(cmd)
+	if jsonRequested(cmd) {
+		return printSectionsJSON(cmd, withinContext([]logbook.DateSection{section}, scope), true)
+	}
+	return printSection(cmd, section, scope, nil, false)
 }
 
 type searchResult struct {
@@ -189,15 +416,11 @@ type searchResult struct {
 	index   int
 }
 
-func filterSectionsByTerm(sections []logbook.DateSection, term string, caseSensitive bool, includeText bool) []searchResult {
+func filterSections(sections []logbook.DateSection, match func(logbook.Entry) bool) []searchResult {
 	var results []searchResult
-	needle := term
-	tagNeedle := strings.TrimPrefix(term, "#")
-	matchTagOnly := strings.HasPrefix(term, "#")
-
 	for _, section := range sections {
 		for idx, entry := range section.Entries {
-			if matchesEntry(entry, needle, tagNeedle, matchTagOnly, caseSensitive, includeText) {
+			if match(entry) {
 				results = append(results, searchResult{
 					section: section,
 					entry:   entry,
@@ -210,6 +433,111 @@ func filterSectionsByTerm(sections []logbook.DateSection, term string, caseSensi
 	return results
 }
 
+type searchOptions struct {
+	caseSensitive bool
+	includeText   bool
+	regex         bool
+	allTerms      bool
+}
+
+// newSearchMatcher combines the terms into one predicate: any term matching
+// is enough unless allTerms is set. It also returns the terms quoted and
+// joined with OR/AND for result headers.
+func newSearchMatcher(terms []string, opts searchOptions) (func(logbook.Entry) bool, string, error) {
+	var (
+		matchers []func(logbook.Entry) bool
+		quoted   []string
+	)
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			return nil, "", fmt.Errorf("term is required")
+		}
+		quoted = append(quoted, strconv.Quote(term))
+
+		tagOnly := strings.HasPrefix(term, "#")
+		if !opts.regex {
+			matchers = append(matchers, func(entry logbook.Entry) bool {
+				return matchesEntry(entry, term, strings.TrimPrefix(term, "#"), tagOnly, opts.caseSensitive, opts.includeText)
+			})
+			continue
+		}
+
+		pattern := strings.TrimPrefix(term, "#")
+		if !opts.caseSensitive {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid regex %q: %w", term, err)
+		}
+		matchers = append(matchers, func(entry logbook.Entry) bool {
+			if (!tagOnly || opts.includeText) && re.MatchString(entry.Text) {
+				return true
+			}
+			return slices.ContainsFunc(entry.Tags, re.MatchString)
+		})
+	}
+
+	joiner := " OR "
+	if opts.allTerms {
+		joiner = " AND "
+	}
+	match := func(entry logbook.Entry) bool {
+		for _, matcher := range matchers {
+			matched := matcher(entry)
+			if matched && !opts.allTerms {
+				return true
+			}
+			if !matched && opts.allTerms {
+				return false
+			}
+		}
+		return opts.allTerms
+	}
+	return match, strings.Join(quoted, joiner), nil
+}
+
+// indexPredicate mirrors newSearchMatcher over a month's indexed words and
+// tags, reporting whether the month may hold a match. Words are lowercased,
+// so the predicate ignores --case-sensitive and may keep months the matcher
+// then rejects. It returns nil when the index cannot help: for --regex and
+// for terms containing whitespace, which can span words.
+func indexPredicate(terms []string, opts searchOptions) func(logbook.MonthTerms) bool {
+	if opts.regex {
+		return nil
+	}
+	var preds []func(logbook.MonthTerms) bool
+	for _, term := range terms {
+		needle := strings.ToLower(strings.TrimSpace(term))
+		tagOnly := strings.HasPrefix(needle, "#")
+		if tagOnly {
+			needle = strings.TrimPrefix(needle, "#")
+		}
+		if strings.ContainsFunc(needle, unicode.IsSpace) {
+			return nil
+		}
+		preds = append(preds, func(t logbook.MonthTerms) bool {
+			if tagOnly {
+				return t.HasTag(needle, true) || opts.includeText && needle != "" && t.HasWord(needle)
+			}
+			return t.HasWord(needle) || t.HasTag(needle, false)
+		})
+	}
+	return func(t logbook.MonthTerms) bool {
+		for _, pred := range preds {
+			matched := pred(t)
+			if matched && !opts.allTerms {
+				return true
+			}
+			if !matched && opts.allTerms {
+				return false
+			}
+		}
+		return opts.allTerms
+	}
+}
+
 func matchesEntry(entry logbook.Entry, needle, tagNeedle string, tagOnly bool, caseSensitive bool, includeText bool) bool {
 	text := entry.Text
 	textNeedle := needle
@@ -252,22 +580,12 @@ func matchesEntry(entry logbook.Entry, needle, tagNeedle string, tagOnly bool, c
 	return false
 }
 
-func printSearchResultsText(cmd *cobra.Command, term string, start time.Time, results []searchResult) error {
-	out := cmd.OutOrStdout()
-	fmt.Fprintf(out, "Results for %q in %s\n", term, start.Format("2006-01"))
-	if len(results) == 0 {
-		fmt.Fprintln(out, "(no matches)")
-		return nil
-	}
-
-	for _, res := range results {
-		fmt.Fprintf(out, "%s #%d %s\n",
-			res.section.Date.Format("2006-01-02"),
-			res.index+1,
-			formatEntry(res.entry),
-		)
-	}
-	return nil
+func printSearchResult(out io.Writer, res searchResult) {
+	fmt.Fprintf(out, "%s #%d %s\n",
+		res.section.Date.Format("2006-01-02"),
+		res.index+1,
+		formatEntry(res.entry),
+	)
 }
 
 func printSearchResultsJSON(cmd *cobra.Command, results []searchResult) error {
@@ -290,3 +608,11 @@ func printSearchResultsJSON(cmd *cobra.Command, results []searchResult) error {
 	enc.SetIndent("", "  ")
 	return enc.Encode(list)
 }
+
+func printSearchResultsScriptFilter(cmd *cobra.Command, results []searchResult) error {
+	items := make([]scriptFilterItem, 0, len(results))
+	for _, res := range results {
+		items = append(items, newScriptFilterItem(res.section.Date, res.index+1, res.entry))
+	}
+	return printScriptFilter(cmd, items)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -156,6 +157,52 @@ func TestListCommandAggregatesRange(t *testing.T) {
 	}
 }
 
+func TestListCommandWorkdaysSkipsDaysOff(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	original := settings
+	t.Cleanup(func() { settings = original })
+	settings.Weekend = "sat,sun"
+	settings.Holidays = "2025-11-11"
+
+	for day := 8; day <= 12; day++ {
+		executeCommand(t, newLogCommand(ctx, mgr), "--date", fmt.Sprintf("2025-11-%02d", day), "--time", "09:00", fmt.Sprintf("Task %d", day))
+	}
+
+	out := executeCommand(t, newListCommand(ctx, mgr), "--date", "2025-11-12", "--days", "5", "--workdays")
+	assertContains(t, out, "Task 10")
+	assertContains(t, out, "Task 12")
+	assertNotContains(t, out, "Task 8")
+	assertNotContains(t, out, "Task 9")
+	assertNotContains(t, out, "Task 11")
+}
+
+func TestListCommandAuthorSeparatesContributions(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	original := settings
+	t.Cleanup(func() { settings = original })
+
+	settings.Author = "faiz"
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-10", "--time", "09:00", "Reviewed rollout plan")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-11", "--time", "10:00", "Paired on billing ~Aina")
+
+	out := executeCommand(t, newListCommand(ctx, mgr), "--date", "2025-11-11", "--days", "2", "--author", "~aina")
+	assertContains(t, out, "Paired on billing ~Aina")
+	assertNotContains(t, out, "Reviewed rollout plan")
+	assertNotContains(t, out, "2025-11-10")
+
+	out = executeCommand(t, newListCommand(ctx, mgr), "--date", "2025-11-11", "--days", "2", "--author", "faiz")
+	assertContains(t, out, "Reviewed rollout plan ~faiz")
+	assertNotContains(t, out, "Paired on billing")
+
+	// Indexes match today's, so toggle and delete find the listed entry.
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-11", "--time", "11:00", "Wrote changelog")
+	out = executeCommand(t, newListCommand(ctx, mgr), "--date", "2025-11-11", "--author", "faiz")
+	assertContains(t, out, "2. [done] 11:00 Wrote changelog ~faiz")
+	assertNotContains(t, out, "1. ")
+}
+
 func TestSearchCommandFindsMatches(t *testing.T) {
 	base := t.TempDir()
 	mgr, err := files.NewManager(base)
@@ -345,3 +392,209 @@ func TestSearchCommandJSONOutput(t *testing.T) {
 		t.Fatalf("unexpected entry payload: %+v", decoded[0].Entry)
 	}
 }
+
+func TestReadCommandsEmitJSON(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-16", "--time", "09:00", "Draft RFC", "#docs")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-17", "--time", "10:30", "Ship release")
+
+	var section logbook.DateSection
+	out := executeCommand(t, NewRootCommand(ctx, mgr), "jump", "2025-11-16", "--json")
+	if err := json.Unmarshal([]byte(out), &section); err != nil {
+		t.Fatalf("Unmarshal jump: %v\n%s", err, out)
+	}
+	if len(section.Entries) != 1 || section.Entries[0].Text != "Draft RFC" || section.Entries[0].Status != logbook.StatusTodo {
+		t.Fatalf("unexpected section: %+v", section)
+	}
+	assertContains(t, out, `"status": "todo"`)
+	assertContains(t, out, `"tags": [`)
+
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "--json", "next", "--date", "2025-11-16")
+	assertContains(t, out, `"text": "Ship release"`)
+
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "--json", "prev", "--date", "2025-11-16")
+	assertContains(t, out, `"entries": []`)
+
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "today", "--date", "2025-11-17", "--json")
+	assertContains(t, out, `"status": "done"`)
+
+	var sections []logbook.DateSection
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "list", "--date", "2025-11-17", "--days", "3", "--json")
+	if err := json.Unmarshal([]byte(out), &sections); err != nil {
+		t.Fatalf("Unmarshal list: %v\n%s", err, out)
+	}
+	if len(sections) != 2 {
+		t.Fatalf("expected 2 sections, got %d", len(sections))
+	}
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "list", "--date", "2025-10-01", "--json")
+	if strings.TrimSpace(out) != "[]" {
+		t.Fatalf("empty list = %q, want []", out)
+	}
+
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "search", "--date", "2025-11-16", "--json", "RFC")
+	assertContains(t, out, `"index": 1`)
+}
+
+func TestSearchCommandScansAllMonthsAndRanges(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-09-10", "--time", "09:00", "Deploy api", "#ops")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-10-03", "--time", "09:00", "Deploy web", "#ops")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-20", "--time", "09:00", "Deploy worker", "#ops")
+	if err := mgr.ArchiveMonth(mustParseDate(t, "2025-09-01")); err != nil {
+		t.Fatalf("ArchiveMonth: %v", err)
+	}
+
+	out := executeCommand(t, newSearchCommand(ctx, mgr), "deploy", "--all")
+	assertContains(t, out, `Results for "deploy" in all months`)
+	api, web, worker := strings.Index(out, "Deploy api"), strings.Index(out, "Deploy web"), strings.Index(out, "Deploy worker")
+	if api < 0 || web < api || worker < web {
+		t.Fatalf("expected every month oldest first:\n%s", out)
+	}
+
+	out = executeCommand(t, newSearchCommand(ctx, mgr), "#ops", "--from", "2025-10-01", "--to", "2025-11-19")
+	assertContains(t, out, `Results for "#ops" in 2025-10-01..2025-11-19`)
+	assertContains(t, out, "2025-10-03 #1 [done] 09:00 Deploy web")
+	assertNotContains(t, out, "Deploy api")
+	assertNotContains(t, out, "Deploy worker")
+
+	out = executeCommand(t, newSearchCommand(ctx, mgr), "deploy", "--to", "2025-10-31", "--json")
+	var decoded []struct {
+		Date string `json:"date"`
+	}
+	if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v\n%s", err, out)
+	}
+	if len(decoded) != 2 || decoded[0].Date != "2025-09-10" {
+		t.Fatalf("unexpected results: %+v", decoded)
+	}
+	if _, err := os.Stat(mgr.MonthPath(mustParseDate(t, "2025-08-01"))); !os.IsNotExist(err) {
+		t.Fatalf("range search should not create empty month files: %v", err)
+	}
+}
+
+func TestSearchCommandRegexAndMultipleTerms(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-03", "--time", "09:00", "Fix bug 1234 in parser", "#backend")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-04", "--time", "09:00", "Review parser docs", "#docs")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-05", "--time", "09:00", "Deploy release", "#ops")
+
+	out := executeCommand(t, newSearchCommand(ctx, mgr), "--date", "2025-11-20", "parser", "deploy")
+	assertContains(t, out, `Results for "parser" OR "deploy" in 2025-11`)
+	assertContains(t, out, "Fix bug 1234")
+	assertContains(t, out, "Review parser docs")
+	assertContains(t, out, "Deploy release")
+
+	out = executeCommand(t, newSearchCommand(ctx, mgr), "--date", "2025-11-20", "--all-terms", "parser", "#docs")
+	assertContains(t, out, `Results for "parser" AND "#docs" in 2025-11`)
+	assertContains(t, out, "Review parser docs")
+	assertNotContains(t, out, "Fix bug")
+
+	out = executeCommand(t, newSearchCommand(ctx, mgr), "--date", "2025-11-20", "--regex", `bug \d+`)
+	assertContains(t, out, "Fix bug 1234")
+	assertNotContains(t, out, "Review")
+
+	out = executeCommand(t, newSearchCommand(ctx, mgr), "--date", "2025-11-20", "--regex", "#^(ops|docs)$")
+	assertContains(t, out, "Deploy release")
+	assertContains(t, out, "Review parser docs")
+	assertNotContains(t, out, "Fix bug")
+
+	cmd := newSearchCommand(ctx, mgr)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--regex", "bug ("})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid regex") {
+		t.Fatalf("expected invalid regex error, got %v", err)
+	}
+}
+
+func TestSearchCommandUsesSearchIndex(t *testing.T) {
+	original := settings
+	t.Cleanup(func() { settings = original })
+	settings.SearchIndex = true
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-09-10", "--time", "09:00", "Deploy api", "#ops")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-10-03", "--time", "09:00", "Write docs", "#docs")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-20", "--time", "09:00", "Deploy worker", "#ops")
+	if _, err := os.Stat(logbook.NewIndex(mgr.BasePath()).Path()); err != nil {
+		t.Fatalf("writes should maintain the index: %v", err)
+	}
+
+	out := executeCommand(t, newSearchCommand(ctx, mgr), "#ops", "--all")
+	assertContains(t, out, "Deploy api")
+	assertContains(t, out, "Deploy worker")
+	assertNotContains(t, out, "Write docs")
+
+	// Hand edits are found before the index catches up.
+	path := mgr.MonthPath(mustParseDate(t, "2025-10-01"))
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(strings.Replace(string(data), "Write docs", "Write deploy docs", 1)), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	out = executeCommand(t, newSearchCommand(ctx, mgr), "deploy", "--all")
+	assertContains(t, out, "Write deploy docs")
+
+	out = executeCommand(t, newIndexCommand(ctx, mgr))
+	assertContains(t, out, "Indexed 3 month files")
+	out = executeCommand(t, newSearchCommand(ctx, mgr), "deploy", "--all", "--all-terms", "#docs")
+	assertContains(t, out, "Write deploy docs")
+	assertNotContains(t, out, "Deploy api")
+}
+
+func TestIndexPredicateMirrorsMatcher(t *testing.T) {
+	month := logbook.MonthTerms{Words: []string{"deploy", "release-notes"}, Tags: []string{"ops-team"}}
+	cases := []struct {
+		terms []string
+		opts  searchOptions
+		want  bool
+	}{
+		{[]string{"PLOY"}, searchOptions{}, true},
+		{[]string{"notes"}, searchOptions{}, true},
+		{[]string{"ops"}, searchOptions{}, true},
+		{[]string{"#ops"}, searchOptions{}, false},
+		{[]string{"#ops-team"}, searchOptions{}, true},
+		{[]string{"#deploy"}, searchOptions{includeText: true}, true},
+		{[]string{"deploy", "missing"}, searchOptions{}, true},
+		{[]string{"deploy", "missing"}, searchOptions{allTerms: true}, false},
+	}
+	for _, tc := range cases {
+		may := indexPredicate(tc.terms, tc.opts)
+		if may == nil || may(month) != tc.want {
+			t.Errorf("indexPredicate(%q, %+v) on %+v should be %v", tc.terms, tc.opts, month, tc.want)
+		}
+	}
+	if indexPredicate([]string{"release notes"}, searchOptions{}) != nil || indexPredicate([]string{"deploy"}, searchOptions{regex: true}) != nil {
+		t.Fatalf("terms with spaces and regexes should skip the index")
+	}
+}
+
+func TestListCommandTagFilterKeepsEntryIndexes(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-12", "--time", "08:00", "first", "#a")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-12", "--time", "08:10", "second", "#billable")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-12", "--time", "08:20", "third")
+
+	today := executeCommand(t, newTodayCommand(ctx, mgr), "--date", "2025-11-12")
+	assertContains(t, today, "2. [todo] 08:10 second")
+
+	out := executeCommand(t, newListCommand(ctx, mgr), "--date", "2025-11-12", "--tag", "billable")
+	assertContains(t, out, "2. [todo] 08:10 second")
+	assertNotContains(t, out, "first")
+	assertNotContains(t, out, "third")
+
+	out = executeCommand(t, newListCommand(ctx, mgr), "--date", "2025-11-12", "--exclude-tag", "a,billable")
+	assertContains(t, out, "3. [todo] 08:20 third")
+	assertNotContains(t, out, "second")
+}
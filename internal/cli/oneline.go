@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// onelineFields replaces the tabs and line breaks an entry's text could hold
+// so every entry stays on one line with exactly six fields.
+var onelineFields = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+
+// printOneline writes one entry as DATE, INDEX, STATUS, TIME, TEXT, and TAGS
+// separated by tabs. TIME is always 24-hour, with the end after a dash for
+// ranged entries, and TAGS are space-separated #tags, so the columns do not
+// shift with time_format or the theme. Index is 1-based within the day.
+func printOneline(out io.Writer, date time.Time, index int, entry logbook.Entry) {
+	clock := entry.Time.Format("15:04")
+	if entry.Duration() > 0 {
+		clock += "-" + entry.End.Format("15:04")
+	}
+	tags := make([]string, len(entry.Tags))
+	for i, tag := range entry.Tags {
+		tags[i] = "#" + tag
+	}
+	fmt.Fprintf(out, "%s\t%d\t%s\t%s\t%s\t%s\n",
+		date.Format("2006-01-02"),
+		index,
+		entry.Status,
+		clock,
+		onelineFields.Replace(entry.Text),
+		strings.Join(tags, " "),
+	)
+}
+
+// printSectionsOneline prints every entry of sections within scope that
+// satisfies match, when it is not nil, with printOneline, keeping each
+// entry's index in its day.
+func printSectionsOneline(out io.Writer, sections []logbook.DateSection, scope []string, match func(logbook.Entry) bool) {
+	for _, section := range sections {
+		for i, entry := range section.Entries {
+			if logbook.MatchesContext(entry, scope) && (match == nil || match(entry)) {
+				printOneline(out, section.Date, i+1, entry)
+			}
+		}
+	}
+}
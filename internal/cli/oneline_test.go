@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestOnelineFormatPrintsTabSeparatedEntries(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-03", "--time", "09:00", "Fix parser", "#backend", "#bug")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-03", "--time", "10:00-11:30", "Pair on deploy")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-04", "--time", "14:00", "Deploy parser", "#ops")
+
+	out := executeCommand(t, newTodayCommand(ctx, mgr), "--date", "2025-11-03", "--format", "oneline")
+	want := "2025-11-03\t1\ttodo\t09:00\tFix parser\t#backend #bug\n" +
+		"2025-11-03\t2\tdone\t10:00-11:30\tPair on deploy\t\n"
+	if out != want {
+		t.Fatalf("today --format oneline =\n%q\nwant\n%q", out, want)
+	}
+	if out := executeCommand(t, newTodayCommand(ctx, mgr), "--date", "2025-11-05", "--format", "oneline"); out != "" {
+		t.Fatalf("a missing day should print nothing, got %q", out)
+	}
+
+	out = executeCommand(t, newListCommand(ctx, mgr), "--date", "2025-11-04", "--days", "2", "--format", "oneline")
+	if lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n"); len(lines) != 3 || lines[2] != "2025-11-04\t1\tdone\t14:00\tDeploy parser\t#ops" {
+		t.Fatalf("list --format oneline =\n%s", out)
+	}
+
+	// Filtering keeps each entry's index in its day.
+	out = executeCommand(t, newListCommand(ctx, mgr), "--date", "2025-11-03", "--exclude-tag", "bug", "--format", "oneline")
+	if want := "2025-11-03\t2\tdone\t10:00-11:30\tPair on deploy\t\n"; out != want {
+		t.Fatalf("list --exclude-tag --format oneline =\n%q\nwant\n%q", out, want)
+	}
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-04", "--time", "15:00", "Review release ~aina")
+	out = executeCommand(t, newListCommand(ctx, mgr), "--date", "2025-11-04", "--author", "aina", "--format", "oneline")
+	if want := "2025-11-04\t2\tdone\t15:00\tReview release\t\n"; out != want {
+		t.Fatalf("list --author --format oneline =\n%q\nwant\n%q", out, want)
+	}
+
+	out = executeCommand(t, newSearchCommand(ctx, mgr), "--date", "2025-11-20", "--format", "oneline", "parser")
+	want = "2025-11-03\t1\ttodo\t09:00\tFix parser\t#backend #bug\n" +
+		"2025-11-04\t1\tdone\t14:00\tDeploy parser\t#ops\n"
+	if out != want {
+		t.Fatalf("search --format oneline =\n%q\nwant\n%q", out, want)
+	}
+	if out := executeCommand(t, newSearchCommand(ctx, mgr), "--date", "2025-11-20", "--format", "oneline", "missing"); out != "" {
+		t.Fatalf("no matches should print nothing, got %q", out)
+	}
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+	"github.com/faizmokh/kerja/internal/ui"
+)
+
+// runPicker runs the match picker and returns its final state. Tests replace
+// it to choose a match without a terminal.
+var runPicker = func(cmd *cobra.Command, m ui.PickerModel) (ui.PickerModel, error) {
+	final, err := tea.NewProgram(m, tea.WithAltScreen(), tea.WithInput(cmd.InOrStdin()), tea.WithOutput(cmd.OutOrStdout())).Run()
+	if err != nil {
+		return m, fmt.Errorf("run picker: %w", err)
+	}
+	return final.(ui.PickerModel), nil
+}
+
+// pickSearchResult lets the user choose one of results, then prints its
+// detail or opens the TUI on its day with it selected.
+func pickSearchResult(ctx context.Context, cmd *cobra.Command, manager *files.Manager, title string, results []searchResult) error {
+	out := cmd.OutOrStdout()
+	if len(results) == 0 {
+		fmt.Fprintf(out, "%s\n(no matches)\n", title)
+		return nil
+	}
+
+	items := make([]ui.PickerItem, len(results))
+	for i, res := range results {
+		items[i] = ui.PickerItem{Date: res.section.Date, Index: res.index + 1, Entry: res.entry}
+	}
+	m, err := runPicker(cmd, ui.NewPickerModel(title, items, ui.Options{
+		TimeLayout: clockLayout(),
+		Theme:      settings.Theme,
+		Plain:      plainRequested(cmd),
+	}))
+	if err != nil {
+		return err
+	}
+	item, open, ok := m.Choice()
+	if !ok {
+		fmt.Fprintln(out, "No entry chosen.")
+		return nil
+	}
+	if open {
+		return runTUI(ctx, cmd, manager, item.Date, item.Index)
+	}
+	printEntryDetail(out, item.Date, item.Index, item.Entry)
+	return nil
+}
+
+// printEntryDetail prints every field of the entry at index (1-based) on
+// date, one per line, with its notes last.
+func printEntryDetail(out io.Writer, date time.Time, index int, entry logbook.Entry) {
+	fmt.Fprintf(out, "%s #%d\n", date.Format("2006-01-02"), index)
+	fmt.Fprintf(out, "Status: %s\n", entry.Status)
+	clock := formatClock(entry.Time)
+	if duration := entry.Duration(); duration > 0 {
+		clock += "-" + formatClock(entry.End) + " (" + formatDuration(duration) + ")"
+	}
+	fmt.Fprintf(out, "Time:   %s\n", clock)
+	fmt.Fprintf(out, "Text:   %s\n", entry.Text)
+	if entry.Pinned {
+		fmt.Fprintln(out, "Pinned: yes")
+	}
+	if len(entry.Tags) > 0 {
+		fmt.Fprintf(out, "Tags:   #%s\n", strings.Join(entry.Tags, " #"))
+	}
+	for _, link := range entry.Links {
+		fmt.Fprintf(out, "Ref:    %s\n", link)
+	}
+	if len(entry.Notes) > 0 {
+		fmt.Fprintln(out, "Notes:")
+		for _, note := range entry.Notes {
+			fmt.Fprintf(out, "  %s\n", note)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/ui"
+)
+
+// pickWith replaces runPicker with one that feeds msgs to the picker.
+func pickWith(t *testing.T, msgs ...tea.Msg) {
+	t.Helper()
+	original := runPicker
+	t.Cleanup(func() { runPicker = original })
+	runPicker = func(cmd *cobra.Command, m ui.PickerModel) (ui.PickerModel, error) {
+		var model tea.Model = m
+		model, _ = model.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+		for _, msg := range msgs {
+			model, _ = model.Update(msg)
+		}
+		return model.(ui.PickerModel), nil
+	}
+}
+
+func TestSearchInteractivePrintsChosenEntry(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-12", "--time", "09:00", "Fix login #bug")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-14", "--time", "10:00-11:30", "Fix flaky test #bug")
+
+	pickWith(t, tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyEnter})
+	out := executeCommand(t, newSearchCommand(ctx, mgr), "--date", "2025-11-14", "--interactive", "#bug")
+	assertContains(t, out, "2025-11-14 #1\nStatus: done\nTime:   10:00-11:30 (1h30m)\nText:   Fix flaky test\nTags:   #bug\n")
+
+	pickWith(t, tea.KeyMsg{Type: tea.KeyEsc})
+	out = executeCommand(t, newSearchCommand(ctx, mgr), "--date", "2025-11-14", "-i", "#bug")
+	assertContains(t, out, "No entry chosen.")
+
+	out = executeCommand(t, newSearchCommand(ctx, mgr), "--date", "2025-11-14", "-i", "#missing")
+	assertContains(t, out, "(no matches)")
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// isTerminal reports whether stream is an interactive terminal. Readers and
+// writers other than files, such as test buffers, never are.
+func isTerminal(stream any) bool {
+	file, ok := stream.(*os.File)
+	return ok && term.IsTerminal(file.Fd())
+}
+
+// runPiped stands in for the TUI when stdin is not a terminal: each
+// non-blank line piped in is added as a todo for today, parsed like capture
+// text (@HH:MM, !done, #tags), and with nothing piped in (as under cron)
+// today's section is printed instead.
+func runPiped(ctx context.Context, cmd *cobra.Command, manager *files.Manager) error {
+	date, err := resolveDate("")
+	if err != nil {
+		return err
+	}
+	var entries []logbook.Entry
+	scanner := bufio.NewScanner(cmd.InOrStdin())
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		entry, err := tokenEntry(text, date, logbook.StatusTodo)
+		if err != nil {
+			return fmt.Errorf("stdin line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read stdin: %w", err)
+	}
+
+	if len(entries) == 0 {
+		section, err := logbook.NewReader(manager).Section(ctx, date)
+		if errors.Is(err, logbook.ErrSectionNotFound) {
+			printMissingSection(cmd, date)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return printSection(cmd, section, activeContext(cmd), nil, false)
+	}
+
+	if err := newWriter(cmd, manager).AppendBatch(ctx, entries); err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	for _, entry := range entries {
+		fmt.Fprintf(out, "Added %s\n", formatEntry(entry))
+	}
+	return nil
+}
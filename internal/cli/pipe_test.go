@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func TestRootAddsPipedLinesAsTodos(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	cmd := NewRootCommand(ctx, mgr)
+	cmd.SetIn(strings.NewReader("Write release notes #docs\n\n!done @08:30 Reply to review\n"))
+	out := executeCommand(t, cmd)
+	assertContains(t, out, "Added [todo]")
+	assertContains(t, out, "Write release notes")
+	assertContains(t, out, "Reply to review")
+
+	section, err := logbook.NewReader(mgr).Section(ctx, time.Now())
+	if err != nil || len(section.Entries) != 2 {
+		t.Fatalf("Section = %+v, %v", section, err)
+	}
+	if section.Entries[0].Status != logbook.StatusTodo || section.Entries[1].Status != logbook.StatusDone {
+		t.Fatalf("statuses = %v, %v", section.Entries[0].Status, section.Entries[1].Status)
+	}
+}
+
+func TestRootPrintsTodayWithoutPipedInput(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	executeCommand(t, newTodoCommand(ctx, mgr), "--time", "09:00", "Existing todo")
+
+	cmd := NewRootCommand(ctx, mgr)
+	cmd.SetIn(strings.NewReader(""))
+	out := executeCommand(t, cmd)
+	assertContains(t, out, "Existing todo")
+	assertNotContains(t, out, "Added")
+}
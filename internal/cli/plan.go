@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// planItem is an open todo from a past day and what to do with it.
+type planItem struct {
+	from   time.Time
+	index  int
+	entry  logbook.Entry
+	action wrapupAction
+	target time.Time
+}
+
+func newPlanCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		dateFlag     string
+		weekFlag     bool
+		lookbackFlag int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Schedule the backlog of unfinished todos onto upcoming days.",
+		Long: "plan gathers the todos still open on past days (within --lookback days) and asks, one by one, which\n" +
+			"day to move each to. Without --week the only day offered is --date (default today); with --week it is\n" +
+			"every workday of the 7 days starting there. Answer with a day's number or any YYYY-MM-DD date, or\n" +
+			"mark the todo [d]one, [x] drop it, [k]eep it where it is, or [q]uit and keep the rest. Nothing is\n" +
+			"written until the last todo is answered.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if lookbackFlag < 1 {
+				return fmt.Errorf("--lookback must be at least 1")
+			}
+			start, err := resolveDate(dateFlag)
+			if err != nil {
+				return err
+			}
+			days := []time.Time{start}
+			if weekFlag {
+				days = planDays(start, 7)
+			}
+
+			reader := logbook.NewReader(manager)
+			sections, err := reader.SectionsBetween(ctx, start.AddDate(0, 0, -lookbackFlag), start.AddDate(0, 0, -1))
+			if err != nil {
+				return err
+			}
+			var items []planItem
+			for _, section := range sections {
+				for i, entry := range section.Entries {
+					if entry.Status.Open() {
+						items = append(items, planItem{from: section.Date, index: i + 1, entry: entry})
+					}
+				}
+			}
+			out := cmd.OutOrStdout()
+			if len(items) == 0 {
+				fmt.Fprintf(out, "No open todos in the %d days before %s.\n", lookbackFlag, start.Format("2006-01-02"))
+				return nil
+			}
+
+			if err := promptPlan(out, bufio.NewReader(cmd.InOrStdin()), days, items); err != nil {
+				return err
+			}
+			if err := applyPlan(ctx, newWriter(cmd, manager), items); err != nil {
+				return err
+			}
+			printPlanSummary(out, days, items)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "First day to plan in YYYY-MM-DD (default: today)")
+	cmd.Flags().BoolVar(&weekFlag, "week", false, "Plan the workdays of the 7 days starting on --date")
+	cmd.Flags().IntVar(&lookbackFlag, "lookback", 30, "How many days back to gather open todos from")
+
+	return cmd
+}
+
+// planDays returns the workdays among the n days starting on start, or start
+// alone when none of them is a workday.
+func planDays(start time.Time, n int) []time.Time {
+	calendar := workCalendar()
+	var days []time.Time
+	for i := range n {
+		if day := start.AddDate(0, 0, i); calendar.Workday(day) {
+			days = append(days, day)
+		}
+	}
+	if len(days) == 0 {
+		return []time.Time{start}
+	}
+	return days
+}
+
+// promptPlan asks where each item goes, oldest first, recording the answer
+// on the item. Items left unanswered after [q]uit are kept.
+func promptPlan(out io.Writer, in *bufio.Reader, days []time.Time, items []planItem) error {
+	fmt.Fprintf(out, "%d open todo%s to plan. Days:\n", len(items), sSuffix(len(items)))
+	for i, day := range days {
+		fmt.Fprintf(out, "  %d  %s\n", i+1, displayLocale().Format(day, "Mon 2006-01-02"))
+	}
+	choices := "1"
+	if len(days) > 1 {
+		choices = fmt.Sprintf("1-%d", len(days))
+	}
+
+	for i := range items {
+		item := &items[i]
+		fmt.Fprintf(out, "\n%s #%d: %s\n", item.from.Format("2006-01-02"), item.index, formatEntry(item.entry))
+		for {
+			fmt.Fprintf(out, "Day [%s] or YYYY-MM-DD, [d]one, [x] drop, [k]eep, [q]uit? ", choices)
+			answer, err := readAnswer(in, "plan")
+			if err != nil {
+				return err
+			}
+			if n, err := strconv.Atoi(answer); err == nil {
+				if n < 1 || n > len(days) {
+					fmt.Fprintf(out, "Choose a day from %s.\n", choices)
+					continue
+				}
+				item.action, item.target = wrapupMove, days[n-1]
+				break
+			}
+			switch answer {
+			case "d", "done":
+				item.action = wrapupDone
+			case "x", "drop":
+				item.action = wrapupDrop
+			case "k", "keep", "":
+				item.action = wrapupKeep
+			case "q", "quit":
+				return nil
+			default:
+				target, err := resolveDate(answer)
+				if err != nil {
+					fmt.Fprintf(out, "Unknown choice %q.\n", answer)
+					continue
+				}
+				if sameDate(target, item.from) {
+					item.action = wrapupKeep
+					break
+				}
+				item.action, item.target = wrapupMove, target
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// applyPlan marks todos done first, then moves and drops them from the
+// highest index down within each day so earlier indexes stay valid.
+func applyPlan(ctx context.Context, writer *logbook.Writer, items []planItem) error {
+	var removals []planItem
+	for _, item := range items {
+		switch item.action {
+		case wrapupDone:
+			updated := item.entry
+			updated.Status = logbook.StatusDone
+			if err := writer.Edit(ctx, item.from, item.index, updated); err != nil {
+				return err
+			}
+		case wrapupMove, wrapupDrop:
+			removals = append(removals, item)
+		}
+	}
+
+	sort.SliceStable(removals, func(i, j int) bool {
+		if !sameDate(removals[i].from, removals[j].from) {
+			return removals[i].from.Before(removals[j].from)
+		}
+		return removals[i].index > removals[j].index
+	})
+	for _, item := range removals {
+		var err error
+		if item.action == wrapupMove {
+			_, err = writer.Move(ctx, item.from, item.index, item.target)
+		} else {
+			_, err = writer.Delete(ctx, item.from, item.index)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printPlanSummary(out io.Writer, days []time.Time, items []planItem) {
+	counts := make(map[string]int)
+	var moved, done, dropped int
+	for _, item := range items {
+		switch item.action {
+		case wrapupMove:
+			moved++
+			counts[item.target.Format("2006-01-02")]++
+		case wrapupDone:
+			done++
+		case wrapupDrop:
+			dropped++
+		}
+	}
+	fmt.Fprintf(out, "\nPlanned %d todo%s, marked %d done, dropped %d, kept %d.\n", moved, sSuffix(moved), done, dropped, len(items)-moved-done-dropped)
+	for _, day := range days {
+		key := day.Format("2006-01-02")
+		fmt.Fprintf(out, "  %s  %d planned\n", displayLocale().Format(day, "Mon 2006-01-02"), counts[key])
+		delete(counts, key)
+	}
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(out, "  %s  %d planned\n", key, counts[key])
+	}
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func TestPlanCommandSchedulesBacklog(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	original := settings
+	t.Cleanup(func() { settings = original })
+	settings.Weekend = "sat,sun"
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-12", "--time", "09:00", "Write spec", "#docs")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-12", "--time", "09:30", "Standup")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-12", "--time", "10:00", "Refactor parser")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-14", "--time", "09:00", "Book travel")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-14", "--time", "10:00", "Old idea")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-14", "--time", "11:00", "Renew passport")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-16", "--time", "12:00", "Sort photos")
+
+	cmd := newPlanCommand(ctx, mgr)
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetIn(strings.NewReader("1\n9\n3\nd\nx\n2025-11-30\nq\n"))
+	cmd.SetArgs([]string{"--week", "--date", "2025-11-17"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute: %v\n%s", err, buf.String())
+	}
+	out := buf.String()
+	assertContains(t, out, "6 open todos to plan. Days:\n  1  Mon 2025-11-17\n")
+	assertContains(t, out, "  5  Fri 2025-11-21\n")
+	assertNotContains(t, out, "Sat 2025-11-22")
+	assertContains(t, out, "Choose a day from 1-5.")
+	assertContains(t, out, "Planned 3 todos, marked 1 done, dropped 1, kept 1.")
+	assertContains(t, out, "  Wed 2025-11-19  1 planned\n")
+	assertContains(t, out, "  2025-11-30  1 planned\n")
+
+	reader := logbook.NewReader(mgr)
+	texts := func(date string) string {
+		t.Helper()
+		section, err := reader.Section(ctx, mustParseDate(t, date))
+		if err != nil {
+			return ""
+		}
+		var texts []string
+		for _, entry := range section.Entries {
+			texts = append(texts, entry.Status.String()+" "+entry.Text)
+		}
+		return strings.Join(texts, "|")
+	}
+	for date, want := range map[string]string{
+		"2025-11-12": "done Standup",
+		"2025-11-14": "done Book travel",
+		"2025-11-16": "todo Sort photos",
+		"2025-11-17": "todo Write spec",
+		"2025-11-19": "todo Refactor parser",
+		"2025-11-30": "todo Renew passport",
+	} {
+		if got := texts(date); got != want {
+			t.Errorf("%s entries = %q, want %q", date, got, want)
+		}
+	}
+}
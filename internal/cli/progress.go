@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// progressWidth is how many cells the bar itself spans.
+const progressWidth = 24
+
+// progressBar redraws one line with how far a multi-month scan has got, so
+// a long search --all does not look hung. Its methods do nothing on a nil
+// bar.
+type progressBar struct {
+	out    io.Writer
+	glyphs textGlyphs
+	// drawn is the width of the line on screen, or zero when it is clear.
+	drawn int
+}
+
+// scanProgress returns a bar drawn on stderr, or nil when stderr is not a
+// terminal, so pipes and logs never see it.
+func scanProgress(cmd *cobra.Command) *progressBar {
+	file, ok := cmd.ErrOrStderr().(*os.File)
+	if !ok {
+		return nil
+	}
+	info, err := file.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return nil
+	}
+	return &progressBar{out: file, glyphs: glyphsFor(cmd)}
+}
+
+// update redraws the bar for p. Single-month scans are too quick to show.
+func (b *progressBar) update(p logbook.Progress) {
+	if b == nil || p.Total <= 1 {
+		return
+	}
+	filled := progressWidth * p.Done / p.Total
+	line := fmt.Sprintf("%s%s %d/%d months (%s)",
+		strings.Repeat(string(b.glyphs.bar), filled), strings.Repeat(string(b.glyphs.shades[0]), progressWidth-filled),
+		p.Done, p.Total, p.Month.Format("2006-01"))
+	width := utf8.RuneCountInString(line)
+	fmt.Fprint(b.out, "\r"+line+strings.Repeat(" ", max(b.drawn-width, 0)))
+	b.drawn = width
+}
+
+// clear erases the bar so other output can take its line.
+func (b *progressBar) clear() {
+	if b == nil || b.drawn == 0 {
+		return
+	}
+	fmt.Fprint(b.out, "\r"+strings.Repeat(" ", b.drawn)+"\r")
+	b.drawn = 0
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func TestProgressBarRedrawsAndClearsOneLine(t *testing.T) {
+	var out bytes.Buffer
+	bar := &progressBar{out: &out, glyphs: asciiText}
+	month := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	bar.update(logbook.Progress{Done: 1, Total: 1, Month: month})
+	if out.Len() != 0 {
+		t.Fatalf("single-month scan drew %q", out.String())
+	}
+	bar.update(logbook.Progress{Done: 1, Total: 4, Month: month})
+	bar.update(logbook.Progress{Done: 4, Total: 4, Month: month.AddDate(0, 3, 0)})
+	want := "\r######.................. 1/4 months (2025-03)" +
+		"\r######################## 4/4 months (2025-06)"
+	if out.String() != want {
+		t.Fatalf("bar = %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	bar.clear()
+	if out.String() != "\r"+string(bytes.Repeat([]byte(" "), 45))+"\r" {
+		t.Fatalf("clear = %q", out.String())
+	}
+
+	var none *progressBar
+	none.update(logbook.Progress{Done: 1, Total: 2})
+	none.clear()
+}
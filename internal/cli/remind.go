@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+	"github.com/faizmokh/kerja/internal/notify"
+)
+
+// remindNow and newNotifier are replaced in tests.
+var (
+	remindNow   = time.Now
+	newNotifier = notify.New
+)
+
+func newRemindCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		notifierFlag string
+		onceFlag     bool
+		intervalFlag time.Duration
+		leadFlag     time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "remind",
+		Short: "Send desktop notifications when timed todos come due.",
+		Long: "remind watches for open todos whose @HH:MM time arrives and sends a notification for each, checking\n" +
+			"every --interval until interrupted. With --once it checks a single time for todos that came due during\n" +
+			"the last interval, so a cron job such as `* * * * * kerja remind --once` fires each reminder once.\n" +
+			"--lead sends reminders early; --notifier picks notify-send, osascript, or the terminal bell.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if intervalFlag < time.Second {
+				return fmt.Errorf("--interval must be at least 1s")
+			}
+			if leadFlag < 0 {
+				return fmt.Errorf("--lead cannot be negative")
+			}
+			notifier, err := newNotifier(notifierFlag, cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+
+			reader := logbook.NewReader(manager)
+			// The daemon starts from now, so a todo whose time passed before it
+			// started, or before a todo was written, is never reminded.
+			last := remindNow()
+			if onceFlag {
+				last = last.Add(-intervalFlag)
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), "Checking for reminders every %s (Ctrl+C to stop).\n", intervalFlag)
+			}
+			for {
+				now := remindNow()
+				due, err := dueReminders(ctx, reader, last, now, leadFlag)
+				if err != nil {
+					return err
+				}
+				for _, entry := range due {
+					if err := sendReminder(cmd.OutOrStdout(), notifier, entry); err != nil {
+						if onceFlag {
+							return err
+						}
+						fmt.Fprintf(cmd.ErrOrStderr(), "remind: %v\n", err)
+					}
+				}
+				last = now
+				if onceFlag {
+					return nil
+				}
+
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(intervalFlag):
+				}
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&notifierFlag, "notifier", "auto", "Notification method: "+strings.Join(notify.Names, ", "))
+	cmd.Flags().BoolVar(&onceFlag, "once", false, "Check once and exit (for cron)")
+	cmd.Flags().DurationVar(&intervalFlag, "interval", time.Minute, "How often to check; with --once, how far back to look")
+	cmd.Flags().DurationVar(&leadFlag, "lead", 0, "Remind this long before the todo's time (e.g. 10m)")
+
+	return cmd
+}
+
+// dueReminders returns the open entries whose reminder time, their @HH:MM
+// minus lead, falls in (after, until]. after is the previous check, so a todo
+// written since then is reminded unless its time had already passed by it.
+func dueReminders(ctx context.Context, reader *logbook.Reader, after, until time.Time, lead time.Duration) ([]logbook.Entry, error) {
+	// The days are those of the log zone, whatever zone after and until
+	// carry, so the range cannot miss a day near midnight.
+	from, err := resolveDate(after.Add(lead).In(logZone()).Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	to, err := resolveDate(until.Add(lead).In(logZone()).Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	var due []logbook.Entry
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		section, err := reader.Section(ctx, day)
+		if errors.Is(err, logbook.ErrSectionNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range section.Entries {
+			remindAt := entry.Time.Add(-lead)
+			if entry.Status.Open() && remindAt.After(after) && !remindAt.After(until) {
+				due = append(due, entry)
+			}
+		}
+	}
+	return due, nil
+}
+
+func sendReminder(out io.Writer, notifier notify.Notifier, entry logbook.Entry) error {
+	body := entry.Time.Format(clockLayout()) + " " + entry.Text
+	if len(entry.Tags) > 0 {
+		body += " #" + strings.Join(entry.Tags, " #")
+	}
+	if err := notifier.Notify("kerja reminder", body); err != nil {
+		return err
+	}
+	// The bell already printed the reminder.
+	if _, ok := notifier.(notify.Bell); !ok {
+		fmt.Fprintf(out, "Reminded: %s\n", body)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+	"github.com/faizmokh/kerja/internal/notify"
+)
+
+type recordingNotifier struct {
+	bodies []string
+}
+
+func (r *recordingNotifier) Notify(title, body string) error {
+	r.bodies = append(r.bodies, body)
+	return nil
+}
+
+func TestRemindOnceNotifiesTodosDueInWindow(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-12", "--time", "09:28", "Standup", "#meeting")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-12", "--time", "09:20", "Too early")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-12", "--time", "09:29", "Already done")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-12", "--time", "09:40", "Review")
+
+	recorder := &recordingNotifier{}
+	originalNow, originalNotifier := remindNow, newNotifier
+	t.Cleanup(func() { remindNow, newNotifier = originalNow, originalNotifier })
+	remindNow = func() time.Time { return time.Date(2025, time.November, 12, 9, 30, 0, 0, time.Local) }
+	newNotifier = func(name string, out io.Writer) (notify.Notifier, error) { return recorder, nil }
+
+	out := executeCommand(t, newRemindCommand(ctx, mgr), "--once", "--interval", "5m")
+	assertContains(t, out, "Reminded: 09:28 Standup #meeting")
+	if len(recorder.bodies) != 1 {
+		t.Fatalf("notifications = %q, want one", recorder.bodies)
+	}
+
+	recorder.bodies = nil
+	executeCommand(t, newRemindCommand(ctx, mgr), "--once", "--interval", "5m", "--lead", "10m")
+	if len(recorder.bodies) != 1 || recorder.bodies[0] != "09:40 Review" {
+		t.Fatalf("lead notifications = %q", recorder.bodies)
+	}
+}
+
+func TestDueRemindersCoversTodosAddedSinceLastCheck(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	reader := logbook.NewReader(mgr)
+	last := time.Date(2025, time.November, 12, 9, 29, 30, 0, time.Local)
+	now := last.Add(time.Minute)
+
+	// Written between the two checks: one just before its time, one after
+	// its time had passed by the previous check.
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-12", "--time", "09:30", "Standup")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-12", "--time", "09:29", "Call the bank")
+	due, err := dueReminders(ctx, reader, last, now, 0)
+	if err != nil {
+		t.Fatalf("dueReminders: %v", err)
+	}
+	if len(due) != 1 || due[0].Text != "Standup" {
+		t.Fatalf("due = %+v, want only Standup", due)
+	}
+}
+
+func TestDueRemindersReadsDaysOfTheLogZone(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	original := settings
+	t.Cleanup(func() { settings = original })
+	settings.TimeZone = "Asia/Tokyo"
+	mgr.SetTimeZone(logZone())
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-13", "--time", "00:00", "Deploy")
+	// Midnight in Tokyo is still the 12th in UTC.
+	last := time.Date(2025, time.November, 12, 14, 59, 30, 0, time.UTC)
+	due, err := dueReminders(ctx, logbook.NewReader(mgr), last, last.Add(time.Minute), 0)
+	if err != nil {
+		t.Fatalf("dueReminders: %v", err)
+	}
+	if len(due) != 1 || due[0].Text != "Deploy" {
+		t.Fatalf("due = %+v, want Deploy", due)
+	}
+}
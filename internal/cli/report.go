@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+	"github.com/faizmokh/kerja/internal/report"
+)
+
+func newReportCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		outFlag        string
+		templateFlag   string
+		groupFlag      string
+		titleFlag      string
+		dateFlag       string
+		weekFlag       bool
+		monthFlag      bool
+		fromFlag       string
+		toFlag         string
+		tagFlag        string
+		excludeTagFlag string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Write a Markdown report of a range, grouped by tag or project.",
+		Long: "report covers the last 7 days ending on --date by default (or with --week); use --month or --from/--to\n" +
+			"for other ranges. Entries are grouped by tag (an entry appears under each of its tags) or by project\n" +
+			"(its first tag), with done and todo lists per group. Customize the layout by saving a Go text/template\n" +
+			"as report.md.tmpl in the logbook directory, or render any template with --template. --tag and\n" +
+			"--exclude-tag choose entries by tag, as in --tag billable --exclude-tag personal for a client report.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateFormat(groupFlag, string(report.ByTag), string(report.ByProject)); err != nil {
+				return fmt.Errorf("--group: %w", err)
+			}
+			start, end, err := resolveSummaryRange(dateFlag, weekFlag, monthFlag, fromFlag, toFlag)
+			if err != nil {
+				return err
+			}
+
+			sections, err := logbook.NewReader(manager).SectionsBetween(ctx, start, end)
+			if err != nil {
+				return err
+			}
+			sections = byTags(sections, logbook.ParseContext(tagFlag), logbook.ParseContext(excludeTagFlag))
+			var tmpl *template.Template
+			if templateFlag != "" {
+				tmpl, err = report.LoadFile(templateFlag)
+			} else {
+				tmpl, err = report.LoadTemplate(report.Path(manager.BasePath()))
+			}
+			if err != nil {
+				return err
+			}
+
+			title := titleFlag
+			if title == "" {
+				title = "Weekly report"
+				if monthFlag {
+					title = "Monthly report"
+				} else if fromFlag != "" {
+					title = "Report"
+				}
+			}
+			data := report.Build(title, start, end, sections, report.Grouping(groupFlag))
+
+			render := func(w io.Writer) error {
+				return report.Render(w, tmpl, data)
+			}
+			if outFlag == "" || outFlag == "-" {
+				return render(cmd.OutOrStdout())
+			}
+
+			file, err := os.Create(outFlag)
+			if err != nil {
+				return fmt.Errorf("create %s: %w", outFlag, err)
+			}
+			if err := render(file); err != nil {
+				file.Close()
+				return err
+			}
+			if err := file.Close(); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Wrote report for %s to %s\n", formatRange(start, end), outFlag)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outFlag, "out", "o", "", "Write the report to this file instead of stdout")
+	cmd.Flags().StringVar(&templateFlag, "template", "", "Render this Go text/template file instead of the default report")
+	cmd.Flags().StringVar(&groupFlag, "group", string(report.ByTag), "Group entries by tag or project (first tag)")
+	cmd.Flags().StringVar(&titleFlag, "title", "", "Report heading (default: Weekly report, Monthly report, or Report)")
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Reference date in YYYY-MM-DD (default: today)")
+	cmd.Flags().BoolVar(&weekFlag, "week", false, "Report on the 7 days ending on the reference date (default)")
+	cmd.Flags().BoolVar(&monthFlag, "month", false, "Report on the calendar month containing the reference date")
+	cmd.Flags().StringVar(&fromFlag, "from", "", "First day of a custom range in YYYY-MM-DD")
+	cmd.Flags().StringVar(&toFlag, "to", "", "Last day of a custom range in YYYY-MM-DD (default: reference date)")
+	cmd.Flags().StringVar(&tagFlag, "tag", "", "Only report entries with one of these comma-separated tags")
+	cmd.Flags().StringVar(&excludeTagFlag, "exclude-tag", "", "Leave out entries with any of these comma-separated tags")
+
+	return cmd
+}
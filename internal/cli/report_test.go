@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReportCommandWritesMarkdownFile(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-10", "Ship login", "#auth", "#web")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-12", "Write tests", "#auth")
+
+	out := executeCommand(t, newReportCommand(ctx, mgr), "--date", "2025-11-12", "--group", "project")
+	assertContains(t, out, "# Weekly report\n")
+	assertContains(t, out, "## auth\n\n### Done\n\n- Ship login (2025-11-10)\n\n### Todo\n\n- Write tests (2025-11-12)\n")
+	assertNotContains(t, out, "## web")
+
+	path := filepath.Join(t.TempDir(), "report.md")
+	out = executeCommand(t, newReportCommand(ctx, mgr), "--date", "2025-11-12", "--out", path)
+	assertContains(t, out, "Wrote report for 2025-11-06..2025-11-12 to "+path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	assertContains(t, string(data), "## web")
+}
+
+func TestReportCommandFiltersByTag(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-10", "Ship login", "#billable", "#acme")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-11", "Dentist", "#personal")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-11", "Call with lawyer", "#billable", "#personal")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-12", "Tidy notes")
+
+	out := executeCommand(t, newReportCommand(ctx, mgr), "--date", "2025-11-12", "--tag", "BILLABLE", "--exclude-tag", "#personal")
+	assertContains(t, out, "- Ship login (2025-11-10)")
+	assertNotContains(t, out, "Dentist")
+	assertNotContains(t, out, "Call with lawyer")
+	assertNotContains(t, out, "Tidy notes")
+	assertNotContains(t, out, "## personal")
+
+	out = executeCommand(t, newExportCommand(ctx, mgr), "todotxt", "--date", "2025-11-12", "--exclude-tag", "personal,billable")
+	assertContains(t, out, "Tidy notes")
+	assertNotContains(t, out, "Ship login")
+	assertNotContains(t, out, "Dentist")
+
+	out = executeCommand(t, newListCommand(ctx, mgr), "--date", "2025-11-12", "--days", "3", "--tag", "personal", "--exclude-tag", "billable")
+	assertContains(t, out, "Dentist")
+	assertNotContains(t, out, "Call with lawyer")
+	assertNotContains(t, out, "2025-11-10")
+}
+
+func TestReportCommandRendersCustomTemplate(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-10", "Ship login", "#auth")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-12", "Write tests", "#auth")
+
+	path := filepath.Join(t.TempDir(), "standup.tmpl")
+	if err := os.WriteFile(path, []byte("{{range .Tags}}{{.Name}}: {{.Done}}/{{.Count}}{{end}}"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	out := executeCommand(t, newReportCommand(ctx, mgr), "--date", "2025-11-12", "--template", path)
+	if out != "auth: 1/2" {
+		t.Fatalf("report = %q", out)
+	}
+
+	cmd := newReportCommand(ctx, mgr)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--template", filepath.Join(t.TempDir(), "missing.tmpl")})
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected a missing --template to fail")
+	}
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+	"github.com/faizmokh/kerja/internal/ui"
+)
+
+// runReview runs the review wizard and returns its final state. Tests replace
+// it to feed key presses without a terminal.
+var runReview = func(cmd *cobra.Command, m ui.ReviewModel) (ui.ReviewModel, error) {
+	final, err := tea.NewProgram(m, tea.WithInput(cmd.InOrStdin()), tea.WithOutput(cmd.OutOrStdout())).Run()
+	if err != nil {
+		return m, fmt.Errorf("run review: %w", err)
+	}
+	return final.(ui.ReviewModel), nil
+}
+
+func newReviewCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var dateFlag string
+
+	cmd := &cobra.Command{
+		Use:   "review",
+		Short: "Decide the fate of today's open todos in one pass.",
+		Long: "review steps through each open todo in a small full-screen wizard and asks whether to mark it\n" +
+			"done, carry it to the next workday, drop it, or keep it. Nothing is written until you confirm the summary;\n" +
+			"the results are then saved as one change that a single kerja undo reverts.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			date, err := resolveDate(dateFlag)
+			if err != nil {
+				return err
+			}
+
+			section, err := logbook.NewReader(manager).Section(ctx, date)
+			if err != nil && !errors.Is(err, logbook.ErrSectionNotFound) {
+				return err
+			}
+			section.Date = date
+
+			m := ui.NewReviewModel(section, ui.Options{
+				TimeLayout: clockLayout(),
+				Theme:      settings.Theme,
+				Plain:      plainRequested(cmd),
+				Calendar:   workCalendar(),
+				Locale:     displayLocale(),
+			})
+			if m.Empty() {
+				fmt.Fprintf(cmd.OutOrStdout(), "No open todos on %s.\n", date.Format("2006-01-02"))
+				return nil
+			}
+
+			m, err = runReview(cmd, m)
+			if err != nil {
+				return err
+			}
+			if !m.Confirmed() {
+				fmt.Fprintln(cmd.OutOrStdout(), "Review cancelled; nothing changed.")
+				return nil
+			}
+
+			decisions := m.Decisions()
+			if len(decisions) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "Kept every todo; nothing changed.")
+				return nil
+			}
+			if err := newWriter(cmd, manager).ApplyReview(ctx, date, decisions); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Saved review of %s: %s\n", date.Format("2006-01-02"), summarizeReview(decisions))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Day to review in YYYY-MM-DD (default: today)")
+
+	return cmd
+}
+
+func summarizeReview(decisions []logbook.ReviewDecision) string {
+	counts := make(map[logbook.ReviewAction]int)
+	for _, decision := range decisions {
+		counts[decision.Action]++
+	}
+	return fmt.Sprintf("%d done, %d carried, %d dropped",
+		counts[logbook.ReviewDone], counts[logbook.ReviewCarry], counts[logbook.ReviewDrop])
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+	"github.com/faizmokh/kerja/internal/ui"
+)
+
+// pressKeys replaces runReview with one that feeds keys to the wizard.
+func pressKeys(t *testing.T, keys ...string) {
+	t.Helper()
+	original := runReview
+	t.Cleanup(func() { runReview = original })
+	runReview = func(cmd *cobra.Command, m ui.ReviewModel) (ui.ReviewModel, error) {
+		var model tea.Model = m
+		for _, key := range keys {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+			if key == "enter" {
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			}
+			model, _ = model.Update(msg)
+		}
+		return model.(ui.ReviewModel), nil
+	}
+}
+
+func TestReviewCommandAppliesDecisionsAsOneChange(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-30", "--time", "09:00", "Finish report")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-30", "--time", "09:30", "Standup")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-30", "--time", "10:00", "Refactor parser")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-30", "--time", "11:00", "Old idea")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-30", "--time", "12:00", "Reply to email")
+
+	pressKeys(t, "d", "c", "x", "k", "enter")
+	out := executeCommand(t, newReviewCommand(ctx, mgr), "--date", "2025-11-30")
+	assertContains(t, out, "Saved review of 2025-11-30: 1 done, 1 carried, 1 dropped")
+
+	reader := logbook.NewReader(mgr)
+	day, err := reader.Section(ctx, mustParseDate(t, "2025-11-30"))
+	if err != nil {
+		t.Fatalf("Section: %v", err)
+	}
+	var texts []string
+	for _, entry := range day.Entries {
+		texts = append(texts, entry.Text)
+	}
+	if got := strings.Join(texts, "|"); got != "Finish report|Standup|Reply to email" || day.Entries[0].Status != logbook.StatusDone {
+		t.Fatalf("remaining entries = %q (%+v)", got, day.Entries[0])
+	}
+	carried, err := reader.Section(ctx, mustParseDate(t, "2025-12-01"))
+	if err != nil || len(carried.Entries) != 1 || carried.Entries[0].Text != "Refactor parser" {
+		t.Fatalf("carried entry missing: %+v, %v", carried, err)
+	}
+
+	executeCommand(t, newUndoCommand(ctx, mgr))
+	if day, _ := reader.Section(ctx, mustParseDate(t, "2025-11-30")); len(day.Entries) != 5 {
+		t.Fatalf("undo should restore the whole review, got %d entries", len(day.Entries))
+	}
+}
+
+func TestReviewCommandCancelWritesNothing(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-30", "Finish report")
+
+	pressKeys(t, "d", "q")
+	out := executeCommand(t, newReviewCommand(ctx, mgr), "--date", "2025-11-30")
+	assertContains(t, out, "Review cancelled; nothing changed.")
+
+	out = executeCommand(t, newReviewCommand(ctx, mgr), "--date", "2025-12-02")
+	assertContains(t, out, "No open todos on 2025-12-02.")
+}
@@ -2,17 +2,26 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
+	"github.com/faizmokh/kerja/internal/config"
 	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logging"
 	"github.com/faizmokh/kerja/internal/ui"
 	"github.com/faizmokh/kerja/internal/version"
 )
 
+// settings holds the user defaults loaded from the config file. Commands read
+// it at run time; tests run against the built-in defaults.
+var settings = config.Default()
+
 // NewRootCommand creates the top-level Cobra command to host subcommands and TUI launcher.
 func NewRootCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
 	cmd := &cobra.Command{
@@ -20,16 +29,41 @@ func NewRootCommand(ctx context.Context, manager *files.Manager) *cobra.Command
 		Short:   "Track and review daily work logs from your terminal.",
 		Version: version.Info(),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			m := ui.NewModel(ctx, manager)
-			if _, err := tea.NewProgram(m).Run(); err != nil {
-				return fmt.Errorf("run TUI: %w", err)
+			if !isTerminal(cmd.InOrStdin()) {
+				return runPiped(ctx, cmd, manager)
+			}
+			if needsSetup(cmd, manager) {
+				fmt.Fprintln(cmd.OutOrStdout(), "No kerja logbook yet; answer a few questions to set one up.")
+				setup, err := runInit(cmd, initAnswers{}, map[string]bool{}, false)
+				if err != nil {
+					return err
+				}
+				return runTUI(ctx, cmd, setup, time.Time{}, 0)
 			}
-			return nil
+			return runTUI(ctx, cmd, manager, time.Time{}, 0)
+		},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := setupLogging(cmd, manager); err != nil {
+				return err
+			}
+			return syncBefore(cmd, manager)
+		},
+		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			return syncAfter(cmd, manager)
 		},
 		SilenceUsage:  true,
 		SilenceErrors: true,
 	}
 
+	cmd.PersistentFlags().Bool("json", false, "Emit today, list, prev, next, and jump output as JSON")
+	cmd.PersistentFlags().Bool("plain", false, "ASCII-only output without colors or styling (also enabled by NO_COLOR)")
+	cmd.PersistentFlags().Bool("no-context", false, "Show every entry, ignoring the active context")
+	cmd.PersistentFlags().Bool("sort", false, "Keep each day ordered by entry time when adding or editing (default from sort_entries)")
+	cmd.PersistentFlags().Bool("show-diff", false, "Print a unified diff of each month file a command changes (default from show_diff)")
+	cmd.PersistentFlags().Bool("verbose", false, "Trace file resolution and writes to stderr")
+	cmd.PersistentFlags().Bool("debug", false, "Trace parse steps as well as --verbose output (also enabled by KERJA_DEBUG=1)")
+	cmd.PersistentFlags().String("log-file", "", "Append the trace to this file instead of stderr (implies --verbose)")
+
 	cmd.AddCommand(
 		newTodayCommand(ctx, manager),
 		newPrevCommand(ctx, manager),
@@ -37,23 +71,129 @@ func NewRootCommand(ctx context.Context, manager *files.Manager) *cobra.Command
 		newJumpCommand(ctx, manager),
 		newListCommand(ctx, manager),
 		newSearchCommand(ctx, manager),
+		newIndexCommand(ctx, manager),
 		newLogCommand(ctx, manager),
 		newTodoCommand(ctx, manager),
 		newToggleCommand(ctx, manager),
+		newPinCommand(ctx, manager),
+		newDoneCommand(ctx, manager),
 		newEditCommand(ctx, manager),
 		newDeleteCommand(ctx, manager),
+		newMoveCommand(ctx, manager),
+		newDupCommand(ctx, manager),
+		newReorderCommand(ctx, manager),
+		newOpenCommand(ctx, manager),
+		newCaptureCommand(ctx, manager),
+		newQuickCommand(ctx, manager),
+		newImportCommand(ctx, manager),
+		newCompareCommand(ctx, manager),
+		newHeatmapCommand(ctx, manager),
+		newBurndownCommand(ctx, manager),
+		newTmuxStatusCommand(ctx, manager),
+		newWrapupCommand(ctx, manager),
+		newPlanCommand(ctx, manager),
+		newReviewCommand(ctx, manager),
+		newStaleCommand(ctx, manager),
+		newUpcomingCommand(ctx, manager),
+		newDueCommand(ctx, manager),
+		newBlockedCommand(ctx, manager),
+		newRemindCommand(ctx, manager),
+		newServeCommand(ctx, manager),
+		newMCPCommand(ctx, manager),
+		newSummaryCommand(ctx, manager),
+		newTimeCommand(ctx, manager),
+		newTagsCommand(ctx, manager),
+		newTagCommand(ctx, manager),
+		newExportCommand(ctx, manager),
+		newReportCommand(ctx, manager),
+		newInvoiceCommand(ctx, manager),
+		newShareCommand(ctx, manager),
+		newUndoCommand(ctx, manager),
+		newHistoryCommand(manager),
+		newDoctorCommand(ctx, manager),
+		newArchiveCommand(manager),
+		newSyncCommand(manager),
+		newPushCommand(manager),
+		newPullCommand(manager),
+		newHooksCommand(ctx, manager),
+		newInitCommand(),
+		newConfigCommand(),
+		newContextCommand(),
+		newBackupCommand(manager),
+		newCompletionCommand(),
+		newVersionCommand(ctx),
 	)
+	cmd.CompletionOptions.DisableDefaultCmd = true
+	registerCompletions(ctx, cmd, manager)
 
 	return cmd
 }
 
+// runTUI launches the TUI on date, or today when date is zero, with the
+// entry at index (1-based) selected when index is positive.
+func runTUI(ctx context.Context, cmd *cobra.Command, manager *files.Manager, date time.Time, index int) error {
+	limit, err := files.ResolveWIPLimit(settings.WIPLimit)
+	if err != nil {
+		return err
+	}
+	if err := ui.ValidateKeyBindings(settings.Keys); err != nil {
+		return fmt.Errorf("config [keys]: %w", err)
+	}
+	m := ui.NewModel(ctx, manager, ui.Options{
+		WIPLimit:    limit,
+		TimeLayout:  clockLayout(),
+		Theme:       settings.Theme,
+		Keys:        settings.Keys,
+		Plain:       plainRequested(cmd),
+		SortByTime:  sortRequested(cmd),
+		SearchIndex: settings.SearchIndex,
+		Author:      settings.Author,
+		// The TUI owns the screen, so webhook failures are only logged.
+		OnChange:    entryWebhook(func(err error) { logging.L().Warn("webhook failed", "err", err) }),
+		Context:     activeContext(cmd),
+		WrapEntries: settings.EntryOverflow == "wrap",
+		Location:    logZone(),
+		Calendar:    workCalendar(),
+		Locale:      displayLocale(),
+		Date:        date,
+		Select:      index,
+	})
+	defer m.Close()
+	// The alternate screen keeps the TUI out of the scrollback. Bubble Tea
+	// leaves it and restores the terminal on exit, and on a panic in the
+	// model or its commands before reporting it here.
+	if _, err := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion()).Run(); err != nil {
+		if errors.Is(err, tea.ErrProgramPanic) {
+			return fmt.Errorf("run TUI: crashed (stack trace above); the terminal has been restored: %w", err)
+		}
+		return fmt.Errorf("run TUI: %w", err)
+	}
+	return nil
+}
+
 // ExecuteCommand is a thin wrapper that executes the Cobra root command.
 func ExecuteCommand(ctx context.Context) error {
-	manager, err := files.NewManager("")
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	settings = cfg
+
+	manager, err := cfg.Manager()
 	if err != nil {
 		return err
 	}
+	// A running capture daemon for the same logbook takes the entry before
+	// the command tree is built. It runs no auto_sync hooks and writes no
+	// trace, so those captures go through the full command; --verbose and
+	// friends already keep parseCaptureArgs from taking the fast path.
+	if debug, _ := strconv.ParseBool(os.Getenv("KERJA_DEBUG")); !cfg.AutoSync && !debug {
+		if sent, err := sendCapture(os.Args[1:], manager.BasePath(), os.Stdout); sent {
+			return err
+		}
+	}
 	cmd := NewRootCommand(ctx, manager)
+	defer closeLog()
 	return cmd.Execute()
 }
 
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+const (
+	formatText         = "text"
+	formatJSON         = "json"
+	formatScriptFilter = "script-filter"
+	formatKerja        = "kerja"
+	formatMarkdown     = "markdown"
+	formatCSV          = "csv"
+	formatICS          = "ics"
+	formatTodoTxt      = "todotxt"
+	formatOneline      = "oneline"
+)
+
+// scriptFilterItem follows the Alfred script filter schema, which Raycast
+// script commands accept as well.
+type scriptFilterItem struct {
+	UID       string            `json:"uid"`
+	Title     string            `json:"title"`
+	Subtitle  string            `json:"subtitle"`
+	Arg       string            `json:"arg"`
+	Match     string            `json:"match,omitempty"`
+	Icon      scriptFilterIcon  `json:"icon"`
+	Variables map[string]string `json:"variables,omitempty"`
+	Valid     bool              `json:"valid"`
+}
+
+type scriptFilterIcon struct {
+	Path string `json:"path"`
+}
+
+func validateFormat(value string, allowed ...string) error {
+	for _, candidate := range allowed {
+		if value == candidate {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid format %q (expected %s)", value, strings.Join(allowed, "|"))
+}
+
+// newScriptFilterItem describes a single entry so launcher actions can feed
+// the date and index straight back into toggle/edit/delete. arg is a single
+// YYYY-MM-DD#N word, so it survives being passed as one argv element.
+func newScriptFilterItem(date time.Time, index int, entry logbook.Entry) scriptFilterItem {
+	dateText := date.Format("2006-01-02")
+	status := entry.Status.String()
+
+	title := entry.Text
+	if title == "" {
+		title = "(no description)"
+	}
+
+	subtitleParts := []string{status, dateText, formatClock(entry.Time)}
+	if len(entry.Tags) > 0 {
+		tags := make([]string, len(entry.Tags))
+		for i, tag := range entry.Tags {
+			tags[i] = "#" + tag
+		}
+		subtitleParts = append(subtitleParts, strings.Join(tags, " "))
+	}
+
+	return scriptFilterItem{
+		UID:      fmt.Sprintf("%s-%d", dateText, index),
+		Title:    title,
+		Subtitle: strings.Join(subtitleParts, " · "),
+		Arg:      fmt.Sprintf("%s#%d", dateText, index),
+		Match:    strings.TrimSpace(title + " " + strings.Join(entry.Tags, " ")),
+		Icon:     scriptFilterIcon{Path: fmt.Sprintf("icons/%s.png", status)},
+		Variables: map[string]string{
+			"date":   dateText,
+			"index":  strconv.Itoa(index),
+			"status": status,
+		},
+		Valid: true,
+	}
+}
+
+func printScriptFilter(cmd *cobra.Command, items []scriptFilterItem) error {
+	if items == nil {
+		items = []scriptFilterItem{}
+	}
+	payload := struct {
+		Items []scriptFilterItem `json:"items"`
+	}{Items: items}
+
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	return enc.Encode(payload)
+}
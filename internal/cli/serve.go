@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/server"
+)
+
+// serveHeaderTimeout and serveIdleTimeout stop idle or slow clients from
+// holding connections open. Event streams keep writing, so there is no write
+// timeout.
+const (
+	serveHeaderTimeout = 10 * time.Second
+	serveIdleTimeout   = 2 * time.Minute
+)
+
+func newServeCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		addrFlag   string
+		originFlag string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the logbook over HTTP with a web page and live change events.",
+		Long: "serve hosts a small web page at / for viewing a day and adding or toggling entries, and the JSON API\n" +
+			"behind it, until interrupted. Changes stream as Server-Sent Events, so a dashboard or browser\n" +
+			"extension can mirror the log live:\n\n" +
+			"  GET  /api/days/YYYY-MM-DD                  one day (or today), shaped like kerja today --json\n" +
+			"  POST /api/days/YYYY-MM-DD/entries          add {\"text\": \"...\"}, written as in the TUI prompt\n" +
+			"  POST /api/days/YYYY-MM-DD/entries/N/toggle advance entry N's status\n" +
+			"  GET  /api/entries?from=YYYY-MM-DD&to=...   every day in the range, like kerja list --json\n" +
+			"  GET  /api/events                           a \"change\" event with {\"month\", \"path\"} whenever a\n" +
+			"                                             month file is written, by kerja or an editor\n\n" +
+			"It listens on localhost only unless --addr says otherwise; use --addr 0.0.0.0:7788 to reach it from a\n" +
+			"phone on the same network. Off localhost, every /api request then needs the token printed in the URL\n" +
+			"at startup, as a token query parameter or an Authorization: Bearer header. Pass --allow-origin to let\n" +
+			"pages on another origin call it from a browser. Requests for a host other than localhost, an IP address,\n" +
+			"or the one in --addr are refused.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			listener, err := net.Listen("tcp", addrFlag)
+			if err != nil {
+				return fmt.Errorf("serve: %w", err)
+			}
+			srv := server.New(manager).UseWriter(newWriter(cmd, manager)).AllowOrigin(originFlag).AllowHost(addrFlag)
+			// Anyone on the network can reach a server that is not bound to
+			// loopback, so its API needs the token printed below.
+			url := fmt.Sprintf("http://%s", listener.Addr())
+			if !loopback(listener.Addr()) {
+				token := rand.Text()
+				srv.RequireToken(token)
+				url += "/?token=" + token
+			}
+
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			watchErr := make(chan error, 1)
+			go func() { watchErr <- srv.Watch(ctx) }()
+
+			httpServer := &http.Server{
+				Handler:           srv.Handler(),
+				BaseContext:       func(net.Listener) context.Context { return ctx },
+				ReadHeaderTimeout: serveHeaderTimeout,
+				IdleTimeout:       serveIdleTimeout,
+			}
+			serveErr := make(chan error, 1)
+			go func() { serveErr <- httpServer.Serve(listener) }()
+			fmt.Fprintf(cmd.OutOrStdout(), "Serving %s on %s (Ctrl+C to stop).\n", manager.BasePath(), url)
+
+			select {
+			case <-ctx.Done():
+				return httpServer.Shutdown(context.Background())
+			case err := <-watchErr:
+				httpServer.Close()
+				return err
+			case err := <-serveErr:
+				if errors.Is(err, http.ErrServerClosed) {
+					return nil
+				}
+				return fmt.Errorf("serve: %w", err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&addrFlag, "addr", "127.0.0.1:7788", "Address to listen on")
+	cmd.Flags().StringVar(&originFlag, "allow-origin", "", "Origin allowed to read the API from a browser, or * for any")
+	return cmd
+}
+
+// loopback reports whether addr only accepts connections from this machine.
+func loopback(addr net.Addr) bool {
+	tcp, ok := addr.(*net.TCPAddr)
+	return ok && tcp.IP.IsLoopback()
+}
@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"net"
+	"testing"
+)
+
+func TestLoopbackOnlyTrustsLoopbackAddresses(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"127.0.0.1:7788":    true,
+		"[::1]:7788":        true,
+		"0.0.0.0:7788":      false,
+		"[::]:7788":         false,
+		"192.168.1.20:7788": false,
+	} {
+		tcp, err := net.ResolveTCPAddr("tcp", addr)
+		if err != nil {
+			t.Fatalf("ResolveTCPAddr(%s): %v", addr, err)
+		}
+		if got := loopback(tcp); got != want {
+			t.Errorf("loopback(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
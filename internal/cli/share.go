@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+	"github.com/faizmokh/kerja/internal/report"
+	"github.com/faizmokh/kerja/internal/slack"
+)
+
+// postSlack sends a message to a Slack incoming webhook. Tests replace it to
+// capture the message. It shares webhookTimeout so an unreachable Slack
+// cannot hang the command.
+var postSlack = func(ctx context.Context, webhook, text string) error {
+	return slack.Post(ctx, &http.Client{Timeout: webhookTimeout}, webhook, text)
+}
+
+func newShareCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		slackFlag    bool
+		webhookFlag  string
+		tagFlag      string
+		templateFlag string
+		titleFlag    string
+		dryRunFlag   bool
+		dateFlag     string
+		weekFlag     bool
+		monthFlag    bool
+		fromFlag     string
+		toFlag       string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "share",
+		Short: "Post a summary of a day or range to Slack.",
+		Long: "share --slack posts the entries of --date (default today), or of --week, --month, or --from/--to, to the\n" +
+			"Slack incoming webhook in the slack_webhook config key (or --webhook). --tag keeps only entries carrying\n" +
+			"one of the comma-separated tags. The message is a Go text/template that receives the same data as\n" +
+			"kerja report; save one as share.slack.tmpl in the logbook directory or pass --template to change the\n" +
+			"layout, and use --dry-run to print the message instead of posting it.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !slackFlag {
+				return fmt.Errorf("choose where to share, such as --slack")
+			}
+			webhook := webhookFlag
+			if webhook == "" {
+				webhook = settings.SlackWebhook
+			}
+			if webhook == "" && !dryRunFlag {
+				return fmt.Errorf("slack_webhook is not set; add it with kerja config set slack_webhook <url> or pass --webhook")
+			}
+
+			start, end, err := resolveShareRange(dateFlag, weekFlag, monthFlag, fromFlag, toFlag)
+			if err != nil {
+				return err
+			}
+			sections, err := logbook.NewReader(manager).SectionsBetween(ctx, start, end)
+			if err != nil {
+				return err
+			}
+			sections = withinContext(sections, logbook.ParseContext(tagFlag))
+
+			var tmpl *template.Template
+			if templateFlag != "" {
+				tmpl, err = report.LoadFile(templateFlag)
+			} else {
+				tmpl, err = report.LoadSlackTemplate(report.SlackPath(manager.BasePath()))
+			}
+			if err != nil {
+				return err
+			}
+
+			title := titleFlag
+			if title == "" {
+				title = "Work log for " + formatRange(start, end)
+			}
+			var message bytes.Buffer
+			if err := report.Render(&message, tmpl, report.Build(title, start, end, sections, report.ByTag)); err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if dryRunFlag {
+				fmt.Fprint(out, message.String())
+				return nil
+			}
+			if err := postSlack(ctx, webhook, message.String()); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Shared %s to Slack\n", formatRange(start, end))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&slackFlag, "slack", false, "Post the summary to the Slack incoming webhook")
+	cmd.Flags().StringVar(&webhookFlag, "webhook", "", "Slack incoming webhook URL (default: slack_webhook)")
+	cmd.Flags().StringVar(&tagFlag, "tag", "", "Only share entries with one of these comma-separated tags")
+	cmd.Flags().StringVar(&templateFlag, "template", "", "Render this Go text/template file instead of the default message")
+	cmd.Flags().StringVar(&titleFlag, "title", "", "Message heading (default: Work log for the range)")
+	cmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Print the message instead of posting it")
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Day to share, or the reference date of a range, in YYYY-MM-DD (default: today)")
+	cmd.Flags().BoolVar(&weekFlag, "week", false, "Share the 7 days ending on the reference date")
+	cmd.Flags().BoolVar(&monthFlag, "month", false, "Share the calendar month containing the reference date")
+	cmd.Flags().StringVar(&fromFlag, "from", "", "First day of a custom range in YYYY-MM-DD")
+	cmd.Flags().StringVar(&toFlag, "to", "", "Last day of a custom range in YYYY-MM-DD (default: reference date)")
+
+	return cmd
+}
+
+// resolveShareRange is resolveSummaryRange, except that without --week,
+// --month, or --from/--to it covers only the reference date.
+func resolveShareRange(dateFlag string, week, month bool, from, to string) (time.Time, time.Time, error) {
+	if !week && !month && from == "" && to == "" {
+		date, err := resolveDate(dateFlag)
+		return date, date, err
+	}
+	return resolveSummaryRange(dateFlag, week, month, from, to)
+}
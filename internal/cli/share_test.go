@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestShareSlackPostsFilteredDay(t *testing.T) {
+	original, originalPost := settings, postSlack
+	t.Cleanup(func() { settings, postSlack = original, originalPost })
+	settings.SlackWebhook = "https://hooks.slack.test/services/T/B/X"
+
+	var webhook, message string
+	postSlack = func(ctx context.Context, url, text string) error {
+		webhook, message = url, text
+		return nil
+	}
+
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-04", "--time", "09:00", "Ship login #work")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-04", "--time", "10:00", "Dentist #home")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-03", "--time", "09:00", "Plan sprint #work")
+
+	out := executeCommand(t, newShareCommand(ctx, mgr), "--slack", "--date", "2025-11-04", "--tag", "work")
+	assertContains(t, out, "Shared 2025-11-04 to Slack")
+	if webhook != settings.SlackWebhook {
+		t.Fatalf("posted to %q", webhook)
+	}
+	assertContains(t, message, "*Work log for 2025-11-04*")
+	assertContains(t, message, ":white_check_mark: Ship login _#work_")
+	assertNotContains(t, message, "Dentist")
+	assertNotContains(t, message, "Plan sprint")
+
+	out = executeCommand(t, newShareCommand(ctx, mgr), "--slack", "--dry-run", "--from", "2025-11-03", "--to", "2025-11-04", "--tag", "work")
+	assertContains(t, out, "*Work log for 2025-11-03..2025-11-04*")
+	assertContains(t, out, "Plan sprint")
+}
+
+func TestShareSlackRequiresWebhook(t *testing.T) {
+	original := settings
+	t.Cleanup(func() { settings = original })
+	settings.SlackWebhook = ""
+
+	cmd := newShareCommand(context.Background(), newTempManager(t))
+	cmd.SetArgs([]string{"--slack"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "slack_webhook is not set") {
+		t.Fatalf("share without webhook error = %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+	"github.com/faizmokh/kerja/internal/snippets"
+)
+
+// expandTemplate fills entry from the named snippet. The snippet text comes
+// first with any typed text appended, tags are merged, its !status replaces
+// the command default, its @time applies unless keepTime, and its notes
+// precede any typed ones.
+func expandTemplate(manager *files.Manager, name string, date time.Time, entry logbook.Entry, keepTime bool) (logbook.Entry, error) {
+	path := snippets.Path(manager.BasePath())
+	list, err := snippets.Load(path)
+	if err != nil {
+		return entry, err
+	}
+	snippet, ok := snippets.Find(list, name)
+	if !ok {
+		return entry, fmt.Errorf("unknown template %q (define it under \"## %s\" in %s)", name, name, path)
+	}
+
+	parsed, err := logbook.ParseTokens(snippet.Text, date)
+	if err != nil {
+		return entry, fmt.Errorf("template %q: %w", snippet.Name, err)
+	}
+	template := parsed.Entry(entry.Time, entry.Status)
+	entry.Text = strings.TrimSpace(template.Text + " " + entry.Text)
+	for _, tag := range entry.Tags {
+		if !slices.Contains(template.Tags, tag) {
+			template.Tags = append(template.Tags, tag)
+		}
+	}
+	entry.Tags = template.Tags
+	entry.Links = append(template.Links, entry.Links...)
+	entry.After = append(template.After, entry.After...)
+	entry.Status = template.Status
+	entry.Pinned = entry.Pinned || template.Pinned
+	if entry.Author == "" {
+		entry.Author = template.Author
+	}
+	if entry.Due.IsZero() {
+		entry.Due = template.Due
+	}
+	if parsed.Time != nil && !keepTime {
+		entry.Time, entry.End = template.Time, template.End
+	}
+	entry.Notes = append(slices.Clone(snippet.Notes), entry.Notes...)
+	return entry, nil
+}
+
+// completeTemplates suggests snippet names for --template.
+func completeTemplates(manager *files.Manager) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		list, err := snippets.Load(snippets.Path(manager.BasePath()))
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		var names []string
+		for _, snippet := range list {
+			if strings.HasPrefix(strings.ToLower(snippet.Name), strings.ToLower(toComplete)) {
+				names = append(names, snippet.Name+"\t"+snippet.Text)
+			}
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
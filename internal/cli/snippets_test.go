@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/faizmokh/kerja/internal/snippets"
+)
+
+func TestTemplateFlagExpandsSnippet(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	content := "## standup\nDaily standup @09:30 #meeting\n  Blockers:\n\n## shipped\nShipped !done #release\n"
+	if err := os.WriteFile(snippets.Path(mgr.BasePath()), []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	out := executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-12", "--template", "standup")
+	assertContains(t, out, "Added todo [todo] 09:30 Daily standup (#meeting)")
+
+	out = executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-12", "--time", "11:00", "--template", "Shipped", "v1.2", "#ops")
+	assertContains(t, out, "[done] 11:00 Shipped v1.2 (#release, #ops)")
+
+	out = executeCommand(t, newListCommand(ctx, mgr), "--date", "2025-11-12")
+	assertContains(t, out, "Blockers:")
+
+	cmd := newLogCommand(ctx, mgr)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--date", "2025-11-12", "--template", "retro"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), `unknown template "retro"`) {
+		t.Fatalf("expected unknown template error, got %v", err)
+	}
+}
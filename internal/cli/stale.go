@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// staleTodo is a todo that has stayed open, possibly carried across several
+// days, since Since.
+type staleTodo struct {
+	Entry    logbook.Entry `json:"entry"`
+	Since    string        `json:"since"`
+	LastSeen string        `json:"last_seen"`
+	AgeDays  int           `json:"age_days"`
+}
+
+func newStaleCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		dateFlag     string
+		daysFlag     int
+		lookbackFlag int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "stale",
+		Short: "List todos that have stayed open for N days or more.",
+		Long: "stale scans recent days for todos that are still open. A todo carried forward to later days\n" +
+			"(same text) keeps the date it first appeared; marking any copy done resets it.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if daysFlag < 1 {
+				return fmt.Errorf("--days must be at least 1")
+			}
+			if lookbackFlag < daysFlag {
+				return fmt.Errorf("--lookback must be at least --days")
+			}
+
+			date, err := resolveDate(dateFlag)
+			if err != nil {
+				return err
+			}
+			sections, err := logbook.NewReader(manager).SectionsBetween(ctx, date.AddDate(0, 0, -lookbackFlag), date)
+			if err != nil {
+				return err
+			}
+
+			stale := findStaleTodos(sections, date, daysFlag)
+			if jsonRequested(cmd) {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(stale)
+			}
+
+			out := cmd.OutOrStdout()
+			if len(stale) == 0 {
+				fmt.Fprintf(out, "No todos open for %d day%s or more.\n", daysFlag, sSuffix(daysFlag))
+				return nil
+			}
+			fmt.Fprintf(out, "Todos open for %d day%s or more (%d)\n", daysFlag, sSuffix(daysFlag), len(stale))
+			for _, todo := range stale {
+				fmt.Fprintf(out, "- %3dd since %s: %s", todo.AgeDays, todo.Since, formatEntry(todo.Entry))
+				if todo.LastSeen != todo.Since {
+					fmt.Fprintf(out, " (last on %s)", todo.LastSeen)
+				}
+				fmt.Fprintln(out)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Reference date in YYYY-MM-DD (default: today)")
+	cmd.Flags().IntVar(&daysFlag, "days", 7, "Minimum age in days for a todo to be reported")
+	cmd.Flags().IntVar(&lookbackFlag, "lookback", 60, "How many days back to scan")
+
+	return cmd
+}
+
+// findStaleTodos follows each todo by its normalised text through sections
+// (oldest first) and returns those still open at least days before date,
+// oldest first.
+func findStaleTodos(sections []logbook.DateSection, date time.Time, days int) []staleTodo {
+	type chain struct {
+		entry logbook.Entry
+		since time.Time
+		last  time.Time
+		open  bool
+	}
+	chains := make(map[string]*chain)
+	var order []string
+	for _, section := range sections {
+		for _, entry := range section.Entries {
+			key := compareKey(entry)
+			c, ok := chains[key]
+			if !ok {
+				c = &chain{}
+				chains[key] = c
+				order = append(order, key)
+			}
+			if !entry.Status.Open() {
+				c.open = false
+				continue
+			}
+			if !c.open {
+				c.open = true
+				c.since = section.Date
+			}
+			c.entry = entry
+			c.last = section.Date
+		}
+	}
+
+	stale := []staleTodo{}
+	for _, key := range order {
+		c := chains[key]
+		if !c.open {
+			continue
+		}
+		age := daysBetween(c.since, date)
+		if age < days {
+			continue
+		}
+		stale = append(stale, staleTodo{
+			Entry:    c.entry,
+			Since:    c.since.Format("2006-01-02"),
+			LastSeen: c.last.Format("2006-01-02"),
+			AgeDays:  age,
+		})
+	}
+	sort.SliceStable(stale, func(i, j int) bool { return stale[i].AgeDays > stale[j].AgeDays })
+	return stale
+}
+
+// daysBetween counts calendar days from a to b, ignoring DST shifts.
+func daysBetween(a, b time.Time) int {
+	from := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
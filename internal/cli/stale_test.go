@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestStaleCommandReportsAgingTodos(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-01", "--time", "09:00", "Write RFC", "#docs")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-05", "--time", "09:00", "write  rfc", "#docs")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-02", "--time", "10:00", "Fix flaky test")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-06", "--time", "10:00", "Fix flaky test")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-10", "--time", "11:00", "Book travel")
+
+	out := executeCommand(t, newStaleCommand(ctx, mgr), "--date", "2025-11-12", "--days", "7")
+	assertContains(t, out, "Todos open for 7 days or more (1)")
+	assertContains(t, out, "11d since 2025-11-01: [todo] 09:00 write  rfc (#docs) (last on 2025-11-05)")
+	assertNotContains(t, out, "Fix flaky test")
+	assertNotContains(t, out, "Book travel")
+
+	out = executeCommand(t, newStaleCommand(ctx, mgr), "--date", "2025-11-12", "--days", "2")
+	if strings.Index(out, "Book travel") < strings.Index(out, "rfc") {
+		t.Fatalf("oldest todo should come first:\n%s", out)
+	}
+
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "stale", "--date", "2025-11-12", "--json")
+	var stale []staleTodo
+	if err := json.Unmarshal([]byte(out), &stale); err != nil {
+		t.Fatalf("Unmarshal: %v\n%s", err, out)
+	}
+	if len(stale) != 1 || stale[0].AgeDays != 11 || stale[0].Since != "2025-11-01" {
+		t.Fatalf("unexpected stale todos: %+v", stale)
+	}
+
+	out = executeCommand(t, newStaleCommand(ctx, mgr), "--date", "2025-11-12", "--days", "30")
+	assertContains(t, out, "No todos open for 30 days or more.")
+}
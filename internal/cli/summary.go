@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+type daySummary struct {
+	Date time.Time
+	Done int
+	Todo int
+}
+
+func newSummaryCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		dateFlag  string
+		weekFlag  bool
+		monthFlag bool
+		fromFlag  string
+		toFlag    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "summary",
+		Short: "Summarize done/todo counts and top tags over a range of days.",
+		Long: "summary aggregates the last 7 days ending on --date by default (or with --week). Use --month for\n" +
+			"the calendar month containing --date, or --from/--to for an arbitrary inclusive range.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start, end, err := resolveSummaryRange(dateFlag, weekFlag, monthFlag, fromFlag, toFlag)
+			if err != nil {
+				return err
+			}
+
+			sections, err := logbook.NewReader(manager).SectionsBetween(ctx, start, end)
+			if err != nil {
+				return err
+			}
+
+			printSummary(cmd, start, end, sections)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Reference date in YYYY-MM-DD (default: today)")
+	cmd.Flags().BoolVar(&weekFlag, "week", false, "Summarize the 7 days ending on the reference date (default)")
+	cmd.Flags().BoolVar(&monthFlag, "month", false, "Summarize the calendar month containing the reference date")
+	cmd.Flags().StringVar(&fromFlag, "from", "", "First day of a custom range in YYYY-MM-DD")
+	cmd.Flags().StringVar(&toFlag, "to", "", "Last day of a custom range in YYYY-MM-DD (default: reference date)")
+
+	return cmd
+}
+
+func resolveSummaryRange(dateFlag string, week, month bool, from, to string) (time.Time, time.Time, error) {
+	custom := from != "" || to != ""
+	modes := 0
+	for _, set := range []bool{week, month, custom} {
+		if set {
+			modes++
+		}
+	}
+	if modes > 1 {
+		return time.Time{}, time.Time{}, fmt.Errorf("--week, --month, and --from/--to are mutually exclusive")
+	}
+
+	date, err := resolveDate(dateFlag)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	switch {
+	case month:
+		start := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+		return start, start.AddDate(0, 1, -1), nil
+	case custom:
+		if from == "" {
+			return time.Time{}, time.Time{}, fmt.Errorf("--to requires --from")
+		}
+		start, err := resolveDate(from)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		end := date
+		if to != "" {
+			if end, err = resolveDate(to); err != nil {
+				return time.Time{}, time.Time{}, err
+			}
+		}
+		if end.Before(start) {
+			return time.Time{}, time.Time{}, fmt.Errorf("--to %s is before --from %s", end.Format("2006-01-02"), start.Format("2006-01-02"))
+		}
+		return start, end, nil
+	default:
+		return date.AddDate(0, 0, -6), date, nil
+	}
+}
+
+// summarizeDays returns one row per day in the range, including days without
+// a section so gaps stay visible.
+func summarizeDays(start, end time.Time, sections []logbook.DateSection) []daySummary {
+	byDate := make(map[string]logbook.DateSection, len(sections))
+	for _, section := range sections {
+		byDate[section.Date.Format("2006-01-02")] = section
+	}
+
+	var days []daySummary
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		section := byDate[day.Format("2006-01-02")]
+		open := section.OpenCount()
+		days = append(days, daySummary{Date: day, Done: len(section.Entries) - open, Todo: open})
+	}
+	return days
+}
+
+func printSummary(cmd *cobra.Command, start, end time.Time, sections []logbook.DateSection) {
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Summary %s\n\n", formatRange(start, end))
+
+	var done, todo int
+	for _, day := range summarizeDays(start, end, sections) {
+		fmt.Fprintf(out, "%s %s  %2d done  %2d todo\n", day.Date.Format("2006-01-02"), day.Date.Format("Mon"), day.Done, day.Todo)
+		done += day.Done
+		todo += day.Todo
+	}
+
+	fmt.Fprintf(out, "\nTotal: %d entr%s (%d done, %d todo)\n", done+todo, pluralSuffix(done+todo), done, todo)
+
+	tags := topTags(sections, 5)
+	if len(tags) == 0 {
+		fmt.Fprintln(out, "Top tags: (none)")
+		return
+	}
+	fmt.Fprintf(out, "Top tags: %s\n", formatTagCounts(tags))
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSummaryCommandAggregatesRanges(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-10", "--time", "09:00", "Standup", "#team")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-10", "--time", "10:00", "Write RFC", "#docs")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-12", "--time", "09:00", "Standup", "#team")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-10-31", "--time", "17:00", "Month end", "#ops")
+
+	out := executeCommand(t, newSummaryCommand(ctx, mgr), "--date", "2025-11-12")
+	assertContains(t, out, "Summary 2025-11-06..2025-11-12")
+	assertContains(t, out, "2025-11-10 Mon   1 done   1 todo")
+	assertContains(t, out, "2025-11-11 Tue   0 done   0 todo")
+	assertContains(t, out, "Total: 3 entries (2 done, 1 todo)")
+	assertContains(t, out, "Top tags: #team (2), #docs (1)")
+
+	out = executeCommand(t, newSummaryCommand(ctx, mgr), "--month", "--date", "2025-10-15")
+	assertContains(t, out, "Summary 2025-10-01..2025-10-31")
+	assertContains(t, out, "Total: 1 entry (1 done, 0 todo)")
+	assertNotContains(t, out, "#team")
+
+	out = executeCommand(t, newSummaryCommand(ctx, mgr), "--from", "2025-10-31", "--to", "2025-11-10")
+	if lines := strings.Count(out, " done "); lines != 11 {
+		t.Fatalf("expected 11 day rows, got %d\n%s", lines, out)
+	}
+	assertContains(t, out, "Total: 3 entries (2 done, 1 todo)")
+}
+
+func TestSummaryCommandRejectsConflictingRanges(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	for _, args := range [][]string{
+		{"--week", "--month"},
+		{"--to", "2025-11-10"},
+		{"--from", "2025-11-10", "--to", "2025-11-01"},
+	} {
+		cmd := newSummaryCommand(ctx, mgr)
+		cmd.SetArgs(args)
+		cmd.SetOut(&strings.Builder{})
+		cmd.SetErr(&strings.Builder{})
+		if err := cmd.Execute(); err == nil {
+			t.Fatalf("expected error for %v", args)
+		}
+	}
+}
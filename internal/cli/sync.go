@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/gitsync"
+)
+
+func newSyncCommand(manager *files.Manager) *cobra.Command {
+	var (
+		remoteFlag  string
+		messageFlag string
+		initFlag    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Commit the logbook and sync it with a git remote.",
+		Long: "sync treats the logbook directory as a git repository: it commits every change with a message\n" +
+			"naming the touched months, pulls the remote branch with --rebase, and pushes. The remote comes from\n" +
+			"--remote, then the sync_remote config key, then \"origin\"; without that remote sync only commits.\n" +
+			"Use --init once to create the repository. Every commit keeps .undo/, .cache/, history.jsonl, index/, and\n" +
+			"backups/ in its .gitignore, as they are local to each machine.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := manager.BasePath()
+			var (
+				repo *gitsync.Repo
+				err  error
+			)
+			if initFlag {
+				repo, err = gitsync.Init(dir)
+			} else {
+				repo, err = gitsync.Open(dir)
+			}
+			if err != nil {
+				return err
+			}
+
+			remote := remoteFlag
+			if remote == "" {
+				remote = settings.SyncRemote
+			}
+			result, err := repo.Sync(remote, messageFlag)
+			if err != nil {
+				if len(result.Committed) > 0 {
+					printSyncCommit(cmd, result)
+				}
+				var conflict *gitsync.ConflictError
+				if errors.As(err, &conflict) {
+					return err
+				}
+				return fmt.Errorf("sync: %w", err)
+			}
+			printSyncResult(cmd, result)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&remoteFlag, "remote", "", "Git remote to pull from and push to (default: sync_remote or origin)")
+	cmd.Flags().StringVarP(&messageFlag, "message", "m", "", "Commit message (default: generated from the changed months)")
+	cmd.Flags().BoolVar(&initFlag, "init", false, "Initialize the logbook directory as a git repository first")
+
+	return cmd
+}
+
+func printSyncCommit(cmd *cobra.Command, result gitsync.Result) {
+	out := cmd.OutOrStdout()
+	if len(result.Committed) == 0 {
+		fmt.Fprintln(out, "No local changes to commit.")
+		return
+	}
+	fmt.Fprintf(out, "Committed %d file%s: %s\n", len(result.Committed), sSuffix(len(result.Committed)), strings.Join(result.Committed, ", "))
+}
+
+func printSyncResult(cmd *cobra.Command, result gitsync.Result) {
+	out := cmd.OutOrStdout()
+	printSyncCommit(cmd, result)
+	switch {
+	case result.Pushed && result.Pulled:
+		fmt.Fprintf(out, "Pulled and pushed %s/%s.\n", result.Remote, result.Branch)
+	case result.Pushed:
+		fmt.Fprintf(out, "Pushed %s/%s.\n", result.Remote, result.Branch)
+	case result.Branch == "":
+		fmt.Fprintf(out, "No remote %q configured; changes are committed locally.\n", result.Remote)
+	}
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSyncCommandInitAndCommit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "kerja")
+	t.Setenv("GIT_AUTHOR_EMAIL", "kerja@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "kerja")
+	t.Setenv("GIT_COMMITTER_EMAIL", "kerja@example.com")
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	cmd := newSyncCommand(mgr)
+	cmd.SetArgs(nil)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("sync outside a git repository should fail")
+	}
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-04", "--time", "09:00", "Shipped")
+	out := executeCommand(t, newSyncCommand(mgr), "--init")
+	assertContains(t, out, "Committed 2 files: .gitignore, 2025/2025-11.md")
+	assertContains(t, out, `No remote "origin" configured`)
+
+	out = executeCommand(t, newSyncCommand(mgr))
+	assertContains(t, out, "No local changes to commit.")
+}
+
+func TestPushAndPullRunConfiguredCommands(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not installed")
+	}
+	original := settings
+	t.Cleanup(func() { settings = original })
+	settings.PushCommand = `printf '%s' "$KERJA_HOME" > pushed.txt`
+
+	mgr := newTempManager(t)
+	executeCommand(t, newPushCommand(mgr))
+	data, err := os.ReadFile(filepath.Join(mgr.BasePath(), "pushed.txt"))
+	if err != nil || string(data) != mgr.BasePath() {
+		t.Fatalf("pushed.txt = %q, %v", data, err)
+	}
+
+	cmd := newPullCommand(mgr)
+	cmd.SetArgs(nil)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "pull_command is not set") {
+		t.Fatalf("pull without pull_command error = %v", err)
+	}
+}
+
+func TestAutoSyncPushesOnlyAfterWrites(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not installed")
+	}
+	original := settings
+	t.Cleanup(func() { settings = original })
+	settings.AutoSync = true
+	settings.PullCommand = "echo pull >> hooks.log"
+	settings.PushCommand = "echo push >> hooks.log"
+
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	executeCommand(t, NewRootCommand(ctx, mgr), "today")
+	executeCommand(t, NewRootCommand(ctx, mgr), "todo", "--time", "09:00", "Ship release")
+	executeCommand(t, NewRootCommand(ctx, mgr), "version")
+
+	data, err := os.ReadFile(filepath.Join(mgr.BasePath(), "hooks.log"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if got := string(data); got != "pull\npull\npush\n" {
+		t.Fatalf("hooks ran %q, want a pull for each command and one push", got)
+	}
+}
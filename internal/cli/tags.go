@@ -0,0 +1,254 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+type tagStat struct {
+	Tag   string `json:"tag"`
+	Total int    `json:"total"`
+	Todo  int    `json:"todo"`
+	Done  int    `json:"done"`
+}
+
+func newTagsCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		dateFlag   string
+		weekFlag   bool
+		monthFlag  bool
+		fromFlag   string
+		toFlag     string
+		sortFlag   string
+		outputJSON bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "tags",
+		Short: "Show how often each tag was used, split by todo and done.",
+		Long: "tags scans the same ranges as summary: the 7 days ending on --date by default, --month for the\n" +
+			"calendar month, or --from/--to for an arbitrary range.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if sortFlag != "count" && sortFlag != "name" {
+				return fmt.Errorf("invalid sort %q (expected count|name)", sortFlag)
+			}
+			start, end, err := resolveSummaryRange(dateFlag, weekFlag, monthFlag, fromFlag, toFlag)
+			if err != nil {
+				return err
+			}
+
+			sections, err := logbook.NewReader(manager).SectionsBetween(ctx, start, end)
+			if err != nil {
+				return err
+			}
+			stats := tagStats(sections, sortFlag)
+
+			if outputJSON {
+				if stats == nil {
+					stats = []tagStat{}
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(stats)
+			}
+
+			if len(stats) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "No tags between %s and %s\n", start.Format("2006-01-02"), end.Format("2006-01-02"))
+				return nil
+			}
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', tabwriter.AlignRight)
+			fmt.Fprintln(tw, "TAG\tTOTAL\tTODO\tDONE\t")
+			for _, stat := range stats {
+				fmt.Fprintf(tw, "#%s\t%d\t%d\t%d\t\n", stat.Tag, stat.Total, stat.Todo, stat.Done)
+			}
+			return tw.Flush()
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Reference date in YYYY-MM-DD (default: today)")
+	cmd.Flags().BoolVar(&weekFlag, "week", false, "Scan the 7 days ending on the reference date (default)")
+	cmd.Flags().BoolVar(&monthFlag, "month", false, "Scan the calendar month containing the reference date")
+	cmd.Flags().StringVar(&fromFlag, "from", "", "First day of a custom range in YYYY-MM-DD")
+	cmd.Flags().StringVar(&toFlag, "to", "", "Last day of a custom range in YYYY-MM-DD (default: reference date)")
+	cmd.Flags().StringVar(&sortFlag, "sort", "count", "Sort by count|name")
+	cmd.Flags().BoolVar(&outputJSON, "json", false, "Emit tag statistics as JSON")
+
+	return cmd
+}
+
+// tagStats counts entries per tag. Sorting by count breaks ties by name.
+func tagStats(sections []logbook.DateSection, sortBy string) []tagStat {
+	byTag := make(map[string]*tagStat)
+	for _, section := range sections {
+		for _, entry := range section.Entries {
+			for _, tag := range entry.Tags {
+				stat, ok := byTag[tag]
+				if !ok {
+					stat = &tagStat{Tag: tag}
+					byTag[tag] = stat
+				}
+				stat.Total++
+				if entry.Status == logbook.StatusDone {
+					stat.Done++
+				} else if entry.Status.Open() {
+					stat.Todo++
+				}
+			}
+		}
+	}
+
+	var stats []tagStat
+	for _, stat := range byTag {
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if sortBy == "count" && stats[i].Total != stats[j].Total {
+			return stats[i].Total > stats[j].Total
+		}
+		return stats[i].Tag < stats[j].Tag
+	})
+	return stats
+}
+
+func newTagCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tag",
+		Short: "Rename, merge, or remove tags across the logbook.",
+		Long: "tag rewrites tags on every entry between --from and --to, the whole logbook by default. Each\n" +
+			"command lists the entries it changed and writes every file as one undoable batch; --dry-run\n" +
+			"lists them without writing.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	rename := tagRewriteCommand(&cobra.Command{
+		Use:   "rename <old> <new>",
+		Short: "Rename a tag; entries that already carry the new one keep one copy.",
+		Args:  cobra.ExactArgs(2),
+	}, func(cmd *cobra.Command, args []string, scope logbook.TagScope) (logbook.TagRewrite, string, error) {
+		oldTag, newTag := trimTag(args[0]), trimTag(args[1])
+		if oldTag == newTag {
+			return logbook.TagRewrite{}, "", fmt.Errorf("#%s and #%s are the same tag", oldTag, newTag)
+		}
+		result, err := newWriter(cmd, manager).RenameTag(ctx, scope, oldTag, newTag)
+		return result, fmt.Sprintf("rename #%s to #%s", oldTag, newTag), err
+	})
+
+	var intoFlag string
+	merge := tagRewriteCommand(&cobra.Command{
+		Use:   "merge <tag>... --into <tag>",
+		Short: "Replace several tags with one.",
+		Args:  cobra.MinimumNArgs(1),
+	}, func(cmd *cobra.Command, args []string, scope logbook.TagScope) (logbook.TagRewrite, string, error) {
+		into := trimTag(intoFlag)
+		if into == "" {
+			return logbook.TagRewrite{}, "", fmt.Errorf("--into is required")
+		}
+		var sources []string
+		for _, arg := range args {
+			if tag := trimTag(arg); tag != into && !slices.Contains(sources, tag) {
+				sources = append(sources, tag)
+			}
+		}
+		if len(sources) == 0 {
+			return logbook.TagRewrite{}, "", fmt.Errorf("nothing to merge into #%s", into)
+		}
+		result, err := newWriter(cmd, manager).MergeTags(ctx, scope, sources, into)
+		return result, fmt.Sprintf("merge #%s into #%s", strings.Join(sources, ", #"), into), err
+	})
+	merge.Flags().StringVar(&intoFlag, "into", "", "Tag that replaces the merged ones")
+
+	remove := tagRewriteCommand(&cobra.Command{
+		Use:     "rm <tag>",
+		Aliases: []string{"remove"},
+		Short:   "Remove a tag from every entry carrying it.",
+		Args:    cobra.ExactArgs(1),
+	}, func(cmd *cobra.Command, args []string, scope logbook.TagScope) (logbook.TagRewrite, string, error) {
+		tag := trimTag(args[0])
+		result, err := newWriter(cmd, manager).RemoveTag(ctx, scope, tag)
+		return result, "remove #" + tag, err
+	})
+
+	cmd.AddCommand(rename, merge, remove)
+	return cmd
+}
+
+// tagRewriteCommand gives cmd the shared range and --dry-run flags and runs
+// rewrite, which returns the rewrite and a description such as "remove #x",
+// then lists the entries it changed.
+func tagRewriteCommand(cmd *cobra.Command, rewrite func(*cobra.Command, []string, logbook.TagScope) (logbook.TagRewrite, string, error)) *cobra.Command {
+	var (
+		fromFlag   string
+		toFlag     string
+		dryRunFlag bool
+	)
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		start, end, err := resolveTagRange(fromFlag, toFlag)
+		if err != nil {
+			return err
+		}
+		result, action, err := rewrite(cmd, args, logbook.TagScope{Start: start, End: end, DryRun: dryRunFlag})
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if result.Entries == 0 {
+			fmt.Fprintf(out, "No entries to %s\n", action)
+			return nil
+		}
+		for _, changed := range result.Changed {
+			fmt.Fprintf(out, "%s  %s\n", changed.Ref, formatEntry(changed.Entry))
+		}
+		summary := fmt.Sprintf("%d entr%s in %d file%s", result.Entries, pluralSuffix(result.Entries), result.Files, sSuffix(result.Files))
+		if dryRunFlag {
+			fmt.Fprintf(out, "\nDry run: would %s on %s.\n", action, summary)
+		} else {
+			fmt.Fprintf(out, "\nDone: %s on %s.\n", action, summary)
+		}
+		return nil
+	}
+	cmd.Flags().StringVar(&fromFlag, "from", "", "First day to rewrite in YYYY-MM-DD (default: the oldest entry)")
+	cmd.Flags().StringVar(&toFlag, "to", "", "Last day to rewrite in YYYY-MM-DD (default: the newest entry)")
+	cmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "List the entries that would change without writing")
+	return cmd
+}
+
+func trimTag(arg string) string {
+	return strings.TrimPrefix(strings.TrimSpace(arg), "#")
+}
+
+// resolveTagRange parses optional --from/--to dates, leaving a missing side
+// zero so the rewrite reaches that end of the logbook.
+func resolveTagRange(fromFlag, toFlag string) (time.Time, time.Time, error) {
+	var start, end time.Time
+	var err error
+	if fromFlag != "" {
+		if start, err = resolveDate(fromFlag); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if toFlag != "" {
+		if end, err = resolveDate(toFlag); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--to must not be before --from")
+	}
+	return start, end, nil
+}
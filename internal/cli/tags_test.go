@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestTagsCommandCountsByStatus(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-10", "--time", "09:00", "Standup", "#team")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-10", "--time", "10:00", "Write RFC", "#docs", "#team")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-11", "--time", "09:00", "Review", "#code")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-11", "--time", "11:00", "Pair", "#code")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-10-01", "--time", "11:00", "Old", "#archive")
+
+	out := executeCommand(t, newTagsCommand(ctx, mgr), "--date", "2025-11-11")
+	assertContains(t, out, "  TAG  TOTAL  TODO  DONE")
+	assertContains(t, out, "#code      2     0     2")
+	assertContains(t, out, "#team      2     1     1")
+	assertNotContains(t, out, "#archive")
+
+	var stats []tagStat
+	jsonOut := executeCommand(t, newTagsCommand(ctx, mgr), "--from", "2025-10-01", "--to", "2025-11-30", "--sort", "name", "--json")
+	if err := json.Unmarshal([]byte(jsonOut), &stats); err != nil {
+		t.Fatalf("json.Unmarshal: %v\n%s", err, jsonOut)
+	}
+	if len(stats) != 4 || stats[0].Tag != "archive" || stats[3] != (tagStat{Tag: "team", Total: 2, Todo: 1, Done: 1}) {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestTagRenameReportsEntriesAndFiles(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-10-31", "--time", "09:00", "Triage", "#bugs")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-10", "--time", "09:00", "Fix crash", "#bugs")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-11", "--time", "09:00", "Review", "#code")
+
+	out := executeCommand(t, newTagCommand(ctx, mgr), "rename", "#bugs", "bug")
+	assertContains(t, out, "2025-11-10#1  [done] 09:00 Fix crash (#bug)")
+	assertContains(t, out, "Done: rename #bugs to #bug on 2 entries in 2 files.")
+
+	out = executeCommand(t, newTagsCommand(ctx, mgr), "--from", "2025-10-01", "--to", "2025-11-30")
+	assertContains(t, out, "#bug ")
+	assertNotContains(t, out, "#bugs")
+
+	out = executeCommand(t, newTagCommand(ctx, mgr), "rename", "code", "review", "--from", "2025-11-12")
+	assertContains(t, out, "No entries to rename #code to #review")
+}
+
+func TestTagMergeAndRemoveSupportDryRun(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-10", "--time", "09:00", "Standup", "#mtg", "#team")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-10", "--time", "10:00", "Sync", "#meeting", "#mtg")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-11", "--time", "09:00", "Retro", "#meetings", "#wip")
+
+	out := executeCommand(t, newTagCommand(ctx, mgr), "merge", "#mtg", "meetings", "--into", "meeting", "--dry-run")
+	assertContains(t, out, "2025-11-10#2  [done] 10:00 Sync (#meeting)")
+	assertContains(t, out, "Dry run: would merge #mtg, #meetings into #meeting on 3 entries in 1 file.")
+	out = executeCommand(t, newTagsCommand(ctx, mgr), "--date", "2025-11-11")
+	assertContains(t, out, "#mtg ")
+
+	executeCommand(t, newTagCommand(ctx, mgr), "merge", "#mtg", "meetings", "--into", "meeting")
+	out = executeCommand(t, newTagCommand(ctx, mgr), "rm", "#wip")
+	assertContains(t, out, "2025-11-11#1  [done] 09:00 Retro (#meeting)")
+	assertContains(t, out, "Done: remove #wip on 1 entry in 1 file.")
+
+	out = executeCommand(t, newTagsCommand(ctx, mgr), "--date", "2025-11-11", "--sort", "name")
+	assertContains(t, out, "#meeting      3")
+	assertNotContains(t, out, "#mtg")
+	assertNotContains(t, out, "#wip")
+}
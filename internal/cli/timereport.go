@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+const untaggedBucket = "(untagged)"
+
+// timeBucket is the tracked time for one day or tag.
+type timeBucket struct {
+	Name    string `json:"name"`
+	Minutes int    `json:"minutes"`
+}
+
+// timeReport totals tracked entry durations over a date range.
+type timeReport struct {
+	From         string       `json:"from"`
+	To           string       `json:"to"`
+	TotalMinutes int          `json:"total_minutes"`
+	Days         []timeBucket `json:"days"`
+	Tags         []timeBucket `json:"tags"`
+}
+
+func newTimeCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		dateFlag  string
+		weekFlag  bool
+		monthFlag bool
+		fromFlag  string
+		toFlag    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "time",
+		Short: "Sum tracked time per day and per tag.",
+		Long: "time totals the durations of entries logged with a range (e.g. --time 09:00-10:30). It covers\n" +
+			"the 7 days ending on --date by default (or with --week); use --month or --from/--to like summary.\n" +
+			"An entry with several tags counts toward each of them.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start, end, err := resolveSummaryRange(dateFlag, weekFlag, monthFlag, fromFlag, toFlag)
+			if err != nil {
+				return err
+			}
+
+			sections, err := logbook.NewReader(manager).SectionsBetween(ctx, start, end)
+			if err != nil {
+				return err
+			}
+
+			report := buildTimeReport(start, end, sections)
+			if jsonRequested(cmd) {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(report)
+			}
+			printTimeReport(cmd.OutOrStdout(), start, end, report)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Reference date in YYYY-MM-DD (default: today)")
+	cmd.Flags().BoolVar(&weekFlag, "week", false, "Report the 7 days ending on the reference date (default)")
+	cmd.Flags().BoolVar(&monthFlag, "month", false, "Report the calendar month containing the reference date")
+	cmd.Flags().StringVar(&fromFlag, "from", "", "First day of a custom range in YYYY-MM-DD")
+	cmd.Flags().StringVar(&toFlag, "to", "", "Last day of a custom range in YYYY-MM-DD (default: reference date)")
+
+	return cmd
+}
+
+// buildTimeReport sums entry durations per day (in date order) and per tag
+// (largest first). Days without tracked time are left out.
+func buildTimeReport(start, end time.Time, sections []logbook.DateSection) timeReport {
+	report := timeReport{
+		From: start.Format("2006-01-02"),
+		To:   end.Format("2006-01-02"),
+		Days: []timeBucket{},
+		Tags: []timeBucket{},
+	}
+
+	tagMinutes := make(map[string]int)
+	for _, section := range sections {
+		dayMinutes := 0
+		for _, entry := range section.Entries {
+			minutes := int(entry.Duration().Round(time.Minute) / time.Minute)
+			if minutes == 0 {
+				continue
+			}
+			dayMinutes += minutes
+			if len(entry.Tags) == 0 {
+				tagMinutes[untaggedBucket] += minutes
+			}
+			for _, tag := range entry.Tags {
+				tagMinutes[tag] += minutes
+			}
+		}
+		if dayMinutes == 0 {
+			continue
+		}
+		report.TotalMinutes += dayMinutes
+		report.Days = append(report.Days, timeBucket{Name: section.Date.Format("2006-01-02"), Minutes: dayMinutes})
+	}
+
+	for tag, minutes := range tagMinutes {
+		report.Tags = append(report.Tags, timeBucket{Name: tag, Minutes: minutes})
+	}
+	sort.Slice(report.Tags, func(i, j int) bool {
+		if report.Tags[i].Minutes != report.Tags[j].Minutes {
+			return report.Tags[i].Minutes > report.Tags[j].Minutes
+		}
+		return report.Tags[i].Name < report.Tags[j].Name
+	})
+	return report
+}
+
+func printTimeReport(out io.Writer, start, end time.Time, report timeReport) {
+	fmt.Fprintf(out, "Tracked time %s\n\n", formatRange(start, end))
+	if report.TotalMinutes == 0 {
+		fmt.Fprintln(out, "No entries with a time range.")
+		return
+	}
+
+	fmt.Fprintln(out, "By day")
+	for _, day := range report.Days {
+		fmt.Fprintf(out, "  %s  %s\n", day.Name, formatDuration(minutesDuration(day.Minutes)))
+	}
+	fmt.Fprintln(out, "\nBy tag")
+	for _, tag := range report.Tags {
+		name := tag.Name
+		if name != untaggedBucket {
+			name = "#" + name
+		}
+		fmt.Fprintf(out, "  %-16s %s\n", name, formatDuration(minutesDuration(tag.Minutes)))
+	}
+	fmt.Fprintf(out, "\nTotal: %s\n", formatDuration(minutesDuration(report.TotalMinutes)))
+}
+
+func minutesDuration(n int) time.Duration {
+	return time.Duration(n) * time.Minute
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestTimeCommandSumsDurationsPerDayAndTag(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	out := executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-03", "--time", "09:00-10:30", "Sprint planning", "#meeting", "#team")
+	assertContains(t, out, "09:00-10:30 (1h30m)")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-04", "--time", "14:00-14:45", "Code review", "#team")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-04", "--time", "16:00-16:30", "Inbox zero")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-05", "--time", "11:00", "Untracked note", "#team")
+
+	out = executeCommand(t, newTimeCommand(ctx, mgr), "--date", "2025-11-05", "--week")
+	assertContains(t, out, "2025-11-03  1h30m")
+	assertContains(t, out, "2025-11-04  1h15m")
+	assertNotContains(t, out, "  2025-11-05  ")
+	assertContains(t, out, "#team            2h15m")
+	assertContains(t, out, "#meeting         1h30m")
+	assertContains(t, out, "(untagged)       30m")
+	assertContains(t, out, "Total: 2h45m")
+
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "time", "--date", "2025-11-05", "--json")
+	var report timeReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("Unmarshal: %v\n%s", err, out)
+	}
+	if report.TotalMinutes != 165 || len(report.Days) != 2 || report.Tags[0].Name != "team" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	out = executeCommand(t, newEditCommand(ctx, mgr), "--date", "2025-11-03", "--time", "13:00", "1")
+	assertContains(t, out, "13:00-14:30 (1h30m)")
+
+	out = executeCommand(t, newTimeCommand(ctx, mgr), "--date", "2025-10-20")
+	assertContains(t, out, "No entries with a time range.")
+}
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+const tmuxCacheFile = "tmux-status"
+
+func newTmuxStatusCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		ttlFlag      time.Duration
+		maxWidthFlag int
+		noCacheFlag  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "tmux-status",
+		Short: "Print a compact status segment for the tmux status line.",
+		Long: "tmux-status prints today's open todo count, the running timer, and the next timed todo in a form that is\n" +
+			"safe to embed in tmux's status-left or status-right. The timer is the latest in-progress entry that has\n" +
+			"started, shown with the time since it began, or an entry whose time range covers now, shown with the\n" +
+			"time left. Output is cached between invocations so frequent polling stays cheap, except for encrypted logbooks.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			now := time.Now().In(logZone())
+			glyphs := glyphsFor(cmd)
+			cachePath := filepath.Join(manager.BasePath(), ".cache", tmuxCacheName(maxWidthFlag, plainRequested(cmd)))
+			// The segment quotes entry text, which must not sit in plaintext
+			// beside an encrypted logbook; drop any cached before encryption.
+			useCache := !noCacheFlag && !manager.Encrypted()
+			if manager.Encrypted() {
+				_ = os.Remove(cachePath)
+			}
+
+			if useCache {
+				if cached, ok := readTmuxCache(cachePath, manager.MonthPath(now), ttlFlag, now); ok {
+					fmt.Fprintln(cmd.OutOrStdout(), cached)
+					return nil
+				}
+			}
+
+			reader := logbook.NewReader(manager)
+			date, err := resolveDate("")
+			if err != nil {
+				return err
+			}
+			section, err := reader.Section(ctx, date)
+			if err != nil && !errors.Is(err, logbook.ErrSectionNotFound) {
+				return err
+			}
+
+			segment := formatTmuxStatus(section, now, maxWidthFlag, glyphs)
+			if useCache {
+				// A failed cache write only costs a re-read on the next poll.
+				_ = writeTmuxCache(cachePath, segment)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), segment)
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&ttlFlag, "ttl", 15*time.Second, "How long a cached segment stays valid")
+	cmd.Flags().IntVar(&maxWidthFlag, "max-width", 24, "Truncate the timer and next todo texts to this many characters (0 disables)")
+	cmd.Flags().BoolVar(&noCacheFlag, "no-cache", false, "Always read the logbook instead of the cached segment")
+
+	return cmd
+}
+
+// tmuxCacheName names the cache file for one combination of the flags that
+// change the segment, so status lines using different ones never share it.
+func tmuxCacheName(maxWidth int, plain bool) string {
+	name := fmt.Sprintf("%s-w%d", tmuxCacheFile, maxWidth)
+	if plain {
+		name += "-plain"
+	}
+	return name
+}
+
+// formatTmuxStatus renders the open count, the running timer, and the next
+// upcoming timed todo.
+func formatTmuxStatus(section logbook.DateSection, now time.Time, maxWidth int, glyphs textGlyphs) string {
+	var (
+		open   int
+		next   *logbook.Entry
+		active *logbook.Entry
+	)
+	for i, entry := range section.Entries {
+		if runningAt(entry, now) && (active == nil || !entry.Time.Before(active.Time)) {
+			active = &section.Entries[i]
+		}
+		if !entry.Status.Open() {
+			continue
+		}
+		open++
+		if entry.Time.Before(now) {
+			continue
+		}
+		if next == nil || entry.Time.Before(next.Time) {
+			next = &section.Entries[i]
+		}
+	}
+
+	parts := []string{fmt.Sprintf("%d open", open)}
+	if active != nil {
+		text := truncateRunes(active.Text, maxWidth, glyphs.ellipsis)
+		timer := formatDuration(now.Sub(active.Time))
+		if !active.End.IsZero() {
+			timer = formatDuration(active.End.Sub(now)) + " left"
+		}
+		parts = append(parts, strings.TrimSpace(fmt.Sprintf("timer %s %s", timer, text)))
+	}
+	if next != nil {
+		text := truncateRunes(next.Text, maxWidth, glyphs.ellipsis)
+		parts = append(parts, strings.TrimSpace(fmt.Sprintf("next %s %s", formatClock(next.Time), text)))
+	}
+	return tmuxEscape(strings.Join(parts, glyphs.sep))
+}
+
+// runningAt reports whether entry is being timed at now: in progress since
+// a time already past with no end, or any entry but a cancelled one whose
+// range covers now.
+func runningAt(entry logbook.Entry, now time.Time) bool {
+	if entry.Time.After(now) || entry.Status == logbook.StatusCancelled {
+		return false
+	}
+	if entry.End.IsZero() {
+		return entry.Status == logbook.StatusInProgress
+	}
+	return now.Before(entry.End)
+}
+
+// tmuxEscape doubles '#' so tmux does not treat entry text as a format sequence.
+func tmuxEscape(value string) string {
+	value = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\r' || r == '\t' {
+			return ' '
+		}
+		return r
+	}, value)
+	return strings.ReplaceAll(value, "#", "##")
+}
+
+func truncateRunes(value string, max int, ellipsis string) string {
+	runes := []rune(value)
+	if max <= 0 || len(runes) <= max {
+		return value
+	}
+	marker := []rune(ellipsis)
+	if max <= len(marker) {
+		return string(marker[:max])
+	}
+	return strings.TrimRight(string(runes[:max-len(marker)]), " ") + ellipsis
+}
+
+func readTmuxCache(cachePath, monthPath string, ttl time.Duration, now time.Time) (string, bool) {
+	info, err := os.Stat(cachePath)
+	if err != nil || now.Sub(info.ModTime()) > ttl {
+		return "", false
+	}
+	// Edits to the month file after the cache was written invalidate it early.
+	if monthInfo, err := os.Stat(monthPath); err == nil && monthInfo.ModTime().After(info.ModTime()) {
+		return "", false
+	}
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimRight(string(data), "\n"), true
+}
+
+func writeTmuxCache(cachePath, segment string) error {
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(cachePath, []byte(segment+"\n"), 0o644)
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func TestFormatTmuxStatusCountsOpenAndPicksNextTodo(t *testing.T) {
+	day := time.Date(2025, time.November, 3, 0, 0, 0, 0, time.Local)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+
+	section := logbook.DateSection{
+		Date: day,
+		Entries: []logbook.Entry{
+			{Status: logbook.StatusTodo, Time: at(8, 0), Text: "Missed standup"},
+			{Status: logbook.StatusDone, Time: at(11, 0), Text: "Deploy"},
+			{Status: logbook.StatusTodo, Time: at(16, 0), Text: "Retro"},
+			{Status: logbook.StatusTodo, Time: at(14, 30), Text: "Review #42 with the platform team"},
+		},
+	}
+
+	got := formatTmuxStatus(section, at(10, 0), 12, unicodeText)
+	want := "3 open · next 14:30 Review ##42…"
+	if got != want {
+		t.Fatalf("formatTmuxStatus = %q, want %q", got, want)
+	}
+
+	if got := formatTmuxStatus(logbook.DateSection{Date: day}, at(10, 0), 12, unicodeText); got != "0 open" {
+		t.Fatalf("empty section = %q, want %q", got, "0 open")
+	}
+
+	section.Entries = append(section.Entries,
+		logbook.Entry{Status: logbook.StatusInProgress, Time: at(9, 15), Text: "Write the migration plan"},
+		logbook.Entry{Status: logbook.StatusInProgress, Time: at(7, 0), Text: "Older work"},
+	)
+	if got, want := formatTmuxStatus(section, at(10, 0), 12, asciiText), "5 open - timer 45m Write the... - next 14:30 Review ##4..."; got != want {
+		t.Fatalf("in-progress timer = %q, want %q", got, want)
+	}
+	section.Entries = append(section.Entries, logbook.Entry{Status: logbook.StatusDone, Time: at(9, 30), End: at(10, 30), Text: "Sync"})
+	if got, want := formatTmuxStatus(section, at(10, 0), 12, unicodeText), "5 open · timer 30m left Sync · next 14:30 Review ##42…"; got != want {
+		t.Fatalf("ranged timer = %q, want %q", got, want)
+	}
+}
+
+func TestTmuxCacheNameCoversOutputFlags(t *testing.T) {
+	names := map[string]bool{}
+	for _, width := range []int{0, 12, 24} {
+		for _, plain := range []bool{false, true} {
+			names[tmuxCacheName(width, plain)] = true
+		}
+	}
+	if len(names) != 6 {
+		t.Fatalf("cache names collide: %v", names)
+	}
+}
+
+func TestTmuxCacheInvalidatesOnTTLAndMonthEdits(t *testing.T) {
+	dir := t.TempDir()
+	cachePath := filepath.Join(dir, ".cache", tmuxCacheFile)
+	monthPath := filepath.Join(dir, "2025-11.md")
+	if err := os.WriteFile(monthPath, []byte("# November 2025\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	past := time.Now().Add(-time.Minute)
+	if err := os.Chtimes(monthPath, past, past); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	if err := writeTmuxCache(cachePath, "2 open"); err != nil {
+		t.Fatalf("writeTmuxCache: %v", err)
+	}
+
+	now := time.Now()
+	if got, ok := readTmuxCache(cachePath, monthPath, time.Minute, now); !ok || got != "2 open" {
+		t.Fatalf("readTmuxCache = %q, %v; want cached segment", got, ok)
+	}
+	if _, ok := readTmuxCache(cachePath, monthPath, time.Minute, now.Add(2*time.Minute)); ok {
+		t.Fatalf("expected cache to expire after TTL")
+	}
+
+	future := now.Add(time.Second)
+	if err := os.Chtimes(monthPath, future, future); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+	if _, ok := readTmuxCache(cachePath, monthPath, time.Minute, now); ok {
+		t.Fatalf("expected cache to be invalidated by month file edit")
+	}
+}
+
+func TestTmuxStatusSkipsCacheForEncryptedLogbooks(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	mgr.EnableEncryption("correct horse")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--time", "23:59", "Rotate secrets")
+
+	stale := filepath.Join(mgr.BasePath(), ".cache", tmuxCacheName(24, false))
+	if err := writeTmuxCache(stale, "written before encryption"); err != nil {
+		t.Fatalf("writeTmuxCache: %v", err)
+	}
+
+	out := executeCommand(t, newTmuxStatusCommand(ctx, mgr))
+	assertContains(t, out, "1 open")
+	if strings.Contains(out, "written before encryption") {
+		t.Fatalf("served the plaintext cache: %q", out)
+	}
+	entries, err := os.ReadDir(filepath.Join(mgr.BasePath(), ".cache"))
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("cache left beside an encrypted logbook: %v", entries)
+	}
+}
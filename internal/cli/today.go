@@ -11,12 +11,25 @@ import (
 )
 
 func newTodayCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
-	var dateFlag string
+	var (
+		dateFlag     string
+		formatFlag   string
+		openOnlyFlag bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "today",
 		Short: "Show the log entries for today or a specific date.",
+		Long: "today lists the entries of today or --date. --open-only hides done and cancelled entries so only\n" +
+			"what is left shows; the progress still counts them.",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateFormat(formatFlag, formatText, formatJSON, formatOneline, formatScriptFilter); err != nil {
+				return err
+			}
+			if jsonRequested(cmd) {
+				formatFlag = formatJSON
+			}
+
 			targetDate, err := resolveDate(dateFlag)
 			if err != nil {
 				return err
@@ -26,17 +39,56 @@ func newTodayCommand(ctx context.Context, manager *files.Manager) *cobra.Command
 			section, err := reader.Section(ctx, targetDate)
 			if err != nil {
 				if errors.Is(err, logbook.ErrSectionNotFound) {
+					switch formatFlag {
+					case formatScriptFilter:
+						return printScriptFilter(cmd, nil)
+					case formatJSON:
+						return printSectionsJSON(cmd, []logbook.DateSection{{Date: targetDate}}, true)
+					case formatOneline:
+						return nil
+					}
 					printMissingSection(cmd, targetDate)
 					return nil
 				}
 				return err
 			}
 
-			return printSection(cmd, section)
+			scope := activeContext(cmd)
+			shown := func(entry logbook.Entry) bool {
+				return logbook.MatchesContext(entry, scope) && (!openOnlyFlag || entry.Status.Open())
+			}
+			switch formatFlag {
+			case formatJSON:
+				filtered := logbook.DateSection{Date: section.Date}
+				for _, entry := range section.Entries {
+					if shown(entry) {
+						filtered.Entries = append(filtered.Entries, entry)
+					}
+				}
+				return printSectionsJSON(cmd, []logbook.DateSection{filtered}, true)
+			case formatScriptFilter:
+				items := make([]scriptFilterItem, 0, len(section.Entries))
+				for i, entry := range section.Entries {
+					if shown(entry) {
+						items = append(items, newScriptFilterItem(section.Date, i+1, entry))
+					}
+				}
+				return printScriptFilter(cmd, items)
+			case formatOneline:
+				for i, entry := range section.Entries {
+					if shown(entry) {
+						printOneline(cmd.OutOrStdout(), section.Date, i+1, entry)
+					}
+				}
+				return nil
+			}
+			return printSection(cmd, section, scope, nil, openOnlyFlag)
 		},
 	}
 
 	cmd.Flags().StringVar(&dateFlag, "date", "", "Target date in YYYY-MM-DD (default: today)")
+	cmd.Flags().StringVar(&formatFlag, "format", formatText, "Output format: text, json, oneline (tab-separated), or script-filter (Alfred/Raycast JSON)")
+	cmd.Flags().BoolVar(&openOnlyFlag, "open-only", false, "Hide done and cancelled entries")
 
 	return cmd
 }
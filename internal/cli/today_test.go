@@ -3,6 +3,7 @@ package cli
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"strings"
 	"testing"
 	"time"
@@ -82,3 +83,85 @@ func TestTodayCommandWithoutEntries(t *testing.T) {
 		t.Fatalf("unexpected output: %q", output)
 	}
 }
+
+func TestTodayCommandScriptFilterFormat(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	writer := logbook.NewWriter(mgr)
+	date := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.Local)
+	if err := writer.Append(ctx, date, logbook.Entry{
+		Status: logbook.StatusTodo,
+		Time:   time.Date(2025, time.November, 4, 10, 0, 0, 0, time.Local),
+		Text:   "Prepare sprint demo",
+		Tags:   []string{"demo"},
+	}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	output := executeCommand(t, newTodayCommand(ctx, mgr), "--date", "2025-11-04", "--format", "script-filter")
+
+	var payload struct {
+		Items []scriptFilterItem `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(output), &payload); err != nil {
+		t.Fatalf("json.Unmarshal: %v\n%s", err, output)
+	}
+	if len(payload.Items) != 1 {
+		t.Fatalf("items len = %d, want 1", len(payload.Items))
+	}
+	item := payload.Items[0]
+	if item.Title != "Prepare sprint demo" || item.Arg != "2025-11-04#1" {
+		t.Fatalf("unexpected item: %#v", item)
+	}
+	if item.Subtitle != "todo · 2025-11-04 · 10:00 · #demo" || item.Icon.Path != "icons/todo.png" {
+		t.Fatalf("unexpected subtitle/icon: %#v", item)
+	}
+
+	toggled := executeCommand(t, newToggleCommand(ctx, mgr), item.Arg)
+	assertContains(t, toggled, "Toggled entry 1: [in-progress]")
+
+	empty := executeCommand(t, newTodayCommand(ctx, mgr), "--date", "2025-11-05", "--format", "script-filter")
+	assertContains(t, empty, `"items": []`)
+}
+
+func TestTodayHeaderShowsProgress(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	for _, text := range []string{"Ship", "Review", "Write", "Dropped"} {
+		executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-16", "--time", "09:00", text)
+	}
+	executeCommand(t, newDoneCommand(ctx, mgr), "--date", "2025-11-16", "1")
+	executeCommand(t, newEditCommand(ctx, mgr), "--date", "2025-11-16", "--status", "cancelled", "4")
+
+	out := executeCommand(t, newTodayCommand(ctx, mgr), "--date", "2025-11-16")
+	assertContains(t, out, "2025-11-16 · 1/3 done, 33%\n")
+}
+
+func TestTodayOpenOnlyHidesClosedEntries(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	for _, text := range []string{"Ship", "Review", "Dropped"} {
+		executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-16", "--time", "09:00", text)
+	}
+	executeCommand(t, newDoneCommand(ctx, mgr), "--date", "2025-11-16", "1")
+	executeCommand(t, newEditCommand(ctx, mgr), "--date", "2025-11-16", "--status", "cancelled", "3")
+
+	out := executeCommand(t, newTodayCommand(ctx, mgr), "--date", "2025-11-16", "--open-only")
+	assertContains(t, out, "2025-11-16 · 1/2 done, 50%\n")
+	assertContains(t, out, "2. [todo] 09:00 Review\n")
+	assertContains(t, out, "(2 closed hidden)\n")
+	assertNotContains(t, out, "Ship")
+	assertNotContains(t, out, "Dropped")
+
+	out = executeCommand(t, newTodayCommand(ctx, mgr), "--date", "2025-11-16", "--open-only", "--format", "oneline")
+	if strings.Count(out, "\n") != 1 || !strings.Contains(out, "Review") {
+		t.Fatalf("oneline --open-only = %q", out)
+	}
+
+	executeCommand(t, newDoneCommand(ctx, mgr), "--date", "2025-11-16", "2")
+	out = executeCommand(t, newTodayCommand(ctx, mgr), "--date", "2025-11-16", "--open-only")
+	assertContains(t, out, "(nothing left to do; 3 closed hidden)\n")
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func newUndoCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "undo",
+		Short: "Revert the most recent change to the logbook.",
+		Long: "Every append, toggle, edit, delete, and import journals the month files it rewrites.\n" +
+			"undo restores the files from the latest journal record; run it again to step further back.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			change, err := newWriter(cmd, manager).Undo(ctx)
+			if err != nil {
+				if errors.Is(err, logbook.ErrNothingToUndo) {
+					fmt.Fprintln(cmd.OutOrStdout(), "Nothing to undo.")
+					return nil
+				}
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Undid %s (%s)\n", change.Op, change.Time.Format("2006-01-02 "+clockLayout()))
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestUndoCommandRevertsLastWrite(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-15", "--time", "09:00", "Keep me")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-15", "--time", "10:00", "Remove me")
+	executeCommand(t, newDeleteCommand(ctx, mgr), "--date", "2025-11-15", "1")
+
+	out := executeCommand(t, newUndoCommand(ctx, mgr))
+	assertContains(t, out, "Undid delete 2025-11-15 #1")
+	list := executeCommand(t, newJumpCommand(ctx, mgr), "2025-11-15")
+	assertContains(t, list, "1. [todo] 09:00 Keep me")
+
+	executeCommand(t, newUndoCommand(ctx, mgr))
+	list = executeCommand(t, newJumpCommand(ctx, mgr), "2025-11-15")
+	assertNotContains(t, list, "Remove me")
+
+	executeCommand(t, newUndoCommand(ctx, mgr))
+	out = executeCommand(t, newUndoCommand(ctx, mgr))
+	assertContains(t, out, "Nothing to undo.")
+}
+
+func TestHistoryCommandListsChangesNewestFirst(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-14", "--time", "09:00", "Ship release")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-15", "--time", "09:00", "Plan sprint")
+	executeCommand(t, newToggleCommand(ctx, mgr), "--date", "2025-11-14", "1")
+
+	out := executeCommand(t, newHistoryCommand(mgr))
+	toggled := strings.Index(out, "toggle 2025-11-14 #1\n  toggled 2025-11-14#1: todo → [in-progress] 09:00 Ship release\n")
+	created := strings.Index(out, "  created 2025-11-14#1: [todo] 09:00 Ship release\n")
+	if toggled < 0 || created < 0 || toggled > created {
+		t.Fatalf("unexpected history:\n%s", out)
+	}
+
+	out = executeCommand(t, newHistoryCommand(mgr), "--date", "2025-11-15")
+	assertContains(t, out, "created 2025-11-15#1: [todo] 09:00 Plan sprint")
+	assertNotContains(t, out, "Ship release")
+}
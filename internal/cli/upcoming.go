@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// upcomingTodo is an open entry scheduled on a later day.
+type upcomingTodo struct {
+	Date  string        `json:"date"`
+	Index int           `json:"index"`
+	Entry logbook.Entry `json:"entry"`
+}
+
+func newUpcomingCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		dateFlag string
+		daysFlag int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "upcoming",
+		Short: "List open todos scheduled on the coming days.",
+		Long: "upcoming gathers the open entries of the --days days after --date (default today), so work added to\n" +
+			"future dates with kerja todo --date is not forgotten. Entries are numbered as on their day, for use\n" +
+			"with kerja toggle --date and friends. The TUI shows the same list with U.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if daysFlag < 1 {
+				return fmt.Errorf("--days must be at least 1")
+			}
+			date, err := resolveDate(dateFlag)
+			if err != nil {
+				return err
+			}
+			start, end := date.AddDate(0, 0, 1), date.AddDate(0, 0, daysFlag)
+			sections, err := logbook.NewReader(manager).SectionsBetween(ctx, start, end)
+			if err != nil {
+				return err
+			}
+
+			scope := activeContext(cmd)
+			todos := []upcomingTodo{}
+			for _, section := range sections {
+				for _, i := range section.DisplayOrder() {
+					entry := section.Entries[i]
+					if entry.Status.Open() && logbook.MatchesContext(entry, scope) {
+						todos = append(todos, upcomingTodo{Date: section.Date.Format("2006-01-02"), Index: i + 1, Entry: entry})
+					}
+				}
+			}
+			if jsonRequested(cmd) {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(todos)
+			}
+
+			out := cmd.OutOrStdout()
+			if len(todos) == 0 {
+				fmt.Fprintf(out, "Nothing scheduled for %s.\n", formatRange(start, end))
+				return nil
+			}
+			fmt.Fprintf(out, "Upcoming todos %s (%d)\n", formatRange(start, end), len(todos))
+			for i, todo := range todos {
+				if i == 0 || todo.Date != todos[i-1].Date {
+					fmt.Fprintf(out, "\n%s\n", displayLocale().Format(todo.Entry.Time, "Mon 2006-01-02"))
+				}
+				fmt.Fprintf(out, "%d. %s\n", todo.Index, formatEntry(todo.Entry))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Look ahead from the day after this date in YYYY-MM-DD (default: today)")
+	cmd.Flags().IntVar(&daysFlag, "days", 14, "How many days ahead to include")
+
+	return cmd
+}
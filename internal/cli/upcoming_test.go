@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestUpcomingCommandListsScheduledTodos(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-12", "--time", "09:00", "Today's todo")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-13", "--time", "09:00", "Already done")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-13", "--time", "10:00", "Renew passport", "#admin")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-20", "--time", "14:00", "Quarterly review")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-27", "--time", "09:00", "Too far out")
+
+	out := executeCommand(t, newUpcomingCommand(ctx, mgr), "--date", "2025-11-12", "--days", "14")
+	assertContains(t, out, "Upcoming todos 2025-11-13..2025-11-26 (2)\n")
+	assertContains(t, out, "\nThu 2025-11-13\n2. [todo] 10:00 Renew passport (#admin)\n")
+	assertContains(t, out, "\nThu 2025-11-20\n1. [todo] 14:00 Quarterly review\n")
+	assertNotContains(t, out, "Today's todo")
+	assertNotContains(t, out, "Already done")
+	assertNotContains(t, out, "Too far out")
+
+	out = executeCommand(t, NewRootCommand(ctx, mgr), "upcoming", "--date", "2025-11-12", "--days", "30", "--json")
+	var todos []upcomingTodo
+	if err := json.Unmarshal([]byte(out), &todos); err != nil {
+		t.Fatalf("Unmarshal: %v\n%s", err, out)
+	}
+	if len(todos) != 3 || todos[2].Date != "2025-11-27" || todos[0].Index != 2 {
+		t.Fatalf("unexpected upcoming todos: %+v", todos)
+	}
+
+	out = executeCommand(t, newUpcomingCommand(ctx, mgr), "--date", "2025-11-27", "--days", "3")
+	assertContains(t, out, "Nothing scheduled for 2025-11-28..2025-11-30.")
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/version"
+)
+
+func newVersionCommand(ctx context.Context) *cobra.Command {
+	var (
+		checkFlag   bool
+		timeoutFlag time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print the kerja version and build details.",
+		Long: "version prints the release, commit, and build date. With --check it also asks GitHub for the\n" +
+			"latest release and reports whether a newer one is available.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "kerja %s\n", version.Info())
+			if !checkFlag {
+				return nil
+			}
+
+			checkCtx, cancel := context.WithTimeout(ctx, timeoutFlag)
+			defer cancel()
+			release, err := version.Latest(checkCtx, http.DefaultClient)
+			if err != nil {
+				return err
+			}
+			switch {
+			case version.Newer(version.Version, release.Tag):
+				fmt.Fprintf(out, "A newer release is available: %s\n", release.Tag)
+				if release.URL != "" {
+					fmt.Fprintln(out, release.URL)
+				}
+			case version.Version == "dev":
+				fmt.Fprintf(out, "Development build; the latest release is %s.\n", release.Tag)
+			default:
+				fmt.Fprintln(out, "You are running the latest release.")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&checkFlag, "check", false, "Query GitHub releases for a newer version")
+	cmd.Flags().DurationVar(&timeoutFlag, "timeout", 5*time.Second, "Give up on --check after this long")
+
+	return cmd
+}
@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/faizmokh/kerja/internal/version"
+)
+
+func TestVersionCommandChecksForUpdates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tag_name":"v1.4.0","html_url":"https://github.com/faizmokh/kerja/releases/tag/v1.4.0"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	originalURL, originalVersion := version.ReleasesURL, version.Version
+	t.Cleanup(func() { version.ReleasesURL, version.Version = originalURL, originalVersion })
+	version.ReleasesURL = server.URL
+
+	version.Version = "v1.3.2"
+	out := executeCommand(t, newVersionCommand(t.Context()))
+	assertContains(t, out, "kerja v1.3.2 (commit")
+	assertNotContains(t, out, "newer")
+
+	out = executeCommand(t, newVersionCommand(t.Context()), "--check")
+	assertContains(t, out, "A newer release is available: v1.4.0\nhttps://github.com/faizmokh/kerja/releases/tag/v1.4.0\n")
+
+	version.Version = "1.4.0"
+	out = executeCommand(t, newVersionCommand(t.Context()), "--check")
+	assertContains(t, out, "You are running the latest release.")
+
+	version.Version = "dev"
+	out = executeCommand(t, newVersionCommand(t.Context()), "--check")
+	assertContains(t, out, "Development build; the latest release is v1.4.0.")
+}
@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+	"github.com/faizmokh/kerja/internal/logging"
+	"github.com/faizmokh/kerja/internal/webhook"
+)
+
+// webhookTimeout bounds each webhook request so a slow endpoint cannot hang
+// a command.
+const webhookTimeout = 10 * time.Second
+
+// entryWebhook returns a Writer.OnChange callback posting the entry events
+// of every change to webhook_url, or nil when it is not set. The change is
+// already saved when the callback runs, so failures go to warn instead of
+// failing the command.
+func entryWebhook(warn func(error)) func(op string, changes []logbook.EntryChange) {
+	if settings.WebhookURL == "" {
+		return nil
+	}
+	hook, err := webhook.New(&http.Client{Timeout: webhookTimeout}, settings.WebhookURL, settings.WebhookTemplate, settings.WebhookEvents)
+	if err != nil {
+		return func(string, []logbook.EntryChange) { warn(err) }
+	}
+	return func(op string, changes []logbook.EntryChange) {
+		events := webhook.Events(changes)
+		logging.L().Info("send webhook", "op", op, "events", len(events))
+		if err := hook.Send(context.Background(), events); err != nil {
+			warn(err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEntryWebhookPostsAddedCompletedAndDeleted(t *testing.T) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+	}))
+	defer srv.Close()
+
+	original := settings
+	t.Cleanup(func() { settings = original })
+	settings.WebhookURL = srv.URL
+	settings.WebhookTemplate = `{"event": "{{.Event}}", "text": {{json .Entry.Text}}}`
+
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-04", "--time", "09:00", `Ship "login"`)
+	executeCommand(t, newDoneCommand(ctx, mgr), "--date", "2025-11-04", "1")
+	executeCommand(t, newDeleteCommand(ctx, mgr), "--date", "2025-11-04", "1")
+
+	want := []string{
+		`{"event": "added", "text": "Ship \"login\""}`,
+		`{"event": "completed", "text": "Ship \"login\""}`,
+		`{"event": "deleted", "text": "Ship \"login\""}`,
+	}
+	if len(bodies) != len(want) {
+		t.Fatalf("webhook bodies = %q, want %q", bodies, want)
+	}
+	for i := range want {
+		if bodies[i] != want[i] {
+			t.Fatalf("body %d = %s, want %s", i, bodies[i], want[i])
+		}
+	}
+}
+
+func TestEntryWebhookFiltersEventsAndWarnsOnFailure(t *testing.T) {
+	var events []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct{ Event string }
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		events = append(events, payload.Event)
+		http.Error(w, "flow disabled", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	original := settings
+	t.Cleanup(func() { settings = original })
+	settings.WebhookURL = srv.URL
+	settings.WebhookEvents = "completed"
+
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-04", "--time", "09:00", "Ship login")
+
+	cmd := newDoneCommand(ctx, mgr)
+	cmd.SetArgs([]string{"--date", "2025-11-04", "1"})
+	var stderr strings.Builder
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(&stderr)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("done with failing webhook: %v", err)
+	}
+	if len(events) != 1 || events[0] != "completed" {
+		t.Fatalf("webhook events = %q, want only completed", events)
+	}
+	assertContains(t, stderr.String(), "warning: completed webhook returned 503 Service Unavailable: flow disabled")
+}
@@ -0,0 +1,297 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/gitsync"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+type wrapupAction uint8
+
+const (
+	wrapupKeep wrapupAction = iota
+	wrapupDone
+	wrapupMove
+	wrapupDrop
+)
+
+type wrapupDecision struct {
+	index  int
+	action wrapupAction
+	target time.Time
+}
+
+func newWrapupCommand(ctx context.Context, manager *files.Manager) *cobra.Command {
+	var (
+		dateFlag   string
+		commitFlag bool
+		syncFlag   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "wrapup",
+		Short: "Review today's open todos and close out the day.",
+		Long: "wrapup walks through each open todo and asks whether to mark it done, carry it to the next workday,\n" +
+			"snooze it to a date, drop it, or keep it. It then prints a summary of the day. With --commit the\n" +
+			"logbook directory is committed to git afterwards; --sync also pulls and pushes it as kerja sync does\n" +
+			"and runs push_command.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			date, err := resolveDate(dateFlag)
+			if err != nil {
+				return err
+			}
+
+			reader := logbook.NewReader(manager)
+			section, err := reader.Section(ctx, date)
+			if err != nil && !errors.Is(err, logbook.ErrSectionNotFound) {
+				return err
+			}
+
+			decisions, err := promptWrapup(cmd, bufio.NewReader(cmd.InOrStdin()), date, section)
+			if err != nil {
+				return err
+			}
+			if err := applyWrapup(ctx, newWriter(cmd, manager), date, decisions); err != nil {
+				return err
+			}
+
+			section, err = reader.Section(ctx, date)
+			if err != nil && !errors.Is(err, logbook.ErrSectionNotFound) {
+				return err
+			}
+			printDaySummary(cmd, section)
+
+			message := fmt.Sprintf("kerja wrapup %s", date.Format("2006-01-02"))
+			switch {
+			case syncFlag:
+				return syncLogbook(cmd, manager, message)
+			case commitFlag:
+				return commitLogbook(cmd, manager.BasePath(), message)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Day to wrap up in YYYY-MM-DD (default: today)")
+	cmd.Flags().BoolVar(&commitFlag, "commit", false, "Commit the logbook directory with git after wrapping up")
+	cmd.Flags().BoolVar(&syncFlag, "sync", false, "Commit, pull, and push the logbook and run push_command after wrapping up")
+
+	return cmd
+}
+
+func promptWrapup(cmd *cobra.Command, in *bufio.Reader, date time.Time, section logbook.DateSection) ([]wrapupDecision, error) {
+	out := cmd.OutOrStdout()
+	var decisions []wrapupDecision
+	for i, entry := range section.Entries {
+		if !entry.Status.Open() {
+			continue
+		}
+		fmt.Fprintf(out, "%d. %s\n", i+1, formatEntry(entry))
+		for {
+			fmt.Fprint(out, "[d]one, [c]arry to the next workday, [s]nooze, [x] drop, [k]eep? ")
+			answer, err := readAnswer(in, "wrapup")
+			if err != nil {
+				return nil, err
+			}
+
+			decision := wrapupDecision{index: i + 1}
+			switch answer {
+			case "d", "done":
+				decision.action = wrapupDone
+			case "c", "carry":
+				decision.action = wrapupMove
+				decision.target = workCalendar().NextWorkday(date)
+			case "s", "snooze":
+				target, err := promptSnoozeDate(out, in, date)
+				if err != nil {
+					return nil, err
+				}
+				decision.action = wrapupMove
+				decision.target = target
+			case "x", "drop":
+				decision.action = wrapupDrop
+			case "k", "keep", "":
+				decision.action = wrapupKeep
+			default:
+				fmt.Fprintf(out, "Unknown choice %q.\n", answer)
+				continue
+			}
+			decisions = append(decisions, decision)
+			break
+		}
+	}
+	return decisions, nil
+}
+
+// promptSnoozeDate asks for the day to move an entry of date to, which must
+// be another day.
+func promptSnoozeDate(out io.Writer, in *bufio.Reader, date time.Time) (time.Time, error) {
+	for {
+		fmt.Fprint(out, "Snooze until (YYYY-MM-DD): ")
+		value, err := readAnswer(in, "wrapup")
+		if err != nil {
+			return time.Time{}, err
+		}
+		if value != "" {
+			if target, err := resolveDate(value); err == nil {
+				if sameDate(target, date) {
+					fmt.Fprintln(out, "That is the day being wrapped up, pick another.")
+					continue
+				}
+				return target, nil
+			}
+		}
+		fmt.Fprintln(out, "Invalid date, try again.")
+	}
+}
+
+// readAnswer reads one lowercased line; command names the flow in the error
+// returned when input ends.
+func readAnswer(in *bufio.Reader, command string) (string, error) {
+	line, err := in.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		if errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("%s aborted: input closed", command)
+		}
+		return "", err
+	}
+	return strings.ToLower(strings.TrimSpace(line)), nil
+}
+
+// applyWrapup carries out the decisions as one journaled change, as review
+// does, so an interrupted wrapup never duplicates an entry and a single undo
+// reverts it.
+func applyWrapup(ctx context.Context, writer *logbook.Writer, date time.Time, decisions []wrapupDecision) error {
+	var review []logbook.ReviewDecision
+	for _, decision := range decisions {
+		switch decision.action {
+		case wrapupDone:
+			review = append(review, logbook.ReviewDecision{Index: decision.index, Action: logbook.ReviewDone})
+		case wrapupMove:
+			review = append(review, logbook.ReviewDecision{Index: decision.index, Action: logbook.ReviewCarry, Target: decision.target})
+		case wrapupDrop:
+			review = append(review, logbook.ReviewDecision{Index: decision.index, Action: logbook.ReviewDrop})
+		}
+	}
+	if len(review) == 0 {
+		return nil
+	}
+	return writer.ApplyReview(ctx, date, review)
+}
+
+func printDaySummary(cmd *cobra.Command, section logbook.DateSection) {
+	out := cmd.OutOrStdout()
+	open := section.OpenCount()
+	done := len(section.Entries) - open
+	fmt.Fprintf(out, "\nSummary for %s: %d done, %d open", section.Date.Format("2006-01-02"), done, open)
+	if tracked := section.TrackedDuration(); tracked > 0 {
+		fmt.Fprintf(out, ", %s tracked", formatDuration(tracked))
+	}
+	fmt.Fprintln(out)
+
+	tags := topTags([]logbook.DateSection{section}, 3)
+	if len(tags) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "Top tags: %s\n", formatTagCounts(tags))
+}
+
+type tagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// topTags ranks tags by frequency, breaking ties alphabetically. A limit of
+// zero or less returns every tag.
+func topTags(sections []logbook.DateSection, limit int) []tagCount {
+	counts := make(map[string]int)
+	for _, section := range sections {
+		for _, entry := range section.Entries {
+			for _, tag := range entry.Tags {
+				counts[tag]++
+			}
+		}
+	}
+
+	ranked := make([]tagCount, 0, len(counts))
+	for tag, count := range counts {
+		ranked = append(ranked, tagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Count != ranked[j].Count {
+			return ranked[i].Count > ranked[j].Count
+		}
+		return ranked[i].Tag < ranked[j].Tag
+	})
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+func formatTagCounts(tags []tagCount) string {
+	parts := make([]string, len(tags))
+	for i, tag := range tags {
+		parts[i] = fmt.Sprintf("#%s (%d)", tag.Tag, tag.Count)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func commitLogbook(cmd *cobra.Command, dir, message string) error {
+	repo, err := gitsync.Open(dir)
+	if err != nil {
+		return err
+	}
+	committed, err := repo.Commit(message)
+	if err != nil {
+		return err
+	}
+	if committed == nil {
+		fmt.Fprintln(cmd.OutOrStdout(), "Nothing to commit.")
+		return nil
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Committed logbook: %s\n", message)
+	return nil
+}
+
+// syncLogbook syncs a git-backed logbook with its remote as kerja sync does,
+// then runs push_command. A logbook that is not a git repository is only
+// pushed, and push_command is left to auto_sync when that is on.
+func syncLogbook(cmd *cobra.Command, manager *files.Manager, message string) error {
+	dir := manager.BasePath()
+	repo, err := gitsync.Open(dir)
+	switch {
+	case err == nil:
+		result, err := repo.Sync(settings.SyncRemote, message)
+		if err != nil {
+			if len(result.Committed) > 0 {
+				printSyncCommit(cmd, result)
+			}
+			var conflict *gitsync.ConflictError
+			if errors.As(err, &conflict) {
+				return err
+			}
+			return fmt.Errorf("sync: %w", err)
+		}
+		printSyncResult(cmd, result)
+	case !errors.Is(err, gitsync.ErrNotRepository) || settings.PushCommand == "":
+		return err
+	}
+
+	if settings.PushCommand == "" || autoSyncs(cmd) {
+		return nil
+	}
+	return runHook(cmd, cmd.OutOrStdout(), dir, settings.PushCommand)
+}
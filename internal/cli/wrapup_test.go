@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func TestWrapupCommandAppliesDecisions(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-14", "--time", "09:00", "Finish report", "#docs")
+	executeCommand(t, newLogCommand(ctx, mgr), "--date", "2025-11-14", "--time", "09:30-10:15", "Standup", "#team")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-14", "--time", "10:00", "Refactor parser", "#code")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-14", "--time", "11:00", "Book travel")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-14", "--time", "12:00", "Old idea")
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-14", "--time", "13:00", "Reply to email", "#docs")
+
+	cmd := newWrapupCommand(ctx, mgr)
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetIn(strings.NewReader("d\nc\ns\nnot-a-date\n2025-11-14\n2025-11-20\n?\nx\nk\n"))
+	cmd.SetArgs([]string{"--date", "2025-11-14"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute: %v\n%s", err, buf.String())
+	}
+	out := buf.String()
+	assertContains(t, out, "Invalid date, try again.")
+	assertContains(t, out, `Unknown choice "?".`)
+	assertContains(t, out, "That is the day being wrapped up, pick another.")
+	assertContains(t, out, "Summary for 2025-11-14: 2 done, 1 open, 45m tracked")
+	assertContains(t, out, "Top tags: #docs (2), #team (1)")
+
+	reader := logbook.NewReader(mgr)
+	today, err := reader.Section(ctx, mustParseDate(t, "2025-11-14"))
+	if err != nil {
+		t.Fatalf("Section: %v", err)
+	}
+	var texts []string
+	for _, entry := range today.Entries {
+		texts = append(texts, entry.Text)
+	}
+	if got := strings.Join(texts, "|"); got != "Finish report|Standup|Reply to email" {
+		t.Fatalf("remaining entries = %q", got)
+	}
+
+	tomorrow, err := reader.Section(ctx, mustParseDate(t, "2025-11-15"))
+	if err != nil || len(tomorrow.Entries) != 1 || tomorrow.Entries[0].Text != "Refactor parser" {
+		t.Fatalf("carried entry missing: %+v, %v", tomorrow, err)
+	}
+	snoozed, err := reader.Section(ctx, mustParseDate(t, "2025-11-20"))
+	if err != nil || len(snoozed.Entries) != 1 || snoozed.Entries[0].Text != "Book travel" {
+		t.Fatalf("snoozed entry missing: %+v, %v", snoozed, err)
+	}
+
+	// The whole wrapup is one change.
+	if _, err := logbook.NewWriter(mgr).Undo(ctx); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	today, err = reader.Section(ctx, mustParseDate(t, "2025-11-14"))
+	if err != nil || len(today.Entries) != 6 || today.OpenCount() != 5 {
+		t.Fatalf("undo left %+v, %v; want the day as it was", today.Entries, err)
+	}
+	if snoozed, _ := reader.Section(ctx, mustParseDate(t, "2025-11-20")); len(snoozed.Entries) != 0 {
+		t.Fatalf("undo kept the snoozed entry: %+v", snoozed.Entries)
+	}
+}
+
+func TestWrapupCarriesPastWeekend(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	original := settings
+	t.Cleanup(func() { settings = original })
+	settings.Weekend = "sat,sun"
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-14", "--time", "09:00", "Refactor parser")
+
+	cmd := newWrapupCommand(ctx, mgr)
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetIn(strings.NewReader("c\n"))
+	cmd.SetArgs([]string{"--date", "2025-11-14"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute: %v\n%s", err, buf.String())
+	}
+
+	monday, err := logbook.NewReader(mgr).Section(ctx, mustParseDate(t, "2025-11-17"))
+	if err != nil || len(monday.Entries) != 1 || monday.Entries[0].Text != "Refactor parser" {
+		t.Fatalf("entry not carried to Monday: %+v, %v\n%s", monday, err, buf.String())
+	}
+}
+
+func TestWrapupSyncRunsPushCommand(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not installed")
+	}
+	ctx := context.Background()
+	mgr := newTempManager(t)
+	original := settings
+	t.Cleanup(func() { settings = original })
+	settings.PushCommand = "echo push >> hooks.log"
+
+	executeCommand(t, newTodoCommand(ctx, mgr), "--date", "2025-11-14", "--time", "09:00", "Refactor parser")
+
+	cmd := newWrapupCommand(ctx, mgr)
+	cmd.SetIn(strings.NewReader("d\n"))
+	executeCommand(t, cmd, "--date", "2025-11-14", "--sync")
+
+	data, err := os.ReadFile(filepath.Join(mgr.BasePath(), "hooks.log"))
+	if err != nil || string(data) != "push\n" {
+		t.Fatalf("hooks.log = %q, %v", data, err)
+	}
+}
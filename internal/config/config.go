@@ -0,0 +1,748 @@
+// Package config loads user defaults from ~/.kerja/config.toml.
+//
+// The file uses a small subset of TOML: one `key = value` pair per line, with
+// quoted strings, integers, and `#` comments. The only table is `[keys]`,
+// which maps TUI actions to a key or an array of keys:
+//
+//	[keys]
+//	up = ["k", "ctrl+p"]
+//	quit = "ctrl+q"
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/locale"
+	"github.com/faizmokh/kerja/internal/logbook"
+	"github.com/faizmokh/kerja/internal/webhook"
+)
+
+// FileName is the config file created beneath the default kerja directory.
+const FileName = "config.toml"
+
+// Config holds user defaults. Zero values mean "use the built-in default".
+type Config struct {
+	BasePath      string
+	TimeFormat    string
+	DefaultStatus string
+	Theme         string
+	WIPLimit      int
+	// EncryptionKeyFile holds the passphrase used to encrypt month files.
+	EncryptionKeyFile string
+	// SyncRemote names the git remote used by kerja sync.
+	SyncRemote string
+	// Author is the name new entries are credited to in a shared logbook.
+	Author string
+	// PullCommand and PushCommand are shell commands, such as rclone copy,
+	// run by kerja pull and kerja push.
+	PullCommand string
+	PushCommand string
+	// AutoSync runs PullCommand before every command and PushCommand after
+	// any that changed the logbook.
+	AutoSync bool
+	// SlackWebhook is the incoming webhook URL kerja share --slack posts to.
+	SlackWebhook string
+	// WebhookURL receives a POST for each entry event in WebhookEvents (all
+	// when empty), with a body rendered from WebhookTemplate or JSON.
+	WebhookURL      string
+	WebhookTemplate string
+	WebhookEvents   string
+	// Layout selects monthly files (the default) or one note per day.
+	Layout        string
+	DailyFolder   string
+	DailyTemplate string
+	// SortEntries keeps day sections ordered by entry time when set to "time".
+	SortEntries string
+	// Context lists the comma-separated tags views are limited to.
+	Context string
+	// EntryOverflow is how the TUI fits long entries: "truncate" or "wrap".
+	EntryOverflow string
+	// ShowDiff prints a diff of the month file after every CLI change.
+	ShowDiff bool
+	// SearchIndex keeps a search index under index/ that multi-month
+	// searches use to skip months that cannot match.
+	SearchIndex bool
+	// Backups is how many copies of each month file to keep; zero disables.
+	Backups int
+	// TimeZone is the IANA zone entries are logged in; empty uses the
+	// system zone.
+	TimeZone string
+	// Locale is the language day and month names are shown in, such as
+	// "de"; empty is English.
+	Locale string
+	// Weekend lists the days not worked each week, such as "sat,sun".
+	Weekend string
+	// Holidays lists comma-separated YYYY-MM-DD or yearly MM-DD dates off.
+	Holidays string
+	// Keys remaps TUI actions, such as "up", to the keys that trigger them.
+	Keys map[string][]string
+}
+
+// Default returns the built-in settings used when no file exists.
+func Default() Config {
+	return Config{
+		TimeFormat:    "24h",
+		DefaultStatus: "todo",
+		Theme:         "default",
+	}
+}
+
+type field struct {
+	get      func(Config) string
+	set      func(*Config, string) error
+	describe string
+}
+
+var fields = map[string]field{
+	"base_path": {
+		get: func(c Config) string { return c.BasePath },
+		set: func(c *Config, v string) error {
+			c.BasePath = v
+			return nil
+		},
+		describe: "Logbook root directory (overridden by KERJA_HOME)",
+	},
+	"time_format": {
+		get: func(c Config) string { return c.TimeFormat },
+		set: func(c *Config, v string) error {
+			return oneOf(&c.TimeFormat, v, "24h", "12h")
+		},
+		describe: "Clock style for displayed times: 24h or 12h",
+	},
+	"default_status": {
+		get: func(c Config) string { return c.DefaultStatus },
+		set: func(c *Config, v string) error {
+			return oneOf(&c.DefaultStatus, v, "todo", "done")
+		},
+		describe: "Status used by quick adds such as capture: todo or done",
+	},
+	"time_zone": {
+		get: func(c Config) string { return c.TimeZone },
+		set: func(c *Config, v string) error {
+			if _, err := time.LoadLocation(v); err != nil {
+				return fmt.Errorf("expected an IANA time zone such as Europe/Berlin, got %q", v)
+			}
+			c.TimeZone = v
+			return nil
+		},
+		describe: "IANA time zone that today and the current time are reckoned in, recorded in new month files (default: system zone)",
+	},
+	"locale": {
+		get: func(c Config) string { return c.Locale },
+		set: func(c *Config, v string) error {
+			if _, err := locale.Parse(v); err != nil {
+				return err
+			}
+			c.Locale = v
+			return nil
+		},
+		describe: "Language of day and month names in the TUI and new month titles: " + strings.Join(locale.Tags(), ", ") + " (default: en)",
+	},
+	"theme": {
+		get: func(c Config) string { return c.Theme },
+		set: func(c *Config, v string) error {
+			return oneOf(&c.Theme, v, "default", "light", "mono")
+		},
+		describe: "TUI color theme: default, light, or mono",
+	},
+	"wip_limit": {
+		get: func(c Config) string { return strconv.Itoa(c.WIPLimit) },
+		set: func(c *Config, v string) error {
+			limit, err := strconv.Atoi(v)
+			if err != nil || limit < 0 {
+				return fmt.Errorf("expected a non-negative integer, got %q", v)
+			}
+			c.WIPLimit = limit
+			return nil
+		},
+		describe: "Maximum open todos per day, 0 disables (overridden by KERJA_WIP_LIMIT)",
+	},
+	"encryption_key_file": {
+		get: func(c Config) string { return c.EncryptionKeyFile },
+		set: func(c *Config, v string) error {
+			c.EncryptionKeyFile = v
+			return nil
+		},
+		describe: "File holding the passphrase that encrypts month files (overridden by KERJA_PASSPHRASE)",
+	},
+	"sync_remote": {
+		get: func(c Config) string { return c.SyncRemote },
+		set: func(c *Config, v string) error {
+			c.SyncRemote = v
+			return nil
+		},
+		describe: "Git remote used by kerja sync (default: origin)",
+	},
+	"author": {
+		get: func(c Config) string { return c.Author },
+		set: func(c *Config, v string) error {
+			if v == "" {
+				c.Author = ""
+				return nil
+			}
+			name, err := logbook.ParseAuthor(v)
+			if err != nil {
+				return err
+			}
+			c.Author = name
+			return nil
+		},
+		describe: "Your name in a shared logbook; new entries are credited with a ~name token (default: none)",
+	},
+	"pull_command": {
+		get: func(c Config) string { return c.PullCommand },
+		set: func(c *Config, v string) error {
+			c.PullCommand = v
+			return nil
+		},
+		describe: "Shell command run in the logbook directory by kerja pull, e.g. rclone copy remote:kerja .",
+	},
+	"push_command": {
+		get: func(c Config) string { return c.PushCommand },
+		set: func(c *Config, v string) error {
+			c.PushCommand = v
+			return nil
+		},
+		describe: "Shell command run in the logbook directory by kerja push, e.g. rclone copy . remote:kerja",
+	},
+	"auto_sync": {
+		get: func(c Config) string { return strconv.FormatBool(c.AutoSync) },
+		set: func(c *Config, v string) error {
+			auto, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("expected true or false, got %q", v)
+			}
+			c.AutoSync = auto
+			return nil
+		},
+		describe: "Run pull_command before every command and push_command after any that changes the logbook",
+	},
+	"search_index": {
+		get: func(c Config) string { return strconv.FormatBool(c.SearchIndex) },
+		set: func(c *Config, v string) error {
+			enabled, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("expected true or false, got %q", v)
+			}
+			c.SearchIndex = enabled
+			return nil
+		},
+		describe: "Keep a search index under index/ so searches across months skip those that cannot match",
+	},
+	"slack_webhook": {
+		get: func(c Config) string { return c.SlackWebhook },
+		set: func(c *Config, v string) error {
+			c.SlackWebhook = v
+			return nil
+		},
+		describe: "Slack incoming webhook URL used by kerja share --slack",
+	},
+	"webhook_url": {
+		get: func(c Config) string { return c.WebhookURL },
+		set: func(c *Config, v string) error {
+			c.WebhookURL = v
+			return nil
+		},
+		describe: "URL sent a POST whenever an entry is added, completed, or deleted, e.g. an n8n or Zapier webhook",
+	},
+	"webhook_template": {
+		get: func(c Config) string { return c.WebhookTemplate },
+		set: func(c *Config, v string) error {
+			if v != "" {
+				if _, err := webhook.ParseTemplate(v); err != nil {
+					return err
+				}
+			}
+			c.WebhookTemplate = v
+			return nil
+		},
+		describe: "Go template for the webhook body, e.g. {\"text\": {{json .Entry.Text}}} (default: the event as JSON)",
+	},
+	"webhook_events": {
+		get: func(c Config) string { return c.WebhookEvents },
+		set: func(c *Config, v string) error {
+			if _, err := webhook.ParseEvents(v); err != nil {
+				return err
+			}
+			c.WebhookEvents = v
+			return nil
+		},
+		describe: "Comma-separated entry events sent to webhook_url: added, completed, deleted (default: all)",
+	},
+	"layout": {
+		get: func(c Config) string { return c.Layout },
+		set: func(c *Config, v string) error {
+			return oneOf(&c.Layout, v, "monthly", "daily")
+		},
+		describe: "Storage layout: monthly files or daily notes (e.g. an Obsidian vault)",
+	},
+	"daily_folder": {
+		get: func(c Config) string { return c.DailyFolder },
+		set: func(c *Config, v string) error {
+			c.DailyFolder = v
+			return nil
+		},
+		describe: "Folder holding daily notes, relative to base_path unless absolute",
+	},
+	"daily_template": {
+		get: func(c Config) string { return c.DailyTemplate },
+		set: func(c *Config, v string) error {
+			c.DailyTemplate = v
+			return nil
+		},
+		describe: "Daily note path as an Obsidian date format (default: YYYY-MM-DD)",
+	},
+	"context": {
+		get: func(c Config) string { return c.Context },
+		set: func(c *Config, v string) error {
+			c.Context = v
+			return nil
+		},
+		describe: "Comma-separated tags that today, list, and the TUI are limited to (see kerja context)",
+	},
+	"entry_overflow": {
+		get: func(c Config) string { return c.EntryOverflow },
+		set: func(c *Config, v string) error {
+			return oneOf(&c.EntryOverflow, v, "truncate", "wrap")
+		},
+		describe: "How the TUI fits entries wider than the list: truncate (with an ellipsis) or wrap",
+	},
+	"backups": {
+		get: func(c Config) string { return strconv.Itoa(c.Backups) },
+		set: func(c *Config, v string) error {
+			keep, err := strconv.Atoi(v)
+			if err != nil || keep < 0 {
+				return fmt.Errorf("expected a non-negative integer, got %q", v)
+			}
+			c.Backups = keep
+			return nil
+		},
+		describe: "Copies of each month file kept in backups/ before it is rewritten, 0 disables (see kerja backup)",
+	},
+	"weekend": {
+		get: func(c Config) string { return c.Weekend },
+		set: func(c *Config, v string) error {
+			if _, err := logbook.ParseCalendar(v, ""); err != nil {
+				return err
+			}
+			c.Weekend = v
+			return nil
+		},
+		describe: "Comma-separated days off each week, such as sat,sun or fri,sat (default: none)",
+	},
+	"holidays": {
+		get: func(c Config) string { return c.Holidays },
+		set: func(c *Config, v string) error {
+			if _, err := logbook.ParseCalendar("", v); err != nil {
+				return err
+			}
+			c.Holidays = v
+			return nil
+		},
+		describe: "Comma-separated holidays as YYYY-MM-DD, or MM-DD for ones that recur every year",
+	},
+	"show_diff": {
+		get: func(c Config) string { return strconv.FormatBool(c.ShowDiff) },
+		set: func(c *Config, v string) error {
+			show, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("expected true or false, got %q", v)
+			}
+			c.ShowDiff = show
+			return nil
+		},
+		describe: "Print a unified diff of each month file a CLI command changes (overridden by --show-diff)",
+	},
+	"sort_entries": {
+		get: func(c Config) string { return c.SortEntries },
+		set: func(c *Config, v string) error {
+			return oneOf(&c.SortEntries, v, "manual", "time")
+		},
+		describe: "Entry order within a day: manual (append order) or time (overridden by --sort)",
+	},
+}
+
+// Keys lists every supported setting in a stable order.
+func Keys() []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Describe returns a one-line explanation of key.
+func Describe(key string) string {
+	return fields[key].describe
+}
+
+// Get returns the string form of key.
+func (c Config) Get(key string) (string, error) {
+	f, ok := fields[key]
+	if !ok {
+		return "", unknownKey(key)
+	}
+	return f.get(c), nil
+}
+
+// Set validates and assigns value to key.
+func (c *Config) Set(key, value string) error {
+	f, ok := fields[key]
+	if !ok {
+		return unknownKey(key)
+	}
+	if err := f.set(c, value); err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	return nil
+}
+
+// Path resolves the config file location, honoring KERJA_CONFIG.
+func Path() (string, error) {
+	if override := strings.TrimSpace(os.Getenv("KERJA_CONFIG")); override != "" {
+		return expandHome(override)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, files.DefaultDirName, FileName), nil
+}
+
+// Load reads the config file, returning defaults when it does not exist.
+func Load() (Config, error) {
+	path, err := Path()
+	if err != nil {
+		return Config{}, err
+	}
+	return LoadFile(path)
+}
+
+// LoadFile reads the config at path, returning defaults when it is missing.
+func LoadFile(path string) (Config, error) {
+	cfg := Default()
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return Config{}, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNo := 0
+	table := ""
+	for scanner.Scan() {
+		lineNo++
+		if name, ok := parseTable(scanner.Text()); ok {
+			if name != keysTable {
+				return Config{}, fmt.Errorf("%s:%d: unknown table [%s] (only [%s] is supported)", path, lineNo, name, keysTable)
+			}
+			table = name
+			continue
+		}
+		if table == keysTable {
+			if err := parseKeyBinding(&cfg, scanner.Text()); err != nil {
+				return Config{}, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+			}
+			continue
+		}
+		key, value, ok, err := parseLine(scanner.Text())
+		if err != nil {
+			return Config{}, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+		}
+		if !ok {
+			continue
+		}
+		if err := cfg.Set(key, value); err != nil {
+			return Config{}, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.BasePath != "" {
+		cfg.BasePath, err = expandHome(cfg.BasePath)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+	if cfg.DailyFolder != "" {
+		cfg.DailyFolder, err = expandHome(cfg.DailyFolder)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+	if cfg.EncryptionKeyFile != "" {
+		cfg.EncryptionKeyFile, err = expandHome(cfg.EncryptionKeyFile)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+	return cfg, nil
+}
+
+// Manager opens the logbook these settings describe: base_path unless
+// KERJA_HOME is set, with the configured layout, encryption, backups, and
+// time zone applied.
+func (c Config) Manager() (*files.Manager, error) {
+	// KERJA_HOME wins over the config file; NewManager resolves it when basePath is empty.
+	basePath := ""
+	if _, ok := os.LookupEnv("KERJA_HOME"); !ok {
+		basePath = c.BasePath
+	}
+	manager, err := files.NewManager(basePath)
+	if err != nil {
+		return nil, err
+	}
+	if c.Layout == "daily" {
+		if err := manager.UseDailyNotes(c.DailyFolder, c.DailyTemplate); err != nil {
+			return nil, err
+		}
+	}
+	passphrase, err := files.ResolvePassphrase(c.EncryptionKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if passphrase != "" {
+		manager.EnableEncryption(passphrase)
+	}
+	manager.KeepBackups(c.Backups)
+	if c.TimeZone != "" {
+		loc, err := time.LoadLocation(c.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("time_zone: %w", err)
+		}
+		manager.SetTimeZone(loc)
+	}
+	names, err := locale.Parse(c.Locale)
+	if err != nil {
+		return nil, fmt.Errorf("locale: %w", err)
+	}
+	manager.SetLocale(names)
+	return manager, nil
+}
+
+// envOverrides names the environment variable that wins over each key.
+var envOverrides = map[string]string{"base_path": "KERJA_HOME", "wip_limit": "KERJA_WIP_LIMIT"}
+
+// Effective returns the settings as commands use them: base_path resolved
+// through KERJA_HOME and the default location, and wip_limit through
+// KERJA_WIP_LIMIT.
+func (c Config) Effective() (Config, error) {
+	if _, ok := os.LookupEnv("KERJA_HOME"); ok || c.BasePath == "" {
+		path, err := files.ResolveBasePath()
+		if err != nil {
+			return Config{}, err
+		}
+		c.BasePath = path
+	}
+	limit, err := files.ResolveWIPLimit(c.WIPLimit)
+	if err != nil {
+		return Config{}, err
+	}
+	c.WIPLimit = limit
+	return c, nil
+}
+
+// EnvOverride returns the environment variable overriding key, when one is
+// set.
+func EnvOverride(key string) (string, bool) {
+	name, ok := envOverrides[key]
+	if !ok || strings.TrimSpace(os.Getenv(name)) == "" {
+		return "", false
+	}
+	return name, true
+}
+
+// SetInFile updates key in the config file at path, keeping comments and the
+// position of existing settings. The file is created when missing.
+func SetInFile(path, key, value string) error {
+	cfg := Default()
+	if err := cfg.Set(key, value); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	rendered := fmt.Sprintf("%s = %s", key, quoteValue(key, value))
+	var lines []string
+	if len(data) > 0 {
+		lines = strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	}
+	// Settings live above the first table; anything after it belongs to
+	// the table.
+	end := len(lines)
+	for i, line := range lines {
+		if _, ok := parseTable(line); ok {
+			end = i
+			break
+		}
+	}
+	replaced := false
+	for i, line := range lines[:end] {
+		existing, _, ok, _ := parseLine(line)
+		if ok && existing == key {
+			lines[i] = rendered
+			replaced = true
+		}
+	}
+	if !replaced {
+		lines = slices.Insert(lines, end, rendered)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return files.WriteAtomic(path, []byte(strings.Join(lines, "\n")+"\n"))
+}
+
+func parseLine(line string) (string, string, bool, error) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return "", "", false, nil
+	}
+	if strings.HasPrefix(trimmed, "[") {
+		return "", "", false, errors.New("tables are not supported")
+	}
+
+	key, value, found := strings.Cut(trimmed, "=")
+	if !found {
+		return "", "", false, fmt.Errorf("expected key = value, got %q", trimmed)
+	}
+	key = strings.TrimSpace(key)
+	value = strings.TrimSpace(value)
+
+	if strings.HasPrefix(value, `"`) {
+		end := strings.LastIndex(value, `"`)
+		if end == 0 {
+			return "", "", false, fmt.Errorf("unterminated string for %s", key)
+		}
+		unquoted, err := strconv.Unquote(value[:end+1])
+		if err != nil {
+			return "", "", false, fmt.Errorf("invalid string for %s: %w", key, err)
+		}
+		return key, unquoted, true, nil
+	}
+
+	if idx := strings.Index(value, "#"); idx >= 0 {
+		value = strings.TrimSpace(value[:idx])
+	}
+	return key, value, true, nil
+}
+
+// keysTable is the table holding TUI key bindings.
+const keysTable = "keys"
+
+// parseTable returns the name of a `[table]` header line.
+func parseTable(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "[") {
+		return "", false
+	}
+	if idx := strings.Index(trimmed, "#"); idx >= 0 {
+		trimmed = strings.TrimSpace(trimmed[:idx])
+	}
+	if !strings.HasSuffix(trimmed, "]") {
+		return "", false
+	}
+	return strings.TrimSpace(trimmed[1 : len(trimmed)-1]), true
+}
+
+// parseKeyBinding reads `action = "key"` or `action = ["key", ...]` into
+// cfg.Keys. The action names are checked by the TUI.
+func parseKeyBinding(cfg *Config, line string) error {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return nil
+	}
+	action, value, found := strings.Cut(trimmed, "=")
+	if !found {
+		return fmt.Errorf("expected action = key, got %q", trimmed)
+	}
+	action = strings.TrimSpace(action)
+	value = strings.TrimSpace(value)
+
+	var keys []string
+	if strings.HasPrefix(value, "[") {
+		end := strings.LastIndex(value, "]")
+		if end < 0 {
+			return fmt.Errorf("unterminated array for %s", action)
+		}
+		for _, item := range strings.Split(value[1:end], ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			key, err := strconv.Unquote(item)
+			if err != nil {
+				return fmt.Errorf("invalid key %s for %s: expected a quoted string", item, action)
+			}
+			keys = append(keys, key)
+		}
+	} else {
+		_, key, _, err := parseLine("k = " + value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", action, err)
+		}
+		keys = []string{key}
+	}
+	if len(keys) == 0 || slices.Contains(keys, "") {
+		return fmt.Errorf("%s: expected at least one key", action)
+	}
+
+	if cfg.Keys == nil {
+		cfg.Keys = make(map[string][]string)
+	}
+	cfg.Keys[action] = keys
+	return nil
+}
+
+func quoteValue(key, value string) string {
+	if key == "wip_limit" || key == "backups" || key == "show_diff" || key == "auto_sync" || key == "search_index" {
+		return value
+	}
+	return strconv.Quote(value)
+}
+
+func oneOf(dst *string, value string, allowed ...string) error {
+	for _, candidate := range allowed {
+		if value == candidate {
+			*dst = value
+			return nil
+		}
+	}
+	return fmt.Errorf("expected one of %s, got %q", strings.Join(allowed, "|"), value)
+}
+
+func unknownKey(key string) error {
+	return fmt.Errorf("unknown config key %q (expected one of %s)", key, strings.Join(Keys(), ", "))
+}
+
+func expandHome(path string) (string, error) {
+	if !strings.HasPrefix(path, "~") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
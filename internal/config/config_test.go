@@ -0,0 +1,139 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadFileParsesSettings(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	path := filepath.Join(t.TempDir(), FileName)
+	content := `# kerja settings
+base_path = "~/worklogs"
+time_format = "12h"   # am/pm
+theme = "mono"
+wip_limit = 5
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	want := Config{
+		BasePath:      filepath.Join(home, "worklogs"),
+		TimeFormat:    "12h",
+		DefaultStatus: "todo",
+		Theme:         "mono",
+		WIPLimit:      5,
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Fatalf("LoadFile = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestLoadFileMissingReturnsDefaultsAndRejectsBadValues(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := LoadFile(filepath.Join(dir, "missing.toml"))
+	if err != nil || !reflect.DeepEqual(cfg, Default()) {
+		t.Fatalf("LoadFile missing = %+v, %v; want defaults", cfg, err)
+	}
+
+	path := filepath.Join(dir, FileName)
+	if err := os.WriteFile(path, []byte("time_format = \"48h\"\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := LoadFile(path); err == nil || !strings.Contains(err.Error(), ":1: time_format") {
+		t.Fatalf("expected line-numbered validation error, got %v", err)
+	}
+	if err := os.WriteFile(path, []byte("locale = \"tlh\"\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := LoadFile(path); err == nil || !strings.Contains(err.Error(), "unsupported locale") {
+		t.Fatalf("expected unsupported locale error, got %v", err)
+	}
+	if err := os.WriteFile(path, []byte("author = \"~1h\"\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := LoadFile(path); err == nil || !strings.Contains(err.Error(), "invalid author") {
+		t.Fatalf("expected invalid author error, got %v", err)
+	}
+	if err := os.WriteFile(path, []byte("webhook_events = \"added,edited\"\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := LoadFile(path); err == nil || !strings.Contains(err.Error(), `invalid webhook event "edited"`) {
+		t.Fatalf("expected invalid webhook event error, got %v", err)
+	}
+}
+
+func TestSetInFilePreservesCommentsAndOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", FileName)
+	if err := SetInFile(path, "theme", "light"); err != nil {
+		t.Fatalf("SetInFile: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("# mine\ntheme = \"light\"\nwip_limit = 2\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := SetInFile(path, "theme", "mono"); err != nil {
+		t.Fatalf("SetInFile: %v", err)
+	}
+	if err := SetInFile(path, "default_status", "done"); err != nil {
+		t.Fatalf("SetInFile: %v", err)
+	}
+	if err := SetInFile(path, "colour", "red"); err == nil {
+		t.Fatalf("expected unknown key error")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	want := "# mine\ntheme = \"mono\"\nwip_limit = 2\ndefault_status = \"done\"\n"
+	if string(data) != want {
+		t.Fatalf("file = %q, want %q", data, want)
+	}
+}
+
+func TestLoadFileParsesKeyBindings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	content := `theme = "light"
+
+[keys]   # TUI bindings
+up = ["k", "ctrl+p"]
+quit = "ctrl+q"
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	want := map[string][]string{"up": {"k", "ctrl+p"}, "quit": {"ctrl+q"}}
+	if cfg.Theme != "light" || !reflect.DeepEqual(cfg.Keys, want) {
+		t.Fatalf("LoadFile = %+v", cfg)
+	}
+
+	if err := SetInFile(path, "wip_limit", "3"); err != nil {
+		t.Fatalf("SetInFile: %v", err)
+	}
+	cfg, err = LoadFile(path)
+	if err != nil || cfg.WIPLimit != 3 || len(cfg.Keys) != 2 {
+		t.Fatalf("setting added after [keys] = %+v, %v", cfg, err)
+	}
+
+	if err := os.WriteFile(path, []byte("[colors]\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := LoadFile(path); err == nil || !strings.Contains(err.Error(), "unknown table [colors]") {
+		t.Fatalf("expected unknown table error, got %v", err)
+	}
+}
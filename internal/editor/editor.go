@@ -0,0 +1,117 @@
+// Package editor composes entries in the user's $EDITOR: it writes a scratch
+// buffer, builds the editor command, and parses what was saved.
+package editor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ErrEmpty is returned by Parse when the buffer holds no entry line.
+var ErrEmpty = errors.New("aborting: empty entry")
+
+const template = `%s
+# Write the entry on the first line; #tags are allowed.
+# Any following lines are saved as notes beneath the entry.
+# Lines starting with "# " are ignored. Leave the file empty to abort.
+`
+
+// Name resolves the editor from $VISUAL, then $EDITOR, falling back to vi.
+func Name() string {
+	if editor := strings.TrimSpace(os.Getenv("VISUAL")); editor != "" {
+		return editor
+	}
+	if editor := strings.TrimSpace(os.Getenv("EDITOR")); editor != "" {
+		return editor
+	}
+	return "vi"
+}
+
+// Command builds the editor invocation for path. Editors given with
+// arguments, such as "code --wait", are split on whitespace.
+func Command(path string) *exec.Cmd {
+	parts := strings.Fields(Name())
+	return exec.Command(parts[0], append(parts[1:], path)...)
+}
+
+// CommandAt builds the editor invocation for path with the cursor on line
+// (1-based) for editors known to take a line: +N for vi-style and terminal
+// editors, --goto for VS Code, and path:N for Sublime Text and Zed. Other
+// editors, and a line below 1, open path at the top.
+func CommandAt(path string, line int) *exec.Cmd {
+	parts := strings.Fields(Name())
+	args := parts[1:len(parts):len(parts)]
+	name := strings.TrimSuffix(filepath.Base(parts[0]), ".exe")
+	switch {
+	case line < 1:
+		args = append(args, path)
+	case slices.Contains([]string{"vi", "vim", "nvim", "gvim", "mvim", "nano", "emacs", "emacsclient", "micro", "hx", "helix", "kak", "joe", "mg"}, name):
+		args = append(args, fmt.Sprintf("+%d", line), path)
+	case slices.Contains([]string{"code", "code-insiders", "codium", "cursor"}, name):
+		args = append(args, "--goto", fmt.Sprintf("%s:%d", path, line))
+	case name == "subl" || name == "zed":
+		args = append(args, fmt.Sprintf("%s:%d", path, line))
+	default:
+		args = append(args, path)
+	}
+	return exec.Command(parts[0], args...)
+}
+
+// Scratch writes a temporary buffer seeded with initial and the usage
+// comments, returning its path. Callers remove the file when done.
+func Scratch(initial string) (string, error) {
+	file, err := os.CreateTemp("", "kerja-entry-*.md")
+	if err != nil {
+		return "", err
+	}
+	if _, err := fmt.Fprintf(file, template, initial); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
+
+// ReadScratch parses the saved buffer at path and removes it.
+func ReadScratch(path string) (string, []string, error) {
+	defer os.Remove(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+	return Parse(string(data))
+}
+
+// Parse returns the first non-comment line as the entry and the remaining
+// non-blank lines as notes. Lines starting with "# " are comments.
+func Parse(content string) (string, []string, error) {
+	var (
+		first string
+		notes []string
+	)
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed == "#" || strings.HasPrefix(trimmed, "# ") {
+			continue
+		}
+		if first == "" {
+			first = trimmed
+			continue
+		}
+		notes = append(notes, trimmed)
+	}
+
+	if first == "" {
+		return "", nil, ErrEmpty
+	}
+	return first, notes, nil
+}
@@ -0,0 +1,84 @@
+package editor
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSplitsEntryAndNotes(t *testing.T) {
+	first, notes, err := Parse("# comment\n\nShip release #ops\r\n  check dashboards\n#\nping team\n")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if first != "Ship release #ops" {
+		t.Fatalf("first = %q", first)
+	}
+	if want := []string{"check dashboards", "ping team"}; !reflect.DeepEqual(notes, want) {
+		t.Fatalf("notes = %q, want %q", notes, want)
+	}
+
+	if _, _, err := Parse("# only comments\n\n"); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("empty Parse error = %v, want ErrEmpty", err)
+	}
+}
+
+func TestScratchRoundTrip(t *testing.T) {
+	path, err := Scratch("Draft plan")
+	if err != nil {
+		t.Fatalf("Scratch: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "Draft plan\n# ") {
+		t.Fatalf("scratch content = %q", data)
+	}
+
+	first, _, err := ReadScratch(path)
+	if err != nil || first != "Draft plan" {
+		t.Fatalf("ReadScratch = %q, %v", first, err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("scratch file not removed: %v", err)
+	}
+}
+
+func TestNamePrefersVisual(t *testing.T) {
+	t.Setenv("VISUAL", "code --wait")
+	t.Setenv("EDITOR", "nano")
+	if got := Name(); got != "code --wait" {
+		t.Fatalf("Name = %q", got)
+	}
+	cmd := Command("/tmp/x.md")
+	if want := []string{"code", "--wait", "/tmp/x.md"}; !reflect.DeepEqual(cmd.Args, want) {
+		t.Fatalf("Args = %q, want %q", cmd.Args, want)
+	}
+
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	if got := Name(); got != "vi" {
+		t.Fatalf("fallback Name = %q", got)
+	}
+}
+
+func TestCommandAtPassesLineToKnownEditors(t *testing.T) {
+	for _, tc := range []struct {
+		editor string
+		want   []string
+	}{
+		{"nvim", []string{"nvim", "+12", "/tmp/x.md"}},
+		{"/usr/bin/emacsclient -t", []string{"/usr/bin/emacsclient", "-t", "+12", "/tmp/x.md"}},
+		{"code --wait", []string{"code", "--wait", "--goto", "/tmp/x.md:12"}},
+		{"subl -w", []string{"subl", "-w", "/tmp/x.md:12"}},
+		{"ed", []string{"ed", "/tmp/x.md"}},
+	} {
+		t.Setenv("VISUAL", tc.editor)
+		if got := CommandAt("/tmp/x.md", 12).Args; !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("%s: Args = %q, want %q", tc.editor, got, tc.want)
+		}
+	}
+}
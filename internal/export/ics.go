@@ -0,0 +1,160 @@
+// Package export renders logbook sections into formats other tools consume.
+package export
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// icsLineLimit is the maximum octets per content line before folding (RFC 5545 §3.1).
+const icsLineLimit = 75
+
+const icsTimeLayout = "20060102T150405Z"
+
+// durationPattern finds a `~45m` or `~1h30m` annotation in entry text.
+var durationPattern = regexp.MustCompile(`(^|\s)~((?:\d+h)?(?:\d+m)?)(\s|$)`)
+
+// ICSOptions tunes calendar output.
+type ICSOptions struct {
+	// DefaultDuration is the event length for entries without an annotation.
+	DefaultDuration time.Duration
+	// Now stamps DTSTAMP; zero means time.Now.
+	Now time.Time
+}
+
+// EntryDuration returns the `~1h30m` style annotation in text and the text with
+// the annotation removed. ok is false when there is no valid annotation.
+func EntryDuration(text string) (time.Duration, string, bool) {
+	match := durationPattern.FindStringSubmatchIndex(text)
+	if match == nil || match[5] == match[4] {
+		return 0, text, false
+	}
+	duration, err := time.ParseDuration(text[match[4]:match[5]])
+	if err != nil || duration <= 0 {
+		return 0, text, false
+	}
+	cleaned := strings.Join(strings.Fields(text[:match[0]]+" "+text[match[1]:]), " ")
+	return duration, cleaned, true
+}
+
+// ICS writes one VEVENT per entry in sections. Events start at the entry time
+// and last for the entry's `~duration` annotation, or opts.DefaultDuration.
+// Todos are marked TENTATIVE and done entries CONFIRMED.
+func ICS(w io.Writer, sections []logbook.DateSection, opts ICSOptions) error {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if opts.DefaultDuration <= 0 {
+		opts.DefaultDuration = 30 * time.Minute
+	}
+
+	out := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeFolded(out, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//kerja//logbook export//EN")
+	line("CALSCALE", "GREGORIAN")
+	uids := make(map[string]int)
+	for _, section := range sections {
+		for _, entry := range section.Entries {
+			duration, summary, ok := EntryDuration(entry.Text)
+			if tracked := entry.Duration(); tracked > 0 {
+				duration = tracked
+			} else if !ok {
+				duration = opts.DefaultDuration
+			}
+			if summary == "" {
+				summary = "(no description)"
+			}
+			start := entry.Time
+
+			line("BEGIN", "VEVENT")
+			key := eventKey(section.Date, entry)
+			line("UID", eventUID(key, uids[key]))
+			uids[key]++
+			line("DTSTAMP", now.UTC().Format(icsTimeLayout))
+			line("DTSTART", start.UTC().Format(icsTimeLayout))
+			line("DTEND", start.Add(duration).UTC().Format(icsTimeLayout))
+			line("SUMMARY", escapeText(summary))
+			if len(entry.Notes) > 0 {
+				line("DESCRIPTION", escapeText(strings.Join(entry.Notes, "\n")))
+			}
+			if len(entry.Tags) > 0 {
+				tags := make([]string, len(entry.Tags))
+				for i, tag := range entry.Tags {
+					tags[i] = escapeText(tag)
+				}
+				line("CATEGORIES", strings.Join(tags, ","))
+			}
+			switch entry.Status {
+			case logbook.StatusDone:
+				line("STATUS", "CONFIRMED")
+			case logbook.StatusCancelled:
+				line("STATUS", "CANCELLED")
+			default:
+				line("STATUS", "TENTATIVE")
+			}
+			line("END", "VEVENT")
+		}
+	}
+	line("END", "VCALENDAR")
+	return out.Flush()
+}
+
+// eventKey identifies an entry by its date, time, and text, which moving,
+// reordering, or toggling entries leaves alone.
+func eventKey(date time.Time, entry logbook.Entry) string {
+	return fmt.Sprintf("%s|%s|%s", date.Format("2006-01-02"), entry.Time.Format("15:04"), entry.Text)
+}
+
+// eventUID is stable across exports as long as the entry keeps its date,
+// time, and text, so re-importing updates events instead of duplicating.
+// repeat counts the earlier entries of the export sharing key, keeping
+// identical entries apart.
+func eventUID(key string, repeat int) string {
+	if repeat > 0 {
+		key = fmt.Sprintf("%s|%d", key, repeat)
+	}
+	sum := sha1.Sum([]byte(key))
+	return hex.EncodeToString(sum[:10]) + "@kerja"
+}
+
+func escapeText(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(value)
+}
+
+// writeFolded writes a CRLF-terminated content line, folding it at
+// icsLineLimit octets without splitting UTF-8 sequences.
+func writeFolded(w *bufio.Writer, content string) {
+	limit := icsLineLimit
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8Start(content[cut]) {
+			cut--
+		}
+		w.WriteString(content[:cut])
+		w.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space, which counts toward the limit.
+		limit = icsLineLimit - 1
+	}
+	w.WriteString(content)
+	w.WriteString("\r\n")
+}
+
+func utf8Start(b byte) bool {
+	return b&0xC0 != 0x80
+}
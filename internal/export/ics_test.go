@@ -0,0 +1,134 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func TestICSWritesEvents(t *testing.T) {
+	date := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.UTC)
+	sections := []logbook.DateSection{{
+		Date: date,
+		Entries: []logbook.Entry{
+			{Status: logbook.StatusDone, Time: date.Add(9 * time.Hour), Text: "Design review ~1h30m", Tags: []string{"work"}, Notes: []string{"with, commas"}},
+			{Status: logbook.StatusTodo, Time: date.Add(14 * time.Hour), Text: "Follow up"},
+		},
+	}}
+
+	var buf bytes.Buffer
+	now := time.Date(2025, time.November, 5, 0, 0, 0, 0, time.UTC)
+	if err := ICS(&buf, sections, ICSOptions{DefaultDuration: 15 * time.Minute, Now: now}); err != nil {
+		t.Fatalf("ICS: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART:20251104T090000Z\r\nDTEND:20251104T103000Z\r\nSUMMARY:Design review\r\n",
+		"DESCRIPTION:with\\, commas\r\n",
+		"CATEGORIES:work\r\nSTATUS:CONFIRMED\r\n",
+		"DTSTART:20251104T140000Z\r\nDTEND:20251104T141500Z\r\nSUMMARY:Follow up\r\nSTATUS:TENTATIVE\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "BEGIN:VEVENT") != 2 {
+		t.Fatalf("expected 2 events:\n%s", out)
+	}
+
+	var again bytes.Buffer
+	_ = ICS(&again, sections, ICSOptions{Now: now})
+	if uid := between(out, "UID:", "\r\n"); uid == "" || !strings.Contains(again.String(), "UID:"+uid) {
+		t.Fatalf("UIDs should be stable across exports")
+	}
+}
+
+func TestICSUIDsFollowEntriesNotPositions(t *testing.T) {
+	date := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.UTC)
+	standup := logbook.Entry{Status: logbook.StatusTodo, Time: date.Add(9 * time.Hour), Text: "Standup"}
+	review := logbook.Entry{Status: logbook.StatusTodo, Time: date.Add(11 * time.Hour), Text: "Review"}
+	uids := func(entries ...logbook.Entry) []string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := ICS(&buf, []logbook.DateSection{{Date: date, Entries: entries}}, ICSOptions{}); err != nil {
+			t.Fatalf("ICS: %v", err)
+		}
+		var out []string
+		for _, line := range strings.Split(buf.String(), "\r\n") {
+			if uid, ok := strings.CutPrefix(line, "UID:"); ok {
+				out = append(out, uid)
+			}
+		}
+		return out
+	}
+
+	before := uids(standup, review)
+	done := standup
+	done.Status = logbook.StatusDone
+	after := uids(review, done)
+	if before[0] != after[1] || before[1] != after[0] {
+		t.Fatalf("UIDs changed when entries were reordered or toggled: %v then %v", before, after)
+	}
+
+	twice := uids(standup, standup)
+	if twice[0] != before[0] || twice[1] == twice[0] {
+		t.Fatalf("identical entries should get distinct UIDs, the first unchanged: %v", twice)
+	}
+}
+
+func TestICSFoldsLongLines(t *testing.T) {
+	date := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.UTC)
+	text := strings.Repeat("ü", 60)
+	var buf bytes.Buffer
+	sections := []logbook.DateSection{{Date: date, Entries: []logbook.Entry{{Time: date, Text: text}}}}
+	if err := ICS(&buf, sections, ICSOptions{}); err != nil {
+		t.Fatalf("ICS: %v", err)
+	}
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		if len(line) > icsLineLimit {
+			t.Fatalf("line longer than %d octets: %q", icsLineLimit, line)
+		}
+	}
+	unfolded := strings.ReplaceAll(buf.String(), "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:"+text+"\r\n") {
+		t.Fatalf("folded summary does not round-trip:\n%s", buf.String())
+	}
+}
+
+func TestEntryDuration(t *testing.T) {
+	cases := []struct {
+		text     string
+		duration time.Duration
+		cleaned  string
+		ok       bool
+	}{
+		{"Pairing ~45m #pair", 45 * time.Minute, "Pairing #pair", true},
+		{"~2h Deep work", 2 * time.Hour, "Deep work", true},
+		{"Approx~3h", 0, "Approx~3h", false},
+		{"No annotation", 0, "No annotation", false},
+	}
+	for _, tc := range cases {
+		duration, cleaned, ok := EntryDuration(tc.text)
+		if duration != tc.duration || cleaned != tc.cleaned || ok != tc.ok {
+			t.Fatalf("EntryDuration(%q) = %v, %q, %v", tc.text, duration, cleaned, ok)
+		}
+	}
+}
+
+func between(s, start, end string) string {
+	i := strings.Index(s, start)
+	if i < 0 {
+		return ""
+	}
+	s = s[i+len(start):]
+	if j := strings.Index(s, end); j >= 0 {
+		return s[:j]
+	}
+	return s
+}
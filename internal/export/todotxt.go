@@ -0,0 +1,53 @@
+package export
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// TodoTxt writes one todo.txt task per entry in sections. The entry's day is
+// its creation date, and also its completion date once it is done or
+// cancelled. Tags become +projects and links ref: values. Pinned entries get
+// priority (A), or pri:A once complete, as todo.txt drops the priority of
+// finished tasks. Times and notes have no todo.txt equivalent and are left
+// out.
+func TodoTxt(w io.Writer, sections []logbook.DateSection) error {
+	out := bufio.NewWriter(w)
+	for _, section := range sections {
+		for _, entry := range section.Entries {
+			out.WriteString(todoTxtLine(entry))
+			out.WriteByte('\n')
+		}
+	}
+	return out.Flush()
+}
+
+func todoTxtLine(entry logbook.Entry) string {
+	day := entry.Time.Format("2006-01-02")
+	complete := entry.Status == logbook.StatusDone || entry.Status == logbook.StatusCancelled
+
+	var parts []string
+	switch {
+	case complete:
+		parts = append(parts, "x", day)
+	case entry.Pinned:
+		parts = append(parts, "(A)")
+	}
+	parts = append(parts, day)
+	if text := strings.TrimSpace(entry.Text); text != "" {
+		parts = append(parts, text)
+	}
+	for _, tag := range entry.Tags {
+		parts = append(parts, "+"+tag)
+	}
+	for _, link := range entry.Links {
+		parts = append(parts, "ref:"+link)
+	}
+	if complete && entry.Pinned {
+		parts = append(parts, "pri:A")
+	}
+	return strings.Join(parts, " ")
+}
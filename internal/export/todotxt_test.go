@@ -0,0 +1,32 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func TestTodoTxtWritesTasks(t *testing.T) {
+	date := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.UTC)
+	sections := []logbook.DateSection{{
+		Date: date,
+		Entries: []logbook.Entry{
+			{Status: logbook.StatusDone, Time: date.Add(9 * time.Hour), Text: "Ship login", Tags: []string{"web"}, Links: []string{"JIRA-12"}, Pinned: true},
+			{Status: logbook.StatusInProgress, Time: date.Add(10 * time.Hour), Text: "Review PR", Pinned: true},
+			{Status: logbook.StatusTodo, Time: date.Add(11 * time.Hour), Text: "Follow up", Notes: []string{"dropped"}},
+		},
+	}}
+
+	var buf bytes.Buffer
+	if err := TodoTxt(&buf, sections); err != nil {
+		t.Fatalf("TodoTxt: %v", err)
+	}
+	want := "x 2025-11-04 2025-11-04 Ship login +web ref:JIRA-12 pri:A\n" +
+		"(A) 2025-11-04 Review PR\n" +
+		"2025-11-04 Follow up\n"
+	if buf.String() != want {
+		t.Fatalf("todo.txt =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,219 @@
+package files
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logging"
+)
+
+// ArchiveDirName is the directory beneath the base path holding compressed
+// month files.
+const ArchiveDirName = "archive"
+
+// ArchivePath resolves where the gzip-compressed copy of a month file lives.
+func (m *Manager) ArchivePath(t time.Time) string {
+	return filepath.Join(m.basePath, ArchiveDirName, fmt.Sprintf("%04d-%02d.md.gz", t.Year(), t.Month()))
+}
+
+// OpenMonth opens the month file for reading. Archived months are decompressed
+// on the fly and left in the archive; a month with neither a live nor an
+// archived file is created with its heading, as EnsureMonthFile does.
+// Encrypted files are decrypted before they are returned.
+func (m *Manager) OpenMonth(t time.Time) (io.ReadCloser, error) {
+	if m == nil {
+		return nil, errors.New("files.Manager is nil")
+	}
+	if m.daily != nil {
+		return m.openDaily(t)
+	}
+
+	data, err := m.ReadFile(m.MonthPath(t))
+	if err == nil {
+		logging.L().Info("read month file", "path", m.MonthPath(t), "bytes", len(data))
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	archived, err := openArchive(m.ArchivePath(t))
+	if err == nil {
+		defer archived.Close()
+		raw, err := io.ReadAll(archived)
+		if err != nil {
+			return nil, fmt.Errorf("read archive: %w", err)
+		}
+		if data, err = m.decode(raw); err != nil {
+			return nil, err
+		}
+		logging.L().Info("read archived month", "path", m.ArchivePath(t), "bytes", len(data))
+		return io.NopCloser(bytes.NewReader(data)), nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	path, err := m.EnsureMonthFile(t)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = m.ReadFile(path); err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// ArchiveMonth compresses the live month file into the archive directory and
+// removes the original.
+func (m *Manager) ArchiveMonth(t time.Time) error {
+	if m == nil {
+		return errors.New("files.Manager is nil")
+	}
+	if m.daily != nil {
+		return fmt.Errorf("archive: %w", ErrDailyLayout)
+	}
+
+	source := m.MonthPath(t)
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return err
+	}
+
+	target := m.ArchivePath(t)
+	if err := os.MkdirAll(filepath.Dir(target), dirPermissions); err != nil {
+		return fmt.Errorf("create archive directory: %w", err)
+	}
+	temp, err := os.CreateTemp(filepath.Dir(target), "kerja-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	zw := gzip.NewWriter(temp)
+	zw.Name = filepath.Base(source)
+	zw.ModTime = time.Now()
+	if _, err := zw.Write(data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(temp.Name(), target); err != nil {
+		return err
+	}
+	return os.Remove(source)
+}
+
+// LiveMonths lists months that still have an uncompressed file, oldest first.
+func (m *Manager) LiveMonths() ([]time.Time, error) {
+	if m.daily != nil {
+		return nil, fmt.Errorf("archive: %w", ErrDailyLayout)
+	}
+	matches, err := filepath.Glob(filepath.Join(m.basePath, "[0-9][0-9][0-9][0-9]", "[0-9][0-9][0-9][0-9]-[0-9][0-9].md"))
+	if err != nil {
+		return nil, err
+	}
+
+	var months []time.Time
+	for _, match := range matches {
+		name := filepath.Base(match)
+		month, err := time.ParseInLocation("2006-01", name[:len(name)-len(".md")], time.Local)
+		if err != nil {
+			continue
+		}
+		months = append(months, month)
+	}
+	sort.Slice(months, func(i, j int) bool { return months[i].Before(months[j]) })
+	return months, nil
+}
+
+// Months lists every month with a live or archived file, oldest first.
+// In the daily-note layout these are the months with at least one note.
+func (m *Manager) Months() ([]time.Time, error) {
+	if m.daily != nil {
+		return m.daily.months()
+	}
+	months, err := m.LiveMonths()
+	if err != nil {
+		return nil, err
+	}
+	archived, err := filepath.Glob(filepath.Join(m.basePath, ArchiveDirName, "[0-9][0-9][0-9][0-9]-[0-9][0-9].md.gz"))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(months))
+	for _, month := range months {
+		seen[month.Format("2006-01")] = true
+	}
+	for _, match := range archived {
+		name := strings.TrimSuffix(filepath.Base(match), ".md.gz")
+		month, err := time.ParseInLocation("2006-01", name, time.Local)
+		if err != nil || seen[name] {
+			continue
+		}
+		seen[name] = true
+		months = append(months, month)
+	}
+	sort.Slice(months, func(i, j int) bool { return months[i].Before(months[j]) })
+	return months, nil
+}
+
+// restoreArchived decompresses an archived month back to its live path so it
+// can be written again. A month without an archive is left alone.
+func (m *Manager) restoreArchived(t time.Time) error {
+	archivePath := m.ArchivePath(t)
+	archived, err := openArchive(archivePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	data, err := io.ReadAll(archived)
+	archived.Close()
+	if err != nil {
+		return fmt.Errorf("read archive: %w", err)
+	}
+
+	if err := os.WriteFile(m.MonthPath(t), data, filePermissions); err != nil {
+		return err
+	}
+	return os.Remove(archivePath)
+}
+
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g gzipFile) Close() error {
+	g.Reader.Close()
+	return g.file.Close()
+}
+
+func openArchive(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("open archive %s: %w", filepath.Base(path), err)
+	}
+	return gzipFile{Reader: zr, file: file}, nil
+}
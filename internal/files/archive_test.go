@@ -0,0 +1,84 @@
+package files
+
+import (
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestArchiveMonthRoundTrip(t *testing.T) {
+	mgr, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	date := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.Local)
+	content := "# March 2024\n\n## 2024-03-05\n- [x] [09:00] Shipped\n"
+	path, err := mgr.EnsureMonthFile(date)
+	if err != nil {
+		t.Fatalf("EnsureMonthFile: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	months, err := mgr.LiveMonths()
+	if err != nil || len(months) != 1 || months[0].Format("2006-01") != "2024-03" {
+		t.Fatalf("LiveMonths = %v, %v", months, err)
+	}
+
+	if err := mgr.ArchiveMonth(date); err != nil {
+		t.Fatalf("ArchiveMonth: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("live file should be removed, stat err = %v", err)
+	}
+
+	reader, err := mgr.OpenMonth(date)
+	if err != nil {
+		t.Fatalf("OpenMonth: %v", err)
+	}
+	got, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil || string(got) != content {
+		t.Fatalf("OpenMonth content = %q, %v", got, err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("reading must not restore the live file")
+	}
+
+	if _, err := mgr.EnsureMonthFile(date); err != nil {
+		t.Fatalf("EnsureMonthFile after archive: %v", err)
+	}
+	restored, err := os.ReadFile(path)
+	if err != nil || string(restored) != content {
+		t.Fatalf("restored content = %q, %v", restored, err)
+	}
+	if _, err := os.Stat(mgr.ArchivePath(date)); !os.IsNotExist(err) {
+		t.Fatalf("archive should be removed after restore")
+	}
+}
+
+func TestMonthsIncludesArchived(t *testing.T) {
+	mgr, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	for _, month := range []time.Month{time.January, time.March} {
+		if _, err := mgr.EnsureMonthFile(time.Date(2025, month, 1, 0, 0, 0, 0, time.Local)); err != nil {
+			t.Fatalf("EnsureMonthFile: %v", err)
+		}
+	}
+	if err := mgr.ArchiveMonth(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.Local)); err != nil {
+		t.Fatalf("ArchiveMonth: %v", err)
+	}
+
+	months, err := mgr.Months()
+	if err != nil {
+		t.Fatalf("Months: %v", err)
+	}
+	if len(months) != 2 || months[0].Format("2006-01") != "2025-01" || months[1].Format("2006-01") != "2025-03" {
+		t.Fatalf("Months = %v", months)
+	}
+}
@@ -0,0 +1,166 @@
+package files
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BackupDirName is the directory beneath the base path holding copies of
+// month files taken before they were overwritten.
+const BackupDirName = "backups"
+
+// backupStamp suffixes each copy; it sorts chronologically as text.
+const backupStamp = "20060102-150405.000000000"
+
+// Backup is one saved copy of a month file.
+type Backup struct {
+	// Name identifies the copy relative to the backup directory, such as
+	// "2025/2025-11.md.20251116-090000.000000000".
+	Name string `json:"name"`
+	// Source is the month file the copy was taken from.
+	Source string    `json:"source"`
+	Time   time.Time `json:"time"`
+}
+
+// KeepBackups makes WriteFile copy a month file into the backup directory
+// before replacing it, keeping the keep most recent copies of each file.
+// Zero turns backups off. Copies hold the file's bytes as they were on
+// disk, so encrypted months stay encrypted.
+func (m *Manager) KeepBackups(keep int) {
+	m.backups = max(keep, 0)
+}
+
+// BackupDir returns the directory holding month file backups.
+func (m *Manager) BackupDir() string {
+	return filepath.Join(m.basePath, BackupDirName)
+}
+
+// backup copies path into the backup directory and drops its oldest copies
+// beyond the configured limit. A missing path has nothing to back up.
+func (m *Manager) backup(path string) error {
+	if m.backups == 0 {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(m.dataRoot(), path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return fmt.Errorf("back up %s: outside %s", path, m.dataRoot())
+	}
+
+	target := filepath.Join(m.BackupDir(), rel+"."+time.Now().Format(backupStamp))
+	if err := os.MkdirAll(filepath.Dir(target), dirPermissions); err != nil {
+		return err
+	}
+	if err := WriteAtomic(target, data); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(target))
+	if err != nil {
+		return err
+	}
+	var copies []string
+	prefix := filepath.Base(rel) + "."
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), prefix) && len(entry.Name()) == len(prefix)+len(backupStamp) {
+			copies = append(copies, filepath.Join(filepath.Dir(target), entry.Name()))
+		}
+	}
+	sort.Strings(copies)
+	for len(copies) > m.backups {
+		if err := os.Remove(copies[0]); err != nil {
+			return err
+		}
+		copies = copies[1:]
+	}
+	return nil
+}
+
+// dataRoot is the directory month file paths are kept relative to in the
+// backup directory: the daily-note folder or the base path.
+func (m *Manager) dataRoot() string {
+	if m.daily != nil {
+		return m.daily.folder
+	}
+	return m.basePath
+}
+
+// Backups lists every saved copy, newest first.
+func (m *Manager) Backups() ([]Backup, error) {
+	var backups []Backup
+	err := filepath.WalkDir(m.BackupDir(), func(path string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && path == m.BackupDir() {
+			return filepath.SkipDir
+		}
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		if backup, ok := m.parseBackup(path); ok {
+			backups = append(backups, backup)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].Time.Equal(backups[j].Time) {
+			return backups[i].Time.After(backups[j].Time)
+		}
+		return backups[i].Name < backups[j].Name
+	})
+	return backups, nil
+}
+
+// RestoreBackup puts the named copy back in place of its month file. The
+// current file is backed up first, so a restore can itself be reverted.
+func (m *Manager) RestoreBackup(name string) (Backup, error) {
+	backup, ok := m.parseBackup(filepath.Join(m.BackupDir(), filepath.FromSlash(name)))
+	if !ok {
+		return Backup{}, fmt.Errorf("unknown backup %q", name)
+	}
+	data, err := os.ReadFile(filepath.Join(m.BackupDir(), filepath.FromSlash(backup.Name)))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Backup{}, fmt.Errorf("unknown backup %q", name)
+		}
+		return Backup{}, err
+	}
+	if err := m.backup(backup.Source); err != nil {
+		return Backup{}, fmt.Errorf("back up %s: %w", backup.Source, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(backup.Source), dirPermissions); err != nil {
+		return Backup{}, err
+	}
+	return backup, WriteAtomic(backup.Source, data)
+}
+
+// parseBackup reads the source and time from a path in the backup directory.
+func (m *Manager) parseBackup(path string) (Backup, bool) {
+	rel, err := filepath.Rel(m.BackupDir(), path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return Backup{}, false
+	}
+	cut := len(rel) - len(backupStamp) - 1
+	if cut <= 0 || rel[cut] != '.' {
+		return Backup{}, false
+	}
+	stamp, err := time.ParseInLocation(backupStamp, rel[cut+1:], time.Local)
+	if err != nil {
+		return Backup{}, false
+	}
+	source := filepath.Join(m.dataRoot(), rel[:cut])
+	return Backup{Name: filepath.ToSlash(rel), Source: source, Time: stamp}, true
+}
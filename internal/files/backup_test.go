@@ -0,0 +1,76 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteFileKeepsRotatingBackups(t *testing.T) {
+	mgr, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	mgr.KeepBackups(2)
+
+	path, err := mgr.EnsureMonthFile(time.Date(2025, time.November, 1, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("EnsureMonthFile: %v", err)
+	}
+	for _, content := range []string{"one\n", "two\n", "three\n", "four\n"} {
+		if err := mgr.WriteFile(path, []byte(content)); err != nil {
+			t.Fatalf("WriteFile %q: %v", content, err)
+		}
+	}
+
+	backups, err := mgr.Backups()
+	if err != nil {
+		t.Fatalf("Backups: %v", err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("expected 2 backups, got %+v", backups)
+	}
+	for _, backup := range backups {
+		if backup.Source != path || filepath.Dir(backup.Name) != "2025" {
+			t.Fatalf("unexpected backup %+v", backup)
+		}
+	}
+	newest, err := os.ReadFile(filepath.Join(mgr.BackupDir(), backups[0].Name))
+	if err != nil || string(newest) != "three\n" {
+		t.Fatalf("newest backup = %q, %v; want three", newest, err)
+	}
+
+	restored, err := mgr.RestoreBackup(backups[1].Name)
+	if err != nil {
+		t.Fatalf("RestoreBackup: %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "two\n" || restored.Source != path {
+		t.Fatalf("after restore file = %q, backup %+v", got, restored)
+	}
+	backups, _ = mgr.Backups()
+	if latest, _ := os.ReadFile(filepath.Join(mgr.BackupDir(), backups[0].Name)); string(latest) != "four\n" {
+		t.Fatalf("restore should back up the replaced file, newest copy = %q", latest)
+	}
+
+	if _, err := mgr.RestoreBackup("2025/2025-11.md.nope"); err == nil {
+		t.Fatalf("expected unknown backup error")
+	}
+}
+
+func TestBackupsDisabledByDefault(t *testing.T) {
+	mgr, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	path, err := mgr.EnsureMonthFile(time.Date(2025, time.November, 1, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("EnsureMonthFile: %v", err)
+	}
+	if err := mgr.WriteFile(path, []byte("changed\n")); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if backups, err := mgr.Backups(); err != nil || len(backups) != 0 {
+		t.Fatalf("Backups = %+v, %v; want none", backups, err)
+	}
+}
@@ -0,0 +1,206 @@
+package files
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/faizmokh/kerja/internal/logging"
+)
+
+// encryptedMagic prefixes every encrypted month file so plaintext and
+// encrypted files can coexist while a logbook is being migrated.
+var encryptedMagic = []byte("KERJA-AESGCM-1\n")
+
+const (
+	saltSize         = 16
+	keySize          = 32
+	pbkdf2Iterations = 600_000
+)
+
+// ErrPassphraseRequired is returned when an encrypted file is read without a passphrase.
+var ErrPassphraseRequired = errors.New("month file is encrypted; set KERJA_PASSPHRASE or encryption_key_file")
+
+// sealer encrypts month files with AES-256-GCM using a key derived from a
+// passphrase with PBKDF2-SHA256. Derived keys are cached per salt because
+// commands read the same file many times.
+type sealer struct {
+	passphrase string
+
+	mu        sync.Mutex
+	writeSalt []byte
+	keys      map[string][]byte
+}
+
+func newSealer(passphrase string) *sealer {
+	return &sealer{passphrase: passphrase, keys: make(map[string][]byte)}
+}
+
+func (s *sealer) key(salt []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if key, ok := s.keys[string(salt)]; ok {
+		return key, nil
+	}
+	key, err := pbkdf2.Key(sha256.New, s.passphrase, salt, pbkdf2Iterations, keySize)
+	if err != nil {
+		return nil, err
+	}
+	s.keys[string(salt)] = key
+	return key, nil
+}
+
+func (s *sealer) seal(plaintext []byte) ([]byte, error) {
+	s.mu.Lock()
+	if s.writeSalt == nil {
+		s.writeSalt = make([]byte, saltSize)
+		if _, err := rand.Read(s.writeSalt); err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+	}
+	salt := s.writeSalt
+	s.mu.Unlock()
+
+	aead, err := s.aead(salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(encryptedMagic)+saltSize+len(nonce)+len(plaintext)+aead.Overhead())
+	out = append(out, encryptedMagic...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plaintext, encryptedMagic), nil
+}
+
+func (s *sealer) open(data []byte) ([]byte, error) {
+	body := data[len(encryptedMagic):]
+	if len(body) < saltSize {
+		return nil, errors.New("encrypted file is truncated")
+	}
+	aead, err := s.aead(body[:saltSize])
+	if err != nil {
+		return nil, err
+	}
+	body = body[saltSize:]
+	if len(body) < aead.NonceSize() {
+		return nil, errors.New("encrypted file is truncated")
+	}
+	plaintext, err := aead.Open(nil, body[:aead.NonceSize()], body[aead.NonceSize():], encryptedMagic)
+	if err != nil {
+		return nil, errors.New("decrypt month file: wrong passphrase or corrupted file")
+	}
+	return plaintext, nil
+}
+
+func (s *sealer) aead(salt []byte) (cipher.AEAD, error) {
+	key, err := s.key(salt)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EnableEncryption makes the manager encrypt every month file it writes with
+// passphrase. Existing plaintext files stay readable and are encrypted the
+// next time they are written.
+func (m *Manager) EnableEncryption(passphrase string) {
+	m.sealer = newSealer(passphrase)
+}
+
+// Encrypted reports whether new writes are encrypted.
+func (m *Manager) Encrypted() bool {
+	return m.sealer != nil
+}
+
+// ReadFile returns the plaintext content of a month file, decrypting it when needed.
+func (m *Manager) ReadFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return m.decode(data)
+}
+
+// WriteFile atomically replaces path with data, encrypting it when
+// encryption is enabled and backing up the old file when KeepBackups is set.
+func (m *Manager) WriteFile(path string, data []byte) error {
+	if err := m.backup(path); err != nil {
+		return fmt.Errorf("back up month file: %w", err)
+	}
+	if m.sealer != nil {
+		sealed, err := m.sealer.seal(data)
+		if err != nil {
+			return fmt.Errorf("encrypt month file: %w", err)
+		}
+		data = sealed
+	}
+	logging.L().Info("write file", "path", path, "bytes", len(data), "encrypted", m.sealer != nil)
+	return WriteAtomic(path, data)
+}
+
+// IsEncrypted reports whether data is an encrypted month file.
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedMagic)
+}
+
+func (m *Manager) decode(data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+	if m.sealer == nil {
+		return nil, ErrPassphraseRequired
+	}
+	return m.sealer.open(data)
+}
+
+// WriteAtomic replaces path with data via a synced temp file and rename,
+// keeping the existing file mode. The bytes are written as-is, so callers
+// restoring raw snapshots do not re-encrypt them.
+func WriteAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	temp, err := os.CreateTemp(dir, "kerja-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Sync(); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+
+	mode := os.FileMode(filePermissions)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode()
+	}
+	if err := os.Chmod(temp.Name(), mode); err != nil {
+		return err
+	}
+
+	return os.Rename(temp.Name(), path)
+}
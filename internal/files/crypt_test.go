@@ -0,0 +1,126 @@
+package files
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEncryptedMonthRoundTrip(t *testing.T) {
+	base := t.TempDir()
+	mgr, err := NewManager(base)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	mgr.EnableEncryption("correct horse")
+
+	date := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.Local)
+	path, err := mgr.EnsureMonthFile(date)
+	if err != nil {
+		t.Fatalf("EnsureMonthFile: %v", err)
+	}
+	content := []byte("# November 2025\n\n## 2025-11-04\n- [ ] [09:00] Secret plan\n")
+	if err := mgr.WriteFile(path, content); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !IsEncrypted(raw) || bytes.Contains(raw, []byte("Secret plan")) {
+		t.Fatalf("file on disk should be ciphertext, got %q", raw)
+	}
+
+	reader, err := mgr.OpenMonth(date)
+	if err != nil {
+		t.Fatalf("OpenMonth: %v", err)
+	}
+	got, _ := io.ReadAll(reader)
+	reader.Close()
+	if !bytes.Equal(got, content) {
+		t.Fatalf("OpenMonth = %q, want %q", got, content)
+	}
+
+	// A fresh manager derives the key again from the salt stored in the file.
+	other, _ := NewManager(base)
+	if _, err := other.ReadFile(path); !errors.Is(err, ErrPassphraseRequired) {
+		t.Fatalf("ReadFile without passphrase err = %v, want ErrPassphraseRequired", err)
+	}
+	other.EnableEncryption("wrong")
+	if _, err := other.ReadFile(path); err == nil {
+		t.Fatal("ReadFile with wrong passphrase should fail")
+	}
+	other.EnableEncryption("correct horse")
+	if got, err := other.ReadFile(path); err != nil || !bytes.Equal(got, content) {
+		t.Fatalf("ReadFile = %q, %v", got, err)
+	}
+}
+
+func TestEncryptionReadsPlaintextFiles(t *testing.T) {
+	mgr, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	date := time.Date(2025, time.October, 1, 0, 0, 0, 0, time.Local)
+	path, err := mgr.EnsureMonthFile(date)
+	if err != nil {
+		t.Fatalf("EnsureMonthFile: %v", err)
+	}
+
+	mgr.EnableEncryption("passphrase")
+	got, err := mgr.ReadFile(path)
+	if err != nil || string(got) != monthHeader(date) {
+		t.Fatalf("ReadFile = %q, %v", got, err)
+	}
+}
+
+func TestEncryptedArchiveIsReadable(t *testing.T) {
+	mgr, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	mgr.EnableEncryption("passphrase")
+
+	date := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.Local)
+	if _, err := mgr.EnsureMonthFile(date); err != nil {
+		t.Fatalf("EnsureMonthFile: %v", err)
+	}
+	if err := mgr.ArchiveMonth(date); err != nil {
+		t.Fatalf("ArchiveMonth: %v", err)
+	}
+
+	reader, err := mgr.OpenMonth(date)
+	if err != nil {
+		t.Fatalf("OpenMonth: %v", err)
+	}
+	got, _ := io.ReadAll(reader)
+	reader.Close()
+	if string(got) != monthHeader(date) {
+		t.Fatalf("OpenMonth = %q", got)
+	}
+}
+
+func TestResolvePassphrase(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(keyFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	t.Setenv("KERJA_PASSPHRASE", "")
+	if got, err := ResolvePassphrase(""); err != nil || got != "" {
+		t.Fatalf("ResolvePassphrase(\"\") = %q, %v", got, err)
+	}
+	if got, err := ResolvePassphrase(keyFile); err != nil || got != "from-file" {
+		t.Fatalf("ResolvePassphrase(file) = %q, %v", got, err)
+	}
+
+	t.Setenv("KERJA_PASSPHRASE", "from-env")
+	if got, err := ResolvePassphrase(keyFile); err != nil || got != "from-env" {
+		t.Fatalf("ResolvePassphrase with env = %q, %v", got, err)
+	}
+}
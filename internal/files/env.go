@@ -1,8 +1,10 @@
 package files
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -32,6 +34,42 @@ func ResolveBasePath() (string, error) {
 	return filepath.Join(home, DefaultDirName), nil
 }
 
+// ResolveWIPLimit returns the maximum number of open todos per day. KERJA_WIP_LIMIT
+// takes precedence over fallback (usually the config file value). Zero means
+// no limit is enforced.
+func ResolveWIPLimit(fallback int) (int, error) {
+	value := strings.TrimSpace(os.Getenv("KERJA_WIP_LIMIT"))
+	if value == "" {
+		return fallback, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("invalid KERJA_WIP_LIMIT %q (expected a non-negative integer)", value)
+	}
+	return limit, nil
+}
+
+// ResolvePassphrase returns the passphrase for encrypted month files.
+// KERJA_PASSPHRASE takes precedence over the contents of keyFile (usually the
+// encryption_key_file config value). An empty result leaves encryption off.
+func ResolvePassphrase(keyFile string) (string, error) {
+	if passphrase := os.Getenv("KERJA_PASSPHRASE"); passphrase != "" {
+		return passphrase, nil
+	}
+	if keyFile == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return "", fmt.Errorf("read encryption key file: %w", err)
+	}
+	passphrase := strings.TrimSpace(string(data))
+	if passphrase == "" {
+		return "", fmt.Errorf("encryption key file %s is empty", keyFile)
+	}
+	return passphrase, nil
+}
+
 func normalizePath(input string) (string, error) {
 	if strings.HasPrefix(input, "~") {
 		home, err := os.UserHomeDir()
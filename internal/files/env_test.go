@@ -51,3 +51,20 @@ func TestResolveBasePathDefaultsToHomeDotKerja(t *testing.T) {
 		t.Fatalf("ResolveBasePath() = %q, want %q", got, want)
 	}
 }
+
+func TestResolveWIPLimit(t *testing.T) {
+	t.Setenv("KERJA_WIP_LIMIT", "")
+	if got, err := ResolveWIPLimit(3); err != nil || got != 3 {
+		t.Fatalf("ResolveWIPLimit(3) unset = %d, %v; want 3, nil", got, err)
+	}
+
+	t.Setenv("KERJA_WIP_LIMIT", " 4 ")
+	if got, err := ResolveWIPLimit(3); err != nil || got != 4 {
+		t.Fatalf("ResolveWIPLimit() = %d, %v; want 4, nil", got, err)
+	}
+
+	t.Setenv("KERJA_WIP_LIMIT", "-1")
+	if _, err := ResolveWIPLimit(0); err == nil {
+		t.Fatalf("expected error for negative limit")
+	}
+}
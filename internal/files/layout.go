@@ -0,0 +1,223 @@
+package files
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultDailyTemplate names daily notes the way Obsidian does by default.
+const DefaultDailyTemplate = "YYYY-MM-DD"
+
+// ErrDailyLayout is returned by month-only operations such as archiving when
+// the manager stores one note per day.
+var ErrDailyLayout = errors.New("not supported with the daily-note layout")
+
+// momentTokens maps the Moment.js tokens Obsidian uses in daily-note formats
+// to Go layout elements. Longer tokens come first so YYYY wins over YY.
+var momentTokens = []struct{ token, layout string }{
+	{"YYYY", "2006"},
+	{"YY", "06"},
+	{"MMMM", "January"},
+	{"MMM", "Jan"},
+	{"MM", "01"},
+	{"DD", "02"},
+	{"dddd", "Monday"},
+	{"ddd", "Mon"},
+}
+
+// literalLayoutElements are the Go layout elements spelled without digits,
+// which literal template text must not contain.
+var literalLayoutElements = []string{"Jan", "Mon", "MST", "PM", "pm"}
+
+// dailyLayout stores each day in its own Markdown note beneath folder.
+type dailyLayout struct {
+	folder string
+	// layout is the Go time layout for the note path relative to folder.
+	layout string
+}
+
+// UseDailyNotes switches the manager from monthly files to one note per day,
+// e.g. inside an Obsidian vault. folder holds the notes (relative paths are
+// resolved against the base path) and template is an Obsidian-style date
+// format such as "YYYY-MM-DD" or "YYYY/MM/YYYY-MM-DD"; ".md" is appended
+// when missing. kerja keeps its entries under a "## YYYY-MM-DD" heading in
+// the note and leaves the rest of the note alone.
+func (m *Manager) UseDailyNotes(folder, template string) error {
+	if folder == "" {
+		folder = m.basePath
+	} else if !filepath.IsAbs(folder) {
+		folder = filepath.Join(m.basePath, folder)
+	}
+	if template == "" {
+		template = DefaultDailyTemplate
+	}
+
+	layout, err := momentToLayout(template)
+	if err != nil {
+		return err
+	}
+	m.daily = &dailyLayout{folder: filepath.Clean(folder), layout: layout}
+	return nil
+}
+
+// Daily reports whether the manager stores one note per day.
+func (m *Manager) Daily() bool {
+	return m.daily != nil
+}
+
+// EntriesDir returns the directory beneath which entries are stored: the
+// daily notes folder, or the base path for monthly files.
+func (m *Manager) EntriesDir() string {
+	if m.daily != nil {
+		return m.daily.folder
+	}
+	return m.basePath
+}
+
+// MonthOf reports the month whose entries are stored at path, the inverse
+// of MonthPath: the month of a month file, or of the day a daily note
+// holds. ok is false for every other file, such as backups or the index.
+func (m *Manager) MonthOf(path string) (month time.Time, ok bool) {
+	path = filepath.Clean(path)
+	var (
+		day time.Time
+		err error
+	)
+	if m.daily != nil {
+		rel, relErr := filepath.Rel(m.daily.folder, path)
+		if relErr != nil {
+			return time.Time{}, false
+		}
+		day, err = time.ParseInLocation(m.daily.layout, filepath.ToSlash(rel), time.Local)
+	} else {
+		day, err = time.ParseInLocation("2006-01.md", filepath.Base(path), time.Local)
+	}
+	if err != nil || m.MonthPath(day) != path {
+		return time.Time{}, false
+	}
+	return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.Local), true
+}
+
+func (d *dailyLayout) path(t time.Time) string {
+	return filepath.Join(d.folder, filepath.FromSlash(t.Format(d.layout)))
+}
+
+// months scans the notes folder and returns every month with at least one note.
+func (d *dailyLayout) months() ([]time.Time, error) {
+	seen := make(map[string]time.Time)
+	err := filepath.WalkDir(d.folder, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() {
+			if strings.HasPrefix(entry.Name(), ".") && path != d.folder {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(d.folder, path)
+		if err != nil {
+			return nil
+		}
+		day, err := time.ParseInLocation(d.layout, filepath.ToSlash(rel), time.Local)
+		if err != nil {
+			return nil
+		}
+		month := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.Local)
+		seen[month.Format("2006-01")] = month
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	months := make([]time.Time, 0, len(seen))
+	for _, month := range seen {
+		months = append(months, month)
+	}
+	sort.Slice(months, func(i, j int) bool { return months[i].Before(months[j]) })
+	return months, nil
+}
+
+// openDaily reads the note for t. A missing note reads as empty rather than
+// being created, so browsing days does not litter the vault with empty notes.
+func (m *Manager) openDaily(t time.Time) (io.ReadCloser, error) {
+	data, err := m.ReadFile(m.daily.path(t))
+	if errors.Is(err, os.ErrNotExist) {
+		return io.NopCloser(bytes.NewReader(nil)), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func momentToLayout(template string) (string, error) {
+	if !strings.HasSuffix(template, ".md") {
+		template += ".md"
+	}
+
+	var (
+		layout  strings.Builder
+		literal strings.Builder
+		hasDay  bool
+	)
+	// Go layouts cannot escape text, so literal text that time.Format would
+	// read as a layout element is refused rather than silently reformatted.
+	flush := func() error {
+		text := literal.String()
+		literal.Reset()
+		if strings.ContainsAny(text, "0123456789") {
+			return fmt.Errorf("daily template %q: literal digits are not supported", template)
+		}
+		for _, element := range literalLayoutElements {
+			if strings.Contains(text, element) {
+				return fmt.Errorf("daily template %q: literal text %q contains %q, which Go reads as a date element", template, text, element)
+			}
+		}
+		layout.WriteString(text)
+		return nil
+	}
+	for i := 0; i < len(template); {
+		matched := false
+		for _, token := range momentTokens {
+			if strings.HasPrefix(template[i:], token.token) {
+				if err := flush(); err != nil {
+					return "", err
+				}
+				layout.WriteString(token.layout)
+				if token.token == "DD" {
+					hasDay = true
+				}
+				i += len(token.token)
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		literal.WriteByte(template[i])
+		i++
+	}
+	if err := flush(); err != nil {
+		return "", err
+	}
+	if !hasDay || !strings.Contains(layout.String(), "2006") && !strings.Contains(layout.String(), "06") {
+		return "", fmt.Errorf("daily template %q must include the year (YYYY) and day (DD)", template)
+	}
+	if !strings.Contains(layout.String(), "01") && !strings.Contains(layout.String(), "Jan") {
+		return "", fmt.Errorf("daily template %q must include the month (MM or MMM)", template)
+	}
+	return layout.String(), nil
+}
@@ -0,0 +1,126 @@
+package files
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDailyNotePaths(t *testing.T) {
+	base := t.TempDir()
+	mgr, err := NewManager(base)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	date := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.Local)
+
+	cases := []struct {
+		folder, template, want string
+	}{
+		{"", "", filepath.Join(base, "2025-11-04.md")},
+		{"Daily", "YYYY/MM/YYYY-MM-DD", filepath.Join(base, "Daily", "2025", "11", "2025-11-04.md")},
+		{"/vault/Journal", "YYYY-MM-DD ddd.md", filepath.Join("/vault/Journal", "2025-11-04 Tue.md")},
+		{"", "DD MMMM YYYY", filepath.Join(base, "04 November 2025.md")},
+		{"", "Journal/YYYY-MM-DD", filepath.Join(base, "Journal", "2025-11-04.md")},
+	}
+	for _, tc := range cases {
+		if err := mgr.UseDailyNotes(tc.folder, tc.template); err != nil {
+			t.Fatalf("UseDailyNotes(%q, %q): %v", tc.folder, tc.template, err)
+		}
+		if got := mgr.MonthPath(date); got != tc.want {
+			t.Fatalf("MonthPath with %q = %q, want %q", tc.template, got, tc.want)
+		}
+	}
+
+	for _, bad := range []string{"YYYY-MM", "MM-DD", "YYYY-MM-DD-2", "[Monthly]/YYYY-MM-DD", "YYYY-MM-DD PM", "January/YYYY-MM-DD", "MST/YYYY-MM-DD"} {
+		if err := mgr.UseDailyNotes("", bad); err == nil {
+			t.Fatalf("UseDailyNotes(%q) should fail", bad)
+		}
+	}
+}
+
+func TestDailyNotesReadAndList(t *testing.T) {
+	base := t.TempDir()
+	mgr, err := NewManager(base)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	if err := mgr.UseDailyNotes("Daily", "YYYY-MM-DD"); err != nil {
+		t.Fatalf("UseDailyNotes: %v", err)
+	}
+
+	missing := time.Date(2025, time.November, 5, 0, 0, 0, 0, time.Local)
+	reader, err := mgr.OpenMonth(missing)
+	if err != nil {
+		t.Fatalf("OpenMonth: %v", err)
+	}
+	data, _ := io.ReadAll(reader)
+	reader.Close()
+	if len(data) != 0 {
+		t.Fatalf("missing note should read empty, got %q", data)
+	}
+	if _, err := os.Stat(mgr.MonthPath(missing)); !os.IsNotExist(err) {
+		t.Fatalf("reading should not create the note, stat err = %v", err)
+	}
+
+	path, err := mgr.EnsureMonthFile(missing)
+	if err != nil {
+		t.Fatalf("EnsureMonthFile: %v", err)
+	}
+	if data, _ := os.ReadFile(path); len(data) != 0 {
+		t.Fatalf("new daily note should be empty, got %q", data)
+	}
+	if err := os.WriteFile(filepath.Join(base, "Daily", "2025-09-30.md"), []byte("# notes\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(base, "Daily", "Ideas.md"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	months, err := mgr.Months()
+	if err != nil {
+		t.Fatalf("Months: %v", err)
+	}
+	if len(months) != 2 || months[0].Format("2006-01") != "2025-09" || months[1].Format("2006-01") != "2025-11" {
+		t.Fatalf("Months = %v", months)
+	}
+
+	if err := mgr.ArchiveMonth(missing); !errors.Is(err, ErrDailyLayout) {
+		t.Fatalf("ArchiveMonth err = %v, want ErrDailyLayout", err)
+	}
+}
+
+func TestMonthOfInvertsMonthPath(t *testing.T) {
+	base := t.TempDir()
+	mgr, err := NewManager(base)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	date := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.Local)
+	want := time.Date(2025, time.November, 1, 0, 0, 0, 0, time.Local)
+
+	if month, ok := mgr.MonthOf(mgr.MonthPath(date)); !ok || !month.Equal(want) {
+		t.Fatalf("MonthOf(month file) = %v, %v; want %v", month, ok, want)
+	}
+	for _, other := range []string{
+		filepath.Join(base, "backups", "2025", "2025-11.md"),
+		filepath.Join(base, "2025", "notes.md"),
+	} {
+		if _, ok := mgr.MonthOf(other); ok {
+			t.Fatalf("MonthOf(%q) should not match", other)
+		}
+	}
+
+	if err := mgr.UseDailyNotes("Daily", "YYYY/MM/YYYY-MM-DD"); err != nil {
+		t.Fatalf("UseDailyNotes: %v", err)
+	}
+	if month, ok := mgr.MonthOf(mgr.MonthPath(date)); !ok || !month.Equal(want) {
+		t.Fatalf("MonthOf(daily note) = %v, %v; want %v", month, ok, want)
+	}
+	if _, ok := mgr.MonthOf(filepath.Join(base, "Daily", "Inbox.md")); ok {
+		t.Fatalf("MonthOf should ignore other notes in the vault")
+	}
+}
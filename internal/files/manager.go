@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
+
+	"github.com/faizmokh/kerja/internal/locale"
+	"github.com/faizmokh/kerja/internal/logging"
 )
 
 const (
@@ -14,9 +18,20 @@ const (
 )
 
 // Manager centralizes where logbooks live on disk and how files are named.
-// I/O responsibilities will grow as the Markdown layer is implemented.
+// It also owns month file I/O, including optional encryption.
 type Manager struct {
 	basePath string
+	// sealer encrypts written month files when encryption is enabled.
+	sealer *sealer
+	// daily stores one note per day instead of monthly files when set.
+	daily *dailyLayout
+	// backups is how many copies of each month file WriteFile keeps; zero
+	// disables backups.
+	backups int
+	// zone is recorded in new month headers when set.
+	zone *time.Location
+	// names spells the month in new month headers.
+	names locale.Locale
 }
 
 // NewManager constructs a Manager rooted at the provided directory. If basePath
@@ -43,15 +58,20 @@ func (m *Manager) BasePath() string {
 	return m.basePath
 }
 
-// MonthPath resolves the absolute path to the markdown file for the supplied time.
-// The file may not exist yet; callers can choose to create it.
+// MonthPath resolves the absolute path to the markdown file holding entries
+// for the supplied time: the month file, or the day's note in the daily-note
+// layout. The file may not exist yet; callers can choose to create it.
 func (m *Manager) MonthPath(t time.Time) string {
+	if m.daily != nil {
+		return m.daily.path(t)
+	}
 	yearDir := filepath.Join(m.basePath, fmt.Sprintf("%04d", t.Year()))
 	return filepath.Join(yearDir, fmt.Sprintf("%04d-%02d.md", t.Year(), t.Month()))
 }
 
 // EnsureMonthFile guarantees the directory tree exists and the month file is
-// present with the expected heading. It returns the absolute path to the file.
+// present with the expected heading. An archived month is restored from the
+// archive first. It returns the absolute path to the file.
 func (m *Manager) EnsureMonthFile(t time.Time) (string, error) {
 	if m == nil {
 		return "", errors.New("files.Manager is nil")
@@ -62,20 +82,21 @@ func (m *Manager) EnsureMonthFile(t time.Time) (string, error) {
 		return "", fmt.Errorf("create directories: %w", err)
 	}
 
-	// Attempt to open the file in append mode; create it if necessary.
-	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, filePermissions)
-	if err != nil {
-		return "", fmt.Errorf("open month file: %w", err)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) && m.daily == nil {
+		if err := m.restoreArchived(t); err != nil {
+			return "", fmt.Errorf("restore archived month: %w", err)
+		}
 	}
-	defer file.Close()
 
-	info, err := file.Stat()
-	if err != nil {
+	info, err := os.Stat(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
 		return "", fmt.Errorf("stat month file: %w", err)
 	}
 
-	if info.Size() == 0 {
-		if _, err := file.WriteString(monthHeader(t)); err != nil {
+	// New (or empty) files get the heading, encrypted when encryption is on.
+	if err != nil || info.Size() == 0 && m.daily == nil {
+		logging.L().Info("create month file", "path", path)
+		if err := m.WriteFile(path, []byte(m.header(t))); err != nil {
 			return "", fmt.Errorf("write month header: %w", err)
 		}
 	}
@@ -83,6 +104,90 @@ func (m *Manager) EnsureMonthFile(t time.Time) (string, error) {
 	return path, nil
 }
 
+// header is the content of a new file: a month title, followed by the time
+// zone when one is set, or nothing for a daily note since the writer adds
+// the date heading itself.
+func (m *Manager) header(t time.Time) string {
+	if m.daily != nil {
+		return ""
+	}
+	title := localizedMonthTitle(t, m.names)
+	if m.zone != nil {
+		return title + "\n" + TimeZoneLine(m.zone) + "\n\n"
+	}
+	return title + "\n\n"
+}
+
+// monthHeader is the header of a new month file when no zone or locale is
+// set.
 func monthHeader(t time.Time) string {
-	return fmt.Sprintf("# %s %04d\n\n", t.Month().String(), t.Year())
+	return MonthTitle(t) + "\n\n"
+}
+
+// SetLocale names the month in the title of every new month file in l's
+// language, such as "# Ogos 2025". Date headings stay ISO.
+func (m *Manager) SetLocale(l locale.Locale) {
+	m.names = l
+}
+
+// SetTimeZone records loc beneath the title of every new month file, so its
+// entries keep reading as wall-clock times in that zone; nil records nothing.
+func (m *Manager) SetTimeZone(loc *time.Location) {
+	m.zone = loc
+}
+
+// TimeZone returns the configured zone, or time.Local when none is set.
+func (m *Manager) TimeZone() *time.Location {
+	if m == nil || m.zone == nil {
+		return time.Local
+	}
+	return m.zone
+}
+
+// zonePrefix and zoneSuffix wrap the zone name in a month header's metadata
+// comment, which Markdown renderers hide.
+const (
+	zonePrefix = "<!-- time_zone: "
+	zoneSuffix = " -->"
+)
+
+// TimeZoneLine is the header metadata recording loc, such as
+// "<!-- time_zone: Asia/Tokyo -->".
+func TimeZoneLine(loc *time.Location) string {
+	return zonePrefix + loc.String() + zoneSuffix
+}
+
+// ParseTimeZoneLine reads the zone from a TimeZoneLine. Unknown zone names
+// are reported as not found.
+func ParseTimeZoneLine(line string) (*time.Location, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, zonePrefix) || !strings.HasSuffix(line, zoneSuffix) {
+		return nil, false
+	}
+	loc, err := time.LoadLocation(strings.TrimSpace(line[len(zonePrefix) : len(line)-len(zoneSuffix)]))
+	if err != nil {
+		return nil, false
+	}
+	return loc, true
+}
+
+// MonthTitle is the heading that opens the month file for t, such as
+// "# November 2025".
+func MonthTitle(t time.Time) string {
+	return localizedMonthTitle(t, locale.English)
+}
+
+func localizedMonthTitle(t time.Time, l locale.Locale) string {
+	return "# " + l.Format(t, "January") + fmt.Sprintf(" %04d", t.Year())
+}
+
+// IsMonthTitle reports whether line is the title of the month file for t in
+// any supported locale, so files keep their title when the locale changes.
+func IsMonthTitle(line string, t time.Time) bool {
+	for _, l := range locale.All() {
+		if line == localizedMonthTitle(t, l) {
+			return true
+		}
+	}
+	return false
 }
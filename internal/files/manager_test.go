@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/faizmokh/kerja/internal/locale"
 )
 
 func TestMonthPath(t *testing.T) {
@@ -65,3 +67,31 @@ func TestEnsureMonthFileCreatesSkeleton(t *testing.T) {
 		t.Fatalf("month file contents after second ensure = %q, want %q", contentsAgain, wantHeader)
 	}
 }
+
+func TestEnsureMonthFileTitlesMonthInLocale(t *testing.T) {
+	mgr, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	fr, err := locale.Parse("fr")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	mgr.SetLocale(fr)
+
+	date := time.Date(2025, time.August, 2, 0, 0, 0, 0, time.UTC)
+	path, err := mgr.EnsureMonthFile(date)
+	if err != nil {
+		t.Fatalf("EnsureMonthFile: %v", err)
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(contents) != "# août 2025\n\n" {
+		t.Fatalf("month file contents = %q", contents)
+	}
+	if !IsMonthTitle("# août 2025", date) || !IsMonthTitle("# August 2025", date) || IsMonthTitle("# juillet 2025", date) {
+		t.Fatalf("IsMonthTitle should accept the month's title in any locale only")
+	}
+}
@@ -0,0 +1,334 @@
+// Package gitsync keeps a logbook directory in sync with a git remote.
+//
+// It shells out to the git binary so the user's credentials, SSH agent, and
+// config apply unchanged. Local changes are committed first, then rebased
+// onto the remote branch and pushed. It also installs the post-commit hook
+// that logs commits in other repositories as done entries.
+package gitsync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultRemote is used when no remote is configured.
+const DefaultRemote = "origin"
+
+// ignored lists logbook paths that are machine-local and never synced.
+// Init and every commit add any missing from .gitignore, so repositories
+// made before a path joined the list pick it up too.
+var ignored = []string{".undo/", ".cache/", "history.jsonl", "index/", "backups/"}
+
+// ErrNotRepository is returned when the logbook directory is not a git work tree.
+var ErrNotRepository = errors.New("logbook is not a git repository (run `kerja sync --init`)")
+
+// ConflictError reports files that could not be merged with the remote.
+type ConflictError struct {
+	Dir    string
+	Remote string
+	Branch string
+	Files  []string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("sync conflict with %s/%s in %s; local commits are kept. Resolve it with:\n"+
+		"  git -C %s pull --rebase %s %s\n"+
+		"  (edit the conflicted files, then `git add` them and `git rebase --continue`)\n"+
+		"and run `kerja sync` again",
+		e.Remote, e.Branch, strings.Join(e.Files, ", "), e.Dir, e.Remote, e.Branch)
+}
+
+// Repo runs git commands inside a logbook directory.
+type Repo struct {
+	dir string
+}
+
+// Result summarizes what Sync did.
+type Result struct {
+	// Committed lists the paths included in the sync commit, if one was made.
+	Committed []string
+	Pulled    bool
+	Pushed    bool
+	Remote    string
+	Branch    string
+}
+
+// Open returns the repository rooted at dir, or ErrNotRepository.
+func Open(dir string) (*Repo, error) {
+	repo := &Repo{dir: dir}
+	out, err := repo.git("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, ErrNotRepository
+	}
+	if !sameDir(strings.TrimSpace(out), dir) {
+		return nil, fmt.Errorf("%s is inside the git repository %s; kerja sync needs the logbook to be the repository root", dir, strings.TrimSpace(out))
+	}
+	return repo, nil
+}
+
+// Init creates a repository in dir with a .gitignore for machine-local state.
+// An existing repository is reused.
+func Init(dir string) (*Repo, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	repo := &Repo{dir: dir}
+	if _, err := repo.git("init", "-q"); err != nil {
+		return nil, err
+	}
+	if err := repo.ensureIgnored(); err != nil {
+		return nil, err
+	}
+	return Open(dir)
+}
+
+// Dir returns the repository root.
+func (r *Repo) Dir() string {
+	return r.dir
+}
+
+// Commit stages every change except machine-local state and commits it with
+// message. It returns the changed paths, or nil when there was nothing to
+// commit.
+func (r *Repo) Commit(message string) ([]string, error) {
+	if err := r.ensureIgnored(); err != nil {
+		return nil, err
+	}
+	if _, err := r.git("add", "-A"); err != nil {
+		return nil, err
+	}
+	changed, err := r.staged()
+	if err != nil || len(changed) == 0 {
+		return nil, err
+	}
+	if message == "" {
+		message = CommitMessage(changed)
+	}
+	if _, err := r.git("commit", "-q", "-m", message); err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// SetRemote points the named remote at url, adding it when missing.
+func (r *Repo) SetRemote(name, url string) error {
+	if r.hasRemote(name) {
+		_, err := r.git("remote", "set-url", name, url)
+		return err
+	}
+	_, err := r.git("remote", "add", name, url)
+	return err
+}
+
+// Sync commits local changes, rebases them onto remote's copy of the current
+// branch, and pushes the result. A repository without the remote only commits.
+func (r *Repo) Sync(remote, message string) (Result, error) {
+	if remote == "" {
+		remote = DefaultRemote
+	}
+	if r.rebaseInProgress() {
+		return Result{}, fmt.Errorf("a rebase is in progress in %s; finish it with `git rebase --continue` or `git rebase --abort` first", r.dir)
+	}
+
+	committed, err := r.Commit(message)
+	if err != nil {
+		return Result{}, err
+	}
+	result := Result{Committed: committed, Remote: remote}
+
+	if !r.hasRemote(remote) {
+		return result, nil
+	}
+	branch, err := r.branch()
+	if err != nil {
+		return result, err
+	}
+	result.Branch = branch
+
+	exists, err := r.remoteBranchExists(remote, branch)
+	if err != nil {
+		return result, err
+	}
+	if exists {
+		if _, err := r.git("pull", "-q", "--rebase", remote, branch); err != nil {
+			if conflicts := r.conflicts(); len(conflicts) > 0 {
+				_, _ = r.git("rebase", "--abort")
+				return result, &ConflictError{Dir: r.dir, Remote: remote, Branch: branch, Files: conflicts}
+			}
+			return result, err
+		}
+		result.Pulled = true
+	}
+
+	if _, err := r.git("push", "-q", remote, "HEAD:"+branch); err != nil {
+		return result, err
+	}
+	result.Pushed = true
+	return result, nil
+}
+
+// CommitMessage describes a sync commit by the month files it touches.
+func CommitMessage(paths []string) string {
+	var months []string
+	others := 0
+	for _, path := range paths {
+		base := filepath.Base(path)
+		if name, ok := strings.CutSuffix(base, ".md"); ok {
+			months = append(months, name)
+			continue
+		}
+		if name, ok := strings.CutSuffix(base, ".md.gz"); ok {
+			months = append(months, name+" (archived)")
+			continue
+		}
+		others++
+	}
+	sort.Strings(months)
+
+	message := "kerja sync"
+	if len(months) > 0 {
+		message += ": " + strings.Join(months, ", ")
+	}
+	if others > 0 {
+		if len(months) > 0 {
+			message += fmt.Sprintf(" (+%d other file%s)", others, plural(others))
+		} else {
+			message += fmt.Sprintf(": %d file%s", others, plural(others))
+		}
+	}
+	return message
+}
+
+func (r *Repo) staged() ([]string, error) {
+	out, err := r.git("diff", "--cached", "--name-only")
+	if err != nil {
+		return nil, err
+	}
+	return lines(out), nil
+}
+
+func (r *Repo) conflicts() []string {
+	out, err := r.git("diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return nil
+	}
+	return lines(out)
+}
+
+func (r *Repo) hasRemote(name string) bool {
+	_, err := r.git("remote", "get-url", name)
+	return err == nil
+}
+
+func (r *Repo) branch() (string, error) {
+	out, err := r.git("symbolic-ref", "--short", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("cannot sync from a detached HEAD in %s; check out a branch first", r.dir)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (r *Repo) remoteBranchExists(remote, branch string) (bool, error) {
+	cmd := exec.Command("git", "-C", r.dir, "ls-remote", "--exit-code", "--heads", remote, branch)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		// Exit code 2 means the remote answered but has no such branch yet.
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 2 {
+			return false, nil
+		}
+		return false, fmt.Errorf("git ls-remote %s: %w: %s", remote, err, strings.TrimSpace(stderr.String()))
+	}
+	return true, nil
+}
+
+func (r *Repo) rebaseInProgress() bool {
+	out, err := r.git("rev-parse", "--git-path", "rebase-merge")
+	if err != nil {
+		return false
+	}
+	path := strings.TrimSpace(out)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(r.dir, path)
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+func (r *Repo) ensureIgnored() error {
+	path := filepath.Join(r.dir, ".gitignore")
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	existing := make(map[string]bool)
+	for _, line := range lines(string(data)) {
+		existing[strings.TrimSpace(line)] = true
+	}
+	content := string(data)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	for _, pattern := range ignored {
+		if !existing[pattern] {
+			content += pattern + "\n"
+		}
+	}
+	if content == string(data) {
+		return nil
+	}
+	return os.WriteFile(path, []byte(content), 0o644)
+}
+
+func (r *Repo) git(args ...string) (string, error) {
+	return git(r.dir, args...)
+}
+
+// git runs git in dir, which may be anywhere inside a work tree.
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		detail := strings.TrimSpace(stderr.String())
+		if detail == "" {
+			detail = strings.TrimSpace(stdout.String())
+		}
+		return stdout.String(), fmt.Errorf("git %s: %w: %s", args[0], err, detail)
+	}
+	return stdout.String(), nil
+}
+
+func sameDir(a, b string) bool {
+	resolvedA, errA := filepath.EvalSymlinks(a)
+	resolvedB, errB := filepath.EvalSymlinks(b)
+	if errA != nil || errB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	return resolvedA == resolvedB
+}
+
+func lines(out string) []string {
+	var result []string
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
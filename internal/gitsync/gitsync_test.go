@@ -0,0 +1,222 @@
+package gitsync
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setupGit(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "kerja")
+	t.Setenv("GIT_AUTHOR_EMAIL", "kerja@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "kerja")
+	t.Setenv("GIT_COMMITTER_EMAIL", "kerja@example.com")
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+}
+
+func runGit(t *testing.T, args ...string) string {
+	t.Helper()
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return string(out)
+}
+
+func writeMonth(t *testing.T, dir, content string) {
+	t.Helper()
+	path := filepath.Join(dir, "2025", "2025-11.md")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+}
+
+// cloneFromRemote returns a logbook repository tracking a fresh bare remote.
+func cloneFromRemote(t *testing.T, remote string) *Repo {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "logbook")
+	runGit(t, "clone", "-q", remote, dir)
+	repo, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	return repo
+}
+
+func TestOpenRequiresRepository(t *testing.T) {
+	setupGit(t)
+	if _, err := Open(t.TempDir()); !errors.Is(err, ErrNotRepository) {
+		t.Fatalf("Open err = %v, want ErrNotRepository", err)
+	}
+}
+
+func TestSyncWithoutRemoteCommitsLocally(t *testing.T) {
+	setupGit(t)
+	dir := t.TempDir()
+	repo, err := Init(dir)
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	writeMonth(t, dir, "# November 2025\n")
+	if err := os.MkdirAll(filepath.Join(dir, ".undo"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".undo", "1.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := repo.Sync("", "")
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if result.Pushed || len(result.Committed) != 2 {
+		t.Fatalf("result = %+v, want two committed files and no push", result)
+	}
+	if log := runGit(t, "-C", dir, "log", "--format=%s"); strings.TrimSpace(log) != "kerja sync: 2025-11 (+1 other file)" {
+		t.Fatalf("commit message = %q", log)
+	}
+	if files := runGit(t, "-C", dir, "ls-files"); strings.Contains(files, ".undo") {
+		t.Fatalf(".undo should be ignored, tracked files:\n%s", files)
+	}
+
+	again, err := repo.Sync("", "")
+	if err != nil || again.Committed != nil {
+		t.Fatalf("second Sync = %+v, %v; want nothing committed", again, err)
+	}
+}
+
+func TestSyncIgnoresLocalStateInExistingRepositories(t *testing.T) {
+	setupGit(t)
+	dir := t.TempDir()
+	runGit(t, "init", "-q", dir)
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte(".undo/"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	writeMonth(t, dir, "# November 2025\n")
+	for _, path := range []string{"history.jsonl", "index/terms.json", "backups/2025/2025-11.md"} {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, path), []byte("local"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	repo, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if _, err := repo.Sync("", ""); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	files := runGit(t, "-C", dir, "ls-files")
+	for _, path := range []string{"history.jsonl", "index/", "backups/"} {
+		if strings.Contains(files, path) {
+			t.Fatalf("%s should be ignored, tracked files:\n%s", path, files)
+		}
+	}
+	gitignore, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	if err != nil || !strings.HasPrefix(string(gitignore), ".undo/\n") || strings.Count(string(gitignore), ".undo/") != 1 {
+		t.Fatalf(".gitignore = %q, %v; want the existing line kept once", gitignore, err)
+	}
+}
+
+func TestSyncPullsAndPushes(t *testing.T) {
+	setupGit(t)
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	runGit(t, "init", "-q", "--bare", remote)
+
+	laptop := cloneFromRemote(t, remote)
+	writeMonth(t, laptop.Dir(), "# November 2025\n\n## 2025-11-04\n- [ ] [09:00] Laptop\n")
+	if result, err := laptop.Sync("", ""); err != nil || !result.Pushed || result.Pulled {
+		t.Fatalf("first Sync = %+v, %v", result, err)
+	}
+
+	desktop := cloneFromRemote(t, remote)
+	if err := os.WriteFile(filepath.Join(desktop.Dir(), "2025", "2025-10.md"), []byte("# October 2025\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if result, err := desktop.Sync("", ""); err != nil || !result.Pulled || !result.Pushed {
+		t.Fatalf("desktop Sync = %+v, %v", result, err)
+	}
+
+	if _, err := laptop.Sync("", ""); err != nil {
+		t.Fatalf("laptop Sync: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(laptop.Dir(), "2025", "2025-10.md")); err != nil {
+		t.Fatalf("laptop should have pulled the October file: %v", err)
+	}
+}
+
+func TestSyncReportsConflicts(t *testing.T) {
+	setupGit(t)
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	runGit(t, "init", "-q", "--bare", remote)
+
+	laptop := cloneFromRemote(t, remote)
+	writeMonth(t, laptop.Dir(), "# November 2025\n\n- [ ] [09:00] Base\n")
+	if _, err := laptop.Sync("", ""); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	desktop := cloneFromRemote(t, remote)
+
+	writeMonth(t, laptop.Dir(), "# November 2025\n\n- [x] [09:00] Base from laptop\n")
+	if _, err := laptop.Sync("", ""); err != nil {
+		t.Fatalf("laptop Sync: %v", err)
+	}
+	writeMonth(t, desktop.Dir(), "# November 2025\n\n- [ ] [09:00] Base from desktop\n")
+
+	_, err := desktop.Sync("", "")
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("Sync err = %v, want ConflictError", err)
+	}
+	if len(conflict.Files) != 1 || conflict.Files[0] != "2025/2025-11.md" {
+		t.Fatalf("conflict files = %v", conflict.Files)
+	}
+	if !strings.Contains(err.Error(), "git -C "+desktop.Dir()+" pull --rebase origin") {
+		t.Fatalf("error should explain how to resolve, got:\n%v", err)
+	}
+	// The rebase is aborted so the local commit and working tree stay intact.
+	data, _ := os.ReadFile(filepath.Join(desktop.Dir(), "2025", "2025-11.md"))
+	if !strings.Contains(string(data), "from desktop") {
+		t.Fatalf("local content lost after conflict: %q", data)
+	}
+}
+
+func TestSetRemoteAddsThenUpdates(t *testing.T) {
+	setupGit(t)
+	repo, err := Init(t.TempDir())
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	for _, url := range []string{"git@example.com:me/first.git", "git@example.com:me/second.git"} {
+		if err := repo.SetRemote("origin", url); err != nil {
+			t.Fatalf("SetRemote(%q): %v", url, err)
+		}
+		if got := strings.TrimSpace(runGit(t, "-C", repo.Dir(), "remote", "get-url", "origin")); got != url {
+			t.Fatalf("origin = %q, want %q", got, url)
+		}
+	}
+}
+
+func TestCommitMessage(t *testing.T) {
+	got := CommitMessage([]string{"2025/2025-11.md", "archive/2025-01.md.gz", "2025/2025-10.md"})
+	want := "kerja sync: 2025-01 (archived), 2025-10, 2025-11"
+	if got != want {
+		t.Fatalf("CommitMessage = %q, want %q", got, want)
+	}
+	if got := CommitMessage([]string{".gitignore"}); got != "kerja sync: 1 file" {
+		t.Fatalf("CommitMessage = %q", got)
+	}
+}
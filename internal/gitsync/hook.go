@@ -0,0 +1,103 @@
+package gitsync
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// hookMarker identifies post-commit hooks written by kerja, so they can be
+// replaced or removed without touching anyone else's.
+const hookMarker = "# Installed by kerja hooks install git."
+
+// postCommitHook logs every commit, amends included, as a done entry. It
+// never fails the commit when kerja is missing or the write fails.
+const postCommitHook = "#!/bin/sh\n" +
+	hookMarker + "\n" +
+	"# Logs each commit as a done entry; remove with kerja hooks uninstall git.\n" +
+	"kerja hooks git-commit || true\n"
+
+// ErrForeignHook is returned when a post-commit hook kerja did not write
+// is in the way.
+var ErrForeignHook = errors.New("a post-commit hook not installed by kerja already exists")
+
+// HookPath returns where git looks for the post-commit hook of the
+// repository containing dir, honoring core.hooksPath.
+func HookPath(dir string) (string, error) {
+	if _, err := git(dir, "rev-parse", "--show-toplevel"); err != nil {
+		return "", fmt.Errorf("%s is not inside a git repository", dir)
+	}
+	out, err := git(dir, "rev-parse", "--git-path", "hooks/post-commit")
+	if err != nil {
+		return "", err
+	}
+	path := strings.TrimSpace(out)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	return path, nil
+}
+
+// InstallHook writes the post-commit hook for the repository containing
+// dir and returns its path. An existing hook from kerja is rewritten; any
+// other is left alone with ErrForeignHook unless force is set.
+func InstallHook(dir string, force bool) (string, error) {
+	path, err := HookPath(dir)
+	if err != nil {
+		return "", err
+	}
+	if !force {
+		if data, err := os.ReadFile(path); err == nil && !strings.Contains(string(data), hookMarker) {
+			return path, ErrForeignHook
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(postCommitHook), 0o755); err != nil {
+		return "", err
+	}
+	// WriteFile keeps the mode of a file it replaces.
+	return path, os.Chmod(path, 0o755)
+}
+
+// UninstallHook removes the post-commit hook kerja wrote for the
+// repository containing dir. removed is false when there was none; a hook
+// kerja did not write is left alone with ErrForeignHook.
+func UninstallHook(dir string) (path string, removed bool, err error) {
+	path, err = HookPath(dir)
+	if err != nil {
+		return "", false, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return path, false, nil
+	}
+	if err != nil {
+		return path, false, err
+	}
+	if !strings.Contains(string(data), hookMarker) {
+		return path, false, ErrForeignHook
+	}
+	return path, true, os.Remove(path)
+}
+
+// LastCommit returns the subject of HEAD in the repository containing dir
+// and the repository's name as a tag: its directory name, lowercased, with
+// spaces turned into dashes.
+func LastCommit(dir string) (subject, tag string, err error) {
+	out, err := git(dir, "log", "-1", "--format=%s")
+	if err != nil {
+		return "", "", err
+	}
+	root, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", "", err
+	}
+	name := strings.ToLower(filepath.Base(strings.TrimSpace(root)))
+	tag = strings.Join(strings.FieldsFunc(name, func(r rune) bool { return unicode.IsSpace(r) || r == '#' }), "-")
+	return strings.TrimSpace(out), tag, nil
+}
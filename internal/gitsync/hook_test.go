@@ -0,0 +1,67 @@
+package gitsync
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstallHookKeepsForeignHooksAndUninstalls(t *testing.T) {
+	setupGit(t)
+	dir := filepath.Join(t.TempDir(), "My Project")
+	runGit(t, "init", "-q", dir)
+	hook := filepath.Join(dir, ".git", "hooks", "post-commit")
+
+	path, err := InstallHook(dir, false)
+	if err != nil || path != hook {
+		t.Fatalf("InstallHook = %q, %v; want %q", path, err, hook)
+	}
+	info, err := os.Stat(hook)
+	if err != nil || info.Mode().Perm()&0o100 == 0 {
+		t.Fatalf("hook not executable: %v, %v", info, err)
+	}
+	if _, err := InstallHook(dir, false); err != nil {
+		t.Fatalf("reinstalling kerja's own hook: %v", err)
+	}
+
+	if err := os.WriteFile(hook, []byte("#!/bin/sh\nmake lint\n"), 0o755); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := InstallHook(dir, false); !errors.Is(err, ErrForeignHook) {
+		t.Fatalf("InstallHook over a foreign hook = %v", err)
+	}
+	if _, _, err := UninstallHook(dir); !errors.Is(err, ErrForeignHook) {
+		t.Fatalf("UninstallHook of a foreign hook = %v", err)
+	}
+	if _, err := InstallHook(dir, true); err != nil {
+		t.Fatalf("InstallHook --force: %v", err)
+	}
+	if _, removed, err := UninstallHook(dir); !removed || err != nil {
+		t.Fatalf("UninstallHook = %v, %v", removed, err)
+	}
+	if _, removed, err := UninstallHook(dir); removed || err != nil {
+		t.Fatalf("UninstallHook without a hook = %v, %v", removed, err)
+	}
+
+	if _, err := InstallHook(t.TempDir(), false); err == nil || !strings.Contains(err.Error(), "not inside a git repository") {
+		t.Fatalf("InstallHook outside a repository = %v", err)
+	}
+}
+
+func TestLastCommitTagsRepositoryName(t *testing.T) {
+	setupGit(t)
+	dir := filepath.Join(t.TempDir(), "My Project")
+	runGit(t, "init", "-q", dir)
+	runGit(t, "-C", dir, "commit", "-q", "--allow-empty", "-m", "Fix login redirect\n\nLonger body.")
+
+	sub := filepath.Join(dir, "cmd")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+	subject, tag, err := LastCommit(sub)
+	if err != nil || subject != "Fix login redirect" || tag != "my-project" {
+		t.Fatalf("LastCommit = %q, %q, %v", subject, tag, err)
+	}
+}
@@ -0,0 +1,127 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// CSVSource reads entries from a CSV file with a header row. The date column
+// is required; time, status, text, and tags are optional. Tags may be
+// separated by spaces, commas, or semicolons, with or without a leading #.
+type CSVSource struct {
+	reader  *csv.Reader
+	loc     *time.Location
+	columns map[string]int
+}
+
+// NewCSVSource reads entries from r, interpreting dates in loc.
+func NewCSVSource(r io.Reader, loc *time.Location) *CSVSource {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	return &CSVSource{reader: reader, loc: loc}
+}
+
+// Next returns the entry on the next non-empty row.
+func (s *CSVSource) Next() (logbook.Entry, error) {
+	if s.columns == nil {
+		if err := s.readHeader(); err != nil {
+			return logbook.Entry{}, err
+		}
+	}
+
+	for {
+		record, err := s.reader.Read()
+		if err != nil {
+			return logbook.Entry{}, err
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		entry, err := s.entry(record)
+		if err != nil {
+			line, _ := s.reader.FieldPos(0)
+			return logbook.Entry{}, fmt.Errorf("line %d: %w", line, err)
+		}
+		return entry, nil
+	}
+}
+
+func (s *CSVSource) readHeader() error {
+	header, err := s.reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.EOF
+		}
+		return fmt.Errorf("read csv header: %w", err)
+	}
+	s.columns = make(map[string]int, len(header))
+	for i, name := range header {
+		s.columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := s.columns["date"]; !ok {
+		return fmt.Errorf("csv header must include a date column")
+	}
+	return nil
+}
+
+func (s *CSVSource) field(record []string, name string) string {
+	i, ok := s.columns[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func (s *CSVSource) entry(record []string) (logbook.Entry, error) {
+	date, err := time.ParseInLocation("2006-01-02", s.field(record, "date"), s.loc)
+	if err != nil {
+		return logbook.Entry{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", s.field(record, "date"))
+	}
+
+	entry := logbook.Entry{Status: logbook.StatusTodo, Time: date, Text: s.field(record, "text")}
+	if clock := s.field(record, "time"); clock != "" {
+		parsed, err := time.ParseInLocation("15:04", clock, s.loc)
+		if err != nil {
+			return logbook.Entry{}, fmt.Errorf("invalid time %q (expected HH:MM)", clock)
+		}
+		entry.Time = time.Date(date.Year(), date.Month(), date.Day(), parsed.Hour(), parsed.Minute(), 0, 0, s.loc)
+	}
+
+	switch status := strings.ToLower(s.field(record, "status")); status {
+	case "", "todo", "open", "[ ]":
+	case "done", "x", "[x]", "closed", "complete", "completed":
+		entry.Status = logbook.StatusDone
+	case "[~]":
+		entry.Status = logbook.StatusInProgress
+	case "[-]":
+		entry.Status = logbook.StatusCancelled
+	case "[!]":
+		entry.Status = logbook.StatusBlocked
+	default:
+		parsed, err := logbook.ParseStatus(status)
+		if err != nil {
+			return logbook.Entry{}, err
+		}
+		entry.Status = parsed
+	}
+
+	for _, tag := range strings.FieldsFunc(s.field(record, "tags"), func(r rune) bool {
+		return r == ' ' || r == ',' || r == ';'
+	}) {
+		if tag = strings.TrimPrefix(tag, "#"); tag != "" {
+			entry.Tags = append(entry.Tags, tag)
+		}
+	}
+
+	if entry.Text == "" && len(entry.Tags) == 0 {
+		return logbook.Entry{}, fmt.Errorf("text is required")
+	}
+	return entry, nil
+}
@@ -0,0 +1,138 @@
+// Package importer streams entries from external sources into the logbook,
+// batching writes per month so each month file is rewritten once when the
+// source is ordered by date.
+package importer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// Source yields entries one at a time and returns io.EOF once exhausted.
+// Each entry's Time carries the date it should be filed under.
+type Source interface {
+	Next() (logbook.Entry, error)
+}
+
+// MonthSummary reports how many entries landed in a month file.
+type MonthSummary struct {
+	Month   string
+	Entries int
+}
+
+// Options tunes an import run.
+type Options struct {
+	// Progress, when set, is called every ProgressEvery records with the running total.
+	Progress      func(read int)
+	ProgressEvery int
+}
+
+// Run drains src and hands each month's entries to writer in one batch as
+// soon as the source moves past that month, so memory stays bounded by a
+// single month and a source ordered by date writes every month file exactly
+// once. Months already written stay written if a later record fails.
+func Run(ctx context.Context, src Source, writer *logbook.Writer, opts Options) ([]MonthSummary, error) {
+	var (
+		batch  []logbook.Entry
+		month  string
+		counts = make(map[string]int)
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := writer.AppendBatch(ctx, batch); err != nil {
+			return err
+		}
+		counts[month] += len(batch)
+		batch = nil
+		return nil
+	}
+
+	err := drain(ctx, src, opts, func(entry logbook.Entry) error {
+		if key := entry.Time.Format("2006-01"); key != month {
+			if err := flush(); err != nil {
+				return err
+			}
+			month = key
+		}
+		batch = append(batch, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return summarizeCounts(counts), nil
+}
+
+// Read drains src without writing anything, reporting progress as it goes.
+// Callers use it directly for dry runs.
+func Read(ctx context.Context, src Source, opts Options) ([]logbook.Entry, error) {
+	var entries []logbook.Entry
+	err := drain(ctx, src, opts, func(entry logbook.Entry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// drain passes every entry of src to emit, reporting progress as it goes.
+func drain(ctx context.Context, src Source, opts Options, emit func(logbook.Entry) error) error {
+	every := opts.ProgressEvery
+	if every <= 0 {
+		every = 500
+	}
+
+	read := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		entry, err := src.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		if err := emit(entry); err != nil {
+			return err
+		}
+		read++
+		if opts.Progress != nil && read%every == 0 {
+			opts.Progress(read)
+		}
+	}
+	if opts.Progress != nil && read%every != 0 {
+		opts.Progress(read)
+	}
+	return nil
+}
+
+// Summarize counts entries per month file, ordered by month.
+func Summarize(entries []logbook.Entry) []MonthSummary {
+	counts := make(map[string]int)
+	for _, entry := range entries {
+		counts[entry.Time.Format("2006-01")]++
+	}
+	return summarizeCounts(counts)
+}
+
+func summarizeCounts(counts map[string]int) []MonthSummary {
+	summary := make([]MonthSummary, 0, len(counts))
+	for month, count := range counts {
+		summary = append(summary, MonthSummary{Month: month, Entries: count})
+	}
+	sort.Slice(summary, func(i, j int) bool { return summary[i].Month < summary[j].Month })
+	return summary
+}
@@ -0,0 +1,210 @@
+package importer
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func TestRunStreamsLogbookAndSummarisesMonths(t *testing.T) {
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	input := `# Archive
+
+## 2025-09-30
+- [x] [09:00] Close quarter #finance
+- [ ] [10:00] File expenses
+
+## 2025-10-01
+- [x] [08:30] Kickoff
+`
+
+	var progress []int
+	summary, err := Run(context.Background(), NewLogbookSource(strings.NewReader(input)), logbook.NewWriter(mgr), Options{
+		Progress:      func(read int) { progress = append(progress, read) },
+		ProgressEvery: 2,
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(summary) != 2 || summary[0] != (MonthSummary{Month: "2025-09", Entries: 2}) || summary[1] != (MonthSummary{Month: "2025-10", Entries: 1}) {
+		t.Fatalf("summary = %+v", summary)
+	}
+	if len(progress) != 2 || progress[0] != 2 || progress[1] != 3 {
+		t.Fatalf("progress = %v, want [2 3]", progress)
+	}
+
+	section, err := logbook.NewReader(mgr).Section(context.Background(), time.Date(2025, time.September, 30, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("Section: %v", err)
+	}
+	if len(section.Entries) != 2 || section.Entries[0].Tags[0] != "finance" {
+		t.Fatalf("unexpected section: %+v", section)
+	}
+}
+
+// sourceFunc adapts a function to Source.
+type sourceFunc func() (logbook.Entry, error)
+
+func (f sourceFunc) Next() (logbook.Entry, error) { return f() }
+
+func TestRunWritesEachMonthOnceTheSourceMovesPastIt(t *testing.T) {
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	reader := logbook.NewReader(mgr)
+	september := time.Date(2025, time.September, 30, 9, 0, 0, 0, time.Local)
+	october := time.Date(2025, time.October, 1, 9, 0, 0, 0, time.Local)
+
+	queue := []logbook.Entry{
+		{Time: september, Status: logbook.StatusTodo, Text: "Close quarter"},
+		{Time: september, Status: logbook.StatusDone, Text: "File expenses"},
+		{Time: october, Status: logbook.StatusTodo, Text: "Kickoff"},
+	}
+	var writtenBeforeOctober int
+	src := sourceFunc(func() (logbook.Entry, error) {
+		if len(queue) == 0 {
+			// September was flushed when Kickoff arrived, before the source ended.
+			section, err := reader.Section(context.Background(), september)
+			if err != nil {
+				return logbook.Entry{}, err
+			}
+			writtenBeforeOctober = len(section.Entries)
+			return logbook.Entry{}, io.EOF
+		}
+		entry := queue[0]
+		queue = queue[1:]
+		return entry, nil
+	})
+
+	summary, err := Run(context.Background(), src, logbook.NewWriter(mgr), Options{})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if writtenBeforeOctober != 2 {
+		t.Fatalf("September held %d entries before the source ended, want 2", writtenBeforeOctober)
+	}
+	if len(summary) != 2 || summary[0] != (MonthSummary{Month: "2025-09", Entries: 2}) || summary[1] != (MonthSummary{Month: "2025-10", Entries: 1}) {
+		t.Fatalf("summary = %+v", summary)
+	}
+}
+
+func TestMarkdownSourceReadsTaskLists(t *testing.T) {
+	input := `- [ ] Undated #inbox
+# Notes for 2025-11-03
+Some prose.
+- plain bullet
+  - [X] [14:30] Nested done
+* [ ] Review @16:00 #code
+- [x] Ping @alice about !urgent deploy #ops
+`
+	entries, err := Read(context.Background(), NewMarkdownSource(strings.NewReader(input), time.Date(2025, time.November, 1, 12, 0, 0, 0, time.Local)), Options{})
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries, got %+v", entries)
+	}
+	if got := entries[0]; got.Time.Format("2006-01-02 15:04") != "2025-11-01 00:00" || got.Status != logbook.StatusTodo || got.Tags[0] != "inbox" {
+		t.Fatalf("undated entry = %+v", got)
+	}
+	if got := entries[1]; got.Time.Format("2006-01-02 15:04") != "2025-11-03 14:30" || got.Status != logbook.StatusDone || got.Text != "Nested done" {
+		t.Fatalf("nested entry = %+v", got)
+	}
+	if got := entries[2]; got.Time.Format("15:04") != "16:00" || got.Text != "Review" {
+		t.Fatalf("token entry = %+v", got)
+	}
+	if got := entries[3]; got.Text != "Ping @alice about !urgent deploy" || got.Status != logbook.StatusDone || got.Tags[0] != "ops" {
+		t.Fatalf("mention entry = %+v", got)
+	}
+}
+
+func TestMarkdownSourceKeepsTextWithNULBytes(t *testing.T) {
+	input := "- [ ] Call @bob re \x000 draft @10:00 about \x001\n"
+	entries, err := Read(context.Background(), NewMarkdownSource(strings.NewReader(input), time.Date(2025, time.November, 1, 12, 0, 0, 0, time.Local)), Options{})
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %+v", entries)
+	}
+	if got := entries[0]; got.Text != "Call @bob re \x000 draft about \x001" || got.Time.Format("15:04") != "10:00" {
+		t.Fatalf("entry = %+v", got)
+	}
+}
+
+func TestMarkdownSourcePinsStarredTasksAndKeepsAuthors(t *testing.T) {
+	input := "- [ ] sync with ~bob about * release #work\n"
+	entries, err := Read(context.Background(), NewMarkdownSource(strings.NewReader(input), time.Date(2025, time.November, 1, 12, 0, 0, 0, time.Local)), Options{})
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %+v", entries)
+	}
+	if got := entries[0]; got.Text != "sync with about release" || !got.Pinned || got.Author != "bob" {
+		t.Fatalf("entry = %+v", got)
+	}
+}
+
+func TestCSVSourceValidatesRows(t *testing.T) {
+	input := "Text,Date,Status,Tags\nShip it,2025-11-04,x,release;ops\n\nBroken,11/04/2025,,\n"
+	src := NewCSVSource(strings.NewReader(input), time.Local)
+
+	entry, err := src.Next()
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if entry.Text != "Ship it" || entry.Status != logbook.StatusDone || len(entry.Tags) != 2 || entry.Time.Format("2006-01-02") != "2025-11-04" {
+		t.Fatalf("entry = %+v", entry)
+	}
+
+	if _, err := src.Next(); err == nil || !strings.Contains(err.Error(), "line 4: invalid date") {
+		t.Fatalf("expected invalid date error, got %v", err)
+	}
+
+	missing := NewCSVSource(strings.NewReader("text\nhello\n"), time.Local)
+	if _, err := missing.Next(); err == nil || !strings.Contains(err.Error(), "date column") {
+		t.Fatalf("expected header error, got %v", err)
+	}
+}
+
+func TestTodoTxtSourceMapsTasks(t *testing.T) {
+	input := `x 2025-11-05 2025-11-03 Ship login +web @office ref:JIRA-12 pri:B
+(B) 2025-11-04 Call dentist @phone due:2025-11-10
+
+Undated idea +inbox
+(A) +solo
+`
+	entries, err := Read(context.Background(), NewTodoTxtSource(strings.NewReader(input), time.Date(2025, time.November, 1, 12, 0, 0, 0, time.Local)), Options{})
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries, got %+v", entries)
+	}
+	if got := entries[0]; got.Status != logbook.StatusDone || got.Time.Format("2006-01-02") != "2025-11-05" || got.Text != "Ship login" ||
+		strings.Join(got.Tags, ",") != "web,office" || got.Links[0] != "JIRA-12" || !got.Pinned {
+		t.Fatalf("completed task = %+v", got)
+	}
+	if got := entries[1]; got.Status != logbook.StatusTodo || got.Time.Format("2006-01-02") != "2025-11-04" || got.Text != "Call dentist due:2025-11-10" || !got.Pinned {
+		t.Fatalf("open task = %+v", got)
+	}
+	if got := entries[2]; got.Time.Format("2006-01-02 15:04") != "2025-11-01 00:00" || got.Pinned || got.Tags[0] != "inbox" {
+		t.Fatalf("undated task = %+v", got)
+	}
+
+	if _, err := NewTodoTxtSource(strings.NewReader("x 2025-11-05\n"), time.Now()).Next(); err == nil || !strings.Contains(err.Error(), "line 1: task has no description") {
+		t.Fatalf("expected empty task error, got %v", err)
+	}
+}
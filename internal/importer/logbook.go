@@ -0,0 +1,33 @@
+package importer
+
+import (
+	"io"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// LogbookSource streams entries from a file already in kerja's Markdown
+// format, such as a month file exported from another machine.
+type LogbookSource struct {
+	parser  *logbook.Parser
+	pending []logbook.Entry
+}
+
+// NewLogbookSource wraps r in a streaming parser.
+func NewLogbookSource(r io.Reader) *LogbookSource {
+	return &LogbookSource{parser: logbook.NewParser(r)}
+}
+
+// Next returns the next entry, reading one date section at a time.
+func (s *LogbookSource) Next() (logbook.Entry, error) {
+	for len(s.pending) == 0 {
+		section, err := s.parser.NextSection()
+		if err != nil {
+			return logbook.Entry{}, err
+		}
+		s.pending = section.Entries
+	}
+	entry := s.pending[0]
+	s.pending = s.pending[1:]
+	return entry, nil
+}
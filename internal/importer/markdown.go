@@ -0,0 +1,125 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+var (
+	markdownHeading  = regexp.MustCompile(`^#{1,6}\s+.*?(\d{4}-\d{2}-\d{2})`)
+	markdownTask     = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX~!-])\]\s+(.*)$`)
+	markdownLeadTime = regexp.MustCompile(`^\[?(\d{1,2}:\d{2})\]?\s+`)
+)
+
+// MarkdownSource reads checkbox task lists such as those kept in Obsidian or
+// GitHub issues. Headings containing a YYYY-MM-DD date set the day for the
+// tasks beneath them; tasks before any dated heading land on the fallback day.
+// Other lines, including plain bullets, are ignored.
+type MarkdownSource struct {
+	scanner *bufio.Scanner
+	date    time.Time
+	lineNo  int
+}
+
+// NewMarkdownSource reads tasks from r, filing undated ones under fallback.
+func NewMarkdownSource(r io.Reader, fallback time.Time) *MarkdownSource {
+	return &MarkdownSource{
+		scanner: bufio.NewScanner(r),
+		date:    time.Date(fallback.Year(), fallback.Month(), fallback.Day(), 0, 0, 0, 0, fallback.Location()),
+	}
+}
+
+// Next returns the next checkbox task. A leading HH:MM or [HH:MM], or an
+// @HH:MM token, sets the entry time; otherwise it defaults to 00:00.
+// A standalone * pins the entry and a ~name credits it. Mentions such as
+// @alice and words such as !urgent stay in the text.
+func (s *MarkdownSource) Next() (logbook.Entry, error) {
+	for s.scanner.Scan() {
+		s.lineNo++
+		line := s.scanner.Text()
+
+		if match := markdownHeading.FindStringSubmatch(line); match != nil {
+			date, err := time.ParseInLocation("2006-01-02", match[1], s.date.Location())
+			if err != nil {
+				return logbook.Entry{}, fmt.Errorf("line %d: %w", s.lineNo, err)
+			}
+			s.date = date
+			continue
+		}
+
+		match := markdownTask.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		status, _ := logbook.StatusFromMarker(match[1][0])
+		entry, err := taskEntry(s.date, status, match[2])
+		if err != nil {
+			return logbook.Entry{}, fmt.Errorf("line %d: %w", s.lineNo, err)
+		}
+		if entry.Text == "" && len(entry.Tags) == 0 {
+			continue
+		}
+		return entry, nil
+	}
+	if err := s.scanner.Err(); err != nil {
+		return logbook.Entry{}, err
+	}
+	return logbook.Entry{}, io.EOF
+}
+
+func taskEntry(date time.Time, status logbook.Status, body string) (logbook.Entry, error) {
+	if match := markdownLeadTime.FindStringSubmatch(body); match != nil {
+		body = "@" + match[1] + " " + body[len(match[0]):]
+	}
+	parsed, err := parseTaskTokens(body, date)
+	if err != nil {
+		return logbook.Entry{}, err
+	}
+	// The checkbox decides the status.
+	parsed.Status = nil
+	return parsed.Entry(date, status), nil
+}
+
+// parseTaskTokens reads body like logbook.ParseTokens, except that @ and !
+// words that are not a time or a status, such as @alice or !urgent, stay in
+// the text: task lists use them for mentions and emphasis, not kerja syntax.
+func parseTaskTokens(body string, date time.Time) (logbook.TokenInput, error) {
+	words := strings.Fields(body)
+	plain := make([]bool, len(words))
+	var kerja []string
+	for i, word := range words {
+		if strings.HasPrefix(word, "@") || strings.HasPrefix(word, "!") {
+			_, err := logbook.ParseTokens(word, date)
+			plain[i] = err != nil
+		}
+		if !plain[i] {
+			kerja = append(kerja, word)
+		}
+	}
+	parsed, err := logbook.ParseTokens(strings.Join(kerja, " "), date)
+	if err != nil || len(kerja) == len(words) {
+		return parsed, err
+	}
+
+	// ParseTokens keeps text words verbatim and in order, so they are matched
+	// back to their places between the plain words.
+	kept := strings.Fields(parsed.Text)
+	text := make([]string, 0, len(kept)+len(words)-len(kerja))
+	for i, word := range words {
+		switch {
+		case plain[i]:
+			text = append(text, word)
+		case len(kept) > 0 && kept[0] == word:
+			text = append(text, word)
+			kept = kept[1:]
+		}
+	}
+	parsed.Text = strings.Join(text, " ")
+	return parsed, nil
+}
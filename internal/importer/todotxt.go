@@ -0,0 +1,103 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+var (
+	todoTxtPriority = regexp.MustCompile(`^\(([A-Z])\)$`)
+	todoTxtDate     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+)
+
+// TodoTxtSource reads tasks in the todo.txt format, one per line. A leading
+// "x" marks a task done; its completion date, or else its creation date, sets
+// the day it is filed under, falling back to a fixed day for undated tasks.
+// Any priority, written as (A) or as a pri:A value on completed tasks, pins
+// the entry. +project, @context, and #tag tokens become tags and ref: values links;
+// everything else, including other key:value pairs, stays in the text.
+type TodoTxtSource struct {
+	scanner  *bufio.Scanner
+	fallback time.Time
+	lineNo   int
+}
+
+// NewTodoTxtSource reads tasks from r, filing undated ones under fallback.
+func NewTodoTxtSource(r io.Reader, fallback time.Time) *TodoTxtSource {
+	return &TodoTxtSource{
+		scanner:  bufio.NewScanner(r),
+		fallback: time.Date(fallback.Year(), fallback.Month(), fallback.Day(), 0, 0, 0, 0, fallback.Location()),
+	}
+}
+
+// Next returns the entry for the next non-blank line at 00:00 on its day.
+func (s *TodoTxtSource) Next() (logbook.Entry, error) {
+	for s.scanner.Scan() {
+		s.lineNo++
+		fields := strings.Fields(s.scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		entry, err := s.entry(fields)
+		if err != nil {
+			return logbook.Entry{}, fmt.Errorf("line %d: %w", s.lineNo, err)
+		}
+		return entry, nil
+	}
+	if err := s.scanner.Err(); err != nil {
+		return logbook.Entry{}, err
+	}
+	return logbook.Entry{}, io.EOF
+}
+
+func (s *TodoTxtSource) entry(fields []string) (logbook.Entry, error) {
+	entry := logbook.Entry{Status: logbook.StatusTodo}
+	if fields[0] == "x" {
+		entry.Status = logbook.StatusDone
+		fields = fields[1:]
+	}
+	if len(fields) > 0 && todoTxtPriority.MatchString(fields[0]) {
+		entry.Pinned = true
+		fields = fields[1:]
+	}
+
+	// A completed task may carry a completion date before its creation date;
+	// the first date is the one the work belongs to.
+	day := s.fallback
+	for dates := 0; len(fields) > 0 && todoTxtDate.MatchString(fields[0]) && dates < 2; dates++ {
+		if dates == 0 {
+			parsed, err := time.ParseInLocation("2006-01-02", fields[0], s.fallback.Location())
+			if err != nil {
+				return logbook.Entry{}, fmt.Errorf("invalid date %q", fields[0])
+			}
+			day = parsed
+		}
+		fields = fields[1:]
+	}
+	entry.Time = day
+
+	var words []string
+	for _, field := range fields {
+		switch {
+		case len(field) > 1 && (field[0] == '+' || field[0] == '@' || field[0] == '#'):
+			entry.Tags = append(entry.Tags, field[1:])
+		case strings.HasPrefix(field, "ref:") && len(field) > len("ref:"):
+			entry.Links = append(entry.Links, strings.TrimPrefix(field, "ref:"))
+		case strings.HasPrefix(field, "pri:") && len(field) == len("pri:")+1:
+			entry.Pinned = true
+		default:
+			words = append(words, field)
+		}
+	}
+	entry.Text = strings.Join(words, " ")
+	if entry.Text == "" && len(entry.Tags) == 0 {
+		return logbook.Entry{}, fmt.Errorf("task has no description")
+	}
+	return entry, nil
+}
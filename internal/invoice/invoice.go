@@ -0,0 +1,117 @@
+// Package invoice drafts invoices from logged work: the tracked time of each
+// day is summed and priced at an hourly rate, one line per day.
+package invoice
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/export"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// Line is one day of billed work.
+type Line struct {
+	Date time.Time
+	// Items lists the day's billed entries, without duration annotations.
+	Items   []string
+	Minutes int
+	// Amount is the day's hours times the rate, rounded to cents.
+	Amount float64
+}
+
+// Invoice is a draft covering Start through End.
+type Invoice struct {
+	Title      string
+	Start, End time.Time
+	Rate       float64
+	Lines      []Line
+	Minutes    int
+	// Amount sums the line amounts, so the items add up to the total.
+	Amount float64
+	// Untimed counts entries without a time range or ~duration, which are
+	// left off the invoice.
+	Untimed int
+}
+
+// Build prices the entries in sections at rate per hour. An entry's time is
+// its range (09:00-10:30), or else its ~1h30m annotation; days without
+// billed time get no line.
+func Build(title string, start, end time.Time, rate float64, sections []logbook.DateSection) Invoice {
+	inv := Invoice{Title: title, Start: start, End: end, Rate: rate}
+	for _, section := range sections {
+		line := Line{Date: section.Date}
+		for _, entry := range section.Entries {
+			duration, text, _ := export.EntryDuration(entry.Text)
+			if ranged := entry.Duration(); ranged > 0 {
+				duration = ranged
+			}
+			minutes := int(duration.Round(time.Minute) / time.Minute)
+			if minutes == 0 {
+				inv.Untimed++
+				continue
+			}
+			line.Items = append(line.Items, text)
+			line.Minutes += minutes
+		}
+		if line.Minutes == 0 {
+			continue
+		}
+		line.Amount = price(line.Minutes, rate)
+		inv.Lines = append(inv.Lines, line)
+		inv.Minutes += line.Minutes
+		inv.Amount += line.Amount
+	}
+	inv.Amount = math.Round(inv.Amount*100) / 100
+	return inv
+}
+
+func price(minutes int, rate float64) float64 {
+	return math.Round(float64(minutes)/60*rate*100) / 100
+}
+
+// Markdown writes the invoice as a heading and an itemized table.
+func Markdown(w io.Writer, inv Invoice) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", inv.Title)
+	fmt.Fprintf(&b, "Period: %s to %s  \n", inv.Start.Format("2006-01-02"), inv.End.Format("2006-01-02"))
+	fmt.Fprintf(&b, "Rate: %.2f per hour\n\n", inv.Rate)
+	if len(inv.Lines) == 0 {
+		b.WriteString("No billable time in this period.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+	b.WriteString("| Date | Work | Hours | Amount |\n")
+	b.WriteString("| --- | --- | ---: | ---: |\n")
+	cell := strings.NewReplacer("|", `\|`)
+	for _, line := range inv.Lines {
+		fmt.Fprintf(&b, "| %s | %s | %s | %.2f |\n", line.Date.Format("2006-01-02"), cell.Replace(strings.Join(line.Items, "; ")), hours(line.Minutes), line.Amount)
+	}
+	fmt.Fprintf(&b, "| **Total** | | **%s** | **%.2f** |\n", hours(inv.Minutes), inv.Amount)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// CSV writes one row per day under a date,work,hours,amount header, then a
+// total row.
+func CSV(w io.Writer, inv Invoice) error {
+	out := csv.NewWriter(w)
+	rows := [][]string{{"date", "work", "hours", "amount"}}
+	for _, line := range inv.Lines {
+		rows = append(rows, []string{line.Date.Format("2006-01-02"), strings.Join(line.Items, "; "), hours(line.Minutes), fmt.Sprintf("%.2f", line.Amount)})
+	}
+	rows = append(rows, []string{"total", "", hours(inv.Minutes), fmt.Sprintf("%.2f", inv.Amount)})
+	if err := out.WriteAll(rows); err != nil {
+		return err
+	}
+	return out.Error()
+}
+
+// hours formats minutes as decimal hours, as invoices list them.
+func hours(minutes int) string {
+	return fmt.Sprintf("%.2f", float64(minutes)/60)
+}
@@ -0,0 +1,80 @@
+package invoice
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func sampleSections() []logbook.DateSection {
+	day := func(d int) time.Time { return time.Date(2025, time.November, d, 0, 0, 0, 0, time.UTC) }
+	at := func(d, h, m int) time.Time {
+		return day(d).Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute)
+	}
+	return []logbook.DateSection{
+		{Date: day(3), Entries: []logbook.Entry{
+			{Time: at(3, 9, 0), End: at(3, 10, 30), Text: "Ship login"},
+			{Time: at(3, 11, 0), Text: "Review | merge PR ~45m"},
+			{Time: at(3, 12, 0), Text: "Standup"},
+		}},
+		{Date: day(4), Entries: []logbook.Entry{
+			{Time: at(4, 9, 0), Text: "No time given"},
+		}},
+		{Date: day(5), Entries: []logbook.Entry{
+			{Time: at(5, 14, 0), End: at(5, 14, 20), Text: "Call ~1h"},
+		}},
+	}
+}
+
+func TestBuildPricesTrackedTimePerDay(t *testing.T) {
+	inv := Build("Invoice", time.Time{}, time.Time{}, 120, sampleSections())
+	if len(inv.Lines) != 2 {
+		t.Fatalf("lines = %+v", inv.Lines)
+	}
+	first := inv.Lines[0]
+	if first.Minutes != 135 || first.Amount != 270 || len(first.Items) != 2 || first.Items[1] != "Review | merge PR" {
+		t.Fatalf("first line = %+v", first)
+	}
+	// A range wins over an annotation.
+	if second := inv.Lines[1]; second.Minutes != 20 || second.Amount != 40 || second.Items[0] != "Call" {
+		t.Fatalf("second line = %+v", second)
+	}
+	if inv.Minutes != 155 || inv.Amount != 310 || inv.Untimed != 2 {
+		t.Fatalf("totals = %d min, %.2f, %d untimed", inv.Minutes, inv.Amount, inv.Untimed)
+	}
+}
+
+func TestMarkdownAndCSV(t *testing.T) {
+	start := time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC)
+	inv := Build("Invoice draft", start, start.AddDate(0, 1, -1), 120, sampleSections())
+
+	var md bytes.Buffer
+	if err := Markdown(&md, inv); err != nil {
+		t.Fatalf("Markdown: %v", err)
+	}
+	want := "# Invoice draft\n\n" +
+		"Period: 2025-11-01 to 2025-11-30  \n" +
+		"Rate: 120.00 per hour\n\n" +
+		"| Date | Work | Hours | Amount |\n" +
+		"| --- | --- | ---: | ---: |\n" +
+		"| 2025-11-03 | Ship login; Review \\| merge PR | 2.25 | 270.00 |\n" +
+		"| 2025-11-05 | Call | 0.33 | 40.00 |\n" +
+		"| **Total** | | **2.58** | **310.00** |\n"
+	if md.String() != want {
+		t.Fatalf("markdown =\n%s\nwant\n%s", md.String(), want)
+	}
+
+	var csv bytes.Buffer
+	if err := CSV(&csv, inv); err != nil {
+		t.Fatalf("CSV: %v", err)
+	}
+	want = "date,work,hours,amount\n" +
+		"2025-11-03,Ship login; Review | merge PR,2.25,270.00\n" +
+		"2025-11-05,Call,0.33,40.00\n" +
+		"total,,2.58,310.00\n"
+	if csv.String() != want {
+		t.Fatalf("csv =\n%s\nwant\n%s", csv.String(), want)
+	}
+}
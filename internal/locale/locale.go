@@ -0,0 +1,167 @@
+// Package locale names days and months in the user's language for headers
+// such as "Monday, 02 January 2006". Only names change: numeric parts, and
+// the ISO date headings in month files, are the same in every locale.
+package locale
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Locale holds the day and month names of one language. The zero Locale is
+// English.
+type Locale struct {
+	// Tag is the language code, such as "de".
+	Tag         string
+	months      [12]string
+	shortMonths [12]string
+	// days and shortDays start on Sunday, like time.Weekday.
+	days      [7]string
+	shortDays [7]string
+}
+
+var locales = []Locale{
+	{
+		Tag:         "en",
+		months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		shortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		days:        [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		shortDays:   [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	},
+	{
+		Tag:         "ms",
+		months:      [12]string{"Januari", "Februari", "Mac", "April", "Mei", "Jun", "Julai", "Ogos", "September", "Oktober", "November", "Disember"},
+		shortMonths: [12]string{"Jan", "Feb", "Mac", "Apr", "Mei", "Jun", "Jul", "Ogo", "Sep", "Okt", "Nov", "Dis"},
+		days:        [7]string{"Ahad", "Isnin", "Selasa", "Rabu", "Khamis", "Jumaat", "Sabtu"},
+		shortDays:   [7]string{"Ahd", "Isn", "Sel", "Rab", "Kha", "Jum", "Sab"},
+	},
+	{
+		Tag:         "id",
+		months:      [12]string{"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"},
+		shortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "Mei", "Jun", "Jul", "Agu", "Sep", "Okt", "Nov", "Des"},
+		days:        [7]string{"Minggu", "Senin", "Selasa", "Rabu", "Kamis", "Jumat", "Sabtu"},
+		shortDays:   [7]string{"Min", "Sen", "Sel", "Rab", "Kam", "Jum", "Sab"},
+	},
+	{
+		Tag:         "de",
+		months:      [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		shortMonths: [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		days:        [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		shortDays:   [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	},
+	{
+		Tag:         "fr",
+		months:      [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		shortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		days:        [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		shortDays:   [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+	},
+	{
+		Tag:         "es",
+		months:      [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		shortMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		days:        [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		shortDays:   [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	},
+	{
+		Tag:         "pt",
+		months:      [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		shortMonths: [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+		days:        [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		shortDays:   [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+	},
+	{
+		Tag:         "it",
+		months:      [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		shortMonths: [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		days:        [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		shortDays:   [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+	},
+	{
+		Tag:         "nl",
+		months:      [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		shortMonths: [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		days:        [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		shortDays:   [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+	},
+}
+
+// English is the default locale.
+var English = locales[0]
+
+// Tags lists the supported language codes.
+func Tags() []string {
+	tags := make([]string, len(locales))
+	for i, l := range locales {
+		tags[i] = l.Tag
+	}
+	return tags
+}
+
+// All returns every supported locale, English first.
+func All() []Locale {
+	return slices.Clone(locales)
+}
+
+// Parse finds the locale for name, a language code optionally followed by a
+// region and encoding as in LANG, such as "de", "pt-BR", or "fr_FR.UTF-8".
+// An empty name, "C", and "POSIX" are English.
+func Parse(name string) (Locale, error) {
+	lang, _, _ := strings.Cut(name, ".")
+	lang, _, _ = strings.Cut(lang, "_")
+	lang, _, _ = strings.Cut(lang, "-")
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if lang == "" || lang == "c" || lang == "posix" {
+		return English, nil
+	}
+	for _, l := range locales {
+		if l.Tag == lang {
+			return l, nil
+		}
+	}
+	return Locale{}, fmt.Errorf("unsupported locale %q (expected one of %s)", name, strings.Join(Tags(), ", "))
+}
+
+// nameTokens are the layout elements Format translates, longest first so
+// "Monday" is not read as "Mon" followed by "day".
+var nameTokens = []string{"January", "Monday", "Jan", "Mon"}
+
+// Format is time.Format with day and month names in l. The names never pass
+// through time.Format, so ones such as "Montag" are not read as layout
+// elements.
+func (l Locale) Format(t time.Time, layout string) string {
+	if l.Tag == "" {
+		l = English
+	}
+	var b strings.Builder
+	for layout != "" {
+		at, token := len(layout), ""
+		for _, candidate := range nameTokens {
+			if i := strings.Index(layout, candidate); i >= 0 && (i < at || i == at && len(candidate) > len(token)) {
+				at, token = i, candidate
+			}
+		}
+		b.WriteString(t.Format(layout[:at]))
+		if token == "" {
+			break
+		}
+		b.WriteString(l.name(t, token))
+		layout = layout[at+len(token):]
+	}
+	return b.String()
+}
+
+func (l Locale) name(t time.Time, token string) string {
+	switch token {
+	case "January":
+		return l.months[t.Month()-1]
+	case "Jan":
+		return l.shortMonths[t.Month()-1]
+	case "Monday":
+		return l.days[t.Weekday()]
+	default:
+		return l.shortDays[t.Weekday()]
+	}
+}
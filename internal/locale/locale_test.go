@@ -0,0 +1,51 @@
+package locale
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFormatTranslatesNamesOnly(t *testing.T) {
+	day := time.Date(2025, time.August, 4, 9, 5, 0, 0, time.UTC) // a Monday
+
+	de, err := Parse("de_DE.UTF-8")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got := de.Format(day, "Monday, 02 January 2006"); got != "Montag, 04 August 2025" {
+		t.Fatalf("de long = %q", got)
+	}
+	// "Mo" and "Montag" must not be read as layout elements themselves.
+	if got := de.Format(day, "Mon 02 Jan 15:04"); got != "Mo 04 Aug 09:05" {
+		t.Fatalf("de short = %q", got)
+	}
+
+	ms, err := Parse("ms")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got := ms.Format(day, "Monday, 02 January 2006"); got != "Isnin, 04 Ogos 2025" {
+		t.Fatalf("ms long = %q", got)
+	}
+	if got := (Locale{}).Format(day, "Monday, 02 January 2006"); got != day.Format("Monday, 02 January 2006") {
+		t.Fatalf("zero locale = %q, want English", got)
+	}
+}
+
+func TestParseAcceptsLangStyleNames(t *testing.T) {
+	for _, name := range []string{"", "C", "en", "en_US.UTF-8"} {
+		if l, err := Parse(name); err != nil || l.Tag != "en" {
+			t.Fatalf("Parse(%q) = %q, %v; want en", name, l.Tag, err)
+		}
+	}
+	if l, err := Parse("pt-BR"); err != nil || l.Tag != "pt" {
+		t.Fatalf("Parse(pt-BR) = %q, %v", l.Tag, err)
+	}
+	if _, err := Parse("tlh"); err == nil {
+		t.Fatalf("expected an unsupported locale error")
+	}
+	if tags := Tags(); tags[0] != "en" || !slices.Contains(tags, "ms") {
+		t.Fatalf("Tags = %v", tags)
+	}
+}
@@ -0,0 +1,70 @@
+package logbook
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// AuthorPrefix marks the author token: ~faiz credits an entry to faiz in a
+// shared logbook. It is written between the text and the tags.
+const AuthorPrefix = "~"
+
+// authorToken returns the name in a ~name token. Names start with a letter
+// so duration notes such as ~1h30m stay part of the text.
+func authorToken(token string) (string, bool) {
+	name, ok := strings.CutPrefix(token, AuthorPrefix)
+	return name, ok && ValidAuthor(name)
+}
+
+// ValidAuthor reports whether name can be written as a ~name token: a letter
+// followed by letters, digits, dots, hyphens, or underscores.
+func ValidAuthor(name string) bool {
+	for i, r := range name {
+		switch {
+		case unicode.IsLetter(r):
+		case i > 0 && (unicode.IsDigit(r) || r == '.' || r == '-' || r == '_'):
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
+
+// ParseAuthor reads an author name, with or without the leading ~.
+func ParseAuthor(value string) (string, error) {
+	name := strings.TrimPrefix(strings.TrimSpace(value), AuthorPrefix)
+	if !ValidAuthor(name) {
+		return "", fmt.Errorf("invalid author %q (expected a name such as faiz, starting with a letter)", value)
+	}
+	return name, nil
+}
+
+// SplitAuthor removes the first ~name token from text and returns the name.
+func SplitAuthor(text string) (string, string) {
+	if !strings.Contains(text, AuthorPrefix) {
+		return text, ""
+	}
+	var (
+		words  []string
+		author string
+	)
+	for _, word := range strings.Fields(text) {
+		if name, ok := authorToken(word); ok && author == "" {
+			author = name
+			continue
+		}
+		words = append(words, word)
+	}
+	if author == "" {
+		return text, ""
+	}
+	return strings.Join(words, " "), author
+}
+
+// MatchesAuthor reports whether entry was written by author, ignoring case
+// and a leading ~. An empty author matches every entry.
+func MatchesAuthor(entry Entry, author string) bool {
+	author = strings.TrimPrefix(author, AuthorPrefix)
+	return author == "" || strings.EqualFold(entry.Author, author)
+}
@@ -0,0 +1,60 @@
+package logbook
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/files"
+)
+
+func TestParseEntryLineReadsAuthor(t *testing.T) {
+	date := time.Date(2025, time.November, 6, 0, 0, 0, 0, time.UTC)
+	line := "- [x] [09:00] Paired on ~1h30m migration ~faiz #infra"
+
+	entry, ok := parseEntryLine(line, date)
+	if !ok {
+		t.Fatalf("parseEntryLine(%q) failed", line)
+	}
+	if entry.Author != "faiz" || entry.Text != "Paired on ~1h30m migration" {
+		t.Fatalf("entry = %+v; want author faiz and the duration kept in the text", entry)
+	}
+	if got := formatEntry(entry); got != line {
+		t.Fatalf("formatEntry = %q, want %q", got, line)
+	}
+}
+
+func TestParseTokensReadsAuthor(t *testing.T) {
+	base := time.Date(2025, time.November, 6, 0, 0, 0, 0, time.UTC)
+	got, err := ParseTokens("Review PR ~aina #review", base)
+	if err != nil || got.Author != "aina" || got.Text != "Review PR" {
+		t.Fatalf("ParseTokens = %+v, %v", got, err)
+	}
+	if _, err := ParseAuthor("~9lives"); err == nil {
+		t.Fatalf("expected an author starting with a digit to be rejected")
+	}
+}
+
+func TestStampAuthorCreditsUncreditedEntries(t *testing.T) {
+	ctx := context.Background()
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	day := time.Date(2025, time.November, 6, 0, 0, 0, 0, time.UTC)
+	writer := NewWriter(mgr).StampAuthor("faiz")
+	if err := writer.AppendBatch(ctx, []Entry{
+		{Status: StatusDone, Time: day.Add(9 * time.Hour), Text: "Mine"},
+		{Status: StatusDone, Time: day.Add(10 * time.Hour), Text: "Theirs", Author: "aina"},
+	}); err != nil {
+		t.Fatalf("AppendBatch: %v", err)
+	}
+	data, _ := os.ReadFile(mgr.MonthPath(day))
+	for _, line := range []string{"- [x] [09:00] Mine ~faiz", "- [x] [10:00] Theirs ~aina"} {
+		if !strings.Contains(string(data), line) {
+			t.Fatalf("month missing %q:\n%s", line, data)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package logbook
+
+import (
+	"io/fs"
+	"slices"
+	"sync"
+	"time"
+)
+
+// monthCache holds parsed month files keyed by path. An entry is valid while
+// the file keeps the modification time and size it had when parsed, so edits
+// made outside kerja are picked up; Writer and Journal also drop the paths
+// they rewrite in case the clock cannot tell two quick writes apart. Only the
+// most recently used limit months are kept, so scanning years of history
+// does not hold them all in memory.
+type monthCache struct {
+	mu      sync.Mutex
+	limit   int
+	entries map[string]cachedMonth
+	// recent lists the cached paths, least recently used first.
+	recent []string
+}
+
+type cachedMonth struct {
+	modTime  time.Time
+	size     int64
+	sections []DateSection
+}
+
+// parsedMonthsLimit covers the months a session moves between, such as a
+// week that straddles two months and the days either side.
+const parsedMonthsLimit = 6
+
+// parsedMonths is shared by every Reader so a Writer's invalidation reaches
+// them all.
+var parsedMonths = newMonthCache(parsedMonthsLimit)
+
+func newMonthCache(limit int) *monthCache {
+	return &monthCache{limit: limit, entries: make(map[string]cachedMonth)}
+}
+
+// get returns the cached sections for path when info still matches. The
+// result is shared; callers must clone what they hand out.
+func (c *monthCache) get(path string, info fs.FileInfo) ([]DateSection, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.entries[path]
+	if !ok || !cached.modTime.Equal(info.ModTime()) || cached.size != info.Size() {
+		return nil, false
+	}
+	c.touch(path)
+	return cached.sections, true
+}
+
+func (c *monthCache) put(path string, info fs.FileInfo, sections []DateSection) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[path] = cachedMonth{modTime: info.ModTime(), size: info.Size(), sections: sections}
+	c.touch(path)
+	for len(c.recent) > c.limit {
+		delete(c.entries, c.recent[0])
+		c.recent = c.recent[1:]
+	}
+}
+
+func (c *monthCache) invalidate(paths ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, path := range paths {
+		delete(c.entries, path)
+		c.recent = slices.DeleteFunc(c.recent, func(p string) bool { return p == path })
+	}
+}
+
+// touch marks path as the most recently used.
+func (c *monthCache) touch(path string) {
+	c.recent = slices.DeleteFunc(c.recent, func(p string) bool { return p == path })
+	c.recent = append(c.recent, path)
+}
+
+// cloneSection copies section deeply enough that callers may edit its
+// entries without touching the cache.
+func cloneSection(section DateSection) DateSection {
+	section.Entries = slices.Clone(section.Entries)
+	for i := range section.Entries {
+		entry := &section.Entries[i]
+		entry.Tags = slices.Clone(entry.Tags)
+		entry.Links = slices.Clone(entry.Links)
+		entry.After = slices.Clone(entry.After)
+		entry.Notes = slices.Clone(entry.Notes)
+	}
+	return section
+}
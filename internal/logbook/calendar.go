@@ -0,0 +1,88 @@
+package logbook
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Calendar knows which days are not worked: weekend days and holidays. The
+// zero Calendar treats every day as a workday.
+type Calendar struct {
+	weekend [7]bool
+	// holidays holds dates as YYYY-MM-DD and yearly ones as MM-DD.
+	holidays map[string]bool
+}
+
+// ParseCalendar builds a calendar from a comma-separated list of weekend
+// days such as "sat,sun" and a comma-separated list of holidays, each a
+// YYYY-MM-DD date or an MM-DD date that recurs yearly. Empty lists mark no
+// days off.
+func ParseCalendar(weekend, holidays string) (Calendar, error) {
+	var c Calendar
+	for _, day := range splitList(weekend) {
+		weekday, ok := parseWeekday(day)
+		if !ok {
+			return Calendar{}, fmt.Errorf("unknown weekday %q (expected mon through sun)", day)
+		}
+		c.weekend[weekday] = true
+	}
+	for _, day := range splitList(holidays) {
+		if _, err := time.Parse("2006-01-02", day); err != nil {
+			if _, err := time.Parse("01-02", day); err != nil {
+				return Calendar{}, fmt.Errorf("invalid holiday %q (expected YYYY-MM-DD or MM-DD)", day)
+			}
+		}
+		if c.holidays == nil {
+			c.holidays = make(map[string]bool)
+		}
+		c.holidays[day] = true
+	}
+	return c, nil
+}
+
+// Weekend reports whether day falls on a weekend day.
+func (c Calendar) Weekend(day time.Time) bool {
+	return c.weekend[day.Weekday()]
+}
+
+// Holiday reports whether day is a listed holiday.
+func (c Calendar) Holiday(day time.Time) bool {
+	return c.holidays[day.Format("2006-01-02")] || c.holidays[day.Format("01-02")]
+}
+
+// Workday reports whether day is neither a weekend day nor a holiday.
+func (c Calendar) Workday(day time.Time) bool {
+	return !c.Weekend(day) && !c.Holiday(day)
+}
+
+// NextWorkday returns the first workday after day. A calendar without any
+// workday in the following year falls back to the next day.
+func (c Calendar) NextWorkday(day time.Time) time.Time {
+	for next := day.AddDate(0, 0, 1); next.Before(day.AddDate(1, 0, 1)); next = next.AddDate(0, 0, 1) {
+		if c.Workday(next) {
+			return next
+		}
+	}
+	return day.AddDate(0, 0, 1)
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, strings.ToLower(item))
+		}
+	}
+	return items
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,38 @@
+package logbook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendarSkipsWeekendsAndHolidays(t *testing.T) {
+	calendar, err := ParseCalendar("Sat, sun", "2025-11-17, 12-25")
+	if err != nil {
+		t.Fatalf("ParseCalendar: %v", err)
+	}
+	day := func(month time.Month, d int) time.Time { return time.Date(2025, month, d, 0, 0, 0, 0, time.Local) }
+
+	if !calendar.Weekend(day(11, 15)) || calendar.Weekend(day(11, 14)) {
+		t.Fatalf("weekend should be Saturday and Sunday")
+	}
+	if !calendar.Holiday(day(11, 17)) || !calendar.Holiday(time.Date(2031, 12, 25, 0, 0, 0, 0, time.Local)) {
+		t.Fatalf("dated and yearly holidays should be recognised")
+	}
+	if got := calendar.NextWorkday(day(11, 14)); !got.Equal(day(11, 18)) {
+		t.Fatalf("NextWorkday(Fri) = %s, want Tue 2025-11-18", got.Format("2006-01-02"))
+	}
+
+	var zero Calendar
+	if !zero.Workday(day(11, 15)) || !zero.NextWorkday(day(11, 14)).Equal(day(11, 15)) {
+		t.Fatalf("zero calendar should treat every day as a workday")
+	}
+}
+
+func TestParseCalendarRejectsBadValues(t *testing.T) {
+	if _, err := ParseCalendar("sat,funday", ""); err == nil {
+		t.Fatalf("expected error for unknown weekday")
+	}
+	if _, err := ParseCalendar("", "2025-13-01"); err == nil {
+		t.Fatalf("expected error for invalid holiday")
+	}
+}
@@ -0,0 +1,255 @@
+package logbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/files"
+)
+
+// Problem is one integrity issue found in a month file.
+type Problem struct {
+	// Line is 1-based.
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+	// Fixable reports whether Writer.Repair resolves the problem.
+	Fixable bool `json:"fixable"`
+	// Future marks a closed entry dated after now, which Writer.FixFuture
+	// settles instead.
+	Future bool `json:"future,omitempty"`
+}
+
+// scannedItem is an entry with its notes, a fenced code block, or a line
+// the parser skips.
+type scannedItem struct {
+	line  int
+	raw   []string
+	entry *Entry
+	code  bool
+}
+
+type scannedSection struct {
+	date  time.Time
+	line  int
+	items []scannedItem
+}
+
+// scannedMonth is a month file broken into the pieces Check and Repair need.
+type scannedMonth struct {
+	headerLine int
+	// title is the month header as written, in whichever locale.
+	title    string
+	preamble []scannedItem
+	sections []scannedSection
+}
+
+func scanMonth(lines []string, month time.Time) scannedMonth {
+	var (
+		scanned scannedMonth
+		current *scannedSection
+		fence   fence
+		code    *scannedItem
+	)
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if fence.step(trimmed) {
+			// Code blocks are kept whole, blank lines included.
+			items := &scanned.preamble
+			if current != nil {
+				items = &current.items
+			}
+			if code == nil {
+				*items = append(*items, scannedItem{line: i + 1, code: true})
+				code = &(*items)[len(*items)-1]
+			}
+			code.raw = append(code.raw, lines[i])
+			if !fence.open() {
+				code = nil
+			}
+			continue
+		}
+		if trimmed == "" {
+			continue
+		}
+		if date, ok := parseSectionHeading(trimmed); ok {
+			scanned.sections = append(scanned.sections, scannedSection{date: date, line: i + 1})
+			current = &scanned.sections[len(scanned.sections)-1]
+			continue
+		}
+		if current == nil {
+			if scanned.headerLine == 0 && files.IsMonthTitle(trimmed, month) {
+				scanned.headerLine, scanned.title = i+1, trimmed
+				continue
+			}
+			scanned.preamble = append(scanned.preamble, scannedItem{line: i + 1, raw: []string{lines[i]}})
+			continue
+		}
+
+		item := scannedItem{line: i + 1, raw: []string{lines[i]}}
+		if entry, ok := parseEntryLine(trimmed, current.date); ok {
+			for i+1 < len(lines) && isNoteLine(lines[i+1]) {
+				i++
+				fence.step(strings.TrimSpace(lines[i]))
+				entry.Notes = append(entry.Notes, strings.TrimSpace(lines[i]))
+				item.raw = append(item.raw, lines[i])
+			}
+			item.entry = &entry
+		}
+		current.items = append(current.items, item)
+	}
+	return scanned
+}
+
+// checkMonth validates the month file lines for month: the header is present,
+// date headings belong to the month, are sorted, and appear once, and every
+// line under them is an entry, a note, or a Markdown heading.
+func checkMonth(lines []string, month time.Time) []Problem {
+	scanned := scanMonth(lines, month)
+	var problems []Problem
+	if scanned.headerLine == 0 {
+		problems = append(problems, Problem{Line: 1, Message: fmt.Sprintf("missing month header %q", files.MonthTitle(month)), Fixable: true})
+	}
+	for _, item := range scanned.preamble {
+		if strings.HasPrefix(strings.TrimSpace(item.raw[0]), "- [") {
+			problems = append(problems, Problem{Line: item.line, Message: "entry outside a date section"})
+		}
+	}
+
+	firstLine := make(map[string]int)
+	var latest time.Time
+	for _, section := range scanned.sections {
+		day := section.date.Format("2006-01-02")
+		switch {
+		case section.date.Year() != month.Year() || section.date.Month() != month.Month():
+			problems = append(problems, Problem{Line: section.line, Message: fmt.Sprintf("section %s belongs to another month", day)})
+		case firstLine[day] != 0:
+			problems = append(problems, Problem{Line: section.line, Message: fmt.Sprintf("duplicate section %s (first at line %d)", day, firstLine[day]), Fixable: true})
+		case section.date.Before(latest):
+			problems = append(problems, Problem{Line: section.line, Message: fmt.Sprintf("section %s is out of order (after %s)", day, latest.Format("2006-01-02")), Fixable: true})
+		}
+		if firstLine[day] == 0 {
+			firstLine[day] = section.line
+		}
+		if section.date.After(latest) {
+			latest = section.date
+		}
+
+		for _, item := range section.items {
+			trimmed := strings.TrimSpace(item.raw[0])
+			switch {
+			case item.code:
+				// Code blocks may quote anything.
+			case item.entry != nil:
+				if !slices.Equal(item.raw, formatEntryLines(*item.entry)) {
+					problems = append(problems, Problem{Line: item.line, Message: "entry is not in canonical form", Fixable: true})
+				}
+			case strings.HasPrefix(trimmed, "## "):
+				problems = append(problems, Problem{Line: item.line, Message: fmt.Sprintf("unrecognised date heading %q", trimmed)})
+			case strings.HasPrefix(trimmed, "#"):
+				// Other Markdown headings are allowed and ignored.
+			default:
+				problems = append(problems, Problem{Line: item.line, Message: fmt.Sprintf("unparseable line %q", trimmed)})
+			}
+		}
+	}
+	return problems
+}
+
+// repairMonth rewrites lines in canonical form: the header first, then any
+// text that preceded the first section, then sections in date order with
+// duplicates merged and every entry reformatted. Lines the parser does not
+// recognise are kept, in place, within their section.
+func repairMonth(lines []string, month time.Time) []string {
+	scanned := scanMonth(lines, month)
+
+	sections := make([]scannedSection, 0, len(scanned.sections))
+	index := make(map[string]int)
+	for _, section := range scanned.sections {
+		day := section.date.Format("2006-01-02")
+		if i, ok := index[day]; ok {
+			sections[i].items = append(sections[i].items, section.items...)
+			continue
+		}
+		index[day] = len(sections)
+		sections = append(sections, section)
+	}
+	sort.SliceStable(sections, func(i, j int) bool { return sections[i].date.Before(sections[j].date) })
+
+	title := scanned.title
+	if title == "" {
+		title = files.MonthTitle(month)
+	}
+	repaired := []string{title, ""}
+	for _, item := range scanned.preamble {
+		repaired = append(repaired, item.raw...)
+	}
+	if len(scanned.preamble) > 0 {
+		repaired = append(repaired, "")
+	}
+	for i, section := range sections {
+		if i > 0 {
+			repaired = append(repaired, "")
+		}
+		repaired = append(repaired, dateHeading(section.date))
+		for _, item := range section.items {
+			if item.entry != nil {
+				repaired = append(repaired, formatEntryLines(*item.entry)...)
+			} else {
+				repaired = append(repaired, item.raw...)
+			}
+		}
+	}
+	return repaired
+}
+
+// errDailyCheck rejects integrity checks in the daily-note layout, whose
+// notes hold arbitrary content around the kerja section.
+var errDailyCheck = fmt.Errorf("check: %w", files.ErrDailyLayout)
+
+// Check validates the month file containing month, including closed entries
+// dated after the current time, and lists its problems in line order.
+func (r *Reader) Check(ctx context.Context, month time.Time) ([]Problem, error) {
+	if r == nil || r.manager == nil {
+		return nil, errors.New("reader not initialized with file manager")
+	}
+	if r.manager.Daily() {
+		return nil, errDailyCheck
+	}
+	data, err := r.manager.ReadFile(r.manager.MonthPath(month))
+	if err != nil {
+		return nil, err
+	}
+	lines := splitLines(string(data))
+	now := clockNow().In(month.Location())
+	wall := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), 0, 0, time.UTC)
+	problems := append(checkMonth(lines, month), checkFuture(lines, month, wall)...)
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Line < problems[j].Line })
+	return problems, nil
+}
+
+// Repair normalizes the month file containing month (see Check) and reports
+// whether anything changed. The rewrite is journaled so it can be undone.
+func (w *Writer) Repair(ctx context.Context, month time.Time) (bool, error) {
+	if w == nil || w.manager == nil {
+		return false, fmt.Errorf("writer not initialized with file manager")
+	}
+	if w.manager.Daily() {
+		return false, errDailyCheck
+	}
+	path := w.manager.MonthPath(month)
+	data, err := w.manager.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	lines := splitLines(string(data))
+	repaired := repairMonth(lines, month)
+	if slices.Equal(lines, repaired) {
+		return false, nil
+	}
+	return true, w.commit(fmt.Sprintf("repair %s", month.Format("2006-01")), monthWrite{path, repaired})
+}
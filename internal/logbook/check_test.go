@@ -0,0 +1,149 @@
+package logbook
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/files"
+)
+
+const brokenMonth = `## 2025-11-05
+- [x] [09:00] Ship release   #ops
+  rollout notes
+- [ ] [25:99] Bad time
+
+## 2025-11-02
+- [X] [10:00] Early entry
+
+## 2025-11-05
+- [ ] [11:00] Second copy
+## 2025-1-7
+`
+
+func TestCheckMonthReportsProblemsWithLines(t *testing.T) {
+	month := time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC)
+	problems := checkMonth(splitLines(brokenMonth), month)
+
+	want := []string{
+		`1: missing month header "# November 2025" (fixable)`,
+		`2: entry is not in canonical form (fixable)`,
+		`4: unparseable line "- [ ] [25:99] Bad time"`,
+		`6: section 2025-11-02 is out of order (after 2025-11-05) (fixable)`,
+		`7: entry is not in canonical form (fixable)`,
+		`9: duplicate section 2025-11-05 (first at line 1) (fixable)`,
+		`11: unrecognised date heading "## 2025-1-7"`,
+	}
+	var got []string
+	for _, problem := range problems {
+		line := fmt.Sprintf("%d: %s", problem.Line, problem.Message)
+		if problem.Fixable {
+			line += " (fixable)"
+		}
+		got = append(got, line)
+	}
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Fatalf("problems =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestRepairMonthNormalizesAndIsIdempotent(t *testing.T) {
+	month := time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC)
+	repaired := repairMonth(splitLines(brokenMonth), month)
+
+	want := []string{
+		"# November 2025",
+		"",
+		"## 2025-11-02",
+		"- [x] [10:00] Early entry",
+		"",
+		"## 2025-11-05",
+		"- [x] [09:00] Ship release #ops",
+		"  rollout notes",
+		"- [ ] [25:99] Bad time",
+		"- [ ] [11:00] Second copy",
+		"## 2025-1-7",
+	}
+	if !slices.Equal(repaired, want) {
+		t.Fatalf("repaired =\n%s", strings.Join(repaired, "\n"))
+	}
+	if again := repairMonth(repaired, month); !slices.Equal(again, repaired) {
+		t.Fatalf("repair is not idempotent:\n%s", strings.Join(again, "\n"))
+	}
+	for _, problem := range checkMonth(repaired, month) {
+		if problem.Fixable {
+			t.Fatalf("fixable problem left after repair: %+v", problem)
+		}
+	}
+}
+
+func TestCheckMonthAcceptsLocalizedTitle(t *testing.T) {
+	month := time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC)
+	lines := []string{"# Ogos 2025", "", "## 2025-08-01", "- [ ] [09:00] Plan"}
+	if problems := checkMonth(lines, month); len(problems) != 0 {
+		t.Fatalf("localized title reported: %+v", problems)
+	}
+	if repaired := repairMonth(lines, month); repaired[0] != "# Ogos 2025" {
+		t.Fatalf("repair should keep the title, got %q", repaired[0])
+	}
+}
+
+func TestWriterRepairJournalsRewrite(t *testing.T) {
+	ctx := context.Background()
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	month := time.Date(2025, time.November, 1, 0, 0, 0, 0, time.Local)
+	path := mgr.MonthPath(month)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(brokenMonth), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	writer := NewWriter(mgr)
+	changed, err := writer.Repair(ctx, month)
+	if err != nil || !changed {
+		t.Fatalf("Repair = %v, %v", changed, err)
+	}
+	if changed, err := writer.Repair(ctx, month); err != nil || changed {
+		t.Fatalf("second Repair = %v, %v", changed, err)
+	}
+	if _, err := writer.Undo(ctx); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != brokenMonth {
+		t.Fatalf("undo did not restore the original: %q, %v", data, err)
+	}
+}
+
+func TestRepairMonthKeepsCodeBlocks(t *testing.T) {
+	month := time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC)
+	lines := []string{
+		"# November 2025",
+		"",
+		"## 2025-11-02",
+		"- [x] [09:00] Ship release",
+		"```sh",
+		"make release",
+		"",
+		"## 2025-11-01",
+		"```",
+	}
+	if problems := checkMonth(lines, month); len(problems) != 0 {
+		t.Fatalf("problems = %+v, want none", problems)
+	}
+	if repaired := repairMonth(lines, month); !slices.Equal(repaired, lines) {
+		t.Fatalf("repaired =\n%s", strings.Join(repaired, "\n"))
+	}
+}
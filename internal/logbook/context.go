@@ -0,0 +1,31 @@
+package logbook
+
+import "strings"
+
+// ParseContext splits a context such as "#work, client" into tags without
+// the leading #. Blank items are dropped.
+func ParseContext(value string) []string {
+	var tags []string
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if tag := strings.TrimPrefix(field, "#"); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// MatchesContext reports whether entry carries any of the context tags,
+// ignoring case. An empty context matches every entry.
+func MatchesContext(entry Entry, context []string) bool {
+	if len(context) == 0 {
+		return true
+	}
+	for _, tag := range entry.Tags {
+		for _, want := range context {
+			if strings.EqualFold(tag, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package logbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+)
+
+// AfterPrefix marks a dependency token: after:2025-11-12#3 makes an entry
+// wait for the third entry of that day. It is written after the ref: links.
+// The Writer adds the fingerprint of the entry it found there, as in
+// after:2025-11-12#3:5d41402a, so the reference survives the entry moving
+// within its day and never silently points at another one.
+const AfterPrefix = "after:"
+
+// afterToken returns the YYYY-MM-DD#N[:fingerprint] reference in an after:
+// token.
+func afterToken(token string) (string, bool) {
+	target, ok := strings.CutPrefix(token, AfterPrefix)
+	if !ok {
+		return "", false
+	}
+	_, _, valid := parseAfter(target)
+	return target, valid
+}
+
+// parseAfter splits an after: reference into the entry it pointed at when
+// written and the fingerprint of that entry, which is empty for references
+// written by hand and not yet pinned.
+func parseAfter(target string) (EntryRef, string, bool) {
+	ref, sum, pinned := strings.Cut(target, ":")
+	if pinned && !isFingerprint(sum) {
+		return EntryRef{}, "", false
+	}
+	parsed, ok := ParseEntryRef(ref)
+	return parsed, sum, ok
+}
+
+// AfterRef returns the YYYY-MM-DD#N part of an after: reference, for display.
+func AfterRef(target string) string {
+	ref, _, _ := strings.Cut(target, ":")
+	return ref
+}
+
+// Fingerprint identifies an entry by its text, so a dependency can tell the
+// entry it was written against from whatever now sits at its position.
+func Fingerprint(entry Entry) string {
+	sum := fnv.New32a()
+	sum.Write([]byte(strings.Join(strings.Fields(entry.Text), " ")))
+	return fmt.Sprintf("%08x", sum.Sum32())
+}
+
+func isFingerprint(sum string) bool {
+	if len(sum) != 8 {
+		return false
+	}
+	for _, r := range sum {
+		if !isDigit(byte(r)) && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// SplitAfter removes after: tokens from text and returns their references.
+// Tokens that do not hold a YYYY-MM-DD#N reference stay part of the text.
+func SplitAfter(text string) (string, []string) {
+	if !strings.Contains(text, AfterPrefix) {
+		return text, nil
+	}
+	var (
+		words []string
+		after []string
+	)
+	for _, word := range strings.Fields(text) {
+		if target, ok := afterToken(word); ok {
+			after = append(after, target)
+			continue
+		}
+		words = append(words, word)
+	}
+	return strings.Join(words, " "), after
+}
+
+// Dependency is an entry that another one waits for, at Ref as the day
+// stands now. Entry is nil when the reference points at no entry, or at one
+// that is not the entry it was written against.
+type Dependency struct {
+	Ref   EntryRef `json:"ref"`
+	Entry *Entry   `json:"entry,omitempty"`
+}
+
+// Done reports whether the dependency no longer holds anything up: its
+// entry exists and is done or cancelled.
+func (d Dependency) Done() bool {
+	return d.Entry != nil && !d.Entry.Status.Open()
+}
+
+// Dependencies looks up the entries that entry waits for, in the order of
+// its after: tokens. A pinned reference whose position now holds another
+// entry follows its fingerprint to wherever the entry went within the day.
+func (r *Reader) Dependencies(ctx context.Context, entry Entry) ([]Dependency, error) {
+	deps := make([]Dependency, 0, len(entry.After))
+	for _, after := range entry.After {
+		ref, sum, ok := parseAfter(after)
+		if !ok {
+			continue
+		}
+		section, err := r.Section(ctx, ref.Date)
+		if err != nil && !errors.Is(err, ErrSectionNotFound) {
+			return nil, err
+		}
+		dep := Dependency{Ref: ref}
+		if index := locate(section.Entries, ref.Index, sum); index > 0 {
+			found := section.Entries[index-1]
+			dep.Ref.Index, dep.Entry = index, &found
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+// locate returns the 1-based position of the entry a reference to index
+// with fingerprint sum means, or 0 when no entry matches.
+func locate(entries []Entry, index int, sum string) int {
+	if index <= len(entries) && (sum == "" || Fingerprint(entries[index-1]) == sum) {
+		return index
+	}
+	if sum == "" {
+		return 0
+	}
+	for i, entry := range entries {
+		if Fingerprint(entry) == sum {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// pinAfter adds the fingerprint of the entry each unpinned after: reference
+// points at, leaving those in keep as they are. A reference to no entry is
+// an error, as it would otherwise wait for whatever is written there later.
+func (w *Writer) pinAfter(ctx context.Context, entry Entry, keep []string) (Entry, error) {
+	if len(entry.After) == 0 {
+		return entry, nil
+	}
+	reader := NewReader(w.manager)
+	pinned := make([]string, len(entry.After))
+	for i, after := range entry.After {
+		ref, sum, ok := parseAfter(after)
+		if !ok || sum != "" || slices.Contains(keep, after) {
+			pinned[i] = after
+			continue
+		}
+		section, err := reader.Section(ctx, ref.Date)
+		if err != nil && !errors.Is(err, ErrSectionNotFound) {
+			return Entry{}, err
+		}
+		if ref.Index > len(section.Entries) {
+			return Entry{}, fmt.Errorf("%s%s: no such entry", AfterPrefix, after)
+		}
+		pinned[i] = after + ":" + Fingerprint(section.Entries[ref.Index-1])
+	}
+	entry.After = pinned
+	return entry, nil
+}
+
+// Pending returns the dependencies that are not done yet.
+func Pending(deps []Dependency) []Dependency {
+	var pending []Dependency
+	for _, dep := range deps {
+		if !dep.Done() {
+			pending = append(pending, dep)
+		}
+	}
+	return pending
+}
+
+// Blocked reports whether entry is still open and waits for at least one
+// entry that is not done yet.
+func (r *Reader) Blocked(ctx context.Context, entry Entry) (bool, error) {
+	if !entry.Status.Open() || len(entry.After) == 0 {
+		return false, nil
+	}
+	deps, err := r.Dependencies(ctx, entry)
+	if err != nil {
+		return false, err
+	}
+	return len(Pending(deps)) > 0, nil
+}
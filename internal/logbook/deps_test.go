@@ -0,0 +1,116 @@
+package logbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/files"
+)
+
+func TestParseEntryLineReadsAfter(t *testing.T) {
+	date := time.Date(2025, time.November, 11, 0, 0, 0, 0, time.UTC)
+	line := "- [ ] [09:00] Deploy ref:https://ci.example/1 after:2025-11-10#1 ~faiz #ops"
+
+	entry, ok := parseEntryLine(line, date)
+	if !ok {
+		t.Fatalf("parseEntryLine(%q) failed", line)
+	}
+	if len(entry.After) != 1 || entry.After[0] != "2025-11-10#1" || entry.Text != "Deploy" {
+		t.Fatalf("entry = %+v; want one dependency", entry)
+	}
+	if got := formatEntry(entry); got != line {
+		t.Fatalf("formatEntry = %q, want %q", got, line)
+	}
+
+	got, err := ParseTokens("Announce after:2025-11-10#2 after:2025-11-10#3:0badf00d after:2025-11-10#4:nope after:lunch", date)
+	if err != nil || got.Text != "Announce after:2025-11-10#4:nope after:lunch" || len(got.After) != 2 {
+		t.Fatalf("ParseTokens = %+v, %v", got, err)
+	}
+}
+
+func TestReaderBlockedUntilDependenciesAreDone(t *testing.T) {
+	ctx := context.Background()
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	day := time.Date(2025, time.November, 10, 0, 0, 0, 0, time.Local)
+	writer := NewWriter(mgr)
+	if err := writer.AppendBatch(ctx, []Entry{
+		{Status: StatusTodo, Time: day.Add(9 * time.Hour), Text: "Ship build"},
+		{Status: StatusCancelled, Time: day.Add(10 * time.Hour), Text: "Dropped"},
+	}); err != nil {
+		t.Fatalf("AppendBatch: %v", err)
+	}
+
+	reader := NewReader(mgr)
+	entry := Entry{Status: StatusTodo, After: []string{"2025-11-10#1", "2025-11-10#2"}}
+	if blocked, err := reader.Blocked(ctx, entry); err != nil || !blocked {
+		t.Fatalf("Blocked = %v, %v; want blocked by the open todo", blocked, err)
+	}
+	if _, err := writer.Toggle(ctx, day, 1); err != nil {
+		t.Fatalf("Toggle: %v", err)
+	}
+	if _, err := writer.Toggle(ctx, day, 1); err != nil {
+		t.Fatalf("Toggle: %v", err)
+	}
+	if blocked, err := reader.Blocked(ctx, entry); err != nil || blocked {
+		t.Fatalf("Blocked = %v, %v; want unblocked once done", blocked, err)
+	}
+
+	entry.After = []string{"2025-10-01#1"}
+	deps, err := reader.Dependencies(ctx, entry)
+	if err != nil || len(deps) != 1 || deps[0].Entry != nil || deps[0].Done() {
+		t.Fatalf("Dependencies = %+v, %v; want one missing entry", deps, err)
+	}
+}
+
+func TestWriterPinsAfterToTheEntryItPointsAt(t *testing.T) {
+	ctx := context.Background()
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	day := time.Date(2025, time.November, 10, 0, 0, 0, 0, time.Local)
+	next := day.AddDate(0, 0, 1)
+	writer := NewWriter(mgr).SortByTime(true)
+	if err := writer.Append(ctx, day, Entry{Status: StatusTodo, Time: day.Add(9 * time.Hour), Text: "Deploy"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := writer.Append(ctx, next, Entry{Status: StatusTodo, Time: next.Add(9 * time.Hour), Text: "Announce", After: []string{"2025-11-10#1"}}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := writer.Append(ctx, next, Entry{Text: "Nothing", After: []string{"2025-11-10#5"}}); err == nil {
+		t.Fatalf("expected a reference to no entry to be refused")
+	}
+
+	reader := NewReader(mgr)
+	section, err := reader.Section(ctx, next)
+	if err != nil {
+		t.Fatalf("Section: %v", err)
+	}
+	announce := section.Entries[0]
+	if want := "2025-11-10#1:" + Fingerprint(Entry{Text: "Deploy"}); len(announce.After) != 1 || announce.After[0] != want {
+		t.Fatalf("After = %q, want [%q]", announce.After, want)
+	}
+
+	// An earlier entry sorted in front moves Deploy to #2.
+	if err := writer.Append(ctx, day, Entry{Status: StatusDone, Time: day.Add(8 * time.Hour), Text: "Coffee"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	deps, err := reader.Dependencies(ctx, announce)
+	if err != nil || len(deps) != 1 || deps[0].Entry == nil || deps[0].Entry.Text != "Deploy" || deps[0].Ref.Index != 2 {
+		t.Fatalf("Dependencies = %+v, %v; want Deploy at #2", deps, err)
+	}
+
+	// Once Deploy is gone, Coffee does not take its place.
+	if _, err := writer.Delete(ctx, day, 2); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	announce.After = []string{"2025-11-10#1:" + Fingerprint(Entry{Text: "Deploy"})}
+	deps, err = reader.Dependencies(ctx, announce)
+	if err != nil || len(deps) != 1 || deps[0].Entry != nil {
+		t.Fatalf("Dependencies = %+v, %v; want a missing entry", deps, err)
+	}
+}
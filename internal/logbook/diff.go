@@ -0,0 +1,146 @@
+package logbook
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+type diffLine struct {
+	kind byte // ' ', '-', or '+'
+	text string
+}
+
+// Diff renders a unified diff from before to after, labelled with name. Each
+// hunk header names the day section the change falls in, the way git names
+// the enclosing function. It returns "" when the lines are identical.
+func Diff(name string, before, after []string) string {
+	lines := diffLines(before, after)
+	var changes []int
+	for i, line := range lines {
+		if line.kind != ' ' {
+			changes = append(changes, i)
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", name, name)
+	for start := 0; start < len(changes); {
+		// Changes closer than twice the context share a hunk.
+		end := start
+		for end+1 < len(changes) && changes[end+1]-changes[end] <= 2*diffContext+1 {
+			end++
+		}
+		writeHunk(&b, lines, before, max(changes[start]-diffContext, 0), min(changes[end]+diffContext+1, len(lines)))
+		start = end + 1
+	}
+	return b.String()
+}
+
+// writeHunk writes lines[from:to] with its header.
+func writeHunk(b *strings.Builder, lines []diffLine, before []string, from, to int) {
+	oldStart, newStart := 0, 0
+	for _, line := range lines[:from] {
+		if line.kind != '+' {
+			oldStart++
+		}
+		if line.kind != '-' {
+			newStart++
+		}
+	}
+	oldCount, newCount := 0, 0
+	for _, line := range lines[from:to] {
+		if line.kind != '+' {
+			oldCount++
+		}
+		if line.kind != '-' {
+			newCount++
+		}
+	}
+
+	header := fmt.Sprintf("@@ -%s +%s @@", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+	for i := oldStart - 1; i >= 0; i-- {
+		if strings.HasPrefix(before[i], "## ") {
+			header += " " + before[i]
+			break
+		}
+	}
+	b.WriteString(header + "\n")
+	for _, line := range lines[from:to] {
+		b.WriteByte(line.kind)
+		b.WriteString(line.text)
+		b.WriteByte('\n')
+	}
+}
+
+// hunkRange formats a 0-based start and a line count as "start,count" with
+// a 1-based start; empty ranges point at the line before them.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// diffLines aligns before and after by their longest common subsequence.
+// Month files are small, so the quadratic table is fine once the shared
+// prefix and suffix are set aside.
+func diffLines(before, after []string) []diffLine {
+	prefix := 0
+	for prefix < len(before) && prefix < len(after) && before[prefix] == after[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(before)-prefix && suffix < len(after)-prefix &&
+		before[len(before)-1-suffix] == after[len(after)-1-suffix] {
+		suffix++
+	}
+	a, c := before[prefix:len(before)-suffix], after[prefix:len(after)-suffix]
+
+	// common[i][j] is the LCS length of a[i:] and c[j:].
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(c)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(c) - 1; j >= 0; j-- {
+			if a[i] == c[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]diffLine, 0, len(before)+len(c))
+	for _, text := range before[:prefix] {
+		lines = append(lines, diffLine{' ', text})
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(c) {
+		switch {
+		case i < len(a) && j < len(c) && a[i] == c[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(c) || common[i+1][j] >= common[i][j+1]):
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', c[j]})
+			j++
+		}
+	}
+	for _, text := range before[len(before)-suffix:] {
+		lines = append(lines, diffLine{' ', text})
+	}
+	return lines
+}
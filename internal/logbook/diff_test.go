@@ -0,0 +1,104 @@
+package logbook
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/files"
+)
+
+func TestDiffNamesTheDayOfEachHunk(t *testing.T) {
+	before := []string{
+		"# 2025-11",
+		"",
+		"## 2025-11-03",
+		"- [x] [09:00] Ship release",
+		"",
+		"## 2025-11-04",
+		"- [ ] [09:00] Draft plan",
+		"- [ ] [10:00] Review PR",
+	}
+	after := []string{
+		"# 2025-11",
+		"",
+		"## 2025-11-03",
+		"- [x] [09:00] Ship release",
+		"",
+		"## 2025-11-04",
+		"- [x] [09:00] Draft plan",
+		"- [ ] [10:00] Review PR",
+		"- [ ] [11:00] Lunch",
+	}
+
+	want := strings.Join([]string{
+		"--- a/2025/2025-11.md",
+		"+++ b/2025/2025-11.md",
+		"@@ -4,5 +4,6 @@ ## 2025-11-03",
+		" - [x] [09:00] Ship release",
+		" ",
+		" ## 2025-11-04",
+		"-- [ ] [09:00] Draft plan",
+		"+- [x] [09:00] Draft plan",
+		" - [ ] [10:00] Review PR",
+		"+- [ ] [11:00] Lunch",
+		"",
+	}, "\n")
+	if got := Diff("2025/2025-11.md", before, after); got != want {
+		t.Fatalf("Diff =\n%s\nwant\n%s", got, want)
+	}
+	if got := Diff("same.md", before, before); got != "" {
+		t.Fatalf("Diff of identical lines = %q, want empty", got)
+	}
+}
+
+func TestDiffSplitsDistantChangesIntoHunks(t *testing.T) {
+	var before []string
+	for i := 1; i <= 20; i++ {
+		before = append(before, strings.Repeat("x", i))
+	}
+	after := append([]string{}, before...)
+	after[1] = "changed"
+	after[19] = "changed"
+	after = append(after[:10], after[11:]...)
+
+	got := Diff("f", before, after)
+	if n := strings.Count(got, "\n@@ "); n != 3 {
+		t.Fatalf("expected 3 hunks, got %d:\n%s", n, got)
+	}
+	for _, header := range []string{"@@ -1,5 +1,5 @@", "@@ -8,7 +8,6 @@", "@@ -17,4 +16,4 @@"} {
+		if !strings.Contains(got, header+"\n") {
+			t.Fatalf("missing %q in\n%s", header, got)
+		}
+	}
+}
+
+func TestWriterOnWriteReportsChangesAndUndo(t *testing.T) {
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	var diffs []string
+	writer := NewWriter(mgr).OnWrite(func(path string, before, after []string) {
+		diffs = append(diffs, Diff("month", before, after))
+	})
+	ctx := context.Background()
+	date := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.UTC)
+	if err := writer.Append(ctx, date, Entry{Status: StatusTodo, Time: date.Add(9 * time.Hour), Text: "Draft plan"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if _, err := writer.Undo(ctx); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+
+	if len(diffs) != 2 {
+		t.Fatalf("expected 2 reported writes, got %d", len(diffs))
+	}
+	if !strings.Contains(diffs[0], "+- [ ] [09:00] Draft plan\n") {
+		t.Fatalf("append diff missing added line:\n%s", diffs[0])
+	}
+	if !strings.Contains(diffs[1], "-- [ ] [09:00] Draft plan\n") {
+		t.Fatalf("undo diff missing removed line:\n%s", diffs[1])
+	}
+}
@@ -0,0 +1,289 @@
+package logbook
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// document is a month file (or daily note) parsed for editing. Lines before
+// the first "## " heading form the preamble; each heading starts a section
+// whose lines are split into blocks: an entry with its notes, a fenced code
+// block, or a single line the parser does not recognise (blank lines,
+// comments, paragraphs, sub-headings). Rendering reproduces the original
+// lines exactly, except for the entry lines of blocks that were replaced or
+// added.
+type document struct {
+	preamble []string
+	sections []*docSection
+}
+
+// docSection is one "## " heading and the blocks beneath it. Headings that
+// are not dates (such as "## Notes" in a daily note) are kept as sections
+// with dated false and are never edited.
+type docSection struct {
+	heading string
+	date    time.Time
+	dated   bool
+	blocks  []*docBlock
+}
+
+// docBlock holds the raw lines it was parsed from. entry is nil for lines
+// that are not entries; dirty marks an entry that must be re-rendered. A
+// code block is one docBlock, so moving and sorting entries never split it.
+type docBlock struct {
+	raw   []string
+	entry *Entry
+	dirty bool
+}
+
+// parseDocument splits lines into a document. Section dates and entry times
+// are anchored in loc.
+func parseDocument(lines []string, loc *time.Location) *document {
+	doc := &document{}
+	var (
+		current *docSection
+		fence   fence
+		code    *docBlock
+	)
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if fence.step(trimmed) {
+			switch {
+			case current == nil:
+				doc.preamble = append(doc.preamble, lines[i])
+			case code == nil:
+				code = &docBlock{raw: []string{lines[i]}}
+				current.blocks = append(current.blocks, code)
+			default:
+				code.raw = append(code.raw, lines[i])
+			}
+			if !fence.open() {
+				code = nil
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "## ") {
+			current = &docSection{heading: lines[i]}
+			if date, ok := parseSectionHeading(trimmed); ok {
+				current.date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+				current.dated = true
+			}
+			doc.sections = append(doc.sections, current)
+			continue
+		}
+		if current == nil {
+			doc.preamble = append(doc.preamble, lines[i])
+			continue
+		}
+
+		block := &docBlock{raw: []string{lines[i]}}
+		if current.dated {
+			if entry, ok := parseEntryLine(trimmed, current.date); ok {
+				for i+1 < len(lines) && isNoteLine(lines[i+1]) {
+					i++
+					fence.step(strings.TrimSpace(lines[i]))
+					entry.Notes = append(entry.Notes, strings.TrimSpace(lines[i]))
+					block.raw = append(block.raw, lines[i])
+				}
+				block.entry = &entry
+			}
+		}
+		current.blocks = append(current.blocks, block)
+	}
+	return doc
+}
+
+// lines renders the document back to lines.
+func (d *document) lines() []string {
+	lines := slices.Clone(d.preamble)
+	for _, section := range d.sections {
+		lines = append(lines, section.heading)
+		for _, block := range section.blocks {
+			lines = append(lines, block.render()...)
+		}
+	}
+	return lines
+}
+
+// section returns the first section headed with date, or nil.
+func (d *document) section(date time.Time) *docSection {
+	for _, section := range d.sections {
+		if section.dated && sameDay(section.date, date) {
+			return section
+		}
+	}
+	return nil
+}
+
+// ensureSection returns the section for date, adding its heading at the end
+// of the document, after a blank line, when it is missing.
+func (d *document) ensureSection(date time.Time) *docSection {
+	if section := d.section(date); section != nil {
+		return section
+	}
+	if needsSeparation(d.lines()) {
+		if len(d.sections) == 0 {
+			d.preamble = append(d.preamble, "")
+		} else {
+			last := d.sections[len(d.sections)-1]
+			last.blocks = append(last.blocks, &docBlock{raw: []string{""}})
+		}
+	}
+	section := &docSection{
+		heading: dateHeading(date),
+		date:    time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location()),
+		dated:   true,
+	}
+	d.sections = append(d.sections, section)
+	return section
+}
+
+// sortSections orders the dated sections chronologically. Undated sections
+// travel with the dated section above them, and every section but the last
+// is left ending in a blank line so headings stay separated.
+func (d *document) sortSections() {
+	type group struct {
+		date     time.Time
+		sections []*docSection
+	}
+	var groups []group
+	for _, section := range d.sections {
+		if section.dated || len(groups) == 0 {
+			groups = append(groups, group{date: section.date})
+		}
+		last := &groups[len(groups)-1]
+		last.sections = append(last.sections, section)
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].date.Before(groups[j].date) })
+
+	d.sections = d.sections[:0]
+	for _, group := range groups {
+		d.sections = append(d.sections, group.sections...)
+	}
+	for _, section := range d.sections[:max(len(d.sections)-1, 0)] {
+		if n := len(section.blocks); n == 0 || !section.blocks[n-1].blank() {
+			section.blocks = append(section.blocks, &docBlock{raw: []string{""}})
+		}
+	}
+}
+
+// entryBlocks returns the section's entry blocks in order; the i-th block
+// holds entry i+1 as the CLI numbers them.
+func (s *docSection) entryBlocks() []*docBlock {
+	var blocks []*docBlock
+	for _, block := range s.blocks {
+		if block.entry != nil {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// entries returns copies of the section's entries.
+func (s *docSection) entries() []Entry {
+	var entries []Entry
+	for _, block := range s.entryBlocks() {
+		entries = append(entries, *block.entry)
+	}
+	return entries
+}
+
+// dateSection returns the section as the reader would.
+func (s *docSection) dateSection() DateSection {
+	return DateSection{Date: s.date, Entries: s.entries()}
+}
+
+// append adds entries after the section's last entry, so paragraphs,
+// sub-headings, and code blocks that follow the list stay below it. A
+// section without entries gets them after its last non-blank line, keeping
+// blank lines separating it from the next heading at its end.
+func (s *docSection) append(entries ...Entry) {
+	at := len(s.blocks)
+	if blocks := s.entryBlocks(); len(blocks) > 0 {
+		at = slices.Index(s.blocks, blocks[len(blocks)-1]) + 1
+	} else {
+		for at > 0 && s.blocks[at-1].blank() {
+			at--
+		}
+	}
+	added := make([]*docBlock, len(entries))
+	for i, entry := range entries {
+		added[i] = newEntryBlock(entry)
+	}
+	s.blocks = slices.Insert(s.blocks, at, added...)
+}
+
+// replace swaps the entry in block for updated.
+func (b *docBlock) replace(updated Entry) {
+	b.entry = &updated
+	b.dirty = true
+}
+
+// remove drops block from the section.
+func (s *docSection) remove(block *docBlock) {
+	s.blocks = slices.DeleteFunc(s.blocks, func(b *docBlock) bool { return b == block })
+}
+
+// moveEntry moves the entry at from (1-based) to position to, carrying its
+// notes along. Lines between entries stay put.
+func (s *docSection) moveEntry(from, to int) {
+	moving := s.entryBlocks()[from-1]
+	s.remove(moving)
+
+	remaining := s.entryBlocks()
+	if to-1 < len(remaining) {
+		at := slices.Index(s.blocks, remaining[to-1])
+		s.blocks = slices.Insert(s.blocks, at, moving)
+		return
+	}
+	at := slices.Index(s.blocks, remaining[len(remaining)-1]) + 1
+	s.blocks = slices.Insert(s.blocks, at, moving)
+}
+
+// sortEntries stably reorders the section's entries by less. Entries fill
+// the positions entries held before, so other lines stay put.
+func (s *docSection) sortEntries(less func(a, b Entry) bool) {
+	blocks := s.entryBlocks()
+	sorted := slices.Clone(blocks)
+	sort.SliceStable(sorted, func(i, j int) bool { return less(*sorted[i].entry, *sorted[j].entry) })
+
+	slot := 0
+	for i, block := range s.blocks {
+		if block.entry != nil {
+			s.blocks[i] = sorted[slot]
+			slot++
+		}
+	}
+}
+
+func newEntryBlock(entry Entry) *docBlock {
+	return &docBlock{entry: &entry, dirty: true}
+}
+
+// render returns the block's lines. A replaced entry keeps its original note
+// lines, nested indentation included, while its notes are unchanged.
+func (b *docBlock) render() []string {
+	if !b.dirty {
+		return b.raw
+	}
+	lines := formatEntryLines(*b.entry)
+	if len(b.raw) > 1 && slices.Equal(b.entry.Notes, noteText(b.raw[1:])) {
+		return append(lines[:1], b.raw[1:]...)
+	}
+	return lines
+}
+
+// noteText trims note lines as the parser does.
+func noteText(raw []string) []string {
+	notes := make([]string, len(raw))
+	for i, line := range raw {
+		notes[i] = strings.TrimSpace(line)
+	}
+	return notes
+}
+
+func (b *docBlock) blank() bool {
+	return b.entry == nil && len(b.raw) == 1 && strings.TrimSpace(b.raw[0]) == ""
+}
@@ -0,0 +1,145 @@
+package logbook
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDocumentRoundTripsUntouchedLines(t *testing.T) {
+	content := strings.TrimLeft(`
+# November
+<!-- kept -->
+
+## 2025-11-02
+- [ ] [09:00] Plan   the week #planning
+  - note with  spacing
+Some prose the parser ignores.
+
+## Notes
+free-form text
+`, "\n")
+
+	lines := splitLines(content)
+	doc := parseDocument(lines, time.UTC)
+	if got := doc.lines(); !slices.Equal(got, lines) {
+		t.Fatalf("round trip changed lines:\n%q\nwant\n%q", got, lines)
+	}
+	if section := doc.section(time.Date(2025, time.November, 2, 0, 0, 0, 0, time.UTC)); section == nil || len(section.entries()) != 1 {
+		t.Fatalf("expected one entry in the dated section, got %+v", section)
+	}
+}
+
+func TestDocumentAppendKeepsSeparatorBeforeNextHeading(t *testing.T) {
+	content := strings.TrimLeft(`
+## 2025-11-02
+- [x] [09:00] First
+
+## 2025-11-03
+- [ ] [10:00] Second
+`, "\n")
+
+	doc := parseDocument(splitLines(content), time.UTC)
+	day := time.Date(2025, time.November, 2, 0, 0, 0, 0, time.UTC)
+	doc.section(day).append(Entry{Status: StatusTodo, Time: day.Add(11 * time.Hour), Text: "Added"})
+
+	want := strings.TrimLeft(`
+## 2025-11-02
+- [x] [09:00] First
+- [ ] [11:00] Added
+
+## 2025-11-03
+- [ ] [10:00] Second
+`, "\n")
+	if got := strings.Join(doc.lines(), "\n") + "\n"; got != want {
+		t.Fatalf("unexpected document:\n%s", got)
+	}
+}
+
+func TestDocumentSortEntriesKeepsOtherLinesInPlace(t *testing.T) {
+	content := strings.TrimLeft(`
+## 2025-11-02
+- [ ] [11:00] Late
+  - late note
+<!-- divider -->
+- [ ] [09:00] Early
+`, "\n")
+
+	doc := parseDocument(splitLines(content), time.UTC)
+	doc.section(time.Date(2025, time.November, 2, 0, 0, 0, 0, time.UTC)).sortEntries(func(a, b Entry) bool {
+		return a.Time.Before(b.Time)
+	})
+
+	want := strings.TrimLeft(`
+## 2025-11-02
+- [ ] [09:00] Early
+<!-- divider -->
+- [ ] [11:00] Late
+  - late note
+`, "\n")
+	if got := strings.Join(doc.lines(), "\n") + "\n"; got != want {
+		t.Fatalf("unexpected document:\n%s", got)
+	}
+}
+
+func TestDocumentSortSections(t *testing.T) {
+	content := strings.TrimLeft(`
+## 2025-11-03
+- [ ] [10:00] Second
+## 2025-11-02
+- [x] [09:00] First
+`, "\n")
+
+	doc := parseDocument(splitLines(content), time.UTC)
+	doc.sortSections()
+
+	want := strings.TrimLeft(`
+## 2025-11-02
+- [x] [09:00] First
+
+## 2025-11-03
+- [ ] [10:00] Second
+`, "\n")
+	if got := strings.Join(doc.lines(), "\n") + "\n"; got != want {
+		t.Fatalf("unexpected document:\n%s", got)
+	}
+}
+
+func TestDocumentKeepsCodeBlocksAndNestedNotes(t *testing.T) {
+	content := strings.TrimLeft(`
+## 2025-11-02
+- [ ] [10:00] Second
+    - nested  note
+`+"```"+`
+
+- [ ] [08:00] Quoted
+`+"```"+`
+- [ ] [09:00] First
+`, "\n")
+
+	doc := parseDocument(splitLines(content), time.UTC)
+	section := doc.section(time.Date(2025, time.November, 2, 0, 0, 0, 0, time.UTC))
+	section.sortEntries(func(a, b Entry) bool { return a.Time.Before(b.Time) })
+	blocks := section.entryBlocks()
+	if len(blocks) != 2 {
+		t.Fatalf("entries = %d, want 2", len(blocks))
+	}
+	updated := *blocks[1].entry
+	updated.Status = StatusDone
+	blocks[1].replace(updated)
+
+	want := strings.TrimLeft(`
+## 2025-11-02
+- [ ] [09:00] First
+`+"```"+`
+
+- [ ] [08:00] Quoted
+`+"```"+`
+- [x] [10:00] Second
+    - nested  note
+`, "\n")
+	if got := strings.Join(doc.lines(), "\n") + "\n"; got != want {
+		t.Fatalf("unexpected document:\n%s", got)
+	}
+}
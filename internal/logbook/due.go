@@ -0,0 +1,60 @@
+package logbook
+
+import (
+	"strings"
+	"time"
+)
+
+// DuePrefix marks the deadline token: due:2025-11-20 gives an entry a due
+// date. It is written between the author and the tags.
+const DuePrefix = "due:"
+
+// dueToken returns the date in a due:YYYY-MM-DD token, at midnight in loc.
+func dueToken(token string, loc *time.Location) (time.Time, bool) {
+	value, ok := strings.CutPrefix(token, DuePrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	due, err := time.ParseInLocation("2006-01-02", value, loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return due, true
+}
+
+// SplitDue removes the first due:YYYY-MM-DD token from text and returns the
+// remaining text with the date, at midnight in loc. Tokens that do not hold
+// a valid date stay part of the text.
+func SplitDue(text string, loc *time.Location) (string, time.Time) {
+	if !strings.Contains(text, DuePrefix) {
+		return text, time.Time{}
+	}
+	var (
+		words []string
+		due   time.Time
+	)
+	for _, word := range strings.Fields(text) {
+		if date, ok := dueToken(word, loc); ok && due.IsZero() {
+			due = date
+			continue
+		}
+		words = append(words, word)
+	}
+	if due.IsZero() {
+		return text, time.Time{}
+	}
+	return strings.Join(words, " "), due
+}
+
+// Overdue reports whether entry is still open past its due date as seen on
+// the day of now.
+func (e Entry) Overdue(now time.Time) bool {
+	if e.Due.IsZero() || !e.Status.Open() {
+		return false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, e.Due.Location())
+	return e.Due.Before(today)
+}
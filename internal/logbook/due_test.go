@@ -0,0 +1,52 @@
+package logbook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseEntryLineReadsDue(t *testing.T) {
+	date := time.Date(2025, time.November, 6, 0, 0, 0, 0, time.UTC)
+	line := "- [ ] [09:00] Send report due:2025-11-20 #ops"
+
+	entry, ok := parseEntryLine(line, date)
+	if !ok {
+		t.Fatalf("parseEntryLine(%q) failed", line)
+	}
+	want := time.Date(2025, time.November, 20, 0, 0, 0, 0, time.UTC)
+	if !entry.Due.Equal(want) || entry.Text != "Send report" {
+		t.Fatalf("entry = %+v; want due 2025-11-20", entry)
+	}
+	if got := formatEntry(entry); got != line {
+		t.Fatalf("formatEntry = %q, want %q", got, line)
+	}
+
+	// A token without a valid date stays part of the text.
+	entry, _ = parseEntryLine("- [ ] [09:00] Ask about due:soon", date)
+	if !entry.Due.IsZero() || entry.Text != "Ask about due:soon" {
+		t.Fatalf("entry = %+v; want the token kept as text", entry)
+	}
+}
+
+func TestParseTokensReadsDue(t *testing.T) {
+	base := time.Date(2025, time.November, 6, 9, 0, 0, 0, time.UTC)
+	got, err := ParseTokens("Ship login due:2025-11-07 #work", base)
+	if err != nil || got.Text != "Ship login" || got.Due.Format("2006-01-02") != "2025-11-07" {
+		t.Fatalf("ParseTokens = %+v, %v", got, err)
+	}
+}
+
+func TestEntryOverdue(t *testing.T) {
+	due := time.Date(2025, time.November, 20, 0, 0, 0, 0, time.UTC)
+	entry := Entry{Status: StatusTodo, Due: due}
+	if entry.Overdue(due.Add(23 * time.Hour)) {
+		t.Fatalf("entry should not be overdue on its due date")
+	}
+	if !entry.Overdue(due.AddDate(0, 0, 1)) {
+		t.Fatalf("entry should be overdue the day after")
+	}
+	entry.Status = StatusDone
+	if entry.Overdue(due.AddDate(0, 0, 1)) {
+		t.Fatalf("done entries are never overdue")
+	}
+}
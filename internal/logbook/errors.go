@@ -7,3 +7,9 @@ var ErrSectionNotFound = errors.New("date section not found")
 
 // ErrInvalidIndex indicates the caller referenced an entry index outside the section bounds.
 var ErrInvalidIndex = errors.New("entry index out of range")
+
+// ErrNothingToUndo is returned when the undo journal has no recorded changes.
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+// ErrSameDate is returned when an entry is moved onto the date it already belongs to.
+var ErrSameDate = errors.New("entry is already on that date")
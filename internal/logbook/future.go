@@ -0,0 +1,161 @@
+package logbook
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// FutureFix is how Writer.FixFuture settles closed entries dated after now.
+type FutureFix int
+
+const (
+	// FutureToday moves them to today, no later than the current time.
+	FutureToday FutureFix = iota + 1
+	// FutureTag tags them FutureTagName so they can be found and fixed by
+	// hand.
+	FutureTag
+)
+
+// FutureTagName is the tag FutureTag adds.
+const FutureTagName = "future"
+
+// ParseFutureFix maps "today" or "tag" to a FutureFix.
+func ParseFutureFix(value string) (FutureFix, error) {
+	switch value {
+	case "today":
+		return FutureToday, nil
+	case "tag":
+		return FutureTag, nil
+	}
+	return 0, fmt.Errorf("invalid future fix %q (expected today or tag)", value)
+}
+
+// closedAfter reports whether entry was closed, done or cancelled, at a time
+// still to come. Open entries dated later are plans and are left alone.
+func closedAfter(entry Entry, now time.Time) bool {
+	return !entry.Status.Open() && entry.Time.After(now)
+}
+
+// checkFuture flags closed entries dated after now, most likely typos in
+// --date or --time: a later section holding them is reported once at its
+// heading, and each one later today at its own line. now is the wall-clock
+// time in the month's zone, expressed in UTC like the scanned dates.
+func checkFuture(lines []string, month, now time.Time) []Problem {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	var problems []Problem
+	for _, section := range scanMonth(lines, month).sections {
+		var closed []scannedItem
+		for _, item := range section.items {
+			if item.entry != nil && closedAfter(*item.entry, now) {
+				closed = append(closed, item)
+			}
+		}
+		if len(closed) == 0 {
+			continue
+		}
+		if section.date.After(today) {
+			problems = append(problems, Problem{
+				Line:    section.line,
+				Message: fmt.Sprintf("section %s is dated after today but holds %d closed %s", section.date.Format("2006-01-02"), len(closed), entryNoun(len(closed))),
+				Future:  true,
+			})
+			continue
+		}
+		for _, item := range closed {
+			problems = append(problems, Problem{
+				Line:    item.line,
+				Message: fmt.Sprintf("entry closed at %s, after the current time %s", item.entry.Time.Format("15:04"), now.Format("15:04")),
+				Future:  true,
+			})
+		}
+	}
+	return problems
+}
+
+// FixFuture settles the closed entries dated after now in the month file
+// containing month, as Check reports them, and returns how many it changed.
+// FutureToday moves entries from later days to today and brings any time
+// after now back to now; FutureTag tags them instead. The change is
+// journaled so it can be undone.
+func (w *Writer) FixFuture(ctx context.Context, month time.Time, fix FutureFix) (int, error) {
+	if w == nil || w.manager == nil {
+		return 0, fmt.Errorf("writer not initialized with file manager")
+	}
+	if w.manager.Daily() {
+		return 0, errDailyCheck
+	}
+	path, doc, err := w.loadMonth(month)
+	if err != nil {
+		return 0, err
+	}
+	now := clockNow().In(month.Location()).Truncate(time.Minute)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	settled := 0
+	var moved []Entry
+	for _, section := range doc.sections {
+		if !section.dated {
+			continue
+		}
+		retimed := false
+		for _, block := range section.entryBlocks() {
+			entry := *block.entry
+			if !closedAfter(entry, now) {
+				continue
+			}
+			settled++
+			switch {
+			case fix == FutureTag:
+				if !slices.Contains(entry.Tags, FutureTagName) {
+					entry.Tags = append(entry.Tags, FutureTagName)
+				}
+				block.replace(entry)
+			case section.date.After(today):
+				section.remove(block)
+				moved = append(moved, clampTo(normalizeEntryTime(today, entry), now))
+			default:
+				block.replace(clampTo(entry, now))
+				retimed = true
+			}
+		}
+		if retimed {
+			w.tidy(section)
+		}
+	}
+	if settled == 0 {
+		return 0, nil
+	}
+
+	op := fmt.Sprintf("settle future entries %s", month.Format("2006-01"))
+	if len(moved) == 0 || w.manager.MonthPath(today) == path {
+		if len(moved) > 0 {
+			w.place(doc.ensureSection(today), moved...)
+		}
+		return settled, w.commit(op, monthWrite{path, doc.lines()})
+	}
+	targetPath, target, err := w.loadMonth(today)
+	if err != nil {
+		return 0, err
+	}
+	w.place(target.ensureSection(today), moved...)
+	// Write today's month first: an interruption leaves a duplicate rather
+	// than losing the entries.
+	return settled, w.commit(op, monthWrite{targetPath, target.lines()}, monthWrite{path, doc.lines()})
+}
+
+// clampTo reschedules entry to now when it starts later.
+func clampTo(entry Entry, now time.Time) Entry {
+	if entry.Time.After(now) {
+		return entry.Reschedule(now)
+	}
+	return entry
+}
+
+func entryNoun(n int) string {
+	if n == 1 {
+		return "entry"
+	}
+	return "entries"
+}
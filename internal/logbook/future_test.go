@@ -0,0 +1,122 @@
+package logbook
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/files"
+)
+
+const futureMonth = `# November 2025
+
+## 2025-11-06
+- [x] [09:00] Morning standup
+- [x] [16:30] Typed the wrong time
+- [ ] [17:00] Planned review
+
+## 2025-11-20
+- [x] [10:00] Typed the wrong date
+- [ ] [11:00] Snoozed todo
+`
+
+func stopClock(t *testing.T, now time.Time) {
+	t.Helper()
+	original := clockNow
+	t.Cleanup(func() { clockNow = original })
+	clockNow = func() time.Time { return now }
+}
+
+func TestCheckFutureFlagsClosedEntriesAfterNow(t *testing.T) {
+	month := time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2025, time.November, 6, 14, 20, 0, 0, time.UTC)
+
+	var got []string
+	for _, problem := range checkFuture(splitLines(futureMonth), month, now) {
+		if !problem.Future || problem.Fixable {
+			t.Fatalf("problem %+v should be a future problem only", problem)
+		}
+		got = append(got, problem.Message)
+	}
+	want := []string{
+		"entry closed at 16:30, after the current time 14:20",
+		"section 2025-11-20 is dated after today but holds 1 closed entry",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("problems =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func writeFutureMonth(t *testing.T) (*files.Manager, string) {
+	t.Helper()
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	path := mgr.MonthPath(time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(futureMonth), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return mgr, path
+}
+
+func TestFixFutureMovesEntriesToNow(t *testing.T) {
+	stopClock(t, time.Date(2025, time.November, 6, 14, 20, 45, 0, time.UTC))
+	ctx := context.Background()
+	mgr, path := writeFutureMonth(t)
+	month := time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC)
+
+	settled, err := NewWriter(mgr).FixFuture(ctx, month, FutureToday)
+	if err != nil || settled != 2 {
+		t.Fatalf("FixFuture = %d, %v; want 2", settled, err)
+	}
+	data, _ := os.ReadFile(path)
+	want := `# November 2025
+
+## 2025-11-06
+- [x] [09:00] Morning standup
+- [x] [14:20] Typed the wrong time
+- [ ] [17:00] Planned review
+- [x] [10:00] Typed the wrong date
+
+## 2025-11-20
+- [ ] [11:00] Snoozed todo
+`
+	if string(data) != want {
+		t.Fatalf("month after FixFuture:\n%s\nwant\n%s", data, want)
+	}
+	if problems, err := NewReader(mgr).Check(ctx, month); err != nil || slices.ContainsFunc(problems, func(p Problem) bool { return p.Future }) {
+		t.Fatalf("Check after FixFuture = %+v, %v", problems, err)
+	}
+}
+
+func TestFixFutureTagsEntries(t *testing.T) {
+	stopClock(t, time.Date(2025, time.November, 6, 14, 20, 0, 0, time.UTC))
+	ctx := context.Background()
+	mgr, path := writeFutureMonth(t)
+	month := time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC)
+
+	writer := NewWriter(mgr)
+	if settled, err := writer.FixFuture(ctx, month, FutureTag); err != nil || settled != 2 {
+		t.Fatalf("FixFuture = %d, %v; want 2", settled, err)
+	}
+	data, _ := os.ReadFile(path)
+	for _, line := range []string{"- [x] [16:30] Typed the wrong time #future", "- [x] [10:00] Typed the wrong date #future"} {
+		if !strings.Contains(string(data), line) {
+			t.Fatalf("month missing %q:\n%s", line, data)
+		}
+	}
+	if _, err := writer.Undo(ctx); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != futureMonth {
+		t.Fatalf("undo did not restore the original:\n%s", data)
+	}
+}
@@ -0,0 +1,220 @@
+package logbook
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// HistoryFile is the append-only changelog beneath the logbook root. Unlike
+// the undo journal it is never pruned.
+const HistoryFile = "history.jsonl"
+
+// ChangeKind names what happened to an entry.
+type ChangeKind string
+
+const (
+	ChangeCreate ChangeKind = "create"
+	ChangeEdit   ChangeKind = "edit"
+	ChangeToggle ChangeKind = "toggle"
+	ChangeDelete ChangeKind = "delete"
+)
+
+// HistoryRecord is one line of the changelog: every entry a single write or
+// undo changed.
+type HistoryRecord struct {
+	Time    time.Time     `json:"time"`
+	Op      string        `json:"op"`
+	Changes []EntryChange `json:"changes"`
+}
+
+// EntryChange is one entry as it was before and after a change. Before is
+// nil for a created entry and After for a deleted one. Index is 1-based
+// within Date: the new position, or the old one for a deletion.
+type EntryChange struct {
+	Kind   ChangeKind `json:"kind"`
+	Date   time.Time  `json:"date"`
+	Index  int        `json:"index"`
+	Before *Entry     `json:"before,omitempty"`
+	After  *Entry     `json:"after,omitempty"`
+}
+
+// History appends to and reads the changelog.
+type History struct {
+	path string
+}
+
+// NewHistory keeps the changelog at root/history.jsonl.
+func NewHistory(root string) *History {
+	return &History{path: filepath.Join(root, HistoryFile)}
+}
+
+// Path returns the changelog's location.
+func (h *History) Path() string {
+	return h.path
+}
+
+func (h *History) append(record HistoryRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(h.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Records returns every record in the changelog, oldest first. A missing
+// changelog has none.
+func (h *History) Records() ([]HistoryRecord, error) {
+	file, err := os.Open(h.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []HistoryRecord
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 16<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var record HistoryRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("read %s line %d: %w", HistoryFile, line, err)
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+// entryChanges compares the entries of every day in a month file before and
+// after a write. Within a day a removed entry followed by an added one is an
+// edit, or a toggle when only the status differs.
+func entryChanges(before, after []string) []EntryChange {
+	old, cur := parseDocument(before, time.Local), parseDocument(after, time.Local)
+	var dates []time.Time
+	for _, doc := range []*document{old, cur} {
+		for _, section := range doc.sections {
+			if section.dated && !slices.ContainsFunc(dates, func(d time.Time) bool { return sameDay(d, section.date) }) {
+				dates = append(dates, section.date)
+			}
+		}
+	}
+	slices.SortFunc(dates, func(a, b time.Time) int { return a.Compare(b) })
+
+	var changes []EntryChange
+	for _, date := range dates {
+		changes = append(changes, dayChanges(date, sectionEntries(old.section(date)), sectionEntries(cur.section(date)))...)
+	}
+	return changes
+}
+
+func sectionEntries(section *docSection) []Entry {
+	if section == nil {
+		return nil
+	}
+	var entries []Entry
+	for _, block := range section.entryBlocks() {
+		entries = append(entries, *block.entry)
+	}
+	return entries
+}
+
+// dayChanges lists what happened to the entries of one day. Entries that
+// only moved within the day, as reorder and sorting do, are left out.
+func dayChanges(date time.Time, before, after []Entry) []EntryChange {
+	key := func(entries []Entry) []string {
+		keys := make([]string, len(entries))
+		for i, entry := range entries {
+			keys[i] = strings.Join(formatEntryLines(entry), "\n")
+		}
+		return keys
+	}
+
+	var (
+		changes      []EntryChange
+		removed      []int
+		added        []int
+		oldAt, newAt int
+	)
+	flush := func() {
+		for i := range max(len(removed), len(added)) {
+			change := EntryChange{Date: date}
+			switch {
+			case i >= len(added):
+				change.Kind, change.Index, change.Before = ChangeDelete, removed[i]+1, &before[removed[i]]
+			case i >= len(removed):
+				change.Kind, change.Index, change.After = ChangeCreate, added[i]+1, &after[added[i]]
+			default:
+				change.Kind, change.Index = ChangeEdit, added[i]+1
+				change.Before, change.After = &before[removed[i]], &after[added[i]]
+				if toggled := *change.Before; toggled.Status != change.After.Status {
+					toggled.Status = change.After.Status
+					if slices.Equal(key([]Entry{toggled}), key([]Entry{*change.After})) {
+						change.Kind = ChangeToggle
+					}
+				}
+			}
+			changes = append(changes, change)
+		}
+		removed, added = nil, nil
+	}
+	lines := diffLines(key(before), key(after))
+	moved := movedLines(lines)
+	for i, line := range lines {
+		switch {
+		case moved[i] && line.kind == '-':
+			oldAt++
+		case moved[i]:
+			newAt++
+		case line.kind == '-':
+			removed = append(removed, oldAt)
+			oldAt++
+		case line.kind == '+':
+			added = append(added, newAt)
+			newAt++
+		default:
+			flush()
+			oldAt++
+			newAt++
+		}
+	}
+	flush()
+	return changes
+}
+
+// movedLines marks the removed and added lines of a diff that pair up with an
+// identical line on the other side, wherever in the day it went.
+func movedLines(lines []diffLine) []bool {
+	moved := make([]bool, len(lines))
+	removed := make(map[string][]int)
+	for i, line := range lines {
+		if line.kind == '-' {
+			removed[line.text] = append(removed[line.text], i)
+		}
+	}
+	for i, line := range lines {
+		if line.kind != '+' || len(removed[line.text]) == 0 {
+			continue
+		}
+		moved[i], moved[removed[line.text][0]] = true, true
+		removed[line.text] = removed[line.text][1:]
+	}
+	return moved
+}
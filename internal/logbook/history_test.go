@@ -0,0 +1,90 @@
+package logbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/files"
+)
+
+func TestWriterRecordsEntryHistory(t *testing.T) {
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	writer := NewWriter(mgr)
+	ctx := context.Background()
+
+	date := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.Local)
+	for _, text := range []string{"Draft plan", "Review PRs"} {
+		if err := writer.Append(ctx, date, Entry{Status: StatusTodo, Time: date.Add(9 * time.Hour), Text: text}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	if _, err := writer.Toggle(ctx, date, 1); err != nil {
+		t.Fatalf("Toggle: %v", err)
+	}
+	if err := writer.Edit(ctx, date, 2, Entry{Status: StatusTodo, Time: date.Add(10 * time.Hour), Text: "Review PRs #team"}); err != nil {
+		t.Fatalf("Edit: %v", err)
+	}
+	if _, err := writer.Delete(ctx, date, 1); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := writer.Undo(ctx); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	// Moving an entry within its day changes none of them.
+	if _, err := writer.Reorder(ctx, date, 2, 1); err != nil {
+		t.Fatalf("Reorder: %v", err)
+	}
+
+	records, err := NewHistory(mgr.BasePath()).Records()
+	if err != nil {
+		t.Fatalf("Records: %v", err)
+	}
+	want := []struct {
+		op    string
+		kind  ChangeKind
+		index int
+	}{
+		{"append to 2025-11-04", ChangeCreate, 1},
+		{"append to 2025-11-04", ChangeCreate, 2},
+		{"toggle 2025-11-04 #1", ChangeToggle, 1},
+		{"edit 2025-11-04 #2", ChangeEdit, 2},
+		{"delete 2025-11-04 #1", ChangeDelete, 1},
+		{"undo delete 2025-11-04 #1", ChangeCreate, 1},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("records = %+v, want %d", records, len(want))
+	}
+	for i, w := range want {
+		record := records[i]
+		if record.Op != w.op || len(record.Changes) != 1 || record.Changes[0].Kind != w.kind || record.Changes[0].Index != w.index {
+			t.Fatalf("record %d = %+v, want %+v", i, record, w)
+		}
+	}
+	edit := records[3].Changes[0]
+	if edit.Before.Text != "Review PRs" || edit.After.Text != "Review PRs" || len(edit.After.Tags) != 1 || !edit.After.Time.Equal(date.Add(10*time.Hour)) {
+		t.Fatalf("edit change = %+v -> %+v", edit.Before, edit.After)
+	}
+}
+
+func TestDayChangesLeaveOutMovesWithinTheDay(t *testing.T) {
+	date := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.Local)
+	entry := func(text string) Entry {
+		return Entry{Status: StatusTodo, Time: date.Add(9 * time.Hour), Text: text}
+	}
+	a, b, c := entry("a"), entry("b"), entry("c")
+
+	if changes := dayChanges(date, []Entry{a, b, c}, []Entry{c, a, b}); len(changes) != 0 {
+		t.Fatalf("reorder changes = %+v, want none", changes)
+	}
+
+	done := c
+	done.Status = StatusDone
+	changes := dayChanges(date, []Entry{a, b, c}, []Entry{b, a, done})
+	if len(changes) != 1 || changes[0].Kind != ChangeToggle || changes[0].Index != 3 {
+		t.Fatalf("changes = %+v, want a toggle of entry 3", changes)
+	}
+}
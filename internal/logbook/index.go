@@ -0,0 +1,220 @@
+package logbook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logging"
+)
+
+// IndexDir holds the search index beneath the logbook root.
+const IndexDir = "index"
+
+const indexFile = "months.json"
+
+// Index records, for every live month file, the distinct lowercased words of
+// its entry text and its tags, so a search can skip months that cannot
+// match. A month counts as indexed only while its file keeps the
+// modification time and size it had when indexed; any other month must be
+// read, so a stale index slows a search down but never hides a match.
+type Index struct {
+	path string
+
+	mu     sync.Mutex
+	months map[string]indexedMonth
+}
+
+type indexedMonth struct {
+	ModTime time.Time `json:"mod_time"`
+	Size    int64     `json:"size"`
+	Words   []string  `json:"words"`
+	Tags    []string  `json:"tags"`
+}
+
+// MonthTerms is what the index holds for one month file.
+type MonthTerms struct {
+	Words []string
+	Tags  []string
+}
+
+// HasWord reports whether some word contains sub. Words never hold
+// whitespace, so any text containing a term without whitespace has a word
+// containing it.
+func (t MonthTerms) HasWord(sub string) bool {
+	return slices.ContainsFunc(t.Words, func(word string) bool { return strings.Contains(word, sub) })
+}
+
+// HasTag reports whether some tag contains sub, or equals it when exact.
+func (t MonthTerms) HasTag(sub string, exact bool) bool {
+	return slices.ContainsFunc(t.Tags, func(tag string) bool {
+		return tag == sub || !exact && strings.Contains(tag, sub)
+	})
+}
+
+// NewIndex keeps the index in root/index.
+func NewIndex(root string) *Index {
+	return &Index{path: filepath.Join(root, IndexDir, indexFile)}
+}
+
+// Path returns the index file's location.
+func (ix *Index) Path() string {
+	return ix.path
+}
+
+// Rebuild indexes every live month file from scratch and returns how many
+// it indexed. Archived months are left out and always read by a search.
+// Encrypted logbooks cannot be indexed, as the index is plain text.
+func (ix *Index) Rebuild(ctx context.Context, manager *files.Manager) (int, error) {
+	if manager.Encrypted() || manager.Daily() {
+		return 0, fmt.Errorf("the search index needs an unencrypted logbook of month files")
+	}
+	months, err := manager.LiveMonths()
+	if err != nil {
+		return 0, err
+	}
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.months = make(map[string]indexedMonth)
+	for _, month := range months {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		path := manager.MonthPath(month)
+		data, err := manager.ReadFile(path)
+		if err != nil {
+			return 0, err
+		}
+		if err := ix.put(path, splitLines(string(data))); err != nil {
+			return 0, err
+		}
+	}
+	return len(ix.months), ix.save()
+}
+
+// Candidates returns the months that may hold a match: those whose indexed
+// terms satisfy may, and every month that is not indexed or has changed
+// since. A missing index keeps every month.
+func (ix *Index) Candidates(manager *files.Manager, months []time.Time, may func(MonthTerms) bool) ([]time.Time, error) {
+	if manager.Encrypted() || manager.Daily() {
+		return months, nil
+	}
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	if err := ix.load(); err != nil {
+		return nil, err
+	}
+	var kept []time.Time
+	for _, month := range months {
+		path := manager.MonthPath(month)
+		indexed, ok := ix.months[ix.key(path)]
+		if ok {
+			info, err := os.Stat(path)
+			ok = err == nil && indexed.ModTime.Equal(info.ModTime()) && indexed.Size == info.Size()
+		}
+		if !ok || may(MonthTerms{Words: indexed.Words, Tags: indexed.Tags}) {
+			kept = append(kept, month)
+		}
+	}
+	logging.L().Debug("narrow search with index", "months", len(months), "candidates", len(kept))
+	return kept, nil
+}
+
+// update reindexes the month files at paths after a write, dropping those
+// that no longer exist.
+func (ix *Index) update(paths []string, read func(string) []string) error {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	if err := ix.load(); err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if err := ix.put(path, read(path)); err != nil {
+			if !os.IsNotExist(err) {
+				return err
+			}
+			delete(ix.months, ix.key(path))
+		}
+	}
+	return ix.save()
+}
+
+// put indexes the entries in lines as the current content of path.
+func (ix *Index) put(path string, lines []string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	words, tags := make(map[string]bool), make(map[string]bool)
+	for _, section := range parseDocument(lines, time.Local).sections {
+		for _, entry := range sectionEntries(section) {
+			for _, word := range strings.Fields(strings.ToLower(entry.Text)) {
+				words[word] = true
+			}
+			for _, tag := range entry.Tags {
+				tags[strings.ToLower(tag)] = true
+			}
+		}
+	}
+	ix.months[ix.key(path)] = indexedMonth{
+		ModTime: info.ModTime(),
+		Size:    info.Size(),
+		Words:   sortedKeys(words),
+		Tags:    sortedKeys(tags),
+	}
+	return nil
+}
+
+// key names path relative to the logbook root so the index survives the
+// logbook moving.
+func (ix *Index) key(path string) string {
+	root := filepath.Dir(filepath.Dir(ix.path))
+	if rel, err := filepath.Rel(root, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+// load reads the index from disk each time, so another process's updates
+// are never overwritten with an older copy.
+func (ix *Index) load() error {
+	ix.months = make(map[string]indexedMonth)
+	data, err := os.ReadFile(ix.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &ix.months); err != nil {
+		return fmt.Errorf("read search index: %w", err)
+	}
+	return nil
+}
+
+func (ix *Index) save() error {
+	data, err := json.Marshal(ix.months)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(ix.path), 0o755); err != nil {
+		return err
+	}
+	return files.WriteAtomic(ix.path, data)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
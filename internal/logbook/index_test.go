@@ -0,0 +1,94 @@
+package logbook
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/files"
+)
+
+func TestIndexNarrowsMonthsAndStaysCurrent(t *testing.T) {
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	writer := NewWriter(mgr).MaintainIndex(true)
+	ctx := context.Background()
+
+	sep, oct, nov := time.Date(2025, time.September, 1, 0, 0, 0, 0, time.Local), time.Date(2025, time.October, 1, 0, 0, 0, 0, time.Local), time.Date(2025, time.November, 1, 0, 0, 0, 0, time.Local)
+	for _, e := range []struct {
+		month time.Time
+		text  string
+		tags  []string
+	}{
+		{sep, "Deploy API", []string{"ops"}},
+		{oct, "Write release notes", []string{"docs"}},
+		{nov, "Plan Q1 roadmap", []string{"planning"}},
+	} {
+		date := e.month.AddDate(0, 0, 9)
+		if err := writer.Append(ctx, date, Entry{Status: StatusTodo, Time: date.Add(9 * time.Hour), Text: e.text, Tags: e.tags}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	months := []time.Time{sep, oct, nov}
+	candidates := func(may func(MonthTerms) bool) []string {
+		t.Helper()
+		kept, err := NewIndex(mgr.BasePath()).Candidates(mgr, months, may)
+		if err != nil {
+			t.Fatalf("Candidates: %v", err)
+		}
+		var names []string
+		for _, month := range kept {
+			names = append(names, month.Format("2006-01"))
+		}
+		return names
+	}
+
+	if got := candidates(func(t MonthTerms) bool { return t.HasWord("deplo") }); !slices.Equal(got, []string{"2025-09"}) {
+		t.Fatalf("word candidates = %v", got)
+	}
+	if got := candidates(func(t MonthTerms) bool { return t.HasTag("doc", false) }); !slices.Equal(got, []string{"2025-10"}) {
+		t.Fatalf("tag candidates = %v", got)
+	}
+	if got := candidates(func(t MonthTerms) bool { return t.HasTag("doc", true) }); len(got) != 0 {
+		t.Fatalf("exact tag candidates = %v, want none", got)
+	}
+
+	// A month edited outside kerja is always read until it is reindexed.
+	path := mgr.MonthPath(nov)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(strings.Replace(string(data), "Plan Q1 roadmap", "Plan Q1 roadmap and deploy", 1)), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if got := candidates(func(t MonthTerms) bool { return t.HasWord("deploy") }); !slices.Equal(got, []string{"2025-09", "2025-11"}) {
+		t.Fatalf("candidates with a stale month = %v", got)
+	}
+	count, err := NewIndex(mgr.BasePath()).Rebuild(ctx, mgr)
+	if err != nil || count != 3 {
+		t.Fatalf("Rebuild = %d, %v; want 3 months", count, err)
+	}
+	if got := candidates(func(t MonthTerms) bool { return t.HasWord("roadmap") && t.HasWord("deploy") }); !slices.Equal(got, []string{"2025-11"}) {
+		t.Fatalf("candidates after rebuild = %v", got)
+	}
+
+	// Writes and undos keep the index in step.
+	if _, err := writer.Delete(ctx, sep.AddDate(0, 0, 9), 1); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if got := candidates(func(t MonthTerms) bool { return t.HasWord("api") }); len(got) != 0 {
+		t.Fatalf("candidates after delete = %v, want none", got)
+	}
+	if _, err := writer.Undo(ctx); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if got := candidates(func(t MonthTerms) bool { return t.HasWord("api") }); !slices.Equal(got, []string{"2025-09"}) {
+		t.Fatalf("candidates after undo = %v", got)
+	}
+}
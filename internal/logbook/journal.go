@@ -0,0 +1,189 @@
+package logbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/files"
+)
+
+// JournalDir is the directory beneath the logbook root that holds undo records.
+const JournalDir = ".undo"
+
+// journalLimit bounds how many changes can be undone.
+const journalLimit = 50
+
+// Change describes one journaled write operation.
+type Change struct {
+	Op    string         `json:"op"`
+	Time  time.Time      `json:"time"`
+	Files []FileSnapshot `json:"files"`
+}
+
+// FileSnapshot holds a month file's content from before a change. Path is
+// relative to the logbook root. Encrypted files are kept as raw ciphertext in
+// Encrypted so the journal never holds plaintext for them.
+type FileSnapshot struct {
+	Path      string `json:"path"`
+	Content   string `json:"content,omitempty"`
+	Encrypted []byte `json:"encrypted,omitempty"`
+}
+
+// Journal stores the prior state of every file a Writer rewrites so the most
+// recent change can be reverted.
+type Journal struct {
+	root string
+	dir  string
+}
+
+// NewJournal keeps undo records beneath root/.undo.
+func NewJournal(root string) *Journal {
+	return &Journal{root: root, dir: filepath.Join(root, JournalDir)}
+}
+
+// record snapshots the current content of paths before they are overwritten.
+func (j *Journal) record(op string, paths []string) error {
+	change := Change{Op: op, Time: time.Now()}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("journal %s: %w", op, err)
+		}
+		rel, err := filepath.Rel(j.root, path)
+		if err != nil {
+			return err
+		}
+		snapshot := FileSnapshot{Path: rel}
+		if files.IsEncrypted(data) {
+			snapshot.Encrypted = data
+		} else {
+			snapshot.Content = string(data)
+		}
+		change.Files = append(change.Files, snapshot)
+	}
+
+	if err := os.MkdirAll(j.dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	// Names sort chronologically; bump the stamp if two writes share a nanosecond.
+	stamp := change.Time.UnixNano()
+	name := filepath.Join(j.dir, fmt.Sprintf("%020d.json", stamp))
+	for {
+		if _, err := os.Stat(name); os.IsNotExist(err) {
+			break
+		}
+		stamp++
+		name = filepath.Join(j.dir, fmt.Sprintf("%020d.json", stamp))
+	}
+	if err := os.WriteFile(name, data, 0o644); err != nil {
+		return err
+	}
+	return j.prune()
+}
+
+// Undo restores the files captured by the most recent change and removes it
+// from the journal.
+func (j *Journal) Undo() (Change, error) {
+	return j.undo(func(_ string, restore func() error) error { return restore() })
+}
+
+// undo is Undo with each file's restore run through wrap.
+func (j *Journal) undo(wrap func(path string, restore func() error) error) (Change, error) {
+	names, err := j.records()
+	if err != nil {
+		return Change{}, err
+	}
+	if len(names) == 0 {
+		return Change{}, ErrNothingToUndo
+	}
+
+	last := filepath.Join(j.dir, names[len(names)-1])
+	data, err := os.ReadFile(last)
+	if err != nil {
+		return Change{}, err
+	}
+	var change Change
+	if err := json.Unmarshal(data, &change); err != nil {
+		return Change{}, fmt.Errorf("read journal %s: %w", filepath.Base(last), err)
+	}
+
+	for _, file := range change.Files {
+		data := file.Encrypted
+		if data == nil {
+			data = []byte(file.Content)
+		}
+		path := filepath.Join(j.root, file.Path)
+		err := wrap(path, func() error { return files.WriteAtomic(path, data) })
+		parsedMonths.invalidate(path)
+		if err != nil {
+			return Change{}, err
+		}
+	}
+	return change, os.Remove(last)
+}
+
+// Changes lists the journaled changes, newest first, without their file
+// snapshots.
+func (j *Journal) Changes() ([]Change, error) {
+	names, err := j.records()
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]Change, 0, len(names))
+	for i := len(names) - 1; i >= 0; i-- {
+		data, err := os.ReadFile(filepath.Join(j.dir, names[i]))
+		if err != nil {
+			return nil, err
+		}
+		var change struct {
+			Op   string    `json:"op"`
+			Time time.Time `json:"time"`
+		}
+		if err := json.Unmarshal(data, &change); err != nil {
+			return nil, fmt.Errorf("read journal %s: %w", names[i], err)
+		}
+		changes = append(changes, Change{Op: change.Op, Time: change.Time})
+	}
+	return changes, nil
+}
+
+func (j *Journal) records() ([]string, error) {
+	dirEntries, err := os.ReadDir(j.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var names []string
+	for _, entry := range dirEntries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (j *Journal) prune() error {
+	names, err := j.records()
+	if err != nil {
+		return err
+	}
+	for len(names) > journalLimit {
+		if err := os.Remove(filepath.Join(j.dir, names[0])); err != nil {
+			return err
+		}
+		names = names[1:]
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package logbook
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/files"
+)
+
+func TestWriterUndoRestoresPreviousContent(t *testing.T) {
+	base := t.TempDir()
+	mgr, err := files.NewManager(base)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	writer := NewWriter(mgr)
+	ctx := context.Background()
+
+	date := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.UTC)
+	entry := Entry{Status: StatusTodo, Time: date.Add(9 * time.Hour), Text: "Draft plan"}
+	if err := writer.Append(ctx, date, entry); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	afterAppend, err := os.ReadFile(mgr.MonthPath(date))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if _, err := writer.Toggle(ctx, date, 1); err != nil {
+		t.Fatalf("Toggle: %v", err)
+	}
+
+	change, err := writer.Undo(ctx)
+	if err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if change.Op != "toggle 2025-11-04 #1" || len(change.Files) != 1 || change.Files[0].Path != filepath.Join("2025", "2025-11.md") {
+		t.Fatalf("unexpected change: %+v", change)
+	}
+	got, err := os.ReadFile(mgr.MonthPath(date))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(got) != string(afterAppend) {
+		t.Fatalf("after undo = %q, want %q", got, afterAppend)
+	}
+
+	if _, err := writer.Undo(ctx); err != nil {
+		t.Fatalf("second Undo: %v", err)
+	}
+	if _, err := writer.Undo(ctx); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("third Undo error = %v, want ErrNothingToUndo", err)
+	}
+}
+
+func TestJournalKeepsBoundedHistory(t *testing.T) {
+	base := t.TempDir()
+	mgr, err := files.NewManager(base)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	writer := NewWriter(mgr)
+	date := time.Date(2025, time.November, 5, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < journalLimit+5; i++ {
+		if err := writer.Append(context.Background(), date, Entry{Time: date, Text: "tick"}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	names, err := writer.journal.records()
+	if err != nil {
+		t.Fatalf("records: %v", err)
+	}
+	if len(names) != journalLimit {
+		t.Fatalf("journal holds %d records, want %d", len(names), journalLimit)
+	}
+}
+
+func TestUndoKeepsEncryptedSnapshots(t *testing.T) {
+	base := t.TempDir()
+	mgr, err := files.NewManager(base)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	mgr.EnableEncryption("passphrase")
+	writer := NewWriter(mgr)
+	ctx := context.Background()
+
+	date := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.UTC)
+	if err := writer.Append(ctx, date, Entry{Status: StatusTodo, Time: date.Add(9 * time.Hour), Text: "Private"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if _, err := writer.Toggle(ctx, date, 1); err != nil {
+		t.Fatalf("Toggle: %v", err)
+	}
+
+	records, _ := filepath.Glob(filepath.Join(base, JournalDir, "*.json"))
+	for _, record := range records {
+		data, _ := os.ReadFile(record)
+		if strings.Contains(string(data), "Private") {
+			t.Fatalf("journal %s holds plaintext", filepath.Base(record))
+		}
+	}
+
+	if _, err := writer.Undo(ctx); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	section, err := NewReader(mgr).Section(ctx, date)
+	if err != nil {
+		t.Fatalf("Section: %v", err)
+	}
+	if len(section.Entries) != 1 || section.Entries[0].Status != StatusTodo {
+		t.Fatalf("after undo entries = %+v", section.Entries)
+	}
+}
+
+func TestJournalChangesListsNewestFirst(t *testing.T) {
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	writer := NewWriter(mgr)
+	ctx := context.Background()
+
+	date := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.UTC)
+	if err := writer.Append(ctx, date, Entry{Status: StatusTodo, Time: date.Add(9 * time.Hour), Text: "Draft plan"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if _, err := writer.Toggle(ctx, date, 1); err != nil {
+		t.Fatalf("Toggle: %v", err)
+	}
+
+	changes, err := NewJournal(mgr.BasePath()).Changes()
+	if err != nil {
+		t.Fatalf("Changes: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Op != "toggle 2025-11-04 #1" || changes[1].Op != "append to 2025-11-04" || changes[0].Files != nil {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+}
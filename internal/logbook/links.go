@@ -0,0 +1,77 @@
+package logbook
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LinkPrefix marks a reference token: ref:https://tracker/ISSUE-1 links an
+// issue tracker URL and ref:2025-11-12#3 links the third entry of that day.
+const LinkPrefix = "ref:"
+
+// EntryRef addresses an entry by date and 1-based index, as the CLI does.
+type EntryRef struct {
+	Date  time.Time
+	Index int
+}
+
+// String formats the reference as it is written after ref:.
+func (r EntryRef) String() string {
+	return fmt.Sprintf("%s#%d", r.Date.Format("2006-01-02"), r.Index)
+}
+
+// ParseEntryRef reads a YYYY-MM-DD#N entry reference.
+func ParseEntryRef(link string) (EntryRef, bool) {
+	day, index, ok := strings.Cut(link, "#")
+	if !ok {
+		return EntryRef{}, false
+	}
+	date, err := time.ParseInLocation("2006-01-02", day, time.Local)
+	if err != nil {
+		return EntryRef{}, false
+	}
+	n, err := strconv.Atoi(index)
+	if err != nil || n < 1 {
+		return EntryRef{}, false
+	}
+	return EntryRef{Date: date, Index: n}, true
+}
+
+// IsURL reports whether link is an absolute URL that can be handed to a
+// browser.
+func IsURL(link string) bool {
+	u, err := url.Parse(link)
+	return err == nil && u.Scheme != "" && (u.Host != "" || u.Opaque != "")
+}
+
+// linkToken returns the target of a ref: token.
+func linkToken(token string) (string, bool) {
+	target, ok := strings.CutPrefix(token, LinkPrefix)
+	return target, ok && target != ""
+}
+
+// SplitLinks removes ref: tokens from text and returns them as links.
+func SplitLinks(text string) (string, []string) {
+	if !strings.Contains(text, LinkPrefix) {
+		return text, nil
+	}
+	var (
+		words strings.Builder
+		links []string
+	)
+	words.Grow(len(text))
+	for word := range strings.FieldsSeq(text) {
+		if link, ok := linkToken(word); ok {
+			links = append(links, link)
+			continue
+		}
+		if words.Len() > 0 {
+			words.WriteByte(' ')
+		}
+		words.WriteString(word)
+	}
+	return words.String(), links
+}
@@ -1,16 +1,118 @@
 package logbook
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // Entry represents a single Markdown task line within a dated section.
 type Entry struct {
-	Status Status
-	Time   time.Time
-	Text   string
-	Tags   []string
+	Status Status    `json:"status"`
+	Time   time.Time `json:"time"`
+	// End closes a [HH:MM-HH:MM] range; it is zero when only a start time
+	// was recorded and falls on the next day for ranges crossing midnight.
+	End  time.Time `json:"end,omitzero"`
+	Text string    `json:"text"`
+	Tags []string  `json:"tags"`
+	// Links holds ref: targets: issue tracker URLs or YYYY-MM-DD#N entry
+	// references.
+	Links []string `json:"links,omitempty"`
+	// After holds the YYYY-MM-DD#N references of the entries this one waits
+	// for, from after: tokens.
+	After []string `json:"after,omitempty"`
+	// Notes holds indented continuation lines written beneath the entry.
+	Notes []string `json:"notes,omitempty"`
+	// Pinned entries are listed ahead of the rest of their day, whatever
+	// their time; the file marks them with a * before the text.
+	Pinned bool `json:"pinned,omitempty"`
+	// Author names who wrote the entry in a shared logbook; the file marks
+	// it with a ~name token after the text.
+	Author string `json:"author,omitempty"`
+	// Due is the deadline set by a due:YYYY-MM-DD token, at midnight; it is
+	// zero when the entry has none.
+	Due time.Time `json:"due,omitzero"`
+}
+
+// Duration returns how long a ranged entry lasted, or zero without an end.
+func (e Entry) Duration() time.Duration {
+	if e.End.IsZero() || !e.End.After(e.Time) {
+		return 0
+	}
+	return e.End.Sub(e.Time)
+}
+
+// Reschedule moves the entry to start, shifting End so a ranged entry keeps
+// its duration.
+func (e Entry) Reschedule(start time.Time) Entry {
+	if duration := e.Duration(); duration > 0 {
+		e.End = start.Add(duration)
+	}
+	e.Time = start
+	return e
+}
+
+// ParseClockRange parses "HH:MM" or "HH:MM-HH:MM" on base's date. Either
+// end may instead be a 12-hour time such as "9:45pm" or "9am". end is zero
+// for a single time; an end at or before start is taken to be the next day.
+func ParseClockRange(value string, base time.Time) (time.Time, time.Time, error) {
+	startText, endText, ranged := strings.Cut(value, "-")
+	start, err := parseClock(startText, base)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if !ranged {
+		return start, time.Time{}, nil
+	}
+	end, err := parseClock(endText, base)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return start, end, nil
+}
+
+// clockNow is the current moment relative times count from; tests replace it.
+var clockNow = time.Now
+
+// ParseRelativeClock parses a time relative to the current moment: "now" or
+// a signed duration such as "+30m" or "-1h30m". The resulting wall-clock
+// time is placed on base's date in base's zone. ok is false when value is
+// not relative, so callers can fall back to ParseClockRange.
+func ParseRelativeClock(value string, base time.Time) (when time.Time, ok bool, err error) {
+	var offset time.Duration
+	switch {
+	case value == "now":
+	case strings.HasPrefix(value, "+") || strings.HasPrefix(value, "-"):
+		offset, err = time.ParseDuration(value)
+		if err != nil {
+			return time.Time{}, true, fmt.Errorf("invalid relative time %q (expected now, +30m, or -1h)", value)
+		}
+	default:
+		return time.Time{}, false, nil
+	}
+	moment := clockNow().In(base.Location()).Add(offset)
+	return time.Date(base.Year(), base.Month(), base.Day(), moment.Hour(), moment.Minute(), 0, 0, base.Location()), true, nil
 }
 
-// Status expresses whether an entry is still a todo or already done.
+// clockLayouts are the accepted clock forms. Values are upper-cased first,
+// so am and pm match in any case.
+var clockLayouts = []string{"15:04", "3:04PM", "3PM"}
+
+func parseClock(value string, base time.Time) (time.Time, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(value, " ", ""))
+	for _, layout := range clockLayouts {
+		if parsed, err := time.Parse(layout, normalized); err == nil {
+			return time.Date(base.Year(), base.Month(), base.Day(), parsed.Hour(), parsed.Minute(), 0, 0, base.Location()), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (expected HH:MM or 9:45pm, or a range such as HH:MM-HH:MM)", value)
+}
+
+// Status expresses where an entry stands: open (todo, in progress, blocked)
+// or closed (done, cancelled).
 type Status uint8
 
 const (
@@ -18,11 +120,165 @@ const (
 	StatusTodo Status = iota
 	// StatusDone marks entries that are completed.
 	StatusDone
+	// StatusInProgress marks entries being worked on.
+	StatusInProgress
+	// StatusCancelled marks entries dropped without being done.
+	StatusCancelled
+	// StatusBlocked marks entries waiting on something else.
+	StatusBlocked
+)
+
+// statusNames and statusMarkers are indexed by Status.
+var (
+	statusNames   = []string{"todo", "done", "in-progress", "cancelled", "blocked"}
+	statusMarkers = []byte{' ', 'x', '~', '-', '!'}
 )
 
+// StatusNames lists every status name in declaration order.
+func StatusNames() []string {
+	return append([]string(nil), statusNames...)
+}
+
+// String returns the status name used in output and flags.
+func (s Status) String() string {
+	if int(s) < len(statusNames) {
+		return statusNames[s]
+	}
+	return fmt.Sprintf("Status(%d)", uint8(s))
+}
+
+// Marker returns the character written between the checkbox brackets.
+func (s Status) Marker() byte {
+	if int(s) < len(statusMarkers) {
+		return statusMarkers[s]
+	}
+	return ' '
+}
+
+// Open reports whether the entry still needs attention: todo, in progress,
+// or blocked.
+func (s Status) Open() bool {
+	return s == StatusTodo || s == StatusInProgress || s == StatusBlocked
+}
+
+// Next returns the status toggling moves to: todo → in progress → done → todo.
+// Blocked and cancelled entries reopen as todo.
+func (s Status) Next() Status {
+	switch s {
+	case StatusTodo:
+		return StatusInProgress
+	case StatusInProgress:
+		return StatusDone
+	default:
+		return StatusTodo
+	}
+}
+
+// ParseStatus resolves a status name. It accepts the names returned by String
+// plus the common spellings "doing", "wip", "canceled", and "cancel".
+func ParseStatus(name string) (Status, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "todo":
+		return StatusTodo, nil
+	case "done":
+		return StatusDone, nil
+	case "in-progress", "inprogress", "progress", "doing", "wip":
+		return StatusInProgress, nil
+	case "cancelled", "canceled", "cancel":
+		return StatusCancelled, nil
+	case "blocked":
+		return StatusBlocked, nil
+	default:
+		return StatusTodo, fmt.Errorf("invalid status %q (expected %s)", name, strings.Join(statusNames, "|"))
+	}
+}
+
+// StatusFromMarker maps a checkbox character back to its status. An upper-case
+// X is read as done.
+func StatusFromMarker(marker byte) (Status, bool) {
+	if marker == 'X' {
+		return StatusDone, true
+	}
+	for i, m := range statusMarkers {
+		if m == marker {
+			return Status(i), true
+		}
+	}
+	return StatusTodo, false
+}
+
+// MarshalText encodes the status by name so JSON output stays readable.
+func (s Status) MarshalText() ([]byte, error) {
+	if int(s) >= len(statusNames) {
+		return nil, fmt.Errorf("unknown status %d", s)
+	}
+	return []byte(statusNames[s]), nil
+}
+
+// UnmarshalText decodes any name ParseStatus accepts.
+func (s *Status) UnmarshalText(text []byte) error {
+	status, err := ParseStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*s = status
+	return nil
+}
+
 // DateSection groups entries beneath the same YYYY-MM-DD heading.
 type DateSection struct {
-	Date   time.Time
-	Entries []Entry
+	Date    time.Time `json:"date"`
+	Entries []Entry   `json:"entries"`
+}
+
+// TrackedDuration sums the durations of the section's ranged entries.
+func (s DateSection) TrackedDuration() time.Duration {
+	var total time.Duration
+	for _, entry := range s.Entries {
+		total += entry.Duration()
+	}
+	return total
 }
 
+// Progress counts the section's done entries against all of its entries
+// except cancelled ones.
+func (s DateSection) Progress() (done, total int) {
+	for _, entry := range s.Entries {
+		switch entry.Status {
+		case StatusCancelled:
+			continue
+		case StatusDone:
+			done++
+		}
+		total++
+	}
+	return done, total
+}
+
+// OpenCount returns how many entries in the section are still open.
+func (s DateSection) OpenCount() int {
+	count := 0
+	for _, entry := range s.Entries {
+		if entry.Status.Open() {
+			count++
+		}
+	}
+	return count
+}
+
+// DisplayOrder returns the indexes of the section's entries with pinned
+// entries first, each group keeping its order in the file.
+func (s DateSection) DisplayOrder() []int {
+	order := make([]int, 0, len(s.Entries))
+	for i, entry := range s.Entries {
+		if entry.Pinned {
+			order = append(order, i)
+		}
+	}
+	for i, entry := range s.Entries {
+		if !entry.Pinned {
+			order = append(order, i)
+		}
+	}
+	return order
+}
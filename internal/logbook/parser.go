@@ -3,10 +3,14 @@ package logbook
 import (
 	"bufio"
 	"errors"
+	"fmt"
 	"io"
-	"regexp"
+	"slices"
 	"strings"
 	"time"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logging"
 )
 
 var (
@@ -14,12 +18,27 @@ var (
 	ErrNotImplemented = errors.New("not implemented")
 )
 
+// maxLineLength is the longest line the parser reads. bufio.Scanner stops at
+// 64 KiB by default, which a pasted log in a note can exceed.
+const maxLineLength = 16 << 20
+
 // Parser incrementally reads Markdown logbooks and emits sections as they are discovered.
 type Parser struct {
 	r        io.Reader
 	scanner  *bufio.Scanner
 	pending  *DateSection
 	initDone bool
+	// loc is the zone recorded in the month header; sections and entries
+	// are placed in it, or in UTC when the header records none.
+	loc *time.Location
+	// lineNo counts the lines read so far, for tracing.
+	lineNo int
+	// fence tracks fenced code blocks, whose lines are never entries or
+	// headings.
+	fence fence
+	// entries collects the current section's entries; it is reused across
+	// sections so each one is allocated once at its final size.
+	entries []Entry
 }
 
 // NewParser returns a parser ready to tokenize Markdown from r.
@@ -38,6 +57,7 @@ func (p *Parser) NextSection() (*DateSection, error) {
 			return nil, io.EOF
 		}
 		p.scanner = bufio.NewScanner(p.r)
+		p.scanner.Buffer(make([]byte, 0, 64*1024), maxLineLength)
 		p.initDone = true
 	}
 
@@ -56,80 +76,248 @@ func (p *Parser) NextSection() (*DateSection, error) {
 			}
 		}
 
+		// undated is set under a "## " heading that is not a date, such as
+		// "## Notes" in a daily note; its lines belong to no day.
+		inEntry, undated := false, false
+		p.entries = p.entries[:0]
 		for p.scanner.Scan() {
-			line := strings.TrimSpace(p.scanner.Text())
-			if date, ok := parseSectionHeading(line); ok {
-				p.pending = &DateSection{Date: date}
-				return section, nil
+			p.lineNo++
+			raw := p.scanner.Text()
+			line := strings.TrimSpace(raw)
+			if inEntry && isNoteLine(raw) {
+				p.fence.step(line)
+				last := &p.entries[len(p.entries)-1]
+				last.Notes = append(last.Notes, line)
+				continue
 			}
+			inEntry = false
 
-			if len(line) == 0 || strings.HasPrefix(line, "#") {
+			if p.fence.step(line) {
+				continue
+			}
+			if date, ok := parseSectionHeading(line); ok {
+				p.pending = &DateSection{Date: p.inZone(date)}
+				return p.finish(section), nil
+			}
+			if strings.HasPrefix(line, "## ") {
+				undated = true
+				continue
+			}
+			if len(line) == 0 || strings.HasPrefix(line, "#") || undated {
 				continue
 			}
 
 			if entry, ok := parseEntryLine(line, section.Date); ok {
-				section.Entries = append(section.Entries, entry)
+				p.entries = append(p.entries, entry)
+				inEntry = true
+			} else {
+				logging.L().Debug("skip line that is not an entry", "line", p.lineNo, "text", line)
 			}
 		}
 
-		if err := p.scanner.Err(); err != nil {
+		if err := p.scanErr(); err != nil {
 			return nil, err
 		}
 
 		if section != nil {
-			return section, nil
+			return p.finish(section), nil
 		}
 	}
 }
 
+// finish hands the collected entries to section.
+func (p *Parser) finish(section *DateSection) *DateSection {
+	if len(p.entries) > 0 {
+		section.Entries = slices.Clone(p.entries)
+		clear(p.entries)
+	}
+	return section
+}
+
 func (p *Parser) consumeUntilSection() (*DateSection, error) {
 	for p.scanner.Scan() {
+		p.lineNo++
 		line := strings.TrimSpace(p.scanner.Text())
+		if p.fence.step(line) {
+			continue
+		}
 		if date, ok := parseSectionHeading(line); ok {
-			return &DateSection{Date: date}, nil
+			return &DateSection{Date: p.inZone(date)}, nil
+		}
+		if loc, ok := files.ParseTimeZoneLine(line); ok && p.loc == nil {
+			p.loc = loc
 		}
 	}
 
-	if err := p.scanner.Err(); err != nil {
+	if err := p.scanErr(); err != nil {
 		return nil, err
 	}
 	return nil, nil
 }
 
-var entryPattern = regexp.MustCompile(`^- \[( |x)\] \[(\d{2}:\d{2})\] (.*)$`)
+// scanErr reports why scanning stopped early, naming the line that was too
+// long to read.
+func (p *Parser) scanErr() error {
+	err := p.scanner.Err()
+	if errors.Is(err, bufio.ErrTooLong) {
+		return fmt.Errorf("line %d is longer than %d MiB: %w", p.lineNo+1, maxLineLength>>20, err)
+	}
+	return err
+}
 
-func parseEntryLine(line string, date time.Time) (Entry, bool) {
-	matches := entryPattern.FindStringSubmatch(line)
-	if matches == nil {
-		return Entry{}, false
+// inZone moves a parsed heading date into the header's zone.
+func (p *Parser) inZone(date time.Time) time.Time {
+	if p.loc == nil {
+		return date
 	}
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, p.loc)
+}
 
-	status := StatusTodo
-	if matches[1] == "x" {
-		status = StatusDone
+// matchEntryLine splits an entry line, "- [x] [09:00] text" or with a
+// "[09:00-10:30]" range, into its status marker, clock, and the text after
+// it. It is matched by hand as every line of a month passes through it.
+func matchEntryLine(line string) (marker byte, clock, rest string, ok bool) {
+	if len(line) < len("- [ ] [00:00] ") || line[:3] != "- [" || line[4:7] != "] [" {
+		return 0, "", "", false
+	}
+	marker = line[3]
+	if strings.IndexByte(" xX~!-", marker) < 0 || !isClock(line[7:12]) {
+		return 0, "", "", false
+	}
+	end := 12
+	if line[end] == '-' {
+		if len(line) < end+len("-00:00] ") || !isClock(line[end+1:end+6]) {
+			return 0, "", "", false
+		}
+		end += 6
 	}
+	if line[end] != ']' || line[end+1] != ' ' {
+		return 0, "", "", false
+	}
+	return marker, line[7:end], line[end+2:], true
+}
 
-	parsedTime, err := time.Parse("15:04", matches[2])
-	if err != nil {
+// isClock reports whether s is two digits, a colon, and two digits.
+func isClock(s string) bool {
+	return len(s) == 5 && isDigit(s[0]) && isDigit(s[1]) && s[2] == ':' && isDigit(s[3]) && isDigit(s[4])
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// entryClock reads the HH:MM or HH:MM-HH:MM clock matched by matchEntryLine
+// on date, as ParseClockRange would but without trying every clock layout.
+func entryClock(clock string, date time.Time) (time.Time, time.Time, bool) {
+	start, ok := clockOn(clock[:5], date)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	if len(clock) == 5 {
+		return start, time.Time{}, true
+	}
+	end, ok := clockOn(clock[6:], date)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return start, end, true
+}
+
+func clockOn(hhmm string, date time.Time) (time.Time, bool) {
+	hour := int(hhmm[0]-'0')*10 + int(hhmm[1]-'0')
+	minute := int(hhmm[3]-'0')*10 + int(hhmm[4]-'0')
+	if hour > 23 || minute > 59 {
+		return time.Time{}, false
+	}
+	year, month, day := date.Date()
+	return time.Date(year, month, day, hour, minute, 0, 0, date.Location()), true
+}
+
+func parseEntryLine(line string, date time.Time) (Entry, bool) {
+	marker, clock, body, ok := matchEntryLine(line)
+	if !ok {
 		return Entry{}, false
 	}
 
-	entryTime := time.Date(
-		date.Year(), date.Month(), date.Day(),
-		parsedTime.Hour(), parsedTime.Minute(), 0, 0,
-		date.Location(),
-	)
+	status, _ := StatusFromMarker(marker)
 
-	text, tags := extractTextAndTags(matches[3])
+	entryTime, end, ok := entryClock(clock, date)
+	if !ok {
+		return Entry{}, false
+	}
+
+	rest, pinned := strings.CutPrefix(body, "* ")
+	if !pinned && body == "*" {
+		rest, pinned = "", true
+	}
+	text, tags, links := extractTextAndTags(rest)
+	text, author := SplitAuthor(text)
+	text, due := SplitDue(text, date.Location())
+	text, after := SplitAfter(text)
 
 	return Entry{
+		After:  after,
+		Author: author,
+		Due:    due,
+		Pinned: pinned,
 		Status: status,
 		Time:   entryTime,
+		End:    end,
 		Text:   text,
 		Tags:   tags,
+		Links:  links,
 	}, true
 }
 
+// isNoteLine reports whether raw is an indented continuation of the preceding
+// entry rather than an entry of its own.
+func isNoteLine(raw string) bool {
+	if raw == "" || (raw[0] != ' ' && raw[0] != '\t') {
+		return false
+	}
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return false
+	}
+	_, _, _, entry := matchEntryLine(trimmed)
+	return !entry
+}
+
+// fence follows fenced code blocks (``` or ~~~) through a file, so entries
+// and headings quoted inside one are left alone.
+type fence struct {
+	// marker is the run of backticks or tildes that opened the current
+	// block, or empty outside one.
+	marker string
+}
+
+// step feeds the trimmed line to the tracker and reports whether it belongs
+// to a code block, counting the lines that open and close one.
+func (f *fence) step(line string) bool {
+	if f.marker != "" {
+		if strings.HasPrefix(line, f.marker) && strings.Trim(line, f.marker[:1]) == "" {
+			f.marker = ""
+		}
+		return true
+	}
+	for _, char := range []string{"`", "~"} {
+		run := len(line) - len(strings.TrimLeft(line, char))
+		if run >= 3 && (char == "~" || !strings.Contains(line[run:], "`")) {
+			f.marker = line[:run]
+			return true
+		}
+	}
+	return false
+}
+
+// open reports whether a code block is still open.
+func (f *fence) open() bool {
+	return f.marker != ""
+}
+
 func parseSectionHeading(line string) (time.Time, bool) {
 	if !strings.HasPrefix(line, "## ") {
 		return time.Time{}, false
@@ -142,34 +330,41 @@ func parseSectionHeading(line string) (time.Time, bool) {
 	return date, true
 }
 
-func extractTextAndTags(rest string) (string, []string) {
+func extractTextAndTags(rest string) (string, []string, []string) {
 	rest = strings.TrimSpace(rest)
 	if rest == "" {
-		return "", nil
+		return "", nil, nil
 	}
 
-	var tags []string
+	var tags, links []string
 	text := rest
 
 	// If tags exist they'll follow a space and start with '#'.
 	tagStart := strings.Index(rest, " #")
 	if tagStart >= 0 {
 		text = strings.TrimSpace(rest[:tagStart])
-		tags = parseTags(rest[tagStart+1:])
+		tags, links = parseTags(rest[tagStart+1:])
 	} else if strings.HasPrefix(rest, "#") {
 		text = ""
-		tags = parseTags(rest)
+		tags, links = parseTags(rest)
 	} else {
 		text = strings.TrimSpace(rest)
 	}
 
-	return text, tags
+	// ref: tokens are written between the text and the tags.
+	text, textLinks := SplitLinks(text)
+	return text, tags, append(textLinks, links...)
 }
 
-func parseTags(segment string) []string {
-	fields := strings.Fields(segment)
-	var tags []string
-	for _, field := range fields {
+// parseTags collects the #tags in segment, along with any ref: links written
+// among them.
+func parseTags(segment string) ([]string, []string) {
+	var tags, links []string
+	for field := range strings.FieldsSeq(segment) {
+		if link, ok := linkToken(field); ok {
+			links = append(links, link)
+			continue
+		}
 		if strings.HasPrefix(field, "#") && len(field) > 1 {
 			tag := strings.TrimLeft(field, "#")
 			if tag != "" {
@@ -177,5 +372,5 @@ func parseTags(segment string) []string {
 			}
 		}
 	}
-	return tags
+	return tags, links
 }
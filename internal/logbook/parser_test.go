@@ -2,7 +2,10 @@ package logbook
 
 import (
 	"errors"
+	"fmt"
 	"io"
+	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -146,3 +149,317 @@ func TestParserWithNilReader(t *testing.T) {
 		t.Fatalf("NextSection with nil reader error = %v, want io.EOF", err)
 	}
 }
+
+func TestParserPlacesEntriesInHeaderTimeZone(t *testing.T) {
+	input := `# November 2025
+<!-- time_zone: Asia/Tokyo -->
+
+## 2025-11-07
+- [ ] [08:30] Board flight #travel
+`
+
+	section, err := NewParser(strings.NewReader(input)).NextSection()
+	if err != nil {
+		t.Fatalf("NextSection: %v", err)
+	}
+	if got := section.Date.Location().String(); got != "Asia/Tokyo" {
+		t.Fatalf("section zone = %s, want Asia/Tokyo", got)
+	}
+	entry := section.Entries[0]
+	if entry.Time.Format("2006-01-02 15:04 MST") != "2025-11-07 08:30 JST" {
+		t.Fatalf("entry time = %s", entry.Time)
+	}
+}
+
+func TestParserAttachesIndentedNotes(t *testing.T) {
+	input := `## 2025-11-07
+- [ ] [08:30] Draft proposal #docs
+  Outline goals
+	Link the RFC
+
+  Stray indented text after a blank line
+- [x] [11:45] Plan retro #team
+`
+
+	section, err := NewParser(strings.NewReader(input)).NextSection()
+	if err != nil {
+		t.Fatalf("NextSection: %v", err)
+	}
+	if len(section.Entries) != 2 {
+		t.Fatalf("entries len = %d, want 2", len(section.Entries))
+	}
+	notes := section.Entries[0].Notes
+	if len(notes) != 2 || notes[0] != "Outline goals" || notes[1] != "Link the RFC" {
+		t.Fatalf("notes = %#v", notes)
+	}
+	if len(section.Entries[1].Notes) != 0 {
+		t.Fatalf("unexpected notes on second entry: %#v", section.Entries[1].Notes)
+	}
+}
+
+func TestParserRoundTripsExtendedStatuses(t *testing.T) {
+	lines := []string{
+		"- [ ] [08:00] Plan #ops",
+		"- [x] [09:00] Ship",
+		"- [~] [10:00] Refactor parser #code",
+		"- [-] [11:00] Old spike",
+		"- [!] [12:00] Wait on review",
+	}
+	input := "## 2025-11-07\n" + strings.Join(lines, "\n") + "\n  note under blocked\n"
+
+	section, err := NewParser(strings.NewReader(input)).NextSection()
+	if err != nil {
+		t.Fatalf("NextSection: %v", err)
+	}
+	want := []Status{StatusTodo, StatusDone, StatusInProgress, StatusCancelled, StatusBlocked}
+	if len(section.Entries) != len(want) {
+		t.Fatalf("entries len = %d, want %d", len(section.Entries), len(want))
+	}
+	for i, entry := range section.Entries {
+		if entry.Status != want[i] {
+			t.Fatalf("entry %d status = %v, want %v", i, entry.Status, want[i])
+		}
+		if got := formatEntry(entry); got != lines[i] {
+			t.Fatalf("formatEntry = %q, want %q", got, lines[i])
+		}
+	}
+	if notes := section.Entries[4].Notes; len(notes) != 1 || notes[0] != "note under blocked" {
+		t.Fatalf("notes = %#v", notes)
+	}
+}
+
+func TestStatusNextCyclesAndOpen(t *testing.T) {
+	cycle := []Status{StatusTodo, StatusInProgress, StatusDone, StatusTodo}
+	for i := 0; i < len(cycle)-1; i++ {
+		if got := cycle[i].Next(); got != cycle[i+1] {
+			t.Fatalf("%v.Next() = %v, want %v", cycle[i], got, cycle[i+1])
+		}
+	}
+	if StatusBlocked.Next() != StatusTodo || StatusCancelled.Next() != StatusTodo {
+		t.Fatalf("blocked and cancelled should reopen as todo")
+	}
+
+	for status, open := range map[Status]bool{
+		StatusTodo: true, StatusInProgress: true, StatusBlocked: true,
+		StatusDone: false, StatusCancelled: false,
+	} {
+		if status.Open() != open {
+			t.Fatalf("%v.Open() = %v, want %v", status, status.Open(), open)
+		}
+	}
+
+	for _, name := range []string{"wip", "doing", "canceled"} {
+		if _, err := ParseStatus(name); err != nil {
+			t.Fatalf("ParseStatus(%q): %v", name, err)
+		}
+	}
+	if _, err := ParseStatus("later"); err == nil {
+		t.Fatalf("expected error for unknown status")
+	}
+}
+
+func TestParserRoundTripsTimeRanges(t *testing.T) {
+	input := "## 2025-11-07\n- [x] [09:00-10:30] Planning #team\n- [x] [23:30-00:15] Deploy\n"
+
+	section, err := NewParser(strings.NewReader(input)).NextSection()
+	if err != nil {
+		t.Fatalf("NextSection: %v", err)
+	}
+	if got := section.Entries[0].Duration(); got != 90*time.Minute {
+		t.Fatalf("Duration = %v, want 1h30m", got)
+	}
+	if got := section.Entries[1].Duration(); got != 45*time.Minute {
+		t.Fatalf("midnight-crossing Duration = %v, want 45m", got)
+	}
+	if got := section.TrackedDuration(); got != 135*time.Minute {
+		t.Fatalf("TrackedDuration = %v", got)
+	}
+	if got := formatEntry(section.Entries[1]); got != "- [x] [23:30-00:15] Deploy" {
+		t.Fatalf("formatEntry = %q", got)
+	}
+}
+
+func TestParserRoundTripsLinks(t *testing.T) {
+	input := "## 2025-11-07\n- [ ] [09:00] Fix login ref:https://jira.example.com/browse/AUTH-12 ref:2025-11-06#2 #bug\n- [x] [10:00] Triage #ops ref:OPS-7\n"
+
+	section, err := NewParser(strings.NewReader(input)).NextSection()
+	if err != nil {
+		t.Fatalf("NextSection: %v", err)
+	}
+	first := section.Entries[0]
+	if first.Text != "Fix login" || !reflect.DeepEqual(first.Tags, []string{"bug"}) {
+		t.Fatalf("entry = %+v", first)
+	}
+	if want := []string{"https://jira.example.com/browse/AUTH-12", "2025-11-06#2"}; !reflect.DeepEqual(first.Links, want) {
+		t.Fatalf("Links = %v, want %v", first.Links, want)
+	}
+	if got := formatEntry(first); got != "- [ ] [09:00] Fix login ref:https://jira.example.com/browse/AUTH-12 ref:2025-11-06#2 #bug" {
+		t.Fatalf("formatEntry = %q", got)
+	}
+	// Links written among the tags are kept and moved before them.
+	if got := formatEntry(section.Entries[1]); got != "- [x] [10:00] Triage ref:OPS-7 #ops" {
+		t.Fatalf("formatEntry = %q", got)
+	}
+
+	ref, ok := ParseEntryRef(first.Links[1])
+	if !ok || ref.Index != 2 || ref.String() != "2025-11-06#2" {
+		t.Fatalf("ParseEntryRef = %+v, %v", ref, ok)
+	}
+	if _, ok := ParseEntryRef("AUTH-12"); ok {
+		t.Fatalf("ParseEntryRef accepted a non-entry reference")
+	}
+	if !IsURL(first.Links[0]) || IsURL("OPS-7") {
+		t.Fatalf("IsURL misclassified links")
+	}
+}
+
+func TestParserRoundTripsPinnedEntries(t *testing.T) {
+	input := "## 2025-11-07\n- [ ] [09:00] Standup #meeting\n- [ ] [14:00] * Ship release #ops\n"
+
+	section, err := NewParser(strings.NewReader(input)).NextSection()
+	if err != nil {
+		t.Fatalf("NextSection: %v", err)
+	}
+	pinned := section.Entries[1]
+	if !pinned.Pinned || pinned.Text != "Ship release" || section.Entries[0].Pinned {
+		t.Fatalf("entries = %+v", section.Entries)
+	}
+	if got := formatEntry(pinned); got != "- [ ] [14:00] * Ship release #ops" {
+		t.Fatalf("formatEntry = %q", got)
+	}
+	if got := section.DisplayOrder(); !reflect.DeepEqual(got, []int{1, 0}) {
+		t.Fatalf("DisplayOrder = %v, want [1 0]", got)
+	}
+}
+
+func TestParserSkipsCodeBlocksAndUndatedSections(t *testing.T) {
+	input := "## 2025-11-02\n" +
+		"- [x] [09:00] Real entry\n" +
+		"```\n" +
+		"## 2025-11-09\n" +
+		"- [ ] [10:00] Quoted entry\n" +
+		"```\n" +
+		"~~~~\n" +
+		"~~~\n" +
+		"- [ ] [10:30] Still quoted\n" +
+		"~~~~\n" +
+		"- [ ] [11:00] Second real entry\n" +
+		"## Notes\n" +
+		"- [ ] [12:00] Not part of the day\n" +
+		"## 2025-11-03\n" +
+		"- [ ] [08:00] Next day\n"
+
+	p := NewParser(strings.NewReader(input))
+	var got []string
+	for {
+		section, err := p.NextSection()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("NextSection: %v", err)
+		}
+		for _, entry := range section.Entries {
+			got = append(got, section.Date.Format("02")+" "+entry.Text)
+		}
+	}
+	want := []string{"02 Real entry", "02 Second real entry", "03 Next day"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("entries = %q, want %q", got, want)
+	}
+}
+
+func TestParserReadsLongLines(t *testing.T) {
+	long := strings.Repeat("word ", 40_000)
+	input := "## 2025-11-02\n- [ ] [09:00] " + long + "#big\n  " + long + "\n- [x] [10:00] After\n"
+
+	section, err := NewParser(strings.NewReader(input)).NextSection()
+	if err != nil {
+		t.Fatalf("NextSection: %v", err)
+	}
+	if len(section.Entries) != 2 || len(section.Entries[0].Notes) != 1 || section.Entries[0].Tags[0] != "big" {
+		t.Fatalf("entries = %d, want the long entry with its note and the one after it", len(section.Entries))
+	}
+}
+
+// entryPatternForTest is the regular expression entry lines were matched
+// with before matchEntryLine; the two must agree.
+var entryPatternForTest = regexp.MustCompile(`^- \[([ xX~!-])\] \[(\d{2}:\d{2}(?:-\d{2}:\d{2})?)\] (.*)$`)
+
+func TestMatchEntryLineAgreesWithPattern(t *testing.T) {
+	lines := []string{
+		"- [ ] [09:00] Todo",
+		"- [X] [09:00-10:30] Ranged #tag",
+		"- [!] [23:59] Blocked",
+		"- [~] [09:00] ",
+		"- [ ] [09:00]",
+		"- [?] [09:00] Unknown marker",
+		"- [ ] [9:00] Short hour",
+		"- [ ] [09:00-10] Half range",
+		"- [ ] [09:00-10:00-11:00] Two ranges",
+		"- [ ] [０9:00] Wide digit",
+		"* [ ] [09:00] Other bullet",
+		"- [ ][09:00] Missing space",
+		"",
+	}
+	for _, line := range lines {
+		marker, clock, rest, ok := matchEntryLine(line)
+		matches := entryPatternForTest.FindStringSubmatch(line)
+		if ok != (matches != nil) {
+			t.Fatalf("matchEntryLine(%q) ok = %v, pattern matched = %v", line, ok, matches != nil)
+		}
+		if ok && (string(marker) != matches[1] || clock != matches[2] || rest != matches[3]) {
+			t.Fatalf("matchEntryLine(%q) = %q, %q, %q; want %q", line, marker, clock, rest, matches[1:])
+		}
+	}
+}
+
+// benchmarkMonth returns a month file with entries per day entries, every
+// fourth one ranged and every fifth one followed by a note.
+func benchmarkMonth(entries int) string {
+	var b strings.Builder
+	b.WriteString("# November 2025\n")
+	for day := 1; day <= 30; day++ {
+		fmt.Fprintf(&b, "\n## 2025-11-%02d\n", day)
+		for i := range entries {
+			clock := fmt.Sprintf("%02d:%02d", 8+i/60%12, i%60)
+			if i%4 == 0 {
+				clock += fmt.Sprintf("-%02d:%02d", 9+i/60%12, i%60)
+			}
+			fmt.Fprintf(&b, "- [%c] [%s] Worked on item %d ref:https://tracker.example/T-%d #work #project%d\n", " x~!-"[i%5], clock, i, i, i%7)
+			if i%5 == 0 {
+				b.WriteString("  Follow-up notes for the item above\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// BenchmarkParserMonth streams a month of about 36,000 lines.
+func BenchmarkParserMonth(b *testing.B) {
+	input := benchmarkMonth(1000)
+	b.SetBytes(int64(len(input)))
+	b.ReportAllocs()
+	for b.Loop() {
+		p := NewParser(strings.NewReader(input))
+		for {
+			if _, err := p.NextSection(); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkParseEntryLine(b *testing.B) {
+	date := time.Date(2025, time.November, 2, 0, 0, 0, 0, time.UTC)
+	line := "- [x] [09:00-10:30] Worked on item ref:https://tracker.example/T-1 #work #project"
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, ok := parseEntryLine(line, date); !ok {
+			b.Fatal("line did not parse")
+		}
+	}
+}
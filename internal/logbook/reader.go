@@ -3,16 +3,31 @@ package logbook
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"os"
+	"runtime"
+	"sort"
 	"time"
 
 	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logging"
 )
 
-// Reader provides helpers to load sections from Markdown log files.
+// Reader provides helpers to load sections from Markdown log files. The most
+// recently read month files are cached in memory until they change on disk,
+// so repeated lookups in the same month parse the file once.
 type Reader struct {
-	manager *files.Manager
+	manager    *files.Manager
+	onProgress func(Progress)
+}
+
+// Progress reports how far a multi-month read has got: Done of Total months
+// read, the latest being Month.
+type Progress struct {
+	Done  int
+	Total int
+	Month time.Time
 }
 
 // NewReader wires a reader using the shared files.Manager.
@@ -20,18 +35,39 @@ func NewReader(manager *files.Manager) *Reader {
 	return &Reader{manager: manager}
 }
 
+// OnProgress registers fn to receive a Progress after each month that
+// SectionsBetween or ScanMonths reads. It returns r so it can follow
+// NewReader.
+func (r *Reader) OnProgress(fn func(Progress)) *Reader {
+	r.onProgress = fn
+	return r
+}
+
+func (r *Reader) progress(done, total int, month time.Time) {
+	if r.onProgress != nil {
+		r.onProgress(Progress{Done: done, Total: total, Month: month})
+	}
+}
+
 // Section returns the DateSection for the provided date.
 func (r *Reader) Section(ctx context.Context, date time.Time) (DateSection, error) {
 	if r == nil || r.manager == nil {
 		return DateSection{}, errors.New("reader not initialized with file manager")
 	}
-
-	path, err := r.manager.EnsureMonthFile(date)
-	if err != nil {
-		return DateSection{}, err
+	if !r.manager.Daily() {
+		sections, err := r.cachedMonth(ctx, date)
+		if err != nil {
+			return DateSection{}, err
+		}
+		for _, section := range sections {
+			if sameDay(section.Date, date) {
+				return cloneSection(section), nil
+			}
+		}
+		return DateSection{}, ErrSectionNotFound
 	}
 
-	file, err := os.Open(path)
+	file, err := r.manager.OpenMonth(date)
 	if err != nil {
 		return DateSection{}, err
 	}
@@ -53,7 +89,8 @@ func (r *Reader) Section(ctx context.Context, date time.Time) (DateSection, erro
 }
 
 // SectionsBetween returns all DateSections that exist between the provided
-// start and end dates (inclusive). Missing sections are skipped silently.
+// start and end dates (inclusive), in date order. Missing sections are
+// skipped silently. Each month file in the range is parsed once.
 func (r *Reader) SectionsBetween(ctx context.Context, start, end time.Time) ([]DateSection, error) {
 	if r == nil || r.manager == nil {
 		return nil, errors.New("reader not initialized with file manager")
@@ -61,7 +98,38 @@ func (r *Reader) SectionsBetween(ctx context.Context, start, end time.Time) ([]D
 	if end.Before(start) {
 		return nil, nil
 	}
+	if r.manager.Daily() {
+		return r.dailySectionsBetween(ctx, start, end)
+	}
+
+	from, to := start.Format("2006-01-02"), end.Format("2006-01-02")
+	var months []time.Time
+	for month := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location()); !month.After(end); month = month.AddDate(0, 1, 0) {
+		months = append(months, month)
+	}
+	var sections []DateSection
+	seen := make(map[string]bool)
+	err := r.scan(ctx, months, r.cachedMonth, func(month time.Time, cached []DateSection) error {
+		for _, section := range cached {
+			// As with Section, the first heading for a day wins.
+			day := section.Date.Format("2006-01-02")
+			if day < from || day > to || seen[day] {
+				continue
+			}
+			seen[day] = true
+			sections = append(sections, cloneSection(section))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(sections, func(i, j int) bool { return sections[i].Date.Before(sections[j].Date) })
+	return sections, nil
+}
 
+// dailySectionsBetween reads one daily note per day in the range.
+func (r *Reader) dailySectionsBetween(ctx context.Context, start, end time.Time) ([]DateSection, error) {
 	var sections []DateSection
 	for current := start; !current.After(end); current = current.AddDate(0, 0, 1) {
 		section, err := r.Section(ctx, current)
@@ -76,6 +144,147 @@ func (r *Reader) SectionsBetween(ctx context.Context, start, end time.Time) ([]D
 	return sections, nil
 }
 
+// ScanMonths reads months, passing the sections of each in file order to
+// visit and then reporting progress. Up to scanWorkers month files are read
+// at once, but visit sees the months in the order given, one at a time. A
+// visit error stops the scan. Only a few months are held ahead of visit, so
+// years of logs are never all in memory.
+func (r *Reader) ScanMonths(ctx context.Context, months []time.Time, visit func(month time.Time, sections []DateSection) error) error {
+	read := func(ctx context.Context, month time.Time) ([]DateSection, error) {
+		sections, err := r.MonthSections(ctx, month)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", month.Format("2006-01"), err)
+		}
+		return sections, nil
+	}
+	return r.scan(ctx, months, read, visit)
+}
+
+// scanWorkers caps how many month files a scan reads concurrently.
+var scanWorkers = runtime.GOMAXPROCS(0)
+
+type monthResult struct {
+	sections []DateSection
+	err      error
+}
+
+// scan reads months with a pool of workers and hands each result to visit
+// in order, reporting progress after every visit. A worker only starts on a
+// month once fewer than scanWorkers results are waiting for visit.
+func (r *Reader) scan(ctx context.Context, months []time.Time, read func(context.Context, time.Time) ([]DateSection, error), visit func(time.Time, []DateSection) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]chan monthResult, len(months))
+	for i := range results {
+		results[i] = make(chan monthResult, 1)
+	}
+	slots := make(chan struct{}, max(scanWorkers, 1))
+	go func() {
+		for i, month := range months {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			go func() {
+				sections, err := read(ctx, month)
+				results[i] <- monthResult{sections, err}
+			}()
+		}
+	}()
+
+	for i, month := range months {
+		var result monthResult
+		select {
+		case result = <-results[i]:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		<-slots
+		if result.err != nil {
+			return result.err
+		}
+		if err := visit(month, result.sections); err != nil {
+			return err
+		}
+		r.progress(i+1, len(months), month)
+	}
+	return nil
+}
+
+// MonthSections parses the whole month file containing month in one pass and
+// returns its sections in file order. In the daily-note layout it reads each
+// day's note instead.
+func (r *Reader) MonthSections(ctx context.Context, month time.Time) ([]DateSection, error) {
+	if r == nil || r.manager == nil {
+		return nil, errors.New("reader not initialized with file manager")
+	}
+	if r.manager.Daily() {
+		first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+		return r.SectionsBetween(ctx, first, first.AddDate(0, 1, -1))
+	}
+
+	cached, err := r.cachedMonth(ctx, month)
+	if err != nil {
+		return nil, err
+	}
+	sections := make([]DateSection, len(cached))
+	for i, section := range cached {
+		sections[i] = cloneSection(section)
+	}
+	return sections, nil
+}
+
+// cachedMonth returns the parsed sections of the live month file containing
+// month, reusing the cache while the file is unchanged. Archived and missing
+// months are parsed without caching. The result is shared with the cache.
+func (r *Reader) cachedMonth(ctx context.Context, month time.Time) ([]DateSection, error) {
+	path := r.manager.MonthPath(month)
+	info, err := os.Stat(path)
+	if err != nil {
+		return r.parseMonth(ctx, month)
+	}
+	if sections, ok := parsedMonths.get(path, info); ok {
+		logging.L().Debug("reuse parsed month", "path", path)
+		return sections, nil
+	}
+	sections, err := r.parseMonth(ctx, month)
+	if err != nil {
+		return nil, err
+	}
+	parsedMonths.put(path, info, sections)
+	return sections, nil
+}
+
+// parseMonth reads every section of the month file containing month.
+func (r *Reader) parseMonth(ctx context.Context, month time.Time) ([]DateSection, error) {
+	file, err := r.manager.OpenMonth(month)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var sections []DateSection
+	parser := NewParser(file)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		section, err := parser.NextSection()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				logging.L().Debug("parsed month", "month", month.Format("2006-01"), "sections", len(sections), "lines", parser.lineNo)
+				return sections, nil
+			}
+			return nil, err
+		}
+		if section != nil {
+			sections = append(sections, *section)
+		}
+	}
+}
+
 func sameDay(a, b time.Time) bool {
 	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
 }
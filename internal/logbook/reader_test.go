@@ -3,7 +3,9 @@ package logbook
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -112,3 +114,268 @@ func TestReaderSectionsBetween(t *testing.T) {
 		t.Fatalf("second section entry status = %v, want StatusDone", sections[1].Entries[0].Status)
 	}
 }
+
+func TestReaderMonthSections(t *testing.T) {
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	writer := NewWriter(mgr)
+	ctx := context.Background()
+
+	for _, day := range []int{3, 1, 20} {
+		date := time.Date(2025, time.November, day, 0, 0, 0, 0, time.UTC)
+		if err := writer.Append(ctx, date, Entry{Status: StatusTodo, Time: date.Add(9 * time.Hour), Text: "Entry"}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	sections, err := NewReader(mgr).MonthSections(ctx, time.Date(2025, time.November, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("MonthSections: %v", err)
+	}
+	var days []int
+	for _, section := range sections {
+		days = append(days, section.Date.Day())
+	}
+	if len(days) != 3 || days[0] != 3 || days[1] != 1 || days[2] != 20 {
+		t.Fatalf("days = %v, want file order [3 1 20]", days)
+	}
+}
+
+func TestReaderCachesMonthUntilItChanges(t *testing.T) {
+	ctx := context.Background()
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	reader := NewReader(mgr)
+	writer := NewWriter(mgr)
+
+	date := time.Date(2025, time.November, 9, 0, 0, 0, 0, time.UTC)
+	path, err := mgr.EnsureMonthFile(date)
+	if err != nil {
+		t.Fatalf("EnsureMonthFile: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("# November 2025\n\n## 2025-11-09\n- [ ] [09:00] First after:2025-11-08#1 #a\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	section, err := reader.Section(ctx, date)
+	if err != nil {
+		t.Fatalf("Section: %v", err)
+	}
+	if _, ok := parsedMonths.get(path, mustStat(t, path)); !ok {
+		t.Fatalf("month was not cached after Section")
+	}
+	// Callers own what they get back.
+	section.Entries[0].Text = "changed"
+	section.Entries[0].Tags[0] = "changed"
+	section.Entries[0].After[0] = "2025-11-01#9"
+	again, err := reader.Section(ctx, date)
+	if err != nil {
+		t.Fatalf("Section: %v", err)
+	}
+	if again.Entries[0].Text != "First" || again.Entries[0].Tags[0] != "a" || again.Entries[0].After[0] != "2025-11-08#1" {
+		t.Fatalf("cached entry was mutated: %+v", again.Entries[0])
+	}
+
+	// Writes drop the cached month so the next read sees them.
+	if err := writer.Append(ctx, date, Entry{Status: StatusTodo, Time: date.Add(10 * time.Hour), Text: "Second"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	again, err = reader.Section(ctx, date)
+	if err != nil {
+		t.Fatalf("Section: %v", err)
+	}
+	if len(again.Entries) != 2 {
+		t.Fatalf("entries after Append = %d, want 2", len(again.Entries))
+	}
+
+	// Edits made outside kerja are detected by modification time.
+	if err := os.WriteFile(path, []byte("# November 2025\n\n## 2025-11-09\n- [x] [09:00] Edited in vim\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+	sections, err := reader.MonthSections(ctx, date)
+	if err != nil {
+		t.Fatalf("MonthSections: %v", err)
+	}
+	if len(sections) != 1 || len(sections[0].Entries) != 1 || sections[0].Entries[0].Text != "Edited in vim" {
+		t.Fatalf("MonthSections after external edit = %+v", sections)
+	}
+}
+
+func mustStat(t *testing.T, path string) os.FileInfo {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	return info
+}
+
+func TestMonthCacheKeepsOnlyRecentMonths(t *testing.T) {
+	dir := t.TempDir()
+	cache := newMonthCache(2)
+	var paths []string
+	for _, name := range []string{"2025-09.md", "2025-10.md", "2025-11.md"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("# "+name+"\n"), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		paths = append(paths, path)
+	}
+
+	cache.put(paths[0], mustStat(t, paths[0]), nil)
+	cache.put(paths[1], mustStat(t, paths[1]), nil)
+	// Reading September makes October the least recently used.
+	if _, ok := cache.get(paths[0], mustStat(t, paths[0])); !ok {
+		t.Fatalf("September was not cached")
+	}
+	cache.put(paths[2], mustStat(t, paths[2]), nil)
+
+	for i, want := range []bool{true, false, true} {
+		if _, ok := cache.get(paths[i], mustStat(t, paths[i])); ok != want {
+			t.Fatalf("cached %s = %v, want %v", filepath.Base(paths[i]), ok, want)
+		}
+	}
+	if len(cache.entries) != 2 || len(cache.recent) != 2 {
+		t.Fatalf("cache holds %d entries and %d recent paths, want 2", len(cache.entries), len(cache.recent))
+	}
+}
+
+func TestReaderSectionsBetweenSpansMonthsInDateOrder(t *testing.T) {
+	ctx := context.Background()
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	write := func(month time.Time, content string) {
+		path, err := mgr.EnsureMonthFile(month)
+		if err != nil {
+			t.Fatalf("EnsureMonthFile: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	write(time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC),
+		"# October 2025\n\n## 2025-10-31\n- [x] [09:00] Halloween prep\n\n## 2025-10-30\n- [x] [09:00] Too early\n")
+	write(time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC),
+		"# November 2025\n\n## 2025-11-02\n- [x] [09:00] Second\n\n## 2025-11-01\n- [x] [09:00] First\n\n## 2025-11-02\n- [x] [10:00] Duplicate heading\n\n## 2025-11-20\n- [x] [09:00] Too late\n")
+
+	sections, err := NewReader(mgr).SectionsBetween(ctx,
+		time.Date(2025, time.October, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.November, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("SectionsBetween: %v", err)
+	}
+	var got []string
+	for _, section := range sections {
+		got = append(got, section.Date.Format("01-02")+" "+section.Entries[0].Text)
+	}
+	want := []string{"10-31 Halloween prep", "11-01 First", "11-02 Second"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("SectionsBetween = %q, want %q", got, want)
+	}
+}
+
+func TestReaderReportsScanProgress(t *testing.T) {
+	ctx := context.Background()
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	months := []time.Time{
+		time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	var got []string
+	reader := NewReader(mgr).OnProgress(func(p Progress) {
+		got = append(got, fmt.Sprintf("%d/%d %s", p.Done, p.Total, p.Month.Format("2006-01")))
+	})
+	var visited []string
+	err = reader.ScanMonths(ctx, months, func(month time.Time, sections []DateSection) error {
+		visited = append(visited, month.Format("2006-01"))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanMonths: %v", err)
+	}
+	if want := "1/3 2025-09|2/3 2025-10|3/3 2025-11"; strings.Join(got, "|") != want {
+		t.Fatalf("progress = %q, want %q", got, want)
+	}
+	if len(visited) != 3 {
+		t.Fatalf("visited %q", visited)
+	}
+
+	got = nil
+	if _, err := reader.SectionsBetween(ctx, months[1].AddDate(0, 0, 14), months[2].AddDate(0, 0, 3)); err != nil {
+		t.Fatalf("SectionsBetween: %v", err)
+	}
+	if want := "1/2 2025-10|2/2 2025-11"; strings.Join(got, "|") != want {
+		t.Fatalf("SectionsBetween progress = %q, want %q", got, want)
+	}
+}
+
+func TestReaderScanMonthsReadsConcurrentlyInOrder(t *testing.T) {
+	original := scanWorkers
+	t.Cleanup(func() { scanWorkers = original })
+	scanWorkers = 4
+
+	ctx := context.Background()
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	var months []time.Time
+	for i := range 12 {
+		month := time.Date(2024, time.Month(i+1), 1, 0, 0, 0, 0, time.UTC)
+		path, err := mgr.EnsureMonthFile(month)
+		if err != nil {
+			t.Fatalf("EnsureMonthFile: %v", err)
+		}
+		content := fmt.Sprintf("# %s\n\n## %s\n- [x] [09:00] Month %d\n", month.Format("January 2006"), month.Format("2006-01-02"), i+1)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		months = append(months, month)
+	}
+
+	var got []string
+	err = NewReader(mgr).ScanMonths(ctx, months, func(month time.Time, sections []DateSection) error {
+		got = append(got, sections[0].Entries[0].Text)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanMonths: %v", err)
+	}
+	for i, text := range got {
+		if want := fmt.Sprintf("Month %d", i+1); text != want {
+			t.Fatalf("month %d = %q, want %q (order %q)", i+1, text, want, got)
+		}
+	}
+
+	stop := errors.New("stop")
+	visited := 0
+	err = NewReader(mgr).ScanMonths(ctx, months, func(month time.Time, sections []DateSection) error {
+		if visited++; visited == 3 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || visited != 3 {
+		t.Fatalf("ScanMonths after stop = %v with %d visits", err, visited)
+	}
+
+	sections, err := NewReader(mgr).SectionsBetween(ctx, months[0], months[11].AddDate(0, 1, -1))
+	if err != nil || len(sections) != 12 || sections[11].Entries[0].Text != "Month 12" {
+		t.Fatalf("SectionsBetween = %d sections, %v", len(sections), err)
+	}
+}
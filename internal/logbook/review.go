@@ -0,0 +1,96 @@
+package logbook
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ReviewAction is the outcome chosen for an open todo at the end of a day.
+type ReviewAction uint8
+
+const (
+	// ReviewKeep leaves the entry untouched.
+	ReviewKeep ReviewAction = iota
+	// ReviewDone marks the entry done.
+	ReviewDone
+	// ReviewCarry moves the entry to the decision's Target day.
+	ReviewCarry
+	// ReviewDrop deletes the entry.
+	ReviewDrop
+)
+
+// ReviewDecision pairs an entry index (1-based) with its outcome.
+type ReviewDecision struct {
+	Index  int
+	Action ReviewAction
+	Target time.Time
+}
+
+// ApplyReview carries out decisions for the entries of date as one journaled
+// change, so a single undo reverts the whole review. Every index is checked
+// before anything is written.
+func (w *Writer) ApplyReview(ctx context.Context, date time.Time, decisions []ReviewDecision) error {
+	path, doc, err := w.loadMonth(date)
+	if err != nil {
+		return err
+	}
+	section := doc.section(date)
+	if section == nil {
+		return ErrSectionNotFound
+	}
+	blocks := section.entryBlocks()
+	for _, decision := range decisions {
+		if decision.Index < 1 || decision.Index > len(blocks) {
+			return ErrInvalidIndex
+		}
+		if decision.Action == ReviewCarry && sameDay(decision.Target, date) {
+			return ErrSameDate
+		}
+	}
+
+	var (
+		order   []string
+		targets = map[string]*document{path: doc}
+		changed int
+	)
+	for _, decision := range decisions {
+		block := blocks[decision.Index-1]
+		switch decision.Action {
+		case ReviewDone:
+			entry := *block.entry
+			entry.Status = StatusDone
+			block.replace(entry)
+			w.tidy(section)
+		case ReviewCarry:
+			targetPath := w.manager.MonthPath(decision.Target)
+			target, ok := targets[targetPath]
+			if !ok {
+				if _, target, err = w.loadMonth(decision.Target); err != nil {
+					return err
+				}
+				targets[targetPath] = target
+				order = append(order, targetPath)
+			}
+			section.remove(block)
+			w.place(target.ensureSection(decision.Target), normalizeEntryTime(decision.Target, *block.entry))
+		case ReviewDrop:
+			section.remove(block)
+		default:
+			continue
+		}
+		changed++
+	}
+	if changed == 0 {
+		return nil
+	}
+
+	// Write carry targets first: an interrupted review leaves duplicates
+	// rather than losing entries.
+	writes := make([]monthWrite, 0, len(order)+1)
+	for _, targetPath := range order {
+		writes = append(writes, monthWrite{targetPath, targets[targetPath].lines()})
+	}
+	writes = append(writes, monthWrite{path, doc.lines()})
+	return w.commit(fmt.Sprintf("review %s (%d changes)", date.Format("2006-01-02"), changed), writes...)
+}
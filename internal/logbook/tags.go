@@ -0,0 +1,170 @@
+package logbook
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// TagScope limits a tag rewrite to entries dated from Start to End; a zero
+// Start or End leaves that side of the range open. DryRun reports what would
+// change without writing anything.
+type TagScope struct {
+	Start  time.Time
+	End    time.Time
+	DryRun bool
+}
+
+// TagRewrite counts what a tag rewrite changed and lists each changed entry
+// with its new tags, oldest first.
+type TagRewrite struct {
+	Entries int             `json:"entries"`
+	Files   int             `json:"files"`
+	Changed []RetaggedEntry `json:"changed"`
+}
+
+// RetaggedEntry is one entry a tag rewrite changed.
+type RetaggedEntry struct {
+	Ref   EntryRef `json:"ref"`
+	Entry Entry    `json:"entry"`
+}
+
+// ValidTag reports why tag cannot be written as a #tag, or nil when it can.
+func ValidTag(tag string) error {
+	if tag == "" || strings.HasPrefix(tag, "#") || strings.IndexFunc(tag, isSpace) >= 0 {
+		return fmt.Errorf("invalid tag %q (expected a single word without #)", tag)
+	}
+	return nil
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
+
+// RenameTag replaces oldTag with newTag on every entry in scope. Entries
+// already carrying newTag keep a single copy of it.
+func (w *Writer) RenameTag(ctx context.Context, scope TagScope, oldTag, newTag string) (TagRewrite, error) {
+	return w.retag(ctx, fmt.Sprintf("rename tag #%s to #%s", oldTag, newTag), scope, []string{oldTag}, newTag)
+}
+
+// MergeTags replaces every tag in sources with into on each entry in scope,
+// keeping a single copy of into.
+func (w *Writer) MergeTags(ctx context.Context, scope TagScope, sources []string, into string) (TagRewrite, error) {
+	return w.retag(ctx, fmt.Sprintf("merge %s into #%s", hashTags(sources), into), scope, sources, into)
+}
+
+// RemoveTag drops tag from every entry in scope.
+func (w *Writer) RemoveTag(ctx context.Context, scope TagScope, tag string) (TagRewrite, error) {
+	return w.rewriteTags(ctx, "remove tag #"+tag, scope, func(tags []string) ([]string, bool) {
+		if !slices.Contains(tags, tag) {
+			return tags, false
+		}
+		return slices.DeleteFunc(slices.Clone(tags), func(t string) bool { return t == tag }), true
+	})
+}
+
+func (w *Writer) retag(ctx context.Context, op string, scope TagScope, sources []string, into string) (TagRewrite, error) {
+	if err := ValidTag(into); err != nil {
+		return TagRewrite{}, err
+	}
+	return w.rewriteTags(ctx, op, scope, func(tags []string) ([]string, bool) {
+		if !slices.ContainsFunc(tags, func(tag string) bool { return slices.Contains(sources, tag) }) {
+			return tags, false
+		}
+		retagged := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			if slices.Contains(sources, tag) {
+				tag = into
+			}
+			if !slices.Contains(retagged, tag) {
+				retagged = append(retagged, tag)
+			}
+		}
+		return retagged, true
+	})
+}
+
+func hashTags(tags []string) string {
+	parts := make([]string, len(tags))
+	for i, tag := range tags {
+		parts[i] = "#" + tag
+	}
+	return strings.Join(parts, ", ")
+}
+
+// rewriteTags applies rewrite to the tags of every entry in scope and
+// writes each changed file as one journaled change, so a single undo
+// reverts the whole rewrite. rewrite reports whether it changed the tags.
+func (w *Writer) rewriteTags(ctx context.Context, op string, scope TagScope, rewrite func([]string) ([]string, bool)) (TagRewrite, error) {
+	if w == nil || w.manager == nil {
+		return TagRewrite{}, fmt.Errorf("writer not initialized with file manager")
+	}
+	months, err := w.manager.Months()
+	if err != nil || len(months) == 0 {
+		return TagRewrite{}, err
+	}
+	start, end := scope.Start, scope.End
+	if start.IsZero() {
+		start = months[0]
+	}
+	if end.IsZero() {
+		end = months[len(months)-1].AddDate(0, 1, -1)
+	}
+	sections, err := NewReader(w.manager).SectionsBetween(ctx, start, end)
+	if err != nil {
+		return TagRewrite{}, err
+	}
+
+	// Find the changes from the cached sections first, so a dry run never
+	// loads a month for writing, which would restore an archived one.
+	var (
+		result  TagRewrite
+		order   []string
+		touched = make(map[string][]DateSection)
+	)
+	for _, section := range sections {
+		changed := false
+		for i, entry := range section.Entries {
+			tags, ok := rewrite(entry.Tags)
+			if !ok {
+				continue
+			}
+			entry.Tags = tags
+			result.Changed = append(result.Changed, RetaggedEntry{Ref: EntryRef{Date: section.Date, Index: i + 1}, Entry: entry})
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		key := w.manager.MonthPath(section.Date)
+		if _, ok := touched[key]; !ok {
+			order = append(order, key)
+		}
+		touched[key] = append(touched[key], section)
+	}
+	result.Entries, result.Files = len(result.Changed), len(order)
+	if result.Entries == 0 || scope.DryRun {
+		return result, nil
+	}
+
+	writes := make([]monthWrite, len(order))
+	for i, key := range order {
+		path, doc, err := w.loadMonth(touched[key][0].Date)
+		if err != nil {
+			return TagRewrite{}, err
+		}
+		for _, section := range touched[key] {
+			for _, block := range doc.section(section.Date).entryBlocks() {
+				if tags, ok := rewrite(block.entry.Tags); ok {
+					entry := *block.entry
+					entry.Tags = tags
+					block.replace(entry)
+				}
+			}
+		}
+		writes[i] = monthWrite{path, doc.lines()}
+	}
+	return result, w.commit(fmt.Sprintf("%s (%d entries)", op, result.Entries), writes...)
+}
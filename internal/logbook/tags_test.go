@@ -0,0 +1,100 @@
+package logbook
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/files"
+)
+
+func TestWriterRenameTagAcrossMonths(t *testing.T) {
+	ctx := context.Background()
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	writer := NewWriter(mgr)
+	day := func(month time.Month, d int) time.Time { return time.Date(2025, month, d, 0, 0, 0, 0, time.Local) }
+
+	for _, entry := range []struct {
+		date time.Time
+		text string
+		tags []string
+	}{
+		{day(10, 30), "Old plan", []string{"proj"}},
+		{day(11, 3), "Kickoff", []string{"proj", "team"}},
+		{day(11, 4), "Both", []string{"project", "proj"}},
+		{day(11, 5), "Other", []string{"ops"}},
+		{day(12, 1), "Later", []string{"proj"}},
+	} {
+		if err := writer.Append(ctx, entry.date, Entry{Time: entry.date.Add(9 * time.Hour), Text: entry.text, Tags: entry.tags}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	result, err := writer.RenameTag(ctx, TagScope{Start: day(11, 1)}, "proj", "project")
+	if err != nil {
+		t.Fatalf("RenameTag: %v", err)
+	}
+	if result.Entries != 3 || result.Files != 2 || result.Changed[1].Ref.String() != "2025-11-04#1" {
+		t.Fatalf("RenameTag = %+v, want 3 entries in 2 files", result)
+	}
+
+	november, err := os.ReadFile(mgr.MonthPath(day(11, 1)))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	for _, want := range []string{"Kickoff #project #team\n", "Both #project\n", "Other #ops\n"} {
+		if !strings.Contains(string(november), want) {
+			t.Fatalf("November missing %q:\n%s", want, november)
+		}
+	}
+	october, err := os.ReadFile(mgr.MonthPath(day(10, 1)))
+	if err != nil || !strings.Contains(string(october), "Old plan #proj\n") {
+		t.Fatalf("October outside the range changed: %s, %v", october, err)
+	}
+
+	if _, err := writer.Undo(ctx); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	december, err := os.ReadFile(mgr.MonthPath(day(12, 1)))
+	if err != nil || !strings.Contains(string(december), "Later #proj\n") {
+		t.Fatalf("undo did not revert December: %s, %v", december, err)
+	}
+
+	if _, err := writer.RenameTag(ctx, TagScope{}, "proj", "two words"); err == nil {
+		t.Fatalf("expected error for invalid tag")
+	}
+}
+
+func TestWriterRemoveTagDryRunLeavesFiles(t *testing.T) {
+	ctx := context.Background()
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	writer := NewWriter(mgr)
+	date := time.Date(2025, time.November, 3, 0, 0, 0, 0, time.Local)
+	if err := writer.Append(ctx, date, Entry{Time: date.Add(9 * time.Hour), Text: "Spike", Tags: []string{"wip", "infra"}}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	before, _ := os.ReadFile(mgr.MonthPath(date))
+
+	result, err := writer.RemoveTag(ctx, TagScope{DryRun: true}, "wip")
+	if err != nil || result.Entries != 1 || strings.Join(result.Changed[0].Entry.Tags, ",") != "infra" {
+		t.Fatalf("RemoveTag dry run = %+v, %v", result, err)
+	}
+	if after, _ := os.ReadFile(mgr.MonthPath(date)); string(after) != string(before) {
+		t.Fatalf("dry run changed the file:\n%s", after)
+	}
+
+	if _, err := writer.RemoveTag(ctx, TagScope{}, "wip"); err != nil {
+		t.Fatalf("RemoveTag: %v", err)
+	}
+	if after, _ := os.ReadFile(mgr.MonthPath(date)); !strings.Contains(string(after), "Spike #infra\n") {
+		t.Fatalf("tag not removed:\n%s", after)
+	}
+}
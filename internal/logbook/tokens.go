@@ -0,0 +1,120 @@
+package logbook
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TokenInput holds the pieces recognised in a free-form entry line. Time,
+// End, and Status are nil when the line did not set them.
+type TokenInput struct {
+	Text   string
+	Tags   []string
+	Links  []string
+	After  []string
+	Time   *time.Time
+	End    *time.Time
+	Status *Status
+	// Pinned is set by a standalone * token.
+	Pinned bool
+	// Author is set by a ~name token.
+	Author string
+	// Due is set by a due:YYYY-MM-DD token, at midnight in base's zone.
+	Due time.Time
+}
+
+// ParseTokens splits a free-form entry line into text, #tags, an optional
+// @HH:MM or @9:45pm timestamp or @HH:MM-HH:MM range (anchored to base's
+// date), a time relative to now such as @now, @+30m, or @-1h, an optional
+// !status such as !done, a standalone * that pins the entry, a ~name author,
+// a due:YYYY-MM-DD deadline, ref: links, and after:YYYY-MM-DD#N
+// dependencies.
+func ParseTokens(input string, base time.Time) (TokenInput, error) {
+	result := TokenInput{}
+	if strings.TrimSpace(input) == "" {
+		return result, nil
+	}
+
+	var textParts []string
+	var tags []string
+	for _, token := range strings.Fields(input) {
+		if link, ok := linkToken(token); ok {
+			result.Links = append(result.Links, link)
+			continue
+		}
+		if name, ok := authorToken(token); ok && result.Author == "" {
+			result.Author = name
+			continue
+		}
+		if target, ok := afterToken(token); ok {
+			result.After = append(result.After, target)
+			continue
+		}
+		if due, ok := dueToken(token, base.Location()); ok && result.Due.IsZero() {
+			result.Due = due
+			continue
+		}
+		switch {
+		case token == "*":
+			result.Pinned = true
+		case strings.HasPrefix(token, "#") && len(token) > 1:
+			tags = append(tags, strings.TrimPrefix(token, "#"))
+		case strings.HasPrefix(token, "@") && len(token) > 1:
+			if when, ok, err := ParseRelativeClock(token[1:], base); ok {
+				if err != nil {
+					return TokenInput{}, err
+				}
+				result.Time = &when
+				result.End = nil
+				continue
+			}
+			when, end, err := ParseClockRange(token[1:], base)
+			if err != nil {
+				return TokenInput{}, err
+			}
+			result.Time = &when
+			if !end.IsZero() {
+				result.End = &end
+			}
+		case strings.HasPrefix(token, "!") && len(token) > 1:
+			status, err := ParseStatus(token[1:])
+			if err != nil {
+				return TokenInput{}, fmt.Errorf("invalid status %q (expected !%s)", token, strings.Join(statusNames, ", !"))
+			}
+			result.Status = &status
+		default:
+			textParts = append(textParts, token)
+		}
+	}
+
+	result.Text = strings.TrimSpace(strings.Join(textParts, " "))
+	result.Tags = tags
+	return result, nil
+}
+
+// Entry builds an entry from the tokens, at the time or range they give or
+// else at, and with status unless they carry a !status.
+func (t TokenInput) Entry(at time.Time, status Status) Entry {
+	entry := Entry{
+		Status: status,
+		Time:   at,
+		Text:   t.Text,
+		Tags:   t.Tags,
+		Links:  t.Links,
+		After:  t.After,
+		Pinned: t.Pinned,
+		Author: t.Author,
+		Due:    t.Due,
+	}
+	if t.Status != nil {
+		entry.Status = *t.Status
+	}
+	if t.Time != nil {
+		entry.Time = *t.Time
+	}
+	if t.End != nil {
+		entry.End = *t.End
+	}
+	return entry
+}
@@ -0,0 +1,153 @@
+package logbook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTokensExtractsTimeStatusAndTags(t *testing.T) {
+	base := time.Date(2025, time.November, 6, 0, 0, 0, 0, time.UTC)
+
+	got, err := ParseTokens("Ship release @14:20 !done #ops #release", base)
+	if err != nil {
+		t.Fatalf("ParseTokens: %v", err)
+	}
+	if got.Text != "Ship release" {
+		t.Fatalf("Text = %q", got.Text)
+	}
+	if len(got.Tags) != 2 || got.Tags[0] != "ops" || got.Tags[1] != "release" {
+		t.Fatalf("Tags = %v", got.Tags)
+	}
+	if got.Time == nil || !got.Time.Equal(time.Date(2025, time.November, 6, 14, 20, 0, 0, time.UTC)) {
+		t.Fatalf("Time = %v", got.Time)
+	}
+	if got.Status == nil || *got.Status != StatusDone {
+		t.Fatalf("Status = %v", got.Status)
+	}
+
+	got, err = ParseTokens("Waiting on vendor !blocked", base)
+	if err != nil || got.Status == nil || *got.Status != StatusBlocked {
+		t.Fatalf("ParseTokens !blocked = %+v, %v", got, err)
+	}
+}
+
+func TestParseTokensRejectsInvalidTokens(t *testing.T) {
+	base := time.Date(2025, time.November, 6, 0, 0, 0, 0, time.UTC)
+
+	if _, err := ParseTokens("Meeting @25:99", base); err == nil {
+		t.Fatalf("expected invalid time error")
+	}
+	if _, err := ParseTokens("Meeting !later", base); err == nil {
+		t.Fatalf("expected invalid status error")
+	}
+}
+
+func TestParseTokensAcceptsTimeRange(t *testing.T) {
+	base := time.Date(2025, time.November, 6, 0, 0, 0, 0, time.UTC)
+
+	got, err := ParseTokens("Planning @09:00-10:30 #team", base)
+	if err != nil {
+		t.Fatalf("ParseTokens: %v", err)
+	}
+	if got.Time == nil || got.End == nil || got.End.Sub(*got.Time) != 90*time.Minute {
+		t.Fatalf("range = %v-%v", got.Time, got.End)
+	}
+	if _, err := ParseTokens("Planning @09:00-25:00", base); err == nil {
+		t.Fatalf("expected invalid range error")
+	}
+}
+
+func TestParseTokensAcceptsTwelveHourTimes(t *testing.T) {
+	base := time.Date(2025, time.November, 6, 0, 0, 0, 0, time.UTC)
+
+	for input, want := range map[string]string{
+		"Call @9:45pm":       "21:45",
+		"Call @9:45PM":       "21:45",
+		"Call @12am":         "00:00",
+		"Call @12:30pm":      "12:30",
+		"Call @7am":          "07:00",
+		"Call @9:05":         "09:05",
+		"Call @11pm-1am #ic": "23:00",
+	} {
+		got, err := ParseTokens(input, base)
+		if err != nil {
+			t.Fatalf("ParseTokens(%q): %v", input, err)
+		}
+		if got.Time == nil || got.Time.Format("15:04") != want {
+			t.Fatalf("ParseTokens(%q) time = %v, want %s", input, got.Time, want)
+		}
+	}
+	got, err := ParseTokens("Standup @9am-9:15am", base)
+	if err != nil || got.End == nil || got.End.Sub(*got.Time) != 15*time.Minute {
+		t.Fatalf("12-hour range = %+v, %v", got, err)
+	}
+	for _, input := range []string{"Call @13pm", "Call @9:45xm", "Call @9:75pm"} {
+		if _, err := ParseTokens(input, base); err == nil {
+			t.Fatalf("ParseTokens(%q) should fail", input)
+		}
+	}
+}
+
+func TestParseTokensCollectsLinks(t *testing.T) {
+	got, err := ParseTokens("Review ref:https://github.com/org/repo/pull/4 PR #review", time.Now())
+	if err != nil {
+		t.Fatalf("ParseTokens: %v", err)
+	}
+	if got.Text != "Review PR" || len(got.Links) != 1 || got.Links[0] != "https://github.com/org/repo/pull/4" {
+		t.Fatalf("ParseTokens = %+v", got)
+	}
+}
+
+func TestParseTokensAcceptsRelativeTimes(t *testing.T) {
+	original := clockNow
+	t.Cleanup(func() { clockNow = original })
+	clockNow = func() time.Time { return time.Date(2025, time.November, 6, 14, 20, 45, 0, time.UTC) }
+	base := time.Date(2025, time.November, 3, 0, 0, 0, 0, time.UTC)
+
+	for input, want := range map[string]time.Time{
+		"Standup @now":      time.Date(2025, time.November, 3, 14, 20, 0, 0, time.UTC),
+		"Standup @+30m":     time.Date(2025, time.November, 3, 14, 50, 0, 0, time.UTC),
+		"Standup @-1h":      time.Date(2025, time.November, 3, 13, 20, 0, 0, time.UTC),
+		"Standup @-1h30m":   time.Date(2025, time.November, 3, 12, 50, 0, 0, time.UTC),
+		"@09:00-10:00 @now": time.Date(2025, time.November, 3, 14, 20, 0, 0, time.UTC),
+	} {
+		got, err := ParseTokens(input, base)
+		if err != nil {
+			t.Fatalf("ParseTokens(%q): %v", input, err)
+		}
+		if got.Time == nil || !got.Time.Equal(want) || got.End != nil {
+			t.Fatalf("ParseTokens(%q) Time = %v, End = %v, want %v", input, got.Time, got.End, want)
+		}
+	}
+
+	if _, err := ParseTokens("Standup @+soon", base); err == nil {
+		t.Fatalf("expected error for invalid relative time")
+	}
+}
+
+func TestTokenInputEntryCarriesEveryToken(t *testing.T) {
+	base := time.Date(2025, time.November, 6, 8, 15, 0, 0, time.UTC)
+
+	parsed, err := ParseTokens("* Sync with ~bob @09:00-10:00 !in-progress due:2025-11-07 ref:https://ex.am/1 after:2025-11-05#2 #ops", base)
+	if err != nil {
+		t.Fatalf("ParseTokens: %v", err)
+	}
+	entry := parsed.Entry(base, StatusTodo)
+	if entry.Text != "Sync with" || !entry.Pinned || entry.Author != "bob" || entry.Status != StatusInProgress {
+		t.Fatalf("entry = %+v", entry)
+	}
+	if entry.Time.Hour() != 9 || entry.End.Hour() != 10 || entry.Due.Day() != 7 {
+		t.Fatalf("times = %v-%v, due %v", entry.Time, entry.End, entry.Due)
+	}
+	if len(entry.Tags) != 1 || len(entry.Links) != 1 || len(entry.After) != 1 {
+		t.Fatalf("tags = %v, links = %v, after = %v", entry.Tags, entry.Links, entry.After)
+	}
+
+	parsed, err = ParseTokens("Plain note", base)
+	if err != nil {
+		t.Fatalf("ParseTokens: %v", err)
+	}
+	if entry := parsed.Entry(base, StatusDone); !entry.Time.Equal(base) || entry.Status != StatusDone || !entry.End.IsZero() {
+		t.Fatalf("plain entry = %+v", entry)
+	}
+}
@@ -3,22 +3,105 @@ package logbook
 import (
 	"context"
 	"fmt"
-	"os"
-	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logging"
 )
 
 // Writer handles append, toggle, edit, and delete operations on Markdown log files.
+// Every mutation is journaled first so it can be reverted with Undo, and the
+// entries it changed are appended to the history afterwards.
 type Writer struct {
-	manager *files.Manager
+	manager    *files.Manager
+	journal    *Journal
+	history    *History
+	index      *Index
+	sortByTime bool
+	author     string
+	onWrite    func(path string, before, after []string)
+	onChange   func(op string, changes []EntryChange)
 }
 
 // NewWriter wires the dependencies required to manipulate Markdown log files.
 func NewWriter(manager *files.Manager) *Writer {
-	return &Writer{manager: manager}
+	w := &Writer{manager: manager}
+	if manager != nil {
+		w.journal = NewJournal(manager.BasePath())
+		// The history holds entries in plain text, so encrypted logbooks
+		// keep none.
+		if !manager.Encrypted() {
+			w.history = NewHistory(manager.BasePath())
+		}
+	}
+	return w
+}
+
+// SortByTime makes appends, edits, and moves keep the day sections they touch
+// ordered by entry time. Entries with equal times keep their order. It
+// returns w so it can follow NewWriter.
+func (w *Writer) SortByTime(enabled bool) *Writer {
+	w.sortByTime = enabled
+	return w
+}
+
+// StampAuthor credits appended entries that name no author to author, so a
+// shared logbook records who wrote what. An empty author leaves them as they
+// are. It returns w so it can follow NewWriter.
+func (w *Writer) StampAuthor(author string) *Writer {
+	w.author = author
+	return w
+}
+
+// OnWrite registers fn to receive the lines of each month file before and
+// after a change or undo rewrites it. It returns w so it can follow NewWriter.
+func (w *Writer) OnWrite(fn func(path string, before, after []string)) *Writer {
+	w.onWrite = fn
+	return w
+}
+
+// OnChange registers fn to receive the entries each change or undo created,
+// edited, toggled, or deleted, once the files are written. Writes that change
+// no entry are not reported. It returns w so it can follow NewWriter.
+func (w *Writer) OnChange(fn func(op string, changes []EntryChange)) *Writer {
+	w.onChange = fn
+	return w
+}
+
+// MaintainIndex keeps the search index in step with every change and undo.
+// Encrypted logbooks and daily notes are never indexed. It returns w so it
+// can follow NewWriter.
+func (w *Writer) MaintainIndex(enabled bool) *Writer {
+	w.index = nil
+	if enabled && w.manager != nil && !w.manager.Encrypted() && !w.manager.Daily() {
+		w.index = NewIndex(w.manager.BasePath())
+	}
+	return w
+}
+
+// Undo reverts the most recent journaled change.
+func (w *Writer) Undo(ctx context.Context) (Change, error) {
+	if w == nil || w.journal == nil {
+		return Change{}, fmt.Errorf("writer not initialized with file manager")
+	}
+	var (
+		changes []EntryChange
+		paths   []string
+	)
+	change, err := w.journal.undo(func(path string, restore func() error) error {
+		paths = append(paths, path)
+		return w.observe(path, restore, &changes)
+	})
+	if err != nil {
+		return change, err
+	}
+	logging.L().Info("undo change", "op", change.Op, "files", len(change.Files))
+	if err := w.reindex(paths); err != nil {
+		return change, err
+	}
+	return change, w.record("undo "+change.Op, changes)
 }
 
 // Append adds a new entry at the end of the target section, creating the section if needed.
@@ -27,54 +110,114 @@ func (w *Writer) Append(ctx context.Context, date time.Time, entry Entry) error
 		return fmt.Errorf("writer not initialized with file manager")
 	}
 
-	entry = normalizeEntryTime(date, entry)
+	entry, err := w.pinAfter(ctx, w.stamp(normalizeEntryTime(date, entry)), nil)
+	if err != nil {
+		return err
+	}
 
-	path, lines, state, err := w.loadSection(ctx, date)
+	path, doc, err := w.loadMonth(date)
 	if err != nil {
 		return err
 	}
 
-	if state == nil {
-		heading := dateHeading(date)
-		if needsSeparation(lines) {
-			lines = append(lines, "")
+	w.place(doc.ensureSection(date), entry)
+	return w.commit(fmt.Sprintf("append to %s", date.Format("2006-01-02")), monthWrite{path, doc.lines()})
+}
+
+// EnsureSection adds the heading for date when its month file lacks one and
+// returns the file's path with the 1-based line number of the heading.
+func (w *Writer) EnsureSection(ctx context.Context, date time.Time) (string, int, error) {
+	path, doc, err := w.loadMonth(date)
+	if err != nil {
+		return "", 0, err
+	}
+	if doc.section(date) == nil {
+		doc.ensureSection(date)
+		if err := w.commit(fmt.Sprintf("add section %s", date.Format("2006-01-02")), monthWrite{path, doc.lines()}); err != nil {
+			return "", 0, err
 		}
-		lines = append(lines, heading)
-		lines = append(lines, formatEntry(entry))
-	} else {
-		insertAt := state.end
-		lines = insertLine(lines, insertAt, formatEntry(entry))
 	}
 
-	return writeLines(path, lines)
+	heading := dateHeading(date)
+	lines := doc.lines()
+	for i, line := range lines {
+		if strings.TrimSpace(line) == heading {
+			return path, i + 1, nil
+		}
+	}
+	return path, len(lines), nil
 }
 
-// Toggle flips StatusTodo <-> StatusDone for the entry at index (1-based) within the section.
-func (w *Writer) Toggle(ctx context.Context, date time.Time, index int) (Entry, error) {
-	path, lines, state, err := w.loadSection(ctx, date)
-	if err != nil {
-		return Entry{}, err
+// AppendBatch appends many entries, using each entry's Time to pick its date
+// section. Entries are grouped by month so every month file is rewritten once,
+// and the relative order of entries within a day is preserved.
+func (w *Writer) AppendBatch(ctx context.Context, entries []Entry) error {
+	if w == nil || w.manager == nil {
+		return fmt.Errorf("writer not initialized with file manager")
 	}
-	if state == nil {
-		return Entry{}, ErrSectionNotFound
+
+	type monthBatch struct {
+		anchor time.Time
+		days   []time.Time
+		byDay  map[string][]Entry
 	}
-	if index < 1 || index > len(state.entryIndexes) {
-		return Entry{}, ErrInvalidIndex
+	var (
+		order   []string
+		batches = make(map[string]*monthBatch)
+	)
+	for _, entry := range entries {
+		// Group by the file holding the entry: a month file, or a daily note.
+		monthKey := w.manager.MonthPath(entry.Time)
+		batch, ok := batches[monthKey]
+		if !ok {
+			batch = &monthBatch{anchor: entry.Time, byDay: make(map[string][]Entry)}
+			batches[monthKey] = batch
+			order = append(order, monthKey)
+		}
+		dayKey := entry.Time.Format("2006-01-02")
+		if _, ok := batch.byDay[dayKey]; !ok {
+			batch.days = append(batch.days, entry.Time)
+		}
+		entry, err := w.pinAfter(ctx, w.stamp(normalizeEntryTime(entry.Time, entry)), nil)
+		if err != nil {
+			return err
+		}
+		batch.byDay[dayKey] = append(batch.byDay[dayKey], entry)
 	}
 
-	lineIdx := state.entryIndexes[index-1]
-	entry := state.section.Entries[index-1]
-	switch entry.Status {
-	case StatusTodo:
-		entry.Status = StatusDone
-	case StatusDone:
-		entry.Status = StatusTodo
-	default:
-		entry.Status = StatusTodo
+	writes := make([]monthWrite, 0, len(order))
+	for _, monthKey := range order {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		batch := batches[monthKey]
+		path, doc, err := w.loadMonth(batch.anchor)
+		if err != nil {
+			return err
+		}
+		for _, day := range batch.days {
+			w.place(doc.ensureSection(day), batch.byDay[day.Format("2006-01-02")]...)
+		}
+		writes = append(writes, monthWrite{path, doc.lines()})
+	}
+	if len(writes) == 0 {
+		return nil
 	}
+	return w.commit(fmt.Sprintf("append %d entries", len(entries)), writes...)
+}
 
-	lines[lineIdx] = formatEntry(entry)
-	if err := writeLines(path, lines); err != nil {
+// Toggle advances the status of the entry at index (1-based) within the
+// section: todo → in progress → done → todo. See Status.Next.
+func (w *Writer) Toggle(ctx context.Context, date time.Time, index int) (Entry, error) {
+	path, doc, block, err := w.loadEntry(date, index)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	entry := *block.entry
+	entry.Status = entry.Status.Next()
+	block.replace(entry)
+	if err := w.commit(fmt.Sprintf("toggle %s #%d", date.Format("2006-01-02"), index), monthWrite{path, doc.lines()}); err != nil {
 		return Entry{}, err
 	}
 	return entry, nil
@@ -84,112 +227,378 @@ func (w *Writer) Toggle(ctx context.Context, date time.Time, index int) (Entry,
 func (w *Writer) Edit(ctx context.Context, date time.Time, index int, updated Entry) error {
 	updated = normalizeEntryTime(date, updated)
 
-	path, lines, state, err := w.loadSection(ctx, date)
+	path, doc, block, err := w.loadEntry(date, index)
 	if err != nil {
 		return err
 	}
-	if state == nil {
-		return ErrSectionNotFound
-	}
-	if index < 1 || index > len(state.entryIndexes) {
-		return ErrInvalidIndex
+	// References the entry already had stay as written, pinned or not.
+	updated, err = w.pinAfter(ctx, updated, block.entry.After)
+	if err != nil {
+		return err
 	}
 
-	lineIdx := state.entryIndexes[index-1]
-	lines[lineIdx] = formatEntry(updated)
-	return writeLines(path, lines)
+	block.replace(updated)
+	w.tidy(doc.section(date))
+	return w.commit(fmt.Sprintf("edit %s #%d", date.Format("2006-01-02"), index), monthWrite{path, doc.lines()})
 }
 
 // Delete removes the entry at index (1-based) from the section.
 func (w *Writer) Delete(ctx context.Context, date time.Time, index int) (Entry, error) {
-	path, lines, state, err := w.loadSection(ctx, date)
+	path, doc, block, err := w.loadEntry(date, index)
 	if err != nil {
 		return Entry{}, err
 	}
-	if state == nil {
-		return Entry{}, ErrSectionNotFound
+
+	doc.section(date).remove(block)
+	return *block.entry, w.commit(fmt.Sprintf("delete %s #%d", date.Format("2006-01-02"), index), monthWrite{path, doc.lines()})
+}
+
+// ToggleMany advances the status of every entry at indexes (1-based) in one
+// write. It returns the updated entries in ascending index order.
+func (w *Writer) ToggleMany(ctx context.Context, date time.Time, indexes []int) ([]Entry, error) {
+	return w.updateMany(ctx, "toggle", date, indexes, func(entry Entry) (Entry, bool) {
+		entry.Status = entry.Status.Next()
+		return entry, true
+	})
+}
+
+// TogglePinMany pins every entry at indexes (1-based) that is not pinned and
+// unpins the rest, in one write. It returns the updated entries in ascending
+// index order.
+func (w *Writer) TogglePinMany(ctx context.Context, date time.Time, indexes []int) ([]Entry, error) {
+	return w.updateMany(ctx, "pin", date, indexes, func(entry Entry) (Entry, bool) {
+		entry.Pinned = !entry.Pinned
+		return entry, true
+	})
+}
+
+// EditMany replaces every entry at indexes (1-based) with edit's result in one
+// write. It returns the updated entries in ascending index order.
+func (w *Writer) EditMany(ctx context.Context, date time.Time, indexes []int, edit func(Entry) Entry) ([]Entry, error) {
+	return w.updateMany(ctx, "edit", date, indexes, func(entry Entry) (Entry, bool) {
+		return normalizeEntryTime(date, edit(entry)), true
+	})
+}
+
+// DeleteMany removes every entry at indexes (1-based) in one write. It returns
+// the removed entries in ascending index order.
+func (w *Writer) DeleteMany(ctx context.Context, date time.Time, indexes []int) ([]Entry, error) {
+	return w.updateMany(ctx, "delete", date, indexes, func(entry Entry) (Entry, bool) {
+		return entry, false
+	})
+}
+
+// updateMany validates every index before touching the document, then
+// applies update to each entry. update returns false to drop the entry.
+func (w *Writer) updateMany(ctx context.Context, op string, date time.Time, indexes []int, update func(Entry) (Entry, bool)) ([]Entry, error) {
+	path, doc, err := w.loadMonth(date)
+	if err != nil {
+		return nil, err
 	}
-	if index < 1 || index > len(state.entryIndexes) {
-		return Entry{}, ErrInvalidIndex
+	section := doc.section(date)
+	if section == nil {
+		return nil, ErrSectionNotFound
+	}
+
+	unique := uniqueIndexes(indexes)
+	if len(unique) == 0 {
+		return nil, ErrInvalidIndex
+	}
+	blocks := section.entryBlocks()
+	for _, index := range unique {
+		if index < 1 || index > len(blocks) {
+			return nil, ErrInvalidIndex
+		}
 	}
 
-	lineIdx := state.entryIndexes[index-1]
-	entry := state.section.Entries[index-1]
+	results := make([]Entry, len(unique))
+	edited := false
+	labels := make([]string, len(unique))
+	for i, index := range unique {
+		block := blocks[index-1]
+		updated, keep := update(*block.entry)
+		labels[i] = fmt.Sprintf("#%d", index)
+		if keep {
+			results[i] = updated
+			edited = edited || !updated.Time.Equal(block.entry.Time)
+			block.replace(updated)
+		} else {
+			results[i] = *block.entry
+			section.remove(block)
+		}
+	}
 
-	lines = append(lines[:lineIdx], lines[lineIdx+1:]...)
-	return entry, writeLines(path, lines)
+	if edited {
+		w.tidy(section)
+	}
+
+	label := fmt.Sprintf("%s %s %s", op, date.Format("2006-01-02"), strings.Join(labels, ","))
+	return results, w.commit(label, monthWrite{path, doc.lines()})
 }
 
-// loadSection pulls the current entries for the date to aid writer operations. Implementation pending.
-func (w *Writer) loadSection(ctx context.Context, date time.Time) (string, []string, *sectionState, error) {
-	if w == nil || w.manager == nil {
-		return "", nil, nil, fmt.Errorf("writer not initialized with file manager")
+// uniqueIndexes sorts indexes and drops repeats.
+func uniqueIndexes(indexes []int) []int {
+	sorted := append([]int(nil), indexes...)
+	sort.Ints(sorted)
+	unique := sorted[:0]
+	for i, index := range sorted {
+		if i == 0 || index != sorted[i-1] {
+			unique = append(unique, index)
+		}
 	}
+	return unique
+}
 
-	path, err := w.manager.EnsureMonthFile(date)
-	if err != nil {
-		return "", nil, nil, err
+// Move removes the entry at index (1-based) from the from section and appends
+// it, with its status, clock time, tags, and notes, to the to section. Both
+// month files are journaled as one change so a single undo reverts the move.
+func (w *Writer) Move(ctx context.Context, from time.Time, index int, to time.Time) (Entry, error) {
+	if sameDay(from, to) {
+		return Entry{}, ErrSameDate
 	}
 
-	data, err := os.ReadFile(path)
+	path, doc, block, err := w.loadEntry(from, index)
 	if err != nil {
-		return "", nil, nil, err
+		return Entry{}, err
 	}
 
-	lines := splitLines(string(data))
-	heading := dateHeading(date)
+	moved := normalizeEntryTime(to, *block.entry)
+	doc.section(from).remove(block)
+	op := fmt.Sprintf("move %s #%d to %s", from.Format("2006-01-02"), index, to.Format("2006-01-02"))
 
-	start := -1
-	for i, line := range lines {
-		if strings.TrimSpace(line) == heading {
-			start = i
-			break
-		}
+	if w.manager.MonthPath(to) == path {
+		w.place(doc.ensureSection(to), moved)
+		return moved, w.commit(op, monthWrite{path, doc.lines()})
 	}
 
-	if start == -1 {
-		return path, lines, nil, nil
+	targetPath, target, err := w.loadMonth(to)
+	if err != nil {
+		return Entry{}, err
 	}
+	w.place(target.ensureSection(to), moved)
+	// Write the target first: an interrupted move leaves a duplicate rather
+	// than losing the entry.
+	return moved, w.commit(op, monthWrite{targetPath, target.lines()}, monthWrite{path, doc.lines()})
+}
 
-	end := len(lines)
-	for i := start + 1; i < len(lines); i++ {
-		if strings.HasPrefix(strings.TrimSpace(lines[i]), "## ") {
-			end = i
-			break
+// MoveMany moves every entry at indexes (1-based) from the from section to
+// the to section in one journaled change. It returns the moved entries in
+// ascending index order.
+func (w *Writer) MoveMany(ctx context.Context, from time.Time, indexes []int, to time.Time) ([]Entry, error) {
+	if sameDay(from, to) {
+		return nil, ErrSameDate
+	}
+	path, doc, err := w.loadMonth(from)
+	if err != nil {
+		return nil, err
+	}
+	section := doc.section(from)
+	if section == nil {
+		return nil, ErrSectionNotFound
+	}
+	unique := uniqueIndexes(indexes)
+	if len(unique) == 0 {
+		return nil, ErrInvalidIndex
+	}
+	blocks := section.entryBlocks()
+	labels := make([]string, len(unique))
+	moved := make([]Entry, len(unique))
+	for i, index := range unique {
+		if index < 1 || index > len(blocks) {
+			return nil, ErrInvalidIndex
 		}
+		labels[i] = fmt.Sprintf("#%d", index)
+		moved[i] = normalizeEntryTime(to, *blocks[index-1].entry)
+	}
+	for _, index := range unique {
+		section.remove(blocks[index-1])
 	}
+	op := fmt.Sprintf("move %s %s to %s", from.Format("2006-01-02"), strings.Join(labels, ","), to.Format("2006-01-02"))
 
-	var (
-		entryIndexes []int
-		entries      []Entry
-	)
-	sectionDate := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-	for i := start + 1; i < end; i++ {
-		line := strings.TrimSpace(lines[i])
-		if entry, ok := parseEntryLine(line, sectionDate); ok {
-			entryIndexes = append(entryIndexes, i)
-			entries = append(entries, entry)
+	if w.manager.MonthPath(to) == path {
+		w.place(doc.ensureSection(to), moved...)
+		return moved, w.commit(op, monthWrite{path, doc.lines()})
+	}
+	targetPath, target, err := w.loadMonth(to)
+	if err != nil {
+		return nil, err
+	}
+	w.place(target.ensureSection(to), moved...)
+	return moved, w.commit(op, monthWrite{targetPath, target.lines()}, monthWrite{path, doc.lines()})
+}
+
+// Reorder moves the entry at from (1-based) so it ends up at position to within
+// the same section, carrying its notes along. Lines between entries stay put.
+func (w *Writer) Reorder(ctx context.Context, date time.Time, from, to int) (Entry, error) {
+	path, doc, err := w.loadMonth(date)
+	if err != nil {
+		return Entry{}, err
+	}
+	section := doc.section(date)
+	if section == nil {
+		return Entry{}, ErrSectionNotFound
+	}
+	blocks := section.entryBlocks()
+	if from < 1 || from > len(blocks) || to < 1 || to > len(blocks) {
+		return Entry{}, ErrInvalidIndex
+	}
+	entry := *blocks[from-1].entry
+	if from == to {
+		return entry, nil
+	}
+
+	section.moveEntry(from, to)
+	op := fmt.Sprintf("reorder %s #%d to #%d", date.Format("2006-01-02"), from, to)
+	return entry, w.commit(op, monthWrite{path, doc.lines()})
+}
+
+type monthWrite struct {
+	path  string
+	lines []string
+}
+
+// commit journals the current content of every target file, then writes the
+// new lines. Nothing is written if the journal cannot be updated.
+func (w *Writer) commit(op string, writes ...monthWrite) error {
+	logging.L().Info("commit change", "op", op, "files", len(writes))
+	paths := make([]string, len(writes))
+	for i, write := range writes {
+		paths[i] = write.path
+	}
+	if w.journal != nil {
+		if err := w.journal.record(op, paths); err != nil {
+			return err
 		}
 	}
+	var changes []EntryChange
+	for _, write := range writes {
+		err := w.observe(write.path, func() error { return w.writeLines(write.path, write.lines) }, &changes)
+		parsedMonths.invalidate(write.path)
+		if err != nil {
+			return err
+		}
+	}
+	if err := w.reindex(paths); err != nil {
+		return err
+	}
+	return w.record(op, changes)
+}
+
+// reindex updates the search index for paths when MaintainIndex is on.
+func (w *Writer) reindex(paths []string) error {
+	if w.index == nil {
+		return nil
+	}
+	if err := w.index.update(paths, w.readLines); err != nil {
+		return fmt.Errorf("update search index: %w", err)
+	}
+	return nil
+}
+
+// observe runs write and reports how it changed path to the OnWrite
+// callback, if any, and adds the entries it changed to changes while the
+// history is kept or an OnChange callback is set.
+func (w *Writer) observe(path string, write func() error, changes *[]EntryChange) error {
+	if w.onWrite == nil && w.history == nil && w.onChange == nil {
+		return write()
+	}
+	before := w.readLines(path)
+	if err := write(); err != nil {
+		return err
+	}
+	after := w.readLines(path)
+	if w.onWrite != nil {
+		w.onWrite(path, before, after)
+	}
+	if w.history != nil || w.onChange != nil {
+		*changes = append(*changes, entryChanges(before, after)...)
+	}
+	return nil
+}
+
+// record appends changes to the history as one record and passes them to
+// the OnChange callback. Writes that changed no entry, such as adding an
+// empty heading, are not recorded.
+func (w *Writer) record(op string, changes []EntryChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	if w.onChange != nil {
+		w.onChange(op, changes)
+	}
+	if w.history == nil {
+		return nil
+	}
+	if err := w.history.append(HistoryRecord{Time: time.Now(), Op: op, Changes: changes}); err != nil {
+		return fmt.Errorf("record history: %w", err)
+	}
+	return nil
+}
+
+// readLines returns the lines of path, or nil when it cannot be read.
+func (w *Writer) readLines(path string) []string {
+	data, err := w.manager.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	return splitLines(string(data))
+}
+
+// stamp credits entry to the writer's author unless it names one.
+func (w *Writer) stamp(entry Entry) Entry {
+	if entry.Author == "" {
+		entry.Author = w.author
+	}
+	return entry
+}
+
+// place appends entries to section, then restores time order when enabled.
+func (w *Writer) place(section *docSection, entries ...Entry) {
+	section.append(entries...)
+	w.tidy(section)
+}
 
-	state := &sectionState{
-		section: DateSection{
-			Date:    sectionDate,
-			Entries: entries,
-		},
-		start:        start,
-		end:          end,
-		entryIndexes: entryIndexes,
+// tidy orders section by entry time when SortByTime is enabled.
+func (w *Writer) tidy(section *docSection) {
+	if !w.sortByTime || section == nil {
+		return
 	}
+	section.sortEntries(func(a, b Entry) bool { return a.Time.Before(b.Time) })
+}
 
-	return path, lines, state, nil
+// loadEntry loads the month for date and finds the entry at index (1-based).
+func (w *Writer) loadEntry(date time.Time, index int) (string, *document, *docBlock, error) {
+	path, doc, err := w.loadMonth(date)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	section := doc.section(date)
+	if section == nil {
+		return "", nil, nil, ErrSectionNotFound
+	}
+	blocks := section.entryBlocks()
+	if index < 1 || index > len(blocks) {
+		return "", nil, nil, ErrInvalidIndex
+	}
+	return path, doc, blocks[index-1], nil
 }
 
-type sectionState struct {
-	section      DateSection
-	start        int
-	end          int
-	entryIndexes []int
+// loadMonth reads and parses the month file for date, creating it when missing.
+func (w *Writer) loadMonth(date time.Time) (string, *document, error) {
+	if w == nil || w.manager == nil {
+		return "", nil, fmt.Errorf("writer not initialized with file manager")
+	}
+
+	path, err := w.manager.EnsureMonthFile(date)
+	if err != nil {
+		return "", nil, err
+	}
+
+	data, err := w.manager.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+	return path, parseDocument(splitLines(string(data)), date.Location()), nil
 }
 
 func dateHeading(date time.Time) string {
@@ -213,62 +622,53 @@ func needsSeparation(lines []string) bool {
 	return strings.TrimSpace(lines[len(lines)-1]) != ""
 }
 
-func insertLine(lines []string, index int, line string) []string {
-	if index < 0 || index > len(lines) {
-		return append(lines, line)
-	}
-	lines = append(lines[:index], append([]string{line}, lines[index:]...)...)
-	return lines
-}
-
-func writeLines(path string, lines []string) error {
-	dir := filepath.Dir(path)
-	temp, err := os.CreateTemp(dir, "kerja-*")
-	if err != nil {
-		return err
-	}
-	defer os.Remove(temp.Name())
-
+// writeLines atomically replaces path with lines, encrypting them when the
+// manager has encryption enabled.
+func (w *Writer) writeLines(path string, lines []string) error {
 	content := strings.Join(lines, "\n")
 	if !strings.HasSuffix(content, "\n") {
 		content += "\n"
 	}
-
-	if _, err := temp.WriteString(content); err != nil {
-		temp.Close()
-		return err
-	}
-	if err := temp.Sync(); err != nil {
-		temp.Close()
-		return err
-	}
-	if err := temp.Close(); err != nil {
-		return err
-	}
-
-	info, err := os.Stat(path)
-	if err == nil {
-		if err := os.Chmod(temp.Name(), info.Mode()); err != nil {
-			return err
-		}
-	}
-
-	return os.Rename(temp.Name(), path)
+	return w.manager.WriteFile(path, []byte(content))
 }
 
 func formatEntry(entry Entry) string {
-	status := ' '
-	if entry.Status == StatusDone {
-		status = 'x'
-	}
+	status := entry.Status.Marker()
 
 	var builder strings.Builder
-	builder.Grow(32 + len(entry.Text) + len(entry.Tags)*6)
-	fmt.Fprintf(&builder, "- [%c] [%s]", status, entry.Time.Format("15:04"))
+	builder.Grow(32 + len(entry.Text) + len(entry.Tags)*6 + len(entry.Links)*24)
+	clock := entry.Time.Format("15:04")
+	if entry.Duration() > 0 {
+		clock += "-" + entry.End.Format("15:04")
+	}
+	fmt.Fprintf(&builder, "- [%c] [%s]", status, clock)
+	if entry.Pinned {
+		builder.WriteString(" *")
+	}
 	if entry.Text != "" {
 		builder.WriteByte(' ')
 		builder.WriteString(entry.Text)
 	}
+	for _, link := range entry.Links {
+		builder.WriteByte(' ')
+		builder.WriteString(LinkPrefix)
+		builder.WriteString(link)
+	}
+	for _, after := range entry.After {
+		builder.WriteByte(' ')
+		builder.WriteString(AfterPrefix)
+		builder.WriteString(after)
+	}
+	if entry.Author != "" {
+		builder.WriteByte(' ')
+		builder.WriteString(AuthorPrefix)
+		builder.WriteString(entry.Author)
+	}
+	if !entry.Due.IsZero() {
+		builder.WriteByte(' ')
+		builder.WriteString(DuePrefix)
+		builder.WriteString(entry.Due.Format("2006-01-02"))
+	}
 	for _, tag := range entry.Tags {
 		builder.WriteByte(' ')
 		builder.WriteByte('#')
@@ -277,6 +677,19 @@ func formatEntry(entry Entry) string {
 	return builder.String()
 }
 
+// formatEntryLines renders the entry line followed by its indented notes.
+func formatEntryLines(entry Entry) []string {
+	lines := make([]string, 0, 1+len(entry.Notes))
+	lines = append(lines, formatEntry(entry))
+	for _, note := range entry.Notes {
+		if strings.TrimSpace(note) == "" {
+			continue
+		}
+		lines = append(lines, "  "+strings.TrimSpace(note))
+	}
+	return lines
+}
+
 func normalizeEntryTime(date time.Time, entry Entry) Entry {
 	loc := date.Location()
 	if loc == nil {
@@ -288,6 +701,11 @@ func normalizeEntryTime(date time.Time, entry Entry) Entry {
 		hour = 0
 		min = 0
 	}
+	duration := entry.Duration()
 	entry.Time = time.Date(date.Year(), date.Month(), date.Day(), hour, min, 0, 0, loc)
+	entry.End = time.Time{}
+	if duration > 0 {
+		entry.End = entry.Time.Add(duration)
+	}
 	return entry
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -130,8 +132,15 @@ func TestWriterToggleFlipsStatus(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Toggle: %v", err)
 	}
+	if updated.Status != StatusInProgress {
+		t.Fatalf("Toggle returned status = %v, want StatusInProgress", updated.Status)
+	}
+	updated, err = writer.Toggle(context.Background(), date, 2)
+	if err != nil {
+		t.Fatalf("second Toggle: %v", err)
+	}
 	if updated.Status != StatusDone {
-		t.Fatalf("Toggle returned status = %v, want StatusDone", updated.Status)
+		t.Fatalf("second Toggle returned status = %v, want StatusDone", updated.Status)
 	}
 
 	got, err := os.ReadFile(path)
@@ -306,3 +315,563 @@ func TestWriterErrorsWhenIndexOutOfRange(t *testing.T) {
 		t.Fatalf("Delete error = %v, want ErrInvalidIndex", err)
 	}
 }
+
+func TestWriterKeepsNotesWithTheirEntry(t *testing.T) {
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	writer := NewWriter(mgr)
+	ctx := context.Background()
+
+	date := time.Date(2025, time.November, 7, 0, 0, 0, 0, time.UTC)
+	path, err := mgr.EnsureMonthFile(date)
+	if err != nil {
+		t.Fatalf("EnsureMonthFile: %v", err)
+	}
+
+	initial := strings.TrimLeft(`
+# November 2025
+
+## 2025-11-07
+- [ ] [08:30] Draft proposal #docs
+  Outline goals
+  Link the RFC
+- [x] [11:45] Plan retro #team
+`, "\n")
+	if err := os.WriteFile(path, []byte(initial), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	updated := Entry{
+		Status: StatusDone,
+		Time:   time.Date(2025, time.November, 7, 9, 0, 0, 0, time.UTC),
+		Text:   "Draft proposal",
+		Tags:   []string{"docs"},
+		Notes:  []string{"Shared with team"},
+	}
+	if err := writer.Edit(ctx, date, 1, updated); err != nil {
+		t.Fatalf("Edit: %v", err)
+	}
+	if _, err := writer.Delete(ctx, date, 2); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	want := strings.TrimLeft(`
+# November 2025
+
+## 2025-11-07
+- [x] [09:00] Draft proposal #docs
+  Shared with team
+`, "\n")
+	if string(got) != want {
+		t.Fatalf("file contents = %q, want %q", got, want)
+	}
+
+	if _, err := writer.Delete(ctx, date, 1); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	got, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if strings.Contains(string(got), "Shared with team") {
+		t.Fatalf("notes survived entry delete: %q", got)
+	}
+}
+
+func TestWriterAppendBatchWritesEachMonthOnce(t *testing.T) {
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	writer := NewWriter(mgr)
+	ctx := context.Background()
+
+	existing := time.Date(2025, time.October, 30, 0, 0, 0, 0, time.UTC)
+	if err := writer.Append(ctx, existing, Entry{Status: StatusDone, Time: existing.Add(8 * time.Hour), Text: "Existing"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2025, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	entries := []Entry{
+		{Status: StatusDone, Time: at(time.November, 2, 9), Text: "Nov first"},
+		{Status: StatusTodo, Time: at(time.October, 30, 10), Text: "Oct append"},
+		{Status: StatusTodo, Time: at(time.November, 1, 11), Text: "Nov earlier day"},
+		{Status: StatusDone, Time: at(time.November, 2, 12), Text: "Nov second"},
+	}
+	if err := writer.AppendBatch(ctx, entries); err != nil {
+		t.Fatalf("AppendBatch: %v", err)
+	}
+
+	october, err := os.ReadFile(mgr.MonthPath(existing))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	wantOctober := strings.TrimLeft(`
+# October 2025
+
+## 2025-10-30
+- [x] [08:00] Existing
+- [ ] [10:00] Oct append
+`, "\n")
+	if string(october) != wantOctober {
+		t.Fatalf("october = %q, want %q", october, wantOctober)
+	}
+
+	november, err := os.ReadFile(mgr.MonthPath(at(time.November, 1, 0)))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	wantNovember := strings.TrimLeft(`
+# November 2025
+
+## 2025-11-02
+- [x] [09:00] Nov first
+- [x] [12:00] Nov second
+
+## 2025-11-01
+- [ ] [11:00] Nov earlier day
+`, "\n")
+	if string(november) != wantNovember {
+		t.Fatalf("november = %q, want %q", november, wantNovember)
+	}
+}
+
+func TestWriterMoveAcrossMonths(t *testing.T) {
+	base := t.TempDir()
+	mgr, err := files.NewManager(base)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	writer := NewWriter(mgr)
+	ctx := context.Background()
+
+	from := time.Date(2025, time.October, 31, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.November, 3, 0, 0, 0, 0, time.UTC)
+	if err := writer.Append(ctx, from, Entry{Status: StatusDone, Time: from.Add(8 * time.Hour), Text: "Stay"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := writer.Append(ctx, from, Entry{Status: StatusTodo, Time: from.Add(9*time.Hour + 30*time.Minute), Text: "Finish report", Tags: []string{"work"}, Notes: []string{"needs numbers"}}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	moved, err := writer.Move(ctx, from, 2, to)
+	if err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if !moved.Time.Equal(to.Add(9*time.Hour + 30*time.Minute)) {
+		t.Fatalf("moved time = %v", moved.Time)
+	}
+
+	november, _ := os.ReadFile(mgr.MonthPath(to))
+	wantNovember := "# November 2025\n\n## 2025-11-03\n- [ ] [09:30] Finish report #work\n  needs numbers\n"
+	if string(november) != wantNovember {
+		t.Fatalf("November = %q, want %q", november, wantNovember)
+	}
+	october, _ := os.ReadFile(mgr.MonthPath(from))
+	if strings.Contains(string(october), "Finish report") || !strings.Contains(string(october), "Stay") {
+		t.Fatalf("October = %q", october)
+	}
+
+	change, err := writer.Undo(ctx)
+	if err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if change.Op != "move 2025-10-31 #2 to 2025-11-03" || len(change.Files) != 2 {
+		t.Fatalf("change = %+v", change)
+	}
+	october, _ = os.ReadFile(mgr.MonthPath(from))
+	if !strings.Contains(string(october), "Finish report") {
+		t.Fatalf("undo should restore the entry, October = %q", october)
+	}
+}
+
+func TestWriterMoveWithinMonth(t *testing.T) {
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	writer := NewWriter(mgr)
+	ctx := context.Background()
+
+	from := time.Date(2025, time.November, 3, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC)
+	if err := writer.Append(ctx, from, Entry{Status: StatusDone, Time: from.Add(10 * time.Hour), Text: "Logged late"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if _, err := writer.Move(ctx, from, 1, from); !errors.Is(err, ErrSameDate) {
+		t.Fatalf("Move to same date err = %v, want ErrSameDate", err)
+	}
+	if _, err := writer.Move(ctx, from, 1, to); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+
+	got, _ := os.ReadFile(mgr.MonthPath(from))
+	want := "# November 2025\n\n## 2025-11-03\n\n## 2025-11-01\n- [x] [10:00] Logged late\n"
+	if string(got) != want {
+		t.Fatalf("file = %q, want %q", got, want)
+	}
+}
+
+func TestWriterReorderMovesEntryWithNotes(t *testing.T) {
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	writer := NewWriter(mgr)
+	ctx := context.Background()
+
+	date := time.Date(2025, time.November, 5, 0, 0, 0, 0, time.UTC)
+	for i, text := range []string{"First", "Second", "Third"} {
+		entry := Entry{Status: StatusTodo, Time: date.Add(time.Duration(9+i) * time.Hour), Text: text}
+		if text == "First" {
+			entry.Notes = []string{"first note"}
+		}
+		if err := writer.Append(ctx, date, entry); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	cases := []struct {
+		from, to int
+		want     []string
+	}{
+		{1, 3, []string{"Second", "Third", "First"}},
+		{3, 1, []string{"First", "Second", "Third"}},
+		{1, 2, []string{"Second", "First", "Third"}},
+		{3, 2, []string{"Second", "Third", "First"}},
+	}
+	for _, tc := range cases {
+		if _, err := writer.Reorder(ctx, date, tc.from, tc.to); err != nil {
+			t.Fatalf("Reorder(%d, %d): %v", tc.from, tc.to, err)
+		}
+		section, err := NewReader(mgr).Section(ctx, date)
+		if err != nil {
+			t.Fatalf("Section: %v", err)
+		}
+		var got []string
+		for _, entry := range section.Entries {
+			got = append(got, entry.Text)
+			if entry.Text == "First" && (len(entry.Notes) != 1 || entry.Notes[0] != "first note") {
+				t.Fatalf("notes did not move with the entry: %+v", entry)
+			}
+		}
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Fatalf("after Reorder(%d, %d) order = %v, want %v", tc.from, tc.to, got, tc.want)
+		}
+	}
+
+	if _, err := writer.Reorder(ctx, date, 1, 4); !errors.Is(err, ErrInvalidIndex) {
+		t.Fatalf("Reorder out of range err = %v, want ErrInvalidIndex", err)
+	}
+}
+
+func TestWriterDailyNotesKeepOtherContent(t *testing.T) {
+	base := t.TempDir()
+	mgr, err := files.NewManager(base)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	if err := mgr.UseDailyNotes("", ""); err != nil {
+		t.Fatalf("UseDailyNotes: %v", err)
+	}
+	writer := NewWriter(mgr)
+	ctx := context.Background()
+
+	date := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.UTC)
+	note := "# Tuesday\n\nMet with [[Alice]].\n"
+	if err := os.WriteFile(mgr.MonthPath(date), []byte(note), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	next := date.AddDate(0, 0, 1)
+	if err := writer.AppendBatch(ctx, []Entry{
+		{Status: StatusDone, Time: date.Add(9 * time.Hour), Text: "Shipped"},
+		{Status: StatusTodo, Time: next.Add(10 * time.Hour), Text: "Follow up"},
+	}); err != nil {
+		t.Fatalf("AppendBatch: %v", err)
+	}
+
+	got, _ := os.ReadFile(mgr.MonthPath(date))
+	want := note + "\n## 2025-11-04\n- [x] [09:00] Shipped\n"
+	if string(got) != want {
+		t.Fatalf("note = %q, want %q", got, want)
+	}
+	section, err := NewReader(mgr).Section(ctx, next)
+	if err != nil || len(section.Entries) != 1 || section.Entries[0].Text != "Follow up" {
+		t.Fatalf("next day section = %+v, %v", section, err)
+	}
+
+	sections, err := NewReader(mgr).MonthSections(ctx, date)
+	if err != nil || len(sections) != 2 {
+		t.Fatalf("MonthSections = %d sections, %v", len(sections), err)
+	}
+}
+
+func TestWriterManyAppliesInOneChange(t *testing.T) {
+	base := t.TempDir()
+	mgr, err := files.NewManager(base)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	writer := NewWriter(mgr)
+	ctx := context.Background()
+
+	date := time.Date(2025, time.November, 6, 0, 0, 0, 0, time.UTC)
+	path, err := mgr.EnsureMonthFile(date)
+	if err != nil {
+		t.Fatalf("EnsureMonthFile: %v", err)
+	}
+	initial := strings.TrimLeft(`
+# November 2025
+
+## 2025-11-06
+- [ ] [08:30] First
+  note under first
+- [ ] [09:00] Second
+- [ ] [10:00] Third
+`, "\n")
+	if err := os.WriteFile(path, []byte(initial), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	toggled, err := writer.ToggleMany(ctx, date, []int{3, 1, 3})
+	if err != nil {
+		t.Fatalf("ToggleMany: %v", err)
+	}
+	if len(toggled) != 2 || toggled[0].Text != "First" || toggled[1].Text != "Third" || toggled[0].Status != StatusInProgress {
+		t.Fatalf("ToggleMany returned %+v", toggled)
+	}
+
+	if _, err := writer.DeleteMany(ctx, date, []int{1, 4}); !errors.Is(err, ErrInvalidIndex) {
+		t.Fatalf("DeleteMany out of range error = %v, want ErrInvalidIndex", err)
+	}
+
+	deleted, err := writer.DeleteMany(ctx, date, []int{1, 2})
+	if err != nil {
+		t.Fatalf("DeleteMany: %v", err)
+	}
+	if len(deleted) != 2 || deleted[1].Text != "Second" {
+		t.Fatalf("DeleteMany returned %+v", deleted)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	want := strings.TrimLeft(`
+# November 2025
+
+## 2025-11-06
+- [~] [10:00] Third
+`, "\n")
+	if string(got) != want {
+		t.Fatalf("file = %q, want %q", got, want)
+	}
+
+	change, err := writer.Undo(ctx)
+	if err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if change.Op != "delete 2025-11-06 #1,#2" {
+		t.Fatalf("undo op = %q", change.Op)
+	}
+}
+
+func TestWriterSortByTimeOrdersAppendsAndEdits(t *testing.T) {
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	writer := NewWriter(mgr).SortByTime(true)
+	ctx := context.Background()
+
+	date := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.UTC)
+	for _, entry := range []Entry{
+		{Status: StatusDone, Time: date.Add(14 * time.Hour), Text: "Afternoon"},
+		{Status: StatusDone, Time: date.Add(9 * time.Hour), Text: "Morning", Notes: []string{"standup"}},
+	} {
+		if err := writer.Append(ctx, date, entry); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	if err := writer.Edit(ctx, date, 2, Entry{Status: StatusDone, Time: date.Add(8 * time.Hour), Text: "Early"}); err != nil {
+		t.Fatalf("Edit: %v", err)
+	}
+
+	got, _ := os.ReadFile(mgr.MonthPath(date))
+	want := "# November 2025\n\n## 2025-11-04\n- [x] [08:00] Early\n- [x] [09:00] Morning\n  standup\n"
+	if string(got) != want {
+		t.Fatalf("file = %q, want %q", got, want)
+	}
+}
+
+func TestWriterMoveManyCarriesNotesInOneChange(t *testing.T) {
+	mgr, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	writer := NewWriter(mgr)
+	ctx := context.Background()
+
+	from := time.Date(2025, time.November, 28, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC)
+	path, err := mgr.EnsureMonthFile(from)
+	if err != nil {
+		t.Fatalf("EnsureMonthFile: %v", err)
+	}
+	initial := strings.TrimLeft(`
+# November 2025
+
+## 2025-11-28
+- [ ] [08:30] First
+  note under first
+- [x] [09:00] Second
+- [ ] [10:00] Third
+`, "\n")
+	if err := os.WriteFile(path, []byte(initial), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	moved, err := writer.MoveMany(ctx, from, []int{3, 1}, to)
+	if err != nil {
+		t.Fatalf("MoveMany: %v", err)
+	}
+	if len(moved) != 2 || moved[0].Text != "First" || !moved[1].Time.Equal(time.Date(2025, time.December, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("MoveMany returned %+v", moved)
+	}
+
+	december, err := os.ReadFile(mgr.MonthPath(to))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !strings.HasSuffix(string(december), "## 2025-12-01\n- [ ] [08:30] First\n  note under first\n- [ ] [10:00] Third\n") {
+		t.Fatalf("December = %q", december)
+	}
+
+	change, err := writer.Undo(ctx)
+	if err != nil || !strings.Contains(change.Op, "#1,#3") {
+		t.Fatalf("Undo = %+v, %v", change, err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != initial {
+		t.Fatalf("undo left %q", got)
+	}
+}
+
+func TestWriterRoundTripsMarkdownAroundEntries(t *testing.T) {
+	base := t.TempDir()
+	mgr, err := files.NewManager(base)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	writer := NewWriter(mgr)
+	reader := NewReader(mgr)
+	ctx := context.Background()
+
+	date := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.UTC)
+	messy := strings.TrimLeft(`
+# November 2025
+<!-- synced from the work laptop -->
+
+## 2025-11-04
+Standup moved to 10:30 today.
+
+- [ ] [09:00] Review rollout plan #ops
+    - check the canary dashboards
+        - p99 under 200ms
+  ~~~ text
+  indented snippet
+  ~~~
+- [x] [09:30] Reply to Aina
+
+### Retro
+
+What went well:
+* the migration
+
+`+"```"+`md
+## 2025-11-05
+- [ ] [11:00] Example entry, not a real one
+
+- [x] [12:00] Another example
+`+"```"+`
+
+| Task | Owner |
+|------|-------|
+| Deploy | ops |
+
+## Notes
+- [ ] [13:00] Kept out of the day's entries
+
+## 2025-11-05
+- [ ] [08:00] Tomorrow
+`, "\n")
+	path := mgr.MonthPath(date)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(messy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	texts := func() []string {
+		t.Helper()
+		section, err := reader.Section(ctx, date)
+		if err != nil {
+			t.Fatalf("Section: %v", err)
+		}
+		var texts []string
+		for _, entry := range section.Entries {
+			texts = append(texts, entry.Text)
+		}
+		return texts
+	}
+	if got := texts(); !slices.Equal(got, []string{"Review rollout plan", "Reply to Aina"}) {
+		t.Fatalf("entries before writes = %q", got)
+	}
+
+	if _, err := writer.Toggle(ctx, date, 1); err != nil {
+		t.Fatalf("Toggle: %v", err)
+	}
+	if err := writer.Append(ctx, date, Entry{Status: StatusTodo, Time: date.Add(14 * time.Hour), Text: "Write retro notes"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := writer.Edit(ctx, date, 2, Entry{Status: StatusDone, Time: date.Add(9*time.Hour + 45*time.Minute), Text: "Replied to Aina"}); err != nil {
+		t.Fatalf("Edit: %v", err)
+	}
+	if got := texts(); !slices.Equal(got, []string{"Review rollout plan", "Replied to Aina", "Write retro notes"}) {
+		t.Fatalf("entries after writes = %q", got)
+	}
+
+	want := strings.Replace(strings.Replace(messy,
+		"- [ ] [09:00] Review", "- [~] [09:00] Review", 1),
+		"- [x] [09:30] Reply to Aina", "- [x] [09:45] Replied to Aina\n- [ ] [14:00] Write retro notes", 1)
+	got, _ := os.ReadFile(path)
+	if string(got) != want {
+		t.Fatalf("file after writes =\n%s\nwant\n%s", got, want)
+	}
+
+	if _, err := writer.Delete(ctx, date, 3); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := writer.Delete(ctx, date, 2); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := writer.Toggle(ctx, date, 1); err != nil {
+		t.Fatalf("Toggle: %v", err)
+	}
+	want = strings.Replace(strings.Replace(messy,
+		"- [ ] [09:00] Review", "- [x] [09:00] Review", 1),
+		"- [x] [09:30] Reply to Aina\n", "", 1)
+	got, _ = os.ReadFile(path)
+	if string(got) != want {
+		t.Fatalf("file after deletes =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,29 @@
+// Package logging holds the slog logger kerja's packages trace their work
+// to: file resolution and writes at Info, parse steps at Debug. It discards
+// everything until the CLI turns it on with --verbose, --debug, or
+// KERJA_DEBUG.
+package logging
+
+import (
+	"log/slog"
+	"sync/atomic"
+)
+
+var current atomic.Pointer[slog.Logger]
+
+func init() {
+	Set(nil)
+}
+
+// L returns the current logger.
+func L() *slog.Logger {
+	return current.Load()
+}
+
+// Set replaces the current logger; nil discards every record.
+func Set(logger *slog.Logger) {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	current.Store(logger)
+}
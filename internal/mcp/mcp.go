@@ -0,0 +1,189 @@
+// Package mcp serves tools to AI assistants over the Model Context Protocol's
+// stdio transport: newline-delimited JSON-RPC 2.0 messages on stdin and
+// stdout. Only the tools capability is implemented.
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ProtocolVersion is the protocol revision offered when the client asks for
+// one this package does not know.
+const ProtocolVersion = "2025-06-18"
+
+// supportedVersions lists the revisions whose tools messages match.
+var supportedVersions = []string{"2024-11-05", "2025-03-26", ProtocolVersion}
+
+// JSON-RPC error codes.
+const (
+	codeParse          = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Tool is one callable tool. Call receives the arguments as sent; its
+// result is returned to the client as JSON text, and its error as a tool
+// error the assistant can read and correct, rather than a protocol error.
+type Tool struct {
+	Name        string
+	Description string
+	// InputSchema is the JSON Schema of the arguments object.
+	InputSchema map[string]any
+	Call        func(ctx context.Context, args json.RawMessage) (any, error)
+}
+
+// Server answers one client.
+type Server struct {
+	name    string
+	version string
+	tools   []Tool
+}
+
+// NewServer returns a server introducing itself as name and version.
+func NewServer(name, version string, tools ...Tool) *Server {
+	return &Server{name: name, version: version, tools: tools}
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+// Serve reads requests from in and writes responses to out until in ends
+// or ctx is done. Notifications get no response.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	enc := json.NewEncoder(out)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return nil
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var req request
+		if err := json.Unmarshal(line, &req); err != nil {
+			if err := enc.Encode(response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{codeParse, "parse error: " + err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+		result, err := s.handle(ctx, req)
+		if req.ID == nil {
+			continue
+		}
+		resp := response{JSONRPC: "2.0", ID: req.ID, Result: result}
+		if err != nil {
+			var rpcErr *rpcError
+			if !errors.As(err, &rpcErr) {
+				rpcErr = &rpcError{codeInvalidRequest, err.Error()}
+			}
+			resp.Result, resp.Error = nil, rpcErr
+		}
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func (s *Server) handle(ctx context.Context, req request) (any, error) {
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(req.Params, &params)
+		version := ProtocolVersion
+		for _, supported := range supportedVersions {
+			if params.ProtocolVersion == supported {
+				version = supported
+			}
+		}
+		return map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": s.name, "version": s.version},
+		}, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "tools/list":
+		tools := make([]map[string]any, 0, len(s.tools))
+		for _, tool := range s.tools {
+			tools = append(tools, map[string]any{
+				"name":        tool.Name,
+				"description": tool.Description,
+				"inputSchema": tool.InputSchema,
+			})
+		}
+		return map[string]any{"tools": tools}, nil
+	case "tools/call":
+		return s.call(ctx, req.Params)
+	default:
+		if req.ID == nil {
+			// Notifications such as notifications/initialized need no action.
+			return nil, nil
+		}
+		return nil, &rpcError{codeMethodNotFound, fmt.Sprintf("method %q not found", req.Method)}
+	}
+}
+
+func (s *Server) call(ctx context.Context, raw json.RawMessage) (any, error) {
+	var params struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, &rpcError{codeInvalidParams, "invalid params: " + err.Error()}
+	}
+	for _, tool := range s.tools {
+		if tool.Name != params.Name {
+			continue
+		}
+		args := params.Arguments
+		if len(args) == 0 || string(args) == "null" {
+			args = json.RawMessage("{}")
+		}
+		value, err := tool.Call(ctx, args)
+		if err != nil {
+			return textResult(err.Error(), true), nil
+		}
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return textResult(string(data), false), nil
+	}
+	return nil, &rpcError{codeInvalidParams, fmt.Sprintf("unknown tool %q", params.Name)}
+}
+
+func textResult(text string, isError bool) map[string]any {
+	return map[string]any{
+		"content": []map[string]any{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
@@ -0,0 +1,88 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func serve(t *testing.T, server *Server, requests ...string) []map[string]any {
+	t.Helper()
+	var out bytes.Buffer
+	if err := server.Serve(context.Background(), strings.NewReader(strings.Join(requests, "\n")+"\n"), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	var responses []map[string]any
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var resp map[string]any
+		if err := dec.Decode(&resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func TestServeAnswersHandshakeAndListsTools(t *testing.T) {
+	server := NewServer("kerja", "1.2.3", Tool{Name: "get_day", Description: "A day", InputSchema: map[string]any{"type": "object"}})
+	responses := serve(t, server,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"resources/list"}`,
+		`not json`,
+	)
+	if len(responses) != 4 {
+		t.Fatalf("got %d responses, want 4 (none for the notification): %v", len(responses), responses)
+	}
+	result := responses[0]["result"].(map[string]any)
+	if result["protocolVersion"] != "2024-11-05" || result["serverInfo"].(map[string]any)["version"] != "1.2.3" {
+		t.Fatalf("initialize result = %v", result)
+	}
+	tools := responses[1]["result"].(map[string]any)["tools"].([]any)
+	if len(tools) != 1 || tools[0].(map[string]any)["name"] != "get_day" {
+		t.Fatalf("tools/list = %v", tools)
+	}
+	if code := responses[2]["error"].(map[string]any)["code"]; code != float64(codeMethodNotFound) {
+		t.Fatalf("unknown method error code = %v", code)
+	}
+	if code := responses[3]["error"].(map[string]any)["code"]; code != float64(codeParse) {
+		t.Fatalf("parse error code = %v", code)
+	}
+}
+
+func TestServeCallsToolsAndReportsToolErrors(t *testing.T) {
+	echo := Tool{Name: "echo", Call: func(ctx context.Context, args json.RawMessage) (any, error) {
+		var in struct{ Text string }
+		if err := json.Unmarshal(args, &in); err != nil {
+			return nil, err
+		}
+		if in.Text == "" {
+			return nil, errors.New("text is required")
+		}
+		return map[string]string{"text": in.Text}, nil
+	}}
+	responses := serve(t, NewServer("kerja", "dev", echo),
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo","arguments":{"text":"hi"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"missing"}}`,
+	)
+
+	content := func(resp map[string]any) (string, bool) {
+		result := resp["result"].(map[string]any)
+		return result["content"].([]any)[0].(map[string]any)["text"].(string), result["isError"].(bool)
+	}
+	if text, isError := content(responses[0]); isError || !strings.Contains(text, `"text": "hi"`) {
+		t.Fatalf("call result = %q, isError %v", text, isError)
+	}
+	if text, isError := content(responses[1]); !isError || text != "text is required" {
+		t.Fatalf("failing call = %q, isError %v", text, isError)
+	}
+	if code := responses[2]["error"].(map[string]any)["code"]; code != float64(codeInvalidParams) {
+		t.Fatalf("unknown tool error code = %v", code)
+	}
+}
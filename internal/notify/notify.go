@@ -0,0 +1,88 @@
+// Package notify delivers desktop notifications through whichever mechanism
+// the platform offers: notify-send on Linux, osascript on macOS, or a
+// terminal bell as the fallback.
+package notify
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Notifier shows a notification with a title and body.
+type Notifier interface {
+	Notify(title, body string) error
+}
+
+// Names lists the notifiers accepted by New.
+var Names = []string{"auto", "notify-send", "osascript", "bell"}
+
+// lookPath is swapped in tests to control which commands appear installed.
+var lookPath = exec.LookPath
+
+// New returns the named notifier. "auto" picks notify-send or osascript when
+// installed and falls back to the bell, which writes to out.
+func New(name string, out io.Writer) (Notifier, error) {
+	switch name {
+	case "", "auto":
+		for _, candidate := range autoOrder() {
+			if _, err := lookPath(candidate); err == nil {
+				return New(candidate, out)
+			}
+		}
+		return Bell{Out: out}, nil
+	case "notify-send":
+		return Command{Name: "notify-send", Args: func(title, body string) []string {
+			return []string{"--app-name=kerja", title, body}
+		}}, nil
+	case "osascript":
+		return Command{Name: "osascript", Args: func(title, body string) []string {
+			return []string{"-e", fmt.Sprintf("display notification %s with title %s", appleScriptString(body), appleScriptString(title))}
+		}}, nil
+	case "bell":
+		return Bell{Out: out}, nil
+	default:
+		return nil, fmt.Errorf("unknown notifier %q (expected one of %v)", name, Names)
+	}
+}
+
+// appleScriptString quotes s as an AppleScript string literal, which only
+// escapes backslashes and double quotes.
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func autoOrder() []string {
+	if runtime.GOOS == "darwin" {
+		return []string{"osascript"}
+	}
+	return []string{"notify-send"}
+}
+
+// Command runs an external program, built from the title and body by Args.
+type Command struct {
+	Name string
+	Args func(title, body string) []string
+}
+
+// Notify runs the command and reports its output when it fails.
+func (c Command) Notify(title, body string) error {
+	output, err := exec.Command(c.Name, c.Args(title, body)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", c.Name, err, output)
+	}
+	return nil
+}
+
+// Bell rings the terminal bell and prints the notification to Out.
+type Bell struct {
+	Out io.Writer
+}
+
+// Notify writes the bell character followed by the title and body.
+func (b Bell) Notify(title, body string) error {
+	_, err := fmt.Fprintf(b.Out, "\a%s: %s\n", title, body)
+	return err
+}
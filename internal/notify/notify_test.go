@@ -0,0 +1,60 @@
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"runtime"
+	"testing"
+)
+
+func TestNewAutoFallsBackToBell(t *testing.T) {
+	original := lookPath
+	t.Cleanup(func() { lookPath = original })
+	lookPath = func(string) (string, error) { return "", errors.New("not found") }
+
+	var out bytes.Buffer
+	notifier, err := New("auto", &out)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := notifier.Notify("kerja", "09:30 Standup"); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if got := out.String(); got != "\akerja: 09:30 Standup\n" {
+		t.Fatalf("bell output = %q", got)
+	}
+}
+
+func TestNewAutoPrefersPlatformCommand(t *testing.T) {
+	original := lookPath
+	t.Cleanup(func() { lookPath = original })
+	lookPath = func(name string) (string, error) { return "/usr/bin/" + name, nil }
+
+	notifier, err := New("auto", nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	want := "notify-send"
+	if runtime.GOOS == "darwin" {
+		want = "osascript"
+	}
+	if command, ok := notifier.(Command); !ok || command.Name != want {
+		t.Fatalf("auto notifier = %#v, want %s", notifier, want)
+	}
+
+	if _, err := New("pager", nil); err == nil {
+		t.Fatalf("expected unknown notifier error")
+	}
+}
+
+func TestOsascriptQuotesAppleScriptStrings(t *testing.T) {
+	notifier, err := New("osascript", nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	args := notifier.(Command).Args(`kerja`, `09:30 Café "sync" \ notes`)
+	want := `display notification "09:30 Café \"sync\" \\ notes" with title "kerja"`
+	if len(args) != 2 || args[1] != want {
+		t.Fatalf("args = %q, want %q", args, want)
+	}
+}
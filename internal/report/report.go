@@ -0,0 +1,275 @@
+// Package report renders logbook sections as a shareable Markdown report
+// through a Go text/template.
+//
+// The built-in template groups the range by tag with done and todo lists.
+// Placing report.md.tmpl in the logbook directory replaces it, and LoadFile
+// reads any other template. Templates receive a *Report and the helpers in
+// Funcs, so they can render any shape, not only Markdown.
+package report
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// FileName is the template override inside the logbook base directory.
+const FileName = "report.md.tmpl"
+
+// SlackFileName overrides the message kerja share --slack posts.
+const SlackFileName = "share.slack.tmpl"
+
+// Untagged names the group holding entries without tags.
+const Untagged = "Other"
+
+//go:embed default.md.tmpl
+var defaultTemplate string
+
+//go:embed slack.tmpl
+var slackTemplate string
+
+// Grouping selects how entries are gathered into groups.
+type Grouping string
+
+const (
+	// ByTag lists an entry under every one of its tags.
+	ByTag Grouping = "tag"
+	// ByProject lists an entry once, under its first tag.
+	ByProject Grouping = "project"
+)
+
+// Report is the data handed to the template.
+type Report struct {
+	Title string
+	Start time.Time
+	End   time.Time
+	// Days holds the sections in the range that have entries, oldest first.
+	Days []logbook.DateSection
+	// Entries lists every entry in Days in order.
+	Entries []logbook.Entry
+	Groups  []Group
+	// Tags counts entries per tag, most used first.
+	Tags  []TagStat
+	Total int
+	Done  int
+	Open  int
+	// Tracked sums the durations of ranged entries.
+	Tracked time.Duration
+}
+
+// TagStat counts the entries carrying one tag.
+type TagStat struct {
+	Name  string
+	Count int
+	Done  int
+	Open  int
+}
+
+// Group is one tag or project with its entries split by status.
+type Group struct {
+	Name string
+	Done []logbook.Entry
+	Open []logbook.Entry
+}
+
+// Build gathers sections into a report. Groups are ordered by entry count,
+// then name, with Untagged last.
+func Build(title string, start, end time.Time, sections []logbook.DateSection, grouping Grouping) *Report {
+	r := &Report{Title: title, Start: start, End: end}
+	byName := make(map[string]*Group)
+	byTag := make(map[string]*TagStat)
+	for _, section := range sections {
+		if len(section.Entries) == 0 {
+			continue
+		}
+		r.Days = append(r.Days, section)
+		r.Tracked += section.TrackedDuration()
+		for _, entry := range section.Entries {
+			r.Entries = append(r.Entries, entry)
+			r.Total++
+			if entry.Status.Open() {
+				r.Open++
+			} else if entry.Status == logbook.StatusDone {
+				r.Done++
+			}
+			for _, tag := range entry.Tags {
+				stat, ok := byTag[tag]
+				if !ok {
+					stat = &TagStat{Name: tag}
+					byTag[tag] = stat
+				}
+				stat.Count++
+				if entry.Status.Open() {
+					stat.Open++
+				} else if entry.Status == logbook.StatusDone {
+					stat.Done++
+				}
+			}
+			for _, name := range groupNames(entry, grouping) {
+				group, ok := byName[name]
+				if !ok {
+					group = &Group{Name: name}
+					byName[name] = group
+				}
+				switch {
+				case entry.Status.Open():
+					group.Open = append(group.Open, entry)
+				case entry.Status == logbook.StatusDone:
+					group.Done = append(group.Done, entry)
+				}
+			}
+		}
+	}
+
+	for _, group := range byName {
+		if len(group.Done)+len(group.Open) > 0 {
+			r.Groups = append(r.Groups, *group)
+		}
+	}
+	sort.Slice(r.Groups, func(i, j int) bool {
+		a, b := r.Groups[i], r.Groups[j]
+		if (a.Name == Untagged) != (b.Name == Untagged) {
+			return b.Name == Untagged
+		}
+		if na, nb := len(a.Done)+len(a.Open), len(b.Done)+len(b.Open); na != nb {
+			return na > nb
+		}
+		return a.Name < b.Name
+	})
+
+	for _, stat := range byTag {
+		r.Tags = append(r.Tags, *stat)
+	}
+	sort.Slice(r.Tags, func(i, j int) bool {
+		if r.Tags[i].Count != r.Tags[j].Count {
+			return r.Tags[i].Count > r.Tags[j].Count
+		}
+		return r.Tags[i].Name < r.Tags[j].Name
+	})
+	return r
+}
+
+func groupNames(entry logbook.Entry, grouping Grouping) []string {
+	switch {
+	case len(entry.Tags) == 0:
+		return []string{Untagged}
+	case grouping == ByProject:
+		return entry.Tags[:1]
+	default:
+		return entry.Tags
+	}
+}
+
+// Path returns the template override for the logbook rooted at base.
+func Path(base string) string {
+	return filepath.Join(base, FileName)
+}
+
+// SlackPath returns the Slack message override for the logbook rooted at
+// base.
+func SlackPath(base string) string {
+	return filepath.Join(base, SlackFileName)
+}
+
+// LoadTemplate parses the template at path, falling back to the built-in
+// template when path is empty or the file does not exist.
+func LoadTemplate(path string) (*template.Template, error) {
+	return loadOr(path, "default", defaultTemplate)
+}
+
+// LoadSlackTemplate is LoadTemplate for Slack messages: its built-in
+// template writes Slack mrkdwn with a line per entry under each day.
+func LoadSlackTemplate(path string) (*template.Template, error) {
+	return loadOr(path, "slack", slackTemplate)
+}
+
+func loadOr(path, name, fallback string) (*template.Template, error) {
+	if path != "" {
+		tmpl, err := LoadFile(path)
+		if !errors.Is(err, fs.ErrNotExist) {
+			return tmpl, err
+		}
+	}
+	return Parse(name, fallback)
+}
+
+// LoadFile parses the template at path; unlike LoadTemplate, a missing file
+// is an error.
+func LoadFile(path string) (*template.Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(filepath.Base(path), string(data))
+}
+
+// Parse parses text as a report template with Funcs available.
+func Parse(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(Funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse report template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// Render executes tmpl with r.
+func Render(w io.Writer, tmpl *template.Template, r *Report) error {
+	if err := tmpl.Execute(w, r); err != nil {
+		return fmt.Errorf("render report: %w", err)
+	}
+	return nil
+}
+
+// Funcs are the helpers available to report templates:
+//
+//	date     formats a time as YYYY-MM-DD
+//	clock    formats a time as HH:MM
+//	duration formats a duration as 1h30m, 2h, or 45m
+//	status   names an entry status, such as done or in-progress
+//	percent  renders part of total as a whole percentage
+//	join     joins strings with a separator
+//	tags     renders tags as "#a #b"
+var Funcs = template.FuncMap{
+	"date":     func(t time.Time) string { return t.Format("2006-01-02") },
+	"clock":    func(t time.Time) string { return t.Format("15:04") },
+	"duration": formatDuration,
+	"status":   func(s logbook.Status) string { return s.String() },
+	"percent": func(part, total int) int {
+		if total == 0 {
+			return 0
+		}
+		return part * 100 / total
+	},
+	"join": strings.Join,
+	"tags": func(tags []string) string {
+		parts := make([]string, len(tags))
+		for i, tag := range tags {
+			parts[i] = "#" + tag
+		}
+		return strings.Join(parts, " ")
+	},
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours := int(d / time.Hour)
+	minutes := int((d % time.Hour) / time.Minute)
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%02dm", hours, minutes)
+	}
+}
@@ -0,0 +1,151 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func sampleSections() []logbook.DateSection {
+	day := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.UTC)
+	return []logbook.DateSection{{
+		Date: day,
+		Entries: []logbook.Entry{
+			{Status: logbook.StatusDone, Time: day.Add(9 * time.Hour), Text: "Ship login", Tags: []string{"auth", "web"}},
+			{Status: logbook.StatusTodo, Time: day.Add(10 * time.Hour), Text: "Write tests", Tags: []string{"auth"}},
+			{Status: logbook.StatusDone, Time: day.Add(11 * time.Hour), Text: "Lunch talk"},
+		},
+	}}
+}
+
+func TestDefaultTemplateGroupsByTag(t *testing.T) {
+	start := time.Date(2025, time.November, 3, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 6)
+	r := Build("Weekly report", start, end, sampleSections(), ByTag)
+
+	tmpl, err := LoadTemplate("")
+	if err != nil {
+		t.Fatalf("LoadTemplate: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := Render(&buf, tmpl, r); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	want := `# Weekly report
+
+_2025-11-03 to 2025-11-09: 2 done, 1 open across 1 days._
+
+## auth
+
+### Done
+
+- Ship login (2025-11-04)
+
+### Todo
+
+- Write tests (2025-11-04)
+
+## web
+
+### Done
+
+- Ship login (2025-11-04)
+
+## Other
+
+### Done
+
+- Lunch talk (2025-11-04)
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("report =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBuildByProjectUsesFirstTag(t *testing.T) {
+	r := Build("", time.Time{}, time.Time{}, sampleSections(), ByProject)
+	var names []string
+	for _, group := range r.Groups {
+		names = append(names, group.Name)
+	}
+	if len(names) != 2 || names[0] != "auth" || names[1] != Untagged {
+		t.Fatalf("groups = %v", names)
+	}
+}
+
+func TestLoadTemplateReadsOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("{{.Done}}/{{.Open}}"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	tmpl, err := LoadTemplate(path)
+	if err != nil {
+		t.Fatalf("LoadTemplate: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := Render(&buf, tmpl, Build("", time.Time{}, time.Time{}, sampleSections(), ByTag)); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if buf.String() != "2/1" {
+		t.Fatalf("report = %q", buf.String())
+	}
+}
+
+func TestTemplatesSeeAggregates(t *testing.T) {
+	sections := sampleSections()
+	sections[0].Entries[0].End = sections[0].Entries[0].Time.Add(90 * time.Minute)
+
+	tmpl, err := Parse("custom", `{{.Total}} entries, {{duration .Tracked}} tracked
+{{range .Tags}}{{.Name}} {{percent .Done .Count}}%
+{{end}}{{range .Entries}}{{status .Status}} {{clock .Time}} {{.Text}} {{tags .Tags}}
+{{end}}`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := Render(&buf, tmpl, Build("", time.Time{}, time.Time{}, sections, ByTag)); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	want := `3 entries, 1h30m tracked
+auth 50%
+web 100%
+done 09:00 Ship login #auth #web
+todo 10:00 Write tests #auth
+done 11:00 Lunch talk 
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("report =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestSlackTemplateListsEntriesPerDay(t *testing.T) {
+	day := time.Date(2025, time.November, 4, 0, 0, 0, 0, time.UTC)
+	r := Build("Work log for 2025-11-04", day, day, sampleSections(), ByTag)
+
+	tmpl, err := LoadSlackTemplate("")
+	if err != nil {
+		t.Fatalf("LoadSlackTemplate: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := Render(&buf, tmpl, r); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	want := `*Work log for 2025-11-04*
+_2 done, 1 open_
+
+*2025-11-04*
+:white_check_mark: Ship login _#auth #web_
+:white_large_square: Write tests _#auth_
+:white_check_mark: Lunch talk
+`
+	if buf.String() != want {
+		t.Fatalf("unexpected message:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
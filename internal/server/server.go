@@ -0,0 +1,502 @@
+// Package server exposes the logbook over HTTP for kerja serve: JSON
+// endpoints to read days and ranges and to add and toggle entries, a
+// Server-Sent Events stream that reports every month file written, by kerja
+// or by an editor, and a small web page built on them.
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+	"github.com/faizmokh/kerja/internal/logging"
+)
+
+// watchDebounce coalesces the burst of events editors emit for one save.
+const watchDebounce = 150 * time.Millisecond
+
+// keepAlive is how often an idle event stream sends a comment so proxies do
+// not close it.
+const keepAlive = 30 * time.Second
+
+// Change reports that the file holding Month was written or removed.
+type Change struct {
+	// Month is the month whose entries changed, as YYYY-MM.
+	Month string `json:"month"`
+	// Path is the file, relative to the logbook directory when inside it.
+	Path string `json:"path"`
+}
+
+// page is the web page served at /, a phone-sized view of one day.
+//
+//go:embed index.html
+var page []byte
+
+// Server serves one logbook and fans changes out to event streams.
+type Server struct {
+	manager     *files.Manager
+	reader      *logbook.Reader
+	writer      *logbook.Writer
+	allowOrigin string
+	allowHost   string
+	token       string
+	// onWatch, when set, is called once Watch has started watching.
+	onWatch func()
+
+	// writeMu serializes writes: each loads a month file, changes it, and
+	// writes it back, so two at once would lose one of them.
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	streams map[chan Change]struct{}
+}
+
+// New returns a server for the logbook manager opens.
+func New(manager *files.Manager) *Server {
+	return &Server{
+		manager: manager,
+		reader:  logbook.NewReader(manager),
+		writer:  logbook.NewWriter(manager),
+		streams: make(map[chan Change]struct{}),
+	}
+}
+
+// UseWriter makes the API write through w, so it honors the same settings
+// as the CLI. It returns s so it can follow New.
+func (s *Server) UseWriter(w *logbook.Writer) *Server {
+	s.writer = w
+	return s
+}
+
+// AllowOrigin lets pages served from origin, or any origin for "*", read
+// the API from a browser. It returns s so it can follow New.
+func (s *Server) AllowOrigin(origin string) *Server {
+	s.allowOrigin = origin
+	return s
+}
+
+// AllowHost lets requests name host, the address the server was asked to
+// listen on, besides localhost and IP addresses. It returns s so it can
+// follow New.
+func (s *Server) AllowHost(host string) *Server {
+	s.allowHost = host
+	return s
+}
+
+// RequireToken makes every API request carry token, as a token query
+// parameter or an Authorization: Bearer header. It returns s so it can
+// follow New.
+func (s *Server) RequireToken(token string) *Server {
+	s.token = token
+	return s
+}
+
+// Handler routes the web page and the API. {date} is YYYY-MM-DD or
+// "today", and {index} is 1-based:
+//
+//	GET  /                                       the web page
+//	GET  /api/days/{date}                        one day as a section, 404 when empty
+//	POST /api/days/{date}/entries                add {"text": "..."} in the TUI prompt syntax
+//	POST /api/days/{date}/entries/{index}/toggle advance the entry's status
+//	GET  /api/entries?from=&to=                  the sections between two dates
+//	GET  /api/events                             a text/event-stream of change events
+//
+// Writes must be sent as application/json, so browsers refuse to send them
+// from pages on another origin unless AllowOrigin lets them. Requests for a
+// host other than localhost, an IP address, or the one AllowHost names are
+// refused, so a page cannot reach the API by rebinding its own domain name.
+// With RequireToken, API requests without the token are refused too; the
+// page passes on the token in its own URL.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	})
+	mux.HandleFunc("GET /api/days/{date}", s.day)
+	mux.HandleFunc("POST /api/days/{date}/entries", s.add)
+	mux.HandleFunc("POST /api/days/{date}/entries/{index}/toggle", s.toggle)
+	mux.HandleFunc("GET /api/entries", s.entries)
+	mux.HandleFunc("GET /api/events", s.events)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.knownHost(r.Host) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("unexpected host %q", r.Host))
+			return
+		}
+		if s.allowOrigin != "" {
+			w.Header().Set("Access-Control-Allow-Origin", s.allowOrigin)
+			if r.Method == http.MethodOptions {
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+				w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		if strings.HasPrefix(r.URL.Path, "/api/") && !s.authorized(r) {
+			writeError(w, http.StatusUnauthorized, errors.New("missing or wrong token"))
+			return
+		}
+		if r.Method == http.MethodPost {
+			if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+				writeError(w, http.StatusUnsupportedMediaType, errors.New("send writes as application/json"))
+				return
+			}
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// authorized reports whether r carries the token RequireToken set, if any.
+func (s *Server) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+	token := r.URL.Query().Get("token")
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		token = bearer
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// knownHost reports whether a request's Host header names this server
+// rather than a domain someone pointed at it.
+func (s *Server) knownHost(host string) bool {
+	name := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		name = h
+	}
+	name = strings.TrimSuffix(strings.TrimPrefix(name, "["), "]")
+	if strings.EqualFold(name, "localhost") || net.ParseIP(name) != nil {
+		return true
+	}
+	if s.allowHost == "" {
+		return false
+	}
+	allowed := s.allowHost
+	if h, _, err := net.SplitHostPort(allowed); err == nil {
+		allowed = h
+	}
+	return strings.EqualFold(name, allowed)
+}
+
+func (s *Server) day(w http.ResponseWriter, r *http.Request) {
+	date, err := s.parseDate(r.PathValue("date"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	section, err := s.reader.Section(r.Context(), date)
+	if errors.Is(err, logbook.ErrSectionNotFound) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no entries for %s", date.Format("2006-01-02")))
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, withEntries([]logbook.DateSection{section})[0])
+}
+
+func (s *Server) add(w http.ResponseWriter, r *http.Request) {
+	date, err := s.parseDate(r.PathValue("date"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var body struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid body: %w", err))
+		return
+	}
+	entry, err := s.newEntry(date, body.Text)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	s.writeMu.Lock()
+	err = s.writer.Append(r.Context(), date, entry)
+	s.writeMu.Unlock()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, entry)
+}
+
+func (s *Server) toggle(w http.ResponseWriter, r *http.Request) {
+	date, err := s.parseDate(r.PathValue("date"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	index, err := strconv.Atoi(r.PathValue("index"))
+	if err != nil || index <= 0 {
+		writeError(w, http.StatusBadRequest, errors.New("index must be a positive integer"))
+		return
+	}
+	s.writeMu.Lock()
+	entry, err := s.writer.Toggle(r.Context(), date, index)
+	s.writeMu.Unlock()
+	switch {
+	case errors.Is(err, logbook.ErrSectionNotFound), errors.Is(err, logbook.ErrInvalidIndex):
+		writeError(w, http.StatusNotFound, err)
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+	default:
+		writeJSON(w, http.StatusOK, entry)
+	}
+}
+
+// newEntry builds a todo stamped now on date from a line in the TUI prompt
+// syntax, with the time, status, tags, and links its tokens give.
+func (s *Server) newEntry(date time.Time, text string) (logbook.Entry, error) {
+	parsed, err := logbook.ParseTokens(text, date)
+	if err != nil {
+		return logbook.Entry{}, err
+	}
+	if parsed.Text == "" && len(parsed.Tags) == 0 {
+		return logbook.Entry{}, errors.New("text is required")
+	}
+	now := time.Now().In(date.Location())
+	stamp := time.Date(date.Year(), date.Month(), date.Day(), now.Hour(), now.Minute(), 0, 0, date.Location())
+	return parsed.Entry(stamp, logbook.StatusTodo), nil
+}
+
+func (s *Server) entries(w http.ResponseWriter, r *http.Request) {
+	from, err := s.parseDate(r.URL.Query().Get("from"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("from: %w", err))
+		return
+	}
+	to, err := s.parseDate(r.URL.Query().Get("to"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("to: %w", err))
+		return
+	}
+	if to.Before(from) {
+		writeError(w, http.StatusBadRequest, errors.New("to is before from"))
+		return
+	}
+	sections, err := s.reader.SectionsBetween(r.Context(), from, to)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, withEntries(sections))
+}
+
+// events streams a "change" event, with a Change as its data, for every
+// write until the client disconnects.
+func (s *Server) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+	changes := s.subscribe()
+	defer s.unsubscribe(changes)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case change := <-changes:
+			data, err := json.Marshal(change)
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(w, "event: change\ndata: %s\n\n", data)
+		case <-ticker.C:
+			fmt.Fprint(w, ": ping\n\n")
+		}
+		flusher.Flush()
+	}
+}
+
+// Publish sends change to every open event stream. Streams too slow to keep
+// up miss it rather than holding up the rest.
+func (s *Server) Publish(change Change) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for stream := range s.streams {
+		select {
+		case stream <- change:
+		default:
+		}
+	}
+}
+
+func (s *Server) subscribe() chan Change {
+	stream := make(chan Change, 16)
+	s.mu.Lock()
+	s.streams[stream] = struct{}{}
+	s.mu.Unlock()
+	return stream
+}
+
+func (s *Server) unsubscribe(stream chan Change) {
+	s.mu.Lock()
+	delete(s.streams, stream)
+	s.mu.Unlock()
+}
+
+// Watch publishes a Change whenever a month file or daily note is written
+// or removed, until ctx is done. Directories are watched rather than files
+// because both kerja and most editors save by renaming a temp file over the
+// original; new year and month directories are picked up as they appear,
+// along with whatever was written into them before they were watched.
+func (s *Server) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("watch logbook: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := watchTree(watcher, s.manager.EntriesDir(), nil); err != nil {
+		return fmt.Errorf("watch logbook: %w", err)
+	}
+	if s.onWatch != nil {
+		s.onWatch()
+	}
+
+	pending := make(map[string]time.Time)
+	var flush <-chan time.Time
+	changed := func(path string) {
+		if month, ok := s.manager.MonthOf(path); ok {
+			pending[path] = month
+			flush = time.After(watchDebounce)
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					// Files written before the watch was added sent no
+					// events, so they are published as found.
+					_ = watchTree(watcher, event.Name, changed)
+					continue
+				}
+			}
+			changed(event.Name)
+		case <-flush:
+			for path, month := range pending {
+				s.Publish(Change{Month: month.Format("2006-01"), Path: s.relative(path)})
+				logging.L().Info("publish change", "path", path)
+			}
+			clear(pending)
+			flush = nil
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logging.L().Warn("watch logbook", "err", err)
+		}
+	}
+}
+
+// relative returns path relative to the logbook directory, slash-separated,
+// or path itself when it lies outside.
+func (s *Server) relative(path string) string {
+	rel, err := filepath.Rel(s.manager.BasePath(), path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// watchTree watches dir and every directory beneath it except hidden ones
+// such as .git, passing the files it finds to found when not nil. A missing
+// dir is skipped.
+func watchTree(watcher *fsnotify.Watcher, dir string, found func(path string)) error {
+	return filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			if path == dir && errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !entry.IsDir() {
+			if found != nil {
+				found(path)
+			}
+			return nil
+		}
+		if strings.HasPrefix(entry.Name(), ".") && path != dir {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+}
+
+// parseDate reads a YYYY-MM-DD date, or "today", in the logbook's zone.
+func (s *Server) parseDate(value string) (time.Time, error) {
+	zone := s.manager.TimeZone()
+	if value == "today" {
+		now := time.Now().In(zone)
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, zone), nil
+	}
+	date, err := time.ParseInLocation("2006-01-02", value, zone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", value)
+	}
+	return date, nil
+}
+
+// withEntries makes sections without entries encode them as [] rather than
+// null, as --json does.
+func withEntries(sections []logbook.DateSection) []logbook.DateSection {
+	if sections == nil {
+		return []logbook.DateSection{}
+	}
+	for i := range sections {
+		if sections[i].Entries == nil {
+			sections[i].Entries = []logbook.Entry{}
+		}
+	}
+	return sections
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
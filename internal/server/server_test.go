@@ -0,0 +1,298 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func newTestServer(t *testing.T) (*Server, *files.Manager) {
+	t.Helper()
+	manager, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	return New(manager), manager
+}
+
+func appendEntry(t *testing.T, manager *files.Manager, date time.Time, text string) {
+	t.Helper()
+	entry := logbook.Entry{Status: logbook.StatusTodo, Time: date.Add(9 * time.Hour), Text: text}
+	if err := logbook.NewWriter(manager).Append(context.Background(), date, entry); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+}
+
+func TestDayAndEntriesServeSectionsAsJSON(t *testing.T) {
+	srv, manager := newTestServer(t)
+	day := time.Date(2025, 11, 4, 0, 0, 0, 0, time.Local)
+	appendEntry(t, manager, day, "Ship login")
+	appendEntry(t, manager, day.AddDate(0, 0, 1), "Plan sprint")
+	handler := srv.AllowOrigin("*").Handler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://localhost/api/days/2025-11-04", nil))
+	var section logbook.DateSection
+	if err := json.NewDecoder(rec.Body).Decode(&section); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("GET day = %d, %v", rec.Code, err)
+	}
+	if len(section.Entries) != 1 || section.Entries[0].Text != "Ship login" {
+		t.Fatalf("day entries = %+v", section.Entries)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Fatalf("Access-Control-Allow-Origin = %q", got)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://localhost/api/entries?from=2025-11-01&to=2025-11-30", nil))
+	var sections []logbook.DateSection
+	if err := json.NewDecoder(rec.Body).Decode(&sections); err != nil || len(sections) != 2 {
+		t.Fatalf("GET entries = %d sections, %v", len(sections), err)
+	}
+
+	for target, want := range map[string]int{
+		"/api/days/2025-11-09":                       http.StatusNotFound,
+		"/api/days/tomorrow":                         http.StatusBadRequest,
+		"/api/entries?from=2025-11-30&to=2025-11-01": http.StatusBadRequest,
+	} {
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://localhost"+target, nil))
+		if rec.Code != want {
+			t.Fatalf("GET %s = %d, want %d", target, rec.Code, want)
+		}
+	}
+}
+
+func TestEventsStreamChangesWrittenToMonthFiles(t *testing.T) {
+	srv, manager := newTestServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watching := make(chan struct{})
+	srv.onWatch = func() { close(watching) }
+	go srv.Watch(ctx)
+
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+	resp, err := ts.Client().Get(ts.URL + "/api/events")
+	if err != nil {
+		t.Fatalf("GET events: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	stream := bufio.NewReader(resp.Body)
+	if line, _ := stream.ReadString('\n'); !strings.HasPrefix(line, ":") {
+		t.Fatalf("first line = %q, want a comment", line)
+	}
+
+	// The year directory is new, so the month file may be written before
+	// the watch on it is in place.
+	<-watching
+	appendEntry(t, manager, time.Date(2025, 11, 4, 0, 0, 0, 0, time.Local), "Ship login")
+
+	lines := make(chan string)
+	go func() {
+		for {
+			line, err := stream.ReadString('\n')
+			if err != nil {
+				close(lines)
+				return
+			}
+			lines <- strings.TrimSpace(line)
+		}
+	}()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatalf("stream closed before a change arrived")
+			}
+			if data, found := strings.CutPrefix(line, "data: "); found {
+				var change Change
+				if err := json.Unmarshal([]byte(data), &change); err != nil {
+					t.Fatalf("decode %q: %v", data, err)
+				}
+				if change.Month != "2025-11" || change.Path != "2025/2025-11.md" {
+					t.Fatalf("change = %+v", change)
+				}
+				return
+			}
+		case <-timeout:
+			t.Fatalf("no change event within 5s")
+		}
+	}
+}
+
+func TestWatchPublishesFilesInNewDirectories(t *testing.T) {
+	srv, manager := newTestServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watching := make(chan struct{})
+	srv.onWatch = func() { close(watching) }
+	changes := srv.subscribe()
+	defer srv.unsubscribe(changes)
+	go srv.Watch(ctx)
+	<-watching
+
+	// A year directory renamed into place sends one event; the month file
+	// inside never sends its own.
+	staged := filepath.Join(t.TempDir(), "2026")
+	if err := os.MkdirAll(staged, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(staged, "2026-01.md"), []byte("## 2026-01-02\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := os.Rename(staged, filepath.Join(manager.BasePath(), "2026")); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+
+	select {
+	case change := <-changes:
+		if change.Month != "2026-01" || change.Path != "2026/2026-01.md" {
+			t.Fatalf("change = %+v", change)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no change for the file in the new directory within 5s")
+	}
+}
+
+func TestAddAndToggleWriteEntries(t *testing.T) {
+	srv, _ := newTestServer(t)
+	handler := srv.Handler()
+	post := func(target, contentType, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "http://localhost"+target, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := post("/api/days/2025-11-04/entries", "application/json", `{"text": "Ship login #work @09:30"}`)
+	var added logbook.Entry
+	if err := json.NewDecoder(rec.Body).Decode(&added); err != nil || rec.Code != http.StatusCreated {
+		t.Fatalf("POST entry = %d, %v", rec.Code, err)
+	}
+	if added.Text != "Ship login" || len(added.Tags) != 1 || added.Time.Format("15:04") != "09:30" {
+		t.Fatalf("added %+v", added)
+	}
+
+	rec = post("/api/days/2025-11-04/entries/1/toggle", "application/json", "")
+	var toggled logbook.Entry
+	if err := json.NewDecoder(rec.Body).Decode(&toggled); err != nil || toggled.Status != logbook.StatusInProgress {
+		t.Fatalf("POST toggle = %d %+v, %v", rec.Code, toggled, err)
+	}
+
+	if rec = post("/api/days/2025-11-04/entries/2/toggle", "application/json", ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("toggle missing entry = %d", rec.Code)
+	}
+	if rec = post("/api/days/2025-11-04/entries", "application/json", `{"text": " "}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("add empty entry = %d", rec.Code)
+	}
+	// A form post from another site must not write.
+	if rec = post("/api/days/2025-11-04/entries", "text/plain", `{"text": "Spam"}`); rec.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("add as text/plain = %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://localhost/", nil))
+	if !strings.Contains(rec.Body.String(), "/api/events") || rec.Header().Get("Content-Type") != "text/html; charset=utf-8" {
+		t.Fatalf("GET / = %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+}
+
+func TestConcurrentAddsAreAllWritten(t *testing.T) {
+	srv, manager := newTestServer(t)
+	handler := srv.Handler()
+
+	const adds = 20
+	var wg sync.WaitGroup
+	for i := range adds {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodPost, "http://localhost/api/days/2025-11-04/entries", strings.NewReader(fmt.Sprintf(`{"text": "Entry %d"}`, i)))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != http.StatusCreated {
+				t.Errorf("POST entry %d = %d %s", i, rec.Code, rec.Body)
+			}
+		}()
+	}
+	wg.Wait()
+
+	section, err := logbook.NewReader(manager).Section(context.Background(), time.Date(2025, 11, 4, 0, 0, 0, 0, time.Local))
+	if err != nil || len(section.Entries) != adds {
+		t.Fatalf("wrote %d entries, %v; want %d", len(section.Entries), err, adds)
+	}
+}
+
+func TestRequestsForOtherHostsAreRefused(t *testing.T) {
+	srv, _ := newTestServer(t)
+	handler := srv.AllowHost("kerja.lan:7788").Handler()
+
+	for host, want := range map[string]int{
+		"localhost:7788":    http.StatusNotFound,
+		"127.0.0.1:7788":    http.StatusNotFound,
+		"[::1]:7788":        http.StatusNotFound,
+		"192.168.1.20:7788": http.StatusNotFound,
+		"kerja.lan:7788":    http.StatusNotFound,
+		"attacker.example":  http.StatusForbidden,
+		"evil.example:7788": http.StatusForbidden,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost/api/days/2025-11-09", nil)
+		req.Host = host
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Fatalf("GET with Host %s = %d, want %d", host, rec.Code, want)
+		}
+	}
+}
+
+func TestRequireTokenGuardsTheAPI(t *testing.T) {
+	srv, _ := newTestServer(t)
+	handler := srv.RequireToken("s3cret").Handler()
+
+	tests := []struct {
+		name   string
+		target string
+		auth   string
+		want   int
+	}{
+		{"page needs no token", "/", "", http.StatusOK},
+		{"missing token", "/api/days/2025-11-09", "", http.StatusUnauthorized},
+		{"wrong token", "/api/days/2025-11-09?token=guess", "", http.StatusUnauthorized},
+		{"query token", "/api/days/2025-11-09?token=s3cret", "", http.StatusNotFound},
+		{"bearer token", "/api/days/2025-11-09", "Bearer s3cret", http.StatusNotFound},
+		{"wrong bearer token", "/api/days/2025-11-09?token=s3cret", "Bearer guess", http.StatusUnauthorized},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost"+tc.target, nil)
+			if tc.auth != "" {
+				req.Header.Set("Authorization", tc.auth)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tc.want {
+				t.Fatalf("GET %s = %d, want %d", tc.target, rec.Code, tc.want)
+			}
+		})
+	}
+}
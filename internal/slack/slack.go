@@ -0,0 +1,43 @@
+// Package slack posts messages to Slack incoming webhooks.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Post sends text to the incoming webhook URL as a mrkdwn message. A nil
+// client uses http.DefaultClient.
+func Post(ctx context.Context, client *http.Client, webhook, text string) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	body, err := json.Marshal(struct {
+		Text string `json:"text"`
+	}{text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("slack webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post to slack: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		// Slack explains rejections in a short plain-text body.
+		reason, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack webhook returned %s: %s", resp.Status, strings.TrimSpace(string(reason)))
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPostSendsTextAsJSON(t *testing.T) {
+	var got struct{ Text string }
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	if err := Post(context.Background(), srv.Client(), srv.URL, "*Done* today"); err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	if got.Text != "*Done* today" {
+		t.Fatalf("text = %q", got.Text)
+	}
+}
+
+func TestPostReportsRejections(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	err := Post(context.Background(), srv.Client(), srv.URL, "hi")
+	if err == nil || !strings.Contains(err.Error(), "403 Forbidden: invalid_token") {
+		t.Fatalf("Post error = %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+// Package snippets loads reusable entry templates from snippets.md in the
+// logbook directory.
+//
+// Each snippet is a level-two heading naming it, followed by the entry line
+// (accepting @HH:MM, !status, and #tags) and optional note lines:
+//
+//	## standup
+//	Daily standup @09:30 !done #meeting
+//	Yesterday:
+//	Today:
+package snippets
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/faizmokh/kerja/internal/editor"
+)
+
+// FileName is the snippets file inside the logbook base directory.
+const FileName = "snippets.md"
+
+// Snippet is a named entry template. Text holds the raw entry line, tokens
+// included, for logbook.ParseTokens.
+type Snippet struct {
+	Name  string
+	Text  string
+	Notes []string
+}
+
+// Path returns the snippets file for the logbook rooted at base.
+func Path(base string) string {
+	return filepath.Join(base, FileName)
+}
+
+// Load reads the snippets at path. A missing file yields no snippets.
+func Load(path string) ([]Snippet, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Parse(string(data))
+}
+
+// Parse splits content into snippets at each "## name" heading. Text before
+// the first heading is ignored; names must be unique and bodies non-empty.
+func Parse(content string) ([]Snippet, error) {
+	var (
+		snippets []Snippet
+		name     string
+		body     []string
+	)
+	flush := func() error {
+		if name == "" {
+			return nil
+		}
+		text, notes, err := editor.Parse(strings.Join(body, "\n"))
+		if err != nil {
+			return fmt.Errorf("snippet %q has no entry line", name)
+		}
+		if _, ok := Find(snippets, name); ok {
+			return fmt.Errorf("duplicate snippet %q", name)
+		}
+		snippets = append(snippets, Snippet{Name: name, Text: text, Notes: notes})
+		return nil
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if heading, ok := strings.CutPrefix(strings.TrimSpace(line), "## "); ok {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			name = strings.TrimSpace(heading)
+			body = nil
+			continue
+		}
+		body = append(body, line)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return snippets, nil
+}
+
+// Find returns the snippet called name, ignoring case.
+func Find(snippets []Snippet, name string) (Snippet, bool) {
+	for _, snippet := range snippets {
+		if strings.EqualFold(snippet.Name, name) {
+			return snippet, true
+		}
+	}
+	return Snippet{}, false
+}
+
+// Names lists the snippet names in file order.
+func Names(snippets []Snippet) []string {
+	names := make([]string, len(snippets))
+	for i, snippet := range snippets {
+		names[i] = snippet.Name
+	}
+	return names
+}
@@ -0,0 +1,55 @@
+package snippets
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseReadsNamedSnippets(t *testing.T) {
+	content := "# Snippets\n\n## standup\nDaily standup @09:30 !done #meeting\n  Yesterday:\n  Today:\n\n## Review\nCode review #team\n"
+
+	got, err := Parse(content)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := []Snippet{
+		{Name: "standup", Text: "Daily standup @09:30 !done #meeting", Notes: []string{"Yesterday:", "Today:"}},
+		{Name: "Review", Text: "Code review #team"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Parse = %#v, want %#v", got, want)
+	}
+	if snippet, ok := Find(got, "review"); !ok || snippet.Name != "Review" {
+		t.Fatalf("Find(review) = %+v, %v", snippet, ok)
+	}
+	if names := Names(got); !reflect.DeepEqual(names, []string{"standup", "Review"}) {
+		t.Fatalf("Names = %v", names)
+	}
+}
+
+func TestParseRejectsEmptyAndDuplicateSnippets(t *testing.T) {
+	if _, err := Parse("## empty\n\n## next\nText\n"); err == nil {
+		t.Fatalf("expected error for empty snippet")
+	}
+	if _, err := Parse("## a\nOne\n## A\nTwo\n"); err == nil {
+		t.Fatalf("expected error for duplicate snippet")
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	dir := t.TempDir()
+	got, err := Load(Path(dir))
+	if err != nil || got != nil {
+		t.Fatalf("Load missing = %v, %v", got, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte("## lunch\nLunch #break\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	got, err = Load(Path(dir))
+	if err != nil || len(got) != 1 || got[0].Text != "Lunch #break" {
+		t.Fatalf("Load = %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// blockedLoadedMsg carries which entries of the day at date still wait for
+// an after: dependency that is not done.
+type blockedLoadedMsg struct {
+	date    time.Time
+	blocked map[int]bool
+	err     error
+}
+
+// loadBlockedCmd checks the after: dependencies of the section's open
+// entries, or returns nil when none of them has any.
+func (m Model) loadBlockedCmd(section logbook.DateSection) tea.Cmd {
+	var waiting []int
+	for i, entry := range section.Entries {
+		if entry.Status.Open() && len(entry.After) > 0 {
+			waiting = append(waiting, i)
+		}
+	}
+	if len(waiting) == 0 {
+		return nil
+	}
+
+	reader := m.reader
+	ctx := m.ctx
+	return func() tea.Msg {
+		blocked := make(map[int]bool)
+		for _, i := range waiting {
+			isBlocked, err := reader.Blocked(ctx, section.Entries[i])
+			if err != nil {
+				return blockedLoadedMsg{date: section.Date, err: err}
+			}
+			if isBlocked {
+				blocked[i] = true
+			}
+		}
+		return blockedLoadedMsg{date: section.Date, blocked: blocked}
+	}
+}
+
+func (m Model) handleBlockedLoaded(msg blockedLoadedMsg) (tea.Model, tea.Cmd) {
+	if !sameDay(m.currentDate, msg.date) {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.errorLine = fmt.Sprintf("Checking dependencies failed: %v", msg.err)
+		return m, nil
+	}
+	m.blocked = msg.blocked
+	return m, nil
+}
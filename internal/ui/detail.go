@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+const (
+	// detailMinListWidth is the narrowest list kept beside the detail pane;
+	// below it the pane replaces the list instead.
+	detailMinListWidth = 36
+	// detailHistoryLimit caps how many journaled changes the pane lists.
+	detailHistoryLimit = 8
+)
+
+type historyLoadedMsg struct {
+	changes []logbook.Change
+	err     error
+}
+
+// toggleDetail shows or hides the pane describing the selected entry.
+func (m Model) toggleDetail() (tea.Model, tea.Cmd) {
+	m.showDetail = !m.showDetail
+	if !m.showDetail {
+		m.history = nil
+		return m, nil
+	}
+	return m, m.loadHistoryCmd()
+}
+
+func (m Model) loadHistoryCmd() tea.Cmd {
+	if m.manager == nil {
+		return nil
+	}
+	journal := logbook.NewJournal(m.manager.BasePath())
+	return func() tea.Msg {
+		changes, err := journal.Changes()
+		return historyLoadedMsg{changes: changes, err: err}
+	}
+}
+
+func (m Model) handleHistoryLoaded(msg historyLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.errorLine = fmt.Sprintf("History failed: %v", msg.err)
+		return m, nil
+	}
+	m.history = msg.changes
+	return m, nil
+}
+
+// refreshDetail reloads the history after the log changed while the pane is
+// open.
+func refreshDetail(model tea.Model, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	m := model.(Model)
+	if !m.showDetail {
+		return m, cmd
+	}
+	return m, tea.Batch(cmd, m.loadHistoryCmd())
+}
+
+// detailLayout returns the list and pane widths, with a zero list width when
+// the terminal is too narrow to show both.
+func (m Model) detailLayout(total int) (int, int) {
+	pane := max(30, total*2/5)
+	list := total - pane - 1
+	if list < detailMinListWidth {
+		return 0, total
+	}
+	return list, pane
+}
+
+// detailVisible reports whether the pane is drawn in the current view.
+func (m Model) detailVisible() bool {
+	return m.showDetail && m.mode != modeSearch && m.mode != modeSnippet && !m.loading
+}
+
+// renderDetail draws the pane for the selected entry at width by height,
+// frame included.
+func (m Model) renderDetail(width, height int) string {
+	inner := max(width-4, 10)
+	wrap := lipgloss.NewStyle().Width(inner)
+
+	var lines []string
+	if !m.hasSelection() {
+		lines = append(lines, placeholderStyle.Render("(no entry selected)"))
+	} else {
+		entry := m.section.Entries[m.selected]
+		lines = append(lines, labelStyle.Render(fmt.Sprintf("Entry %d of %d%s%s", m.selected+1, len(m.section.Entries), glyphs.sep, formatDate(m.currentDate, "Mon 02 Jan 2006"))))
+		clock := timeStyle.Render(entry.Time.Format(m.timeLayout))
+		if duration := entry.Duration(); duration > 0 {
+			clock = timeStyle.Render(entry.Time.Format(m.timeLayout)+"-"+entry.End.Format(m.timeLayout)) + " " + placeholderStyle.Render(formatDuration(duration))
+		}
+		lines = append(lines, renderStatusBadge(entry.Status)+" "+clock, "")
+
+		text := strings.TrimSpace(entry.Text)
+		if text == "" {
+			text = "(no description)"
+		}
+		lines = append(lines, strings.Split(wrap.Render(entryTextStyle.Render(text)), "\n")...)
+		if len(entry.Tags) > 0 {
+			tags := make([]string, len(entry.Tags))
+			for i, tag := range entry.Tags {
+				tags[i] = tagStyle.Render("#" + tag)
+			}
+			lines = append(lines, strings.Split(wrap.Render(strings.Join(tags, " ")), "\n")...)
+		}
+		for _, link := range entry.Links {
+			lines = append(lines, strings.Split(wrap.Render(linkStyle.Render(logbook.LinkPrefix+link)), "\n")...)
+		}
+		for _, after := range entry.After {
+			lines = append(lines, placeholderStyle.Render("waits for "+logbook.AfterPrefix+logbook.AfterRef(after)))
+		}
+		if m.blocked[m.selected] && entry.Status.Open() {
+			lines = append(lines, statusErrorStyle.Render("Blocked until what it waits for is done."))
+		}
+		if entry.Author != "" {
+			lines = append(lines, placeholderStyle.Render("by "+logbook.AuthorPrefix+entry.Author))
+		}
+		if !entry.Due.IsZero() {
+			lines = append(lines, renderDue(entry))
+		}
+
+		if len(entry.Notes) > 0 {
+			lines = append(lines, "", labelStyle.Render("Notes"))
+			for _, note := range entry.Notes {
+				lines = append(lines, strings.Split(wrap.Render(note), "\n")...)
+			}
+		}
+
+		lines = append(lines, "", labelStyle.Render("History of this day"))
+		day := m.currentDate.Format("2006-01-02")
+		shown := 0
+		for _, change := range m.history {
+			if !strings.Contains(change.Op, day) {
+				continue
+			}
+			if shown == detailHistoryLimit {
+				break
+			}
+			shown++
+			stamp := placeholderStyle.Render(formatDate(change.Time.Local(), "Jan 02 "+m.timeLayout))
+			lines = append(lines, strings.Split(wrap.Render(stamp+" "+change.Op), "\n")...)
+		}
+		if shown == 0 {
+			lines = append(lines, placeholderStyle.Render("(no undoable changes)"))
+		}
+	}
+
+	// Keep the pane the same height as the list beside it.
+	rows := max(height-2, 1)
+	if len(lines) > rows {
+		lines = append(lines[:rows-1], placeholderStyle.Render(glyphs.continued))
+	}
+	return viewportFrameStyle.Width(width - 2).Height(rows).Render(strings.Join(lines, "\n"))
+}
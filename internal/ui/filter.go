@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// matchesFilter reports whether entry satisfies the filter term. A leading #
+// matches tags by prefix and a leading ~ the author by prefix; anything else
+// is a substring match on text and tags. Matching is case-insensitive.
+func matchesFilter(entry logbook.Entry, term string) bool {
+	term = strings.ToLower(strings.TrimSpace(term))
+	if term == "" {
+		return true
+	}
+
+	if strings.HasPrefix(term, "#") {
+		prefix := strings.TrimPrefix(term, "#")
+		for _, tag := range entry.Tags {
+			if strings.HasPrefix(strings.ToLower(tag), prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	if author, ok := strings.CutPrefix(term, logbook.AuthorPrefix); ok {
+		return entry.Author != "" && strings.HasPrefix(strings.ToLower(entry.Author), author)
+	}
+
+	if strings.Contains(strings.ToLower(entry.Text), term) {
+		return true
+	}
+	for _, tag := range entry.Tags {
+		if strings.Contains(strings.ToLower(tag), term) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether entry belongs to the active context and matches
+// the filter.
+func (m Model) matches(entry logbook.Entry) bool {
+	return logbook.MatchesContext(entry, m.context) && matchesFilter(entry, m.filter)
+}
+
+// shown reports whether the list shows entry: it matches, and is open when
+// focus is on.
+func (m Model) shown(entry logbook.Entry) bool {
+	return m.matches(entry) && (!m.focus || entry.Status.Open())
+}
+
+// applyFilter recomputes the visible entry indexes and keeps the selection on
+// a visible entry. m.selected always indexes m.section.Entries, so writer
+// operations use the real position no matter what is filtered out.
+func (m Model) applyFilter() Model {
+	visible := make([]int, 0, len(m.section.Entries))
+	for _, i := range m.section.DisplayOrder() {
+		if m.shown(m.section.Entries[i]) {
+			visible = append(visible, i)
+		}
+	}
+	m.visible = visible
+
+	if len(m.visible) == 0 {
+		return m
+	}
+	if m.visiblePosition() < 0 {
+		// Snap to the nearest visible entry at or after the old selection.
+		next := m.visible[len(m.visible)-1]
+		for _, index := range m.visible {
+			if index >= m.selected {
+				next = index
+				break
+			}
+		}
+		m.selected = next
+	}
+	return m
+}
+
+// visiblePosition returns the row of the selected entry in the filtered list,
+// or -1 when it is hidden.
+func (m Model) visiblePosition() int {
+	for pos, index := range m.visible {
+		if index == m.selected {
+			return pos
+		}
+	}
+	return -1
+}
+
+func (m Model) hasSelection() bool {
+	return len(m.visible) > 0 && m.visiblePosition() >= 0
+}
@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func TestFilterKeepsSelectionOnRealEntries(t *testing.T) {
+	const (
+		todo       = logbook.StatusTodo
+		inProgress = logbook.StatusInProgress
+	)
+	tests := []struct {
+		name         string
+		keys         []string
+		wantFilter   string
+		wantSelected int
+		wantVisible  []int
+		wantStatuses []logbook.Status
+	}{
+		{
+			name:         "selection snaps to the first match after the cursor",
+			keys:         []string{"j", "j", "/", "f", "i", "x", "enter"},
+			wantFilter:   "fix",
+			wantSelected: 3,
+			wantVisible:  []int{1, 3},
+			wantStatuses: []logbook.Status{todo, todo, todo, todo},
+		},
+		{
+			name:         "moving skips rows hidden before the cursor",
+			keys:         []string{"/", "f", "i", "x", "enter", "j", "k", "k"},
+			wantFilter:   "fix",
+			wantSelected: 1,
+			wantVisible:  []int{1, 3},
+			wantStatuses: []logbook.Status{todo, todo, todo, todo},
+		},
+		{
+			name:         "toggle acts on the entry under the cursor",
+			keys:         []string{"/", "f", "i", "x", "enter", "j", "x"},
+			wantFilter:   "fix",
+			wantSelected: 3,
+			wantVisible:  []int{1, 3},
+			wantStatuses: []logbook.Status{todo, todo, todo, inProgress},
+		},
+		{
+			name:         "clearing the filter keeps the selected entry",
+			keys:         []string{"/", "f", "i", "x", "enter", "j", "esc"},
+			wantFilter:   "",
+			wantSelected: 3,
+			wantVisible:  []int{0, 1, 2, 3},
+			wantStatuses: []logbook.Status{todo, todo, todo, todo},
+		},
+		{
+			name:         "cancelling the prompt clears the filter",
+			keys:         []string{"j", "j", "/", "f", "i", "x", "esc", "x"},
+			wantFilter:   "",
+			wantSelected: 3,
+			wantVisible:  []int{0, 1, 2, 3},
+			wantStatuses: []logbook.Status{todo, todo, todo, inProgress},
+		},
+		{
+			name:         "toggle does nothing when nothing matches",
+			keys:         []string{"/", "z", "z", "enter", "x"},
+			wantFilter:   "zz",
+			wantSelected: 0,
+			wantVisible:  []int{},
+			wantStatuses: []logbook.Status{todo, todo, todo, todo},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newTestModel(t, "Write docs", "Fix bug", "Write tests", "Fix typo")
+			m = press(t, m, tc.keys...)
+
+			if m.filter != tc.wantFilter {
+				t.Fatalf("filter = %q, want %q", m.filter, tc.wantFilter)
+			}
+			if m.selected != tc.wantSelected {
+				t.Fatalf("selected = %d, want %d", m.selected, tc.wantSelected)
+			}
+			if !slices.Equal(m.visible, tc.wantVisible) {
+				t.Fatalf("visible = %v, want %v", m.visible, tc.wantVisible)
+			}
+			if got := statuses(m); !slices.Equal(got, tc.wantStatuses) {
+				t.Fatalf("statuses = %v, want %v", got, tc.wantStatuses)
+			}
+		})
+	}
+}
+
+func TestFocusHidesClosedEntries(t *testing.T) {
+	const (
+		todo       = logbook.StatusTodo
+		done       = logbook.StatusDone
+		inProgress = logbook.StatusInProgress
+	)
+	tests := []struct {
+		name         string
+		keys         []string
+		wantSelected int
+		wantVisible  []int
+		wantStatuses []logbook.Status
+	}{
+		{
+			name:         "focus hides done entries and moves the selection on",
+			keys:         []string{"x", "x", "f"},
+			wantSelected: 1,
+			wantVisible:  []int{1, 2, 3},
+			wantStatuses: []logbook.Status{done, todo, todo, todo},
+		},
+		{
+			name:         "closing an entry under focus hides it",
+			keys:         []string{"f", "j", "x", "x"},
+			wantSelected: 2,
+			wantVisible:  []int{0, 2, 3},
+			wantStatuses: []logbook.Status{todo, done, todo, todo},
+		},
+		{
+			name:         "toggle under focus acts on the entry under the cursor",
+			keys:         []string{"x", "x", "f", "j", "x"},
+			wantSelected: 2,
+			wantVisible:  []int{1, 2, 3},
+			wantStatuses: []logbook.Status{done, todo, inProgress, todo},
+		},
+		{
+			name:         "focus off shows every entry and keeps the selection",
+			keys:         []string{"x", "x", "f", "f"},
+			wantSelected: 1,
+			wantVisible:  []int{0, 1, 2, 3},
+			wantStatuses: []logbook.Status{done, todo, todo, todo},
+		},
+		{
+			name:         "focus and a filter narrow the list together",
+			keys:         []string{"j", "x", "x", "f", "/", "f", "i", "x", "enter"},
+			wantSelected: 3,
+			wantVisible:  []int{3},
+			wantStatuses: []logbook.Status{todo, done, todo, todo},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newTestModel(t, "Write docs", "Fix bug", "Write tests", "Fix typo")
+			m = press(t, m, tc.keys...)
+
+			if m.selected != tc.wantSelected {
+				t.Fatalf("selected = %d, want %d", m.selected, tc.wantSelected)
+			}
+			if !slices.Equal(m.visible, tc.wantVisible) {
+				t.Fatalf("visible = %v, want %v", m.visible, tc.wantVisible)
+			}
+			if got := statuses(m); !slices.Equal(got, tc.wantStatuses) {
+				t.Fatalf("statuses = %v, want %v", got, tc.wantStatuses)
+			}
+		})
+	}
+}
+
+func TestMatchesFilter(t *testing.T) {
+	entry := logbook.Entry{Text: "Fix login bug", Tags: []string{"backend"}, Author: "alice"}
+	tests := []struct {
+		term string
+		want bool
+	}{
+		{"", true},
+		{"LOGIN", true},
+		{"#back", true},
+		{"#end", false},
+		{"~ali", true},
+		{"~bob", false},
+		{"frontend", false},
+	}
+	for _, tc := range tests {
+		if got := matchesFilter(entry, tc.term); got != tc.want {
+			t.Errorf("matchesFilter(%q) = %v, want %v", tc.term, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// actions maps the names used in the config file's [keys] table to the
+// bindings they remap.
+func (k *keyMap) actions() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":          &k.Up,
+		"down":        &k.Down,
+		"prev_day":    &k.PrevDay,
+		"next_day":    &k.NextDay,
+		"today":       &k.Today,
+		"reload":      &k.Reload,
+		"toggle":      &k.Toggle,
+		"add_todo":    &k.AddTodo,
+		"add_done":    &k.AddDone,
+		"compose":     &k.Compose,
+		"edit":        &k.Edit,
+		"edit_time":   &k.EditTime,
+		"edit_status": &k.EditStatus,
+		"delete":      &k.Delete,
+		"duplicate":   &k.Duplicate,
+		"move":        &k.Move,
+		"mark":        &k.Mark,
+		"retag":       &k.Retag,
+		"pin":         &k.Pin,
+		"shift_down":  &k.ShiftDown,
+		"shift_up":    &k.ShiftUp,
+		"undo":        &k.Undo,
+		"filter":      &k.Filter,
+		"focus":       &k.Focus,
+		"week":        &k.Week,
+		"timeline":    &k.Timeline,
+		"search":      &k.Search,
+		"snippet":     &k.Snippet,
+		"open_link":   &k.OpenLink,
+		"detail":      &k.Detail,
+		"stats":       &k.Stats,
+		"upcoming":    &k.Upcoming,
+		"help":        &k.Help,
+		"quit":        &k.Quit,
+	}
+}
+
+// KeyActions lists the action names accepted in the [keys] table.
+func KeyActions() []string {
+	var k keyMap
+	names := make([]string, 0, len(k.actions()))
+	for name := range k.actions() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// withBindings replaces the keys of each named action. Unknown actions and
+// keys claimed by two actions are errors.
+func (k keyMap) withBindings(bindings map[string][]string) (keyMap, error) {
+	actions := k.actions()
+	names := make([]string, 0, len(bindings))
+	for name := range bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		binding, ok := actions[name]
+		if !ok {
+			return k, fmt.Errorf("unknown key action %q (expected one of %s)", name, strings.Join(KeyActions(), ", "))
+		}
+		keys := bindings[name]
+		binding.SetKeys(keys...)
+		binding.SetHelp(strings.Join(keys, "/"), binding.Help().Desc)
+	}
+
+	owner := make(map[string]string)
+	for _, name := range KeyActions() {
+		for _, pressed := range actions[name].Keys() {
+			if other, ok := owner[pressed]; ok {
+				return k, fmt.Errorf("key %q is bound to both %s and %s", pressed, other, name)
+			}
+			owner[pressed] = name
+		}
+	}
+	return k, nil
+}
+
+// asciiArrows spells out arrow keys in help text for plain mode.
+var asciiArrows = strings.NewReplacer("↑", "up", "↓", "down", "←", "left", "→", "right")
+
+func (k keyMap) withASCIIHelp() keyMap {
+	for _, binding := range k.actions() {
+		help := binding.Help()
+		binding.SetHelp(asciiArrows.Replace(help.Key), help.Desc)
+	}
+	return k
+}
+
+// ValidateKeyBindings reports whether bindings, as read from the config
+// file, can be applied on top of the default keys.
+func ValidateKeyBindings(bindings map[string][]string) error {
+	_, err := newKeyMap().withBindings(bindings)
+	return err
+}
+
+func (m Model) handleHelpKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyCtrlC:
+		return m, tea.Quit
+	case msg.Type == tea.KeyEsc, key.Matches(msg, m.keys.Help), key.Matches(msg, m.keys.Quit):
+		m.showHelp = false
+	}
+	return m, nil
+}
+
+// helpGroupTitles names the FullHelp columns in order.
+var helpGroupTitles = []string{"Select", "Add & edit", "Navigate & view", "Manage"}
+
+// renderHelpOverlay lists every binding, grouped as in FullHelp, across the
+// whole screen.
+func (m Model) renderHelpOverlay() string {
+	var groups []string
+	for i, bindings := range m.keys.FullHelp() {
+		width := 0
+		for _, binding := range bindings {
+			width = max(width, lipgloss.Width(binding.Help().Key))
+		}
+		lines := []string{labelStyle.Render(helpGroupTitles[i])}
+		for _, binding := range bindings {
+			help := binding.Help()
+			lines = append(lines, fmt.Sprintf("%s  %s", timeStyle.Render(fmt.Sprintf("%-*s", width, help.Key)), entryTextStyle.Render(help.Desc)))
+		}
+		groups = append(groups, strings.Join(lines, "\n"))
+	}
+
+	// Lay the groups out side by side when they fit, stacked otherwise.
+	body := lipgloss.JoinHorizontal(lipgloss.Top, spaced(groups)...)
+	if m.width > 0 && lipgloss.Width(body) > m.width-viewportHorizontalPadding {
+		body = strings.Join(groups, "\n\n")
+	}
+
+	title := "Keybindings"
+	footer := placeholderStyle.Render("Inside prompts: Enter saves, Esc cancels. Remap keys in the [keys] table of config.toml.")
+	closeHint := statusInfoStyle.Render(fmt.Sprintf("Press %s or Esc to close.", m.keys.Help.Help().Key))
+	return lipgloss.JoinVertical(lipgloss.Left,
+		headerStyle.Render(title),
+		underlineStyle.Render(strings.Repeat(glyphs.rule, lipgloss.Width(title))),
+		"",
+		viewportFrameStyle.Render(body),
+		footer,
+		closeHint,
+	)
+}
+
+// spaced pads every column but the last with a gutter.
+func spaced(columns []string) []string {
+	out := make([]string, len(columns))
+	for i, column := range columns {
+		if i < len(columns)-1 {
+			column = lipgloss.NewStyle().PaddingRight(4).Render(column)
+		}
+		out[i] = column
+	}
+	return out
+}
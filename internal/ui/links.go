@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// openURL hands a URL to the desktop's default handler without waiting for
+// it to exit.
+var openURL = func(link string) error {
+	name := "xdg-open"
+	if runtime.GOOS == "darwin" {
+		name = "open"
+	}
+	return exec.Command(name, link).Start()
+}
+
+type linksOpenedMsg struct {
+	count int
+	err   error
+}
+
+func openURLsCmd(urls []string) tea.Cmd {
+	return func() tea.Msg {
+		for _, link := range urls {
+			if err := openURL(link); err != nil {
+				return linksOpenedMsg{err: fmt.Errorf("open %s: %w", link, err)}
+			}
+		}
+		return linksOpenedMsg{count: len(urls)}
+	}
+}
+
+func (m Model) handleLinksOpened(msg linksOpenedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.errorLine = msg.err.Error()
+		return m, nil
+	}
+	m.statusLine = fmt.Sprintf("Opened %s.", linkCount(msg.count))
+	return m, nil
+}
+
+// openSelectedLinks follows the selected entry's ref: links. URLs open in
+// the browser; otherwise the first entry reference is jumped to.
+func (m Model) openSelectedLinks() (tea.Model, tea.Cmd) {
+	entry := m.section.Entries[m.selected]
+	if len(entry.Links) == 0 {
+		m.statusLine = "Entry has no ref: links."
+		return m, nil
+	}
+
+	var (
+		urls    []string
+		refs    []logbook.EntryRef
+		unknown []string
+	)
+	for _, link := range entry.Links {
+		if ref, ok := logbook.ParseEntryRef(link); ok {
+			refs = append(refs, ref)
+			continue
+		}
+		if logbook.IsURL(link) {
+			urls = append(urls, link)
+			continue
+		}
+		unknown = append(unknown, logbook.LinkPrefix+link)
+	}
+
+	m.errorLine = ""
+	if len(unknown) > 0 {
+		m.errorLine = fmt.Sprintf("Cannot open %s (expected a URL or YYYY-MM-DD#N).", strings.Join(unknown, ", "))
+	}
+	switch {
+	case len(urls) > 0:
+		m.statusLine = fmt.Sprintf("Opening %s...", linkCount(len(urls)))
+		return m, openURLsCmd(urls)
+	case len(refs) > 0:
+		errorLine := m.errorLine
+		model, cmd := m.jumpToHit(searchHit{date: refs[0].Date, index: refs[0].Index - 1})
+		next := model.(Model)
+		next.errorLine = errorLine
+		next.statusLine = fmt.Sprintf("Following ref:%s.", refs[0])
+		return next, cmd
+	}
+	return m, nil
+}
+
+func linkCount(n int) string {
+	if n == 1 {
+		return "1 link"
+	}
+	return fmt.Sprintf("%d links", n)
+}
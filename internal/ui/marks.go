@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// batchResultMsg reports a toggle, pin, delete, retag, or move applied to the
+// marked entries in one write.
+type batchResultMsg struct {
+	action string
+	done   string
+	count  int
+	err    error
+}
+
+// toggleMark marks or unmarks the selected entry and steps to the next one,
+// so holding v marks a run of entries.
+func (m Model) toggleMark() (tea.Model, tea.Cmd) {
+	if !m.hasSelection() || m.weekView || m.timelineView || m.loading {
+		return m, nil
+	}
+	if m.marked == nil {
+		m.marked = make(map[int]bool)
+	}
+	if m.marked[m.selected] {
+		delete(m.marked, m.selected)
+	} else {
+		m.marked[m.selected] = true
+	}
+	m.errorLine = ""
+	m.statusLine = ""
+	return m.moveSelection(1), nil
+}
+
+// clearMarks drops every mark.
+func (m Model) clearMarks() Model {
+	m.marked = nil
+	return m
+}
+
+// markedIndexes returns the marked entries (0-based) in ascending order.
+func (m Model) markedIndexes() []int {
+	indexes := make([]int, 0, len(m.marked))
+	for index := range m.marked {
+		if index < len(m.section.Entries) {
+			indexes = append(indexes, index)
+		}
+	}
+	sort.Ints(indexes)
+	return indexes
+}
+
+// beginRetag prompts for tags to add or remove on the marked entries, or on
+// the selected entry when none are marked.
+func (m Model) beginRetag() (tea.Model, tea.Cmd) {
+	if !m.hasSelection() || m.loading {
+		return m, nil
+	}
+	m.mode = modeRetag
+	m.editingIndex = m.selected
+	target := fmt.Sprintf("entry %d", m.selected+1)
+	if n := len(m.markedIndexes()); n > 0 {
+		target = fmt.Sprintf("%d marked entr%s", n, plural(n))
+	}
+	m.inputLabel = fmt.Sprintf("Retag %s (#tag adds, -#tag removes; Enter to apply, Esc to cancel):", target)
+	m.statusLine = ""
+	m.errorLine = ""
+	return m.focusTextInput("", "#tag -#old")
+}
+
+// parseRetag splits input into tags to add and tags to remove.
+func parseRetag(input string) (add, remove []string, err error) {
+	for _, field := range strings.Fields(input) {
+		list := &add
+		if rest, ok := strings.CutPrefix(field, "-"); ok {
+			field, list = rest, &remove
+		}
+		tag := strings.TrimPrefix(field, "#")
+		if err := logbook.ValidTag(tag); err != nil {
+			return nil, nil, err
+		}
+		*list = append(*list, tag)
+	}
+	if len(add)+len(remove) == 0 {
+		return nil, nil, fmt.Errorf("enter #tags to add or -#tags to remove")
+	}
+	return add, remove, nil
+}
+
+// retagEntry adds and removes tags, keeping the existing order.
+func retagEntry(entry logbook.Entry, add, remove []string) logbook.Entry {
+	tags := slices.DeleteFunc(slices.Clone(entry.Tags), func(tag string) bool { return slices.Contains(remove, tag) })
+	for _, tag := range add {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	entry.Tags = tags
+	return entry
+}
+
+// batchTargets returns the 1-based indexes a batch action applies to: the
+// marked entries, or the one at index when none are marked.
+func (m Model) batchTargets(index int) []int {
+	marked := m.markedIndexes()
+	if len(marked) == 0 {
+		return []int{index + 1}
+	}
+	targets := make([]int, len(marked))
+	for i, index := range marked {
+		targets[i] = index + 1
+	}
+	return targets
+}
+
+func (m Model) toggleManyCmd(date time.Time, indexes []int) tea.Cmd {
+	writer, ctx := m.writer, m.ctx
+	return func() tea.Msg {
+		_, err := writer.ToggleMany(ctx, date, indexes)
+		return batchResultMsg{action: "Toggle", done: "Toggled", count: len(indexes), err: err}
+	}
+}
+
+func (m Model) pinManyCmd(date time.Time, indexes []int) tea.Cmd {
+	writer, ctx := m.writer, m.ctx
+	return func() tea.Msg {
+		_, err := writer.TogglePinMany(ctx, date, indexes)
+		return batchResultMsg{action: "Pin", done: "Toggled pin on", count: len(indexes), err: err}
+	}
+}
+
+func (m Model) deleteManyCmd(date time.Time, indexes []int) tea.Cmd {
+	writer, ctx := m.writer, m.ctx
+	return func() tea.Msg {
+		_, err := writer.DeleteMany(ctx, date, indexes)
+		return batchResultMsg{action: "Delete", done: "Deleted", count: len(indexes), err: err}
+	}
+}
+
+func (m Model) retagManyCmd(date time.Time, indexes []int, add, remove []string) tea.Cmd {
+	writer, ctx := m.writer, m.ctx
+	return func() tea.Msg {
+		_, err := writer.EditMany(ctx, date, indexes, func(entry logbook.Entry) logbook.Entry {
+			return retagEntry(entry, add, remove)
+		})
+		return batchResultMsg{action: "Retag", done: "Retagged", count: len(indexes), err: err}
+	}
+}
+
+func (m Model) moveManyCmd(date time.Time, indexes []int, to time.Time) tea.Cmd {
+	writer, ctx := m.writer, m.ctx
+	return func() tea.Msg {
+		_, err := writer.MoveMany(ctx, date, indexes, to)
+		return batchResultMsg{action: "Move", done: "Moved", count: len(indexes), err: err}
+	}
+}
+
+func (m Model) handleBatchResult(msg batchResultMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.errorLine = fmt.Sprintf("%s failed: %v", msg.action, msg.err)
+		m.statusLine = ""
+		return m, nil
+	}
+	m = m.clearMarks()
+	m.errorLine = ""
+	m.statusLine = fmt.Sprintf("%s %d entr%s.", msg.done, msg.count, plural(msg.count))
+	m.loading = true
+	m.pendingSelectIndex = m.selected
+	return m, m.refreshCmd()
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func TestMarksApplyBatchActions(t *testing.T) {
+	const (
+		todo       = logbook.StatusTodo
+		inProgress = logbook.StatusInProgress
+	)
+	tests := []struct {
+		name         string
+		keys         []string
+		wantMarked   []int
+		wantSelected int
+		wantTexts    []string
+		wantStatuses []logbook.Status
+	}{
+		{
+			name:         "v marks and steps to the next entry",
+			keys:         []string{"v", "v"},
+			wantMarked:   []int{0, 1},
+			wantSelected: 2,
+			wantTexts:    []string{"Write docs", "Fix bug", "Write tests", "Fix typo"},
+			wantStatuses: []logbook.Status{todo, todo, todo, todo},
+		},
+		{
+			name:         "v on a marked entry unmarks it",
+			keys:         []string{"v", "k", "v"},
+			wantMarked:   []int{},
+			wantSelected: 1,
+			wantTexts:    []string{"Write docs", "Fix bug", "Write tests", "Fix typo"},
+			wantStatuses: []logbook.Status{todo, todo, todo, todo},
+		},
+		{
+			name:         "toggle applies to every mark and clears them",
+			keys:         []string{"v", "j", "v", "x"},
+			wantMarked:   []int{},
+			wantSelected: 3,
+			wantTexts:    []string{"Write docs", "Fix bug", "Write tests", "Fix typo"},
+			wantStatuses: []logbook.Status{inProgress, todo, inProgress, todo},
+		},
+		{
+			name:         "esc clears marks before the filter",
+			keys:         []string{"/", "f", "i", "x", "enter", "v", "esc"},
+			wantMarked:   []int{},
+			wantSelected: 3,
+			wantTexts:    []string{"Write docs", "Fix bug", "Write tests", "Fix typo"},
+			wantStatuses: []logbook.Status{todo, todo, todo, todo},
+		},
+		{
+			name:         "marks made under a filter survive clearing it",
+			keys:         []string{"/", "f", "i", "x", "enter", "v", "v", "/", "esc"},
+			wantMarked:   []int{1, 3},
+			wantSelected: 3,
+			wantTexts:    []string{"Write docs", "Fix bug", "Write tests", "Fix typo"},
+			wantStatuses: []logbook.Status{todo, todo, todo, todo},
+		},
+		{
+			name:         "toggle after clearing the filter reaches the marks it hid",
+			keys:         []string{"/", "f", "i", "x", "enter", "v", "v", "/", "esc", "k", "k", "k", "x"},
+			wantMarked:   []int{},
+			wantSelected: 0,
+			wantTexts:    []string{"Write docs", "Fix bug", "Write tests", "Fix typo"},
+			wantStatuses: []logbook.Status{todo, inProgress, todo, inProgress},
+		},
+		{
+			name:         "delete removes every mark after confirming",
+			keys:         []string{"j", "v", "j", "v", "d", "y"},
+			wantMarked:   []int{},
+			wantSelected: 1,
+			wantTexts:    []string{"Write docs", "Write tests"},
+			wantStatuses: []logbook.Status{todo, todo},
+		},
+		{
+			name:         "esc clears the marks",
+			keys:         []string{"v", "v", "esc", "x"},
+			wantMarked:   []int{},
+			wantSelected: 2,
+			wantTexts:    []string{"Write docs", "Fix bug", "Write tests", "Fix typo"},
+			wantStatuses: []logbook.Status{todo, todo, inProgress, todo},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newTestModel(t, "Write docs", "Fix bug", "Write tests", "Fix typo")
+			m = press(t, m, tc.keys...)
+
+			if got := m.markedIndexes(); !slices.Equal(got, tc.wantMarked) {
+				t.Fatalf("marked = %v, want %v", got, tc.wantMarked)
+			}
+			if m.selected != tc.wantSelected {
+				t.Fatalf("selected = %d, want %d", m.selected, tc.wantSelected)
+			}
+			texts := make([]string, len(m.section.Entries))
+			for i, entry := range m.section.Entries {
+				texts[i] = entry.Text
+			}
+			if !slices.Equal(texts, tc.wantTexts) {
+				t.Fatalf("texts = %q, want %q", texts, tc.wantTexts)
+			}
+			if got := statuses(m); !slices.Equal(got, tc.wantStatuses) {
+				t.Fatalf("statuses = %v, want %v", got, tc.wantStatuses)
+			}
+		})
+	}
+}
+
+func TestParseRetag(t *testing.T) {
+	add, remove, err := parseRetag("#ops -#old review")
+	if err != nil {
+		t.Fatalf("parseRetag: %v", err)
+	}
+	if !slices.Equal(add, []string{"ops", "review"}) || !slices.Equal(remove, []string{"old"}) {
+		t.Fatalf("add = %v, remove = %v", add, remove)
+	}
+	if _, _, err := parseRetag("  "); err == nil {
+		t.Fatal("parseRetag accepted no tags")
+	}
+
+	entry := retagEntry(logbook.Entry{Tags: []string{"old", "ops", "keep"}}, add, remove)
+	if !slices.Equal(entry.Tags, []string{"ops", "keep", "review"}) {
+		t.Fatalf("tags = %v", entry.Tags)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,29 +18,47 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	gumstyle "github.com/charmbracelet/gum/style"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
+	"github.com/faizmokh/kerja/internal/editor"
 	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/locale"
 	"github.com/faizmokh/kerja/internal/logbook"
+	"github.com/faizmokh/kerja/internal/snippets"
 )
 
 const (
 	viewportHorizontalPadding = 4
 	viewportChromeHeight      = 9
+	viewportMinWidth          = 20
+	viewportMinHeight         = 3
+	// minWidth and minHeight are the smallest terminal the layout fits;
+	// anything smaller shows a notice until it is resized.
+	minWidth  = viewportMinWidth + viewportHorizontalPadding
+	minHeight = viewportMinHeight + viewportChromeHeight
 )
 
 var (
-	headerStyle        = gumstyle.Styles{Foreground: "213", Bold: true}.ToLipgloss()
-	loadingStyle       = gumstyle.Styles{Foreground: "111"}.ToLipgloss()
-	statusInfoStyle    = gumstyle.Styles{Foreground: "244"}.ToLipgloss()
-	statusErrorStyle   = gumstyle.Styles{Foreground: "196", Bold: true}.ToLipgloss()
-	labelStyle         = gumstyle.Styles{Foreground: "244", Bold: true}.ToLipgloss()
-	todoBadgeStyle     = gumstyle.Styles{Foreground: "51", Background: "236", Bold: true}.ToLipgloss()
-	doneBadgeStyle     = gumstyle.Styles{Foreground: "120", Background: "236", Bold: true}.ToLipgloss()
-	timeStyle          = gumstyle.Styles{Foreground: "111"}.ToLipgloss()
-	tagStyle           = gumstyle.Styles{Foreground: "177"}.ToLipgloss()
-	placeholderStyle   = gumstyle.Styles{Foreground: "241"}.ToLipgloss()
-	cursorActiveStyle  = gumstyle.Styles{Foreground: "51", Bold: true}.ToLipgloss()
-	cursorPassiveStyle = gumstyle.Styles{Foreground: "238"}.ToLipgloss()
+	headerStyle         = gumstyle.Styles{Foreground: "213", Bold: true}.ToLipgloss()
+	offDayHeaderStyle   = gumstyle.Styles{Foreground: "244", Bold: true, Italic: true}.ToLipgloss()
+	loadingStyle        = gumstyle.Styles{Foreground: "111"}.ToLipgloss()
+	statusInfoStyle     = gumstyle.Styles{Foreground: "244"}.ToLipgloss()
+	statusErrorStyle    = gumstyle.Styles{Foreground: "196", Bold: true}.ToLipgloss()
+	labelStyle          = gumstyle.Styles{Foreground: "244", Bold: true}.ToLipgloss()
+	todoBadgeStyle      = gumstyle.Styles{Foreground: "51", Background: "236", Bold: true}.ToLipgloss()
+	doneBadgeStyle      = gumstyle.Styles{Foreground: "120", Background: "236", Bold: true}.ToLipgloss()
+	progressBadgeStyle  = gumstyle.Styles{Foreground: "214", Background: "236", Bold: true}.ToLipgloss()
+	blockedBadgeStyle   = gumstyle.Styles{Foreground: "203", Background: "236", Bold: true}.ToLipgloss()
+	cancelledBadgeStyle = gumstyle.Styles{Foreground: "245", Background: "236", Strikethrough: true}.ToLipgloss()
+	timeStyle           = gumstyle.Styles{Foreground: "111"}.ToLipgloss()
+	tagStyle            = gumstyle.Styles{Foreground: "177"}.ToLipgloss()
+	linkStyle           = gumstyle.Styles{Foreground: "111", Underline: true}.ToLipgloss()
+	barDoneStyle        = gumstyle.Styles{Foreground: "120"}.ToLipgloss()
+	barOpenStyle        = gumstyle.Styles{Foreground: "51"}.ToLipgloss()
+	barTagStyle         = gumstyle.Styles{Foreground: "177"}.ToLipgloss()
+	placeholderStyle    = gumstyle.Styles{Foreground: "241"}.ToLipgloss()
+	cursorActiveStyle   = gumstyle.Styles{Foreground: "51", Bold: true}.ToLipgloss()
+	cursorPassiveStyle  = gumstyle.Styles{Foreground: "238"}.ToLipgloss()
 
 	viewportFrameStyle = lipgloss.NewStyle().
 				BorderStyle(lipgloss.NormalBorder()).
@@ -53,13 +74,72 @@ var (
 
 // Model owns Bubble Tea state for the main TUI experience.
 type Model struct {
-	ctx    context.Context
-	reader *logbook.Reader
-	writer *logbook.Writer
+	ctx     context.Context
+	manager *files.Manager
+	reader  *logbook.Reader
+	writer  *logbook.Writer
+	watcher *fileWatcher
 
 	currentDate time.Time
 	section     logbook.DateSection
 	selected    int
+	// filter narrows the list to matching entries and context to entries
+	// with one of its tags; visible holds their indexes into section.Entries
+	// in display order.
+	filter  string
+	context []string
+	visible []int
+	// focus hides closed entries for the rest of the session.
+	focus bool
+	// blocked marks the open entries of section that still wait for an
+	// after: dependency; they are drawn greyed out.
+	blocked map[int]bool
+
+	// weekView stacks the 7 days ending on weekEnd; currentDate and section
+	// track the focused day so entry actions work unchanged.
+	weekView     bool
+	weekEnd      time.Time
+	weekSections []logbook.DateSection
+
+	// timelineView lays the focused day out hour by hour instead of as a list.
+	timelineView bool
+
+	// showHelp replaces the screen with the full keybinding list.
+	showHelp bool
+
+	// showDetail splits the list with a pane describing the selected entry;
+	// history holds the journaled changes it lists.
+	showDetail bool
+	history    []logbook.Change
+
+	// showStats replaces the screen with charts for the month in stats.
+	showStats    bool
+	stats        monthStats
+	statsLoading bool
+
+	// showUpcoming replaces the screen with the open entries of the coming
+	// days; upcomingCursor marks the one Enter opens.
+	showUpcoming    bool
+	upcoming        []upcomingItem
+	upcomingCursor  int
+	upcomingLoading bool
+
+	// searchSections holds the entries loaded for the fuzzy search scope;
+	// searchHits are the ranked matches and searchCursor the highlighted one.
+	searchScope    searchScope
+	searchSections []logbook.DateSection
+	searchHits     []searchHit
+	searchCursor   int
+	searchLoading  bool
+	// searchProgress counts the months an all-months search has read.
+	searchProgress logbook.Progress
+
+	// snippets are the templates from snippets.md; snippetHits narrows them by
+	// the typed name and snippetCursor marks the one Enter will add.
+	snippets        []snippets.Snippet
+	snippetHits     []snippets.Snippet
+	snippetCursor   int
+	snippetsLoading bool
 
 	mode               mode
 	inputBuffer        string
@@ -81,6 +161,66 @@ type Model struct {
 	spinner       spinner.Model
 	width         int
 	height        int
+
+	// lastClick and lastClickIndex detect double-clicks on an entry.
+	lastClick      time.Time
+	lastClickIndex int
+
+	wipLimit   int
+	timeLayout string
+	// wrapEntries wraps long entries instead of truncating them.
+	wrapEntries bool
+	calendar    logbook.Calendar
+	// marked holds the entries (0-based) chosen with v for batch toggle,
+	// delete, retag, and move.
+	marked map[int]bool
+
+	// watchSeq debounces reloads triggered by external edits; changedPaths
+	// collects the files they touched and staleOnDisk defers one until an
+	// open prompt closes.
+	watchSeq     int
+	changedPaths []string
+	staleOnDisk  bool
+}
+
+// Options carries user preferences that shape the TUI.
+type Options struct {
+	// WIPLimit caps open todos per day; zero disables the warning.
+	WIPLimit int
+	// TimeLayout formats displayed times; defaults to 24h "15:04".
+	TimeLayout string
+	// Theme names a color palette: default, light, or mono.
+	Theme string
+	// Keys remaps actions to keys; see ValidateKeyBindings.
+	Keys map[string][]string
+	// Plain drops colors and styling and draws with ASCII only.
+	Plain bool
+	// SortByTime keeps each day ordered by entry time when writing.
+	SortByTime bool
+	// SearchIndex keeps the search index up to date when writing.
+	SearchIndex bool
+	// Author credits new entries in a shared logbook; empty credits no one.
+	Author string
+	// OnChange, when set, receives the entries each write changed, as
+	// Writer.OnChange does.
+	OnChange func(op string, changes []logbook.EntryChange)
+	// Context limits every view to entries carrying one of these tags.
+	Context []string
+	// WrapEntries wraps entries wider than the list onto extra lines instead
+	// of truncating them with an ellipsis.
+	WrapEntries bool
+	// Location is the zone today and the current time are reckoned in;
+	// defaults to time.Local.
+	Location *time.Location
+	// Calendar marks weekends and holidays; the zero value has none.
+	Calendar logbook.Calendar
+	// Locale names days and months in headers; the zero value is English.
+	Locale locale.Locale
+	// Date opens the TUI on this day instead of today.
+	Date time.Time
+	// Select is the 1-based index of the entry selected once Date loads;
+	// zero keeps the default selection.
+	Select int
 }
 
 type keyMap struct {
@@ -93,10 +233,30 @@ type keyMap struct {
 	Toggle     key.Binding
 	AddTodo    key.Binding
 	AddDone    key.Binding
+	Compose    key.Binding
 	Edit       key.Binding
 	EditTime   key.Binding
 	EditStatus key.Binding
 	Delete     key.Binding
+	Duplicate  key.Binding
+	Move       key.Binding
+	Mark       key.Binding
+	Retag      key.Binding
+	Pin        key.Binding
+	ShiftDown  key.Binding
+	ShiftUp    key.Binding
+	Undo       key.Binding
+	Filter     key.Binding
+	Focus      key.Binding
+	Week       key.Binding
+	Timeline   key.Binding
+	Search     key.Binding
+	Snippet    key.Binding
+	OpenLink   key.Binding
+	Detail     key.Binding
+	Stats      key.Binding
+	Upcoming   key.Binding
+	Help       key.Binding
 	Quit       key.Binding
 }
 
@@ -111,24 +271,44 @@ func newKeyMap() keyMap {
 		Toggle:     key.NewBinding(key.WithKeys("space", "x"), key.WithHelp("space/x", "toggle status")),
 		AddTodo:    key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "add todo")),
 		AddDone:    key.NewBinding(key.WithKeys("A"), key.WithHelp("A", "add done")),
+		Compose:    key.NewBinding(key.WithKeys("E"), key.WithHelp("E", "add todo in $EDITOR")),
 		Edit:       key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit entry")),
 		EditTime:   key.NewBinding(key.WithKeys("T"), key.WithHelp("T", "edit time")),
 		EditStatus: key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "edit status")),
 		Delete:     key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete entry")),
+		Duplicate:  key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "duplicate as todo")),
+		Move:       key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "move to date")),
+		Mark:       key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "mark for batch action")),
+		Retag:      key.NewBinding(key.WithKeys("#"), key.WithHelp("#", "add/remove tags")),
+		Pin:        key.NewBinding(key.WithKeys("P"), key.WithHelp("P", "pin/unpin entry")),
+		ShiftDown:  key.NewBinding(key.WithKeys("J", "shift+down"), key.WithHelp("J", "move entry down")),
+		ShiftUp:    key.NewBinding(key.WithKeys("K", "shift+up"), key.WithHelp("K", "move entry up")),
+		Undo:       key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "undo last change")),
+		Filter:     key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter entries")),
+		Focus:      key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "focus: hide closed entries")),
+		Week:       key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "toggle week view")),
+		Timeline:   key.NewBinding(key.WithKeys("V"), key.WithHelp("V", "toggle timeline view")),
+		Search:     key.NewBinding(key.WithKeys("ctrl+f"), key.WithHelp("ctrl+f", "fuzzy search")),
+		Snippet:    key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "add from snippet")),
+		OpenLink:   key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open ref: link")),
+		Detail:     key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "entry details")),
+		Stats:      key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "month stats")),
+		Upcoming:   key.NewBinding(key.WithKeys("U"), key.WithHelp("U", "upcoming todos")),
+		Help:       key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "all keybindings")),
 		Quit:       key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
 	}
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Up, k.Down, k.Toggle, k.AddTodo, k.Edit, k.Quit}
+	return []key.Binding{k.Up, k.Down, k.Toggle, k.AddTodo, k.Edit, k.Help, k.Quit}
 }
 
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down, k.Toggle},
-		{k.AddTodo, k.AddDone, k.Edit, k.EditTime, k.EditStatus},
-		{k.PrevDay, k.NextDay, k.Today, k.Reload},
-		{k.Delete, k.Quit},
+		{k.Up, k.Down, k.ShiftUp, k.ShiftDown, k.Toggle},
+		{k.AddTodo, k.AddDone, k.Compose, k.Snippet, k.Edit, k.EditTime, k.EditStatus, k.Retag},
+		{k.PrevDay, k.NextDay, k.Today, k.Reload, k.Week, k.Timeline, k.Filter, k.Focus, k.Search, k.OpenLink, k.Detail, k.Stats, k.Upcoming},
+		{k.Mark, k.Pin, k.Delete, k.Duplicate, k.Move, k.Undo, k.Help, k.Quit},
 	}
 }
 
@@ -142,6 +322,11 @@ const (
 	modeEditTime
 	modeEditStatus
 	modeConfirmDelete
+	modeFilter
+	modeMove
+	modeSearch
+	modeSnippet
+	modeRetag
 )
 
 type sectionLoadedMsg struct {
@@ -161,6 +346,12 @@ type appendResultMsg struct {
 	err   error
 }
 
+type composeClosedMsg struct {
+	date time.Time
+	path string
+	err  error
+}
+
 type editResultMsg struct {
 	index int
 	entry logbook.Entry
@@ -172,28 +363,70 @@ type deleteResultMsg struct {
 	err   error
 }
 
-type entryInput struct {
-	text   string
-	tags   []string
-	when   *time.Time
-	status *logbook.Status
+type moveResultMsg struct {
+	index int
+	to    time.Time
+	err   error
+}
+
+type reorderResultMsg struct {
+	to  int
+	err error
+}
+
+type undoResultMsg struct {
+	change logbook.Change
+	err    error
 }
 
 // NewModel seeds a Bubble Tea model with required collaborators.
-func NewModel(ctx context.Context, manager *files.Manager) Model {
+func NewModel(ctx context.Context, manager *files.Manager, opts Options) Model {
 	reader := logbook.NewReader(manager)
-	writer := logbook.NewWriter(manager)
+	writer := logbook.NewWriter(manager).SortByTime(opts.SortByTime).MaintainIndex(opts.SearchIndex).StampAuthor(opts.Author)
+	if opts.OnChange != nil {
+		writer.OnChange(opts.OnChange)
+	}
+	watcher := newFileWatcher()
+	writer.OnWrite(func(path string, _, _ []string) { watcher.recordWrite(path) })
+	location = time.Local
+	names = opts.Locale
+	if opts.Location != nil {
+		location = opts.Location
+	}
 	initialDate := today()
+	if !opts.Date.IsZero() {
+		initialDate = opts.Date
+	}
+
+	applyTheme(opts.Theme, opts.Plain)
+	timeLayout := opts.TimeLayout
+	if timeLayout == "" {
+		timeLayout = "15:04"
+	}
+
+	// Invalid bindings are reported by ValidateKeyBindings before launch;
+	// here they just leave the defaults in place.
+	keys, err := newKeyMap().withBindings(opts.Keys)
+	if err != nil {
+		keys = newKeyMap()
+	}
+	if opts.Plain {
+		keys = keys.withASCIIHelp()
+	}
 
 	vp := viewport.New(0, 0)
 	vp.Style = viewportFrameStyle
 
 	helpModel := help.New()
-	helpModel.ShowAll = true
+	if opts.Plain {
+		helpModel.Styles = help.Styles{}
+		helpModel.ShortSeparator = " | "
+		helpModel.Ellipsis = "..."
+	}
 
 	input := textinput.New()
-	input.Prompt = cursorPassiveStyle.Render("› ")
-	input.Placeholder = "Describe the entry. Use @HH:MM, !todo|!done, #tags"
+	input.Prompt = cursorPassiveStyle.Render(glyphs.cursor + " ")
+	input.Placeholder = "Describe the entry. Use @HH:MM or @-15m, !todo|!done, #tags"
 	input.CharLimit = 512
 	input.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
 	input.PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
@@ -202,11 +435,20 @@ func NewModel(ctx context.Context, manager *files.Manager) Model {
 		spinner.WithSpinner(spinner.Dot),
 		spinner.WithStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("63"))),
 	)
+	if opts.Plain {
+		input.TextStyle = lipgloss.NewStyle()
+		input.PromptStyle = lipgloss.NewStyle()
+		input.PlaceholderStyle = lipgloss.NewStyle()
+		input.Cursor.Style = lipgloss.NewStyle()
+		spin = spinner.New(spinner.WithSpinner(spinner.Line))
+	}
 
 	return Model{
 		ctx:         ctx,
+		manager:     manager,
 		reader:      reader,
 		writer:      writer,
+		watcher:     watcher,
 		currentDate: initialDate,
 		section: logbook.DateSection{
 			Date: initialDate,
@@ -214,22 +456,34 @@ func NewModel(ctx context.Context, manager *files.Manager) Model {
 		mode:               modeNormal,
 		pendingStatus:      logbook.StatusTodo,
 		editingIndex:       -1,
-		pendingSelectIndex: -1,
+		pendingSelectIndex: opts.Select - 1,
 		loading:            true,
 		statusLine:         "Loading today's entries...",
 		viewport:           vp,
 		help:               helpModel,
-		keys:               newKeyMap(),
+		keys:               keys,
 		textInput:          input,
 		spinner:            spin,
+		context:            opts.Context,
+		wipLimit:           opts.WIPLimit,
+		timeLayout:         timeLayout,
+		wrapEntries:        opts.WrapEntries,
+		calendar:           opts.Calendar,
 	}
 }
 
+// Close stops watching the month files for external edits. Call it once
+// the program running the model has exited.
+func (m Model) Close() error {
+	return m.watcher.close()
+}
+
 // Init loads the initial date section.
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		func() tea.Msg { return m.spinner.Tick() },
 		m.loadSectionCmd(m.currentDate),
+		m.watcher.listen(),
 	)
 }
 
@@ -240,20 +494,57 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleWindowSize(msg)
 	case tea.KeyMsg:
 		return m.handleKey(msg)
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
 	case sectionLoadedMsg:
-		return m.handleSectionLoaded(msg)
+		return refreshDetail(m.handleSectionLoaded(msg))
 	case toggleResultMsg:
-		return m.handleToggleResult(msg)
+		return refreshDetail(m.handleToggleResult(msg))
 	case appendResultMsg:
-		return m.handleAppendResult(msg)
+		return refreshDetail(m.handleAppendResult(msg))
+	case composeClosedMsg:
+		return m.handleComposeClosed(msg)
 	case editResultMsg:
-		return m.handleEditResult(msg)
+		return refreshDetail(m.handleEditResult(msg))
 	case deleteResultMsg:
-		return m.handleDeleteResult(msg)
+		return refreshDetail(m.handleDeleteResult(msg))
+	case moveResultMsg:
+		return refreshDetail(m.handleMoveResult(msg))
+	case batchResultMsg:
+		return refreshDetail(m.handleBatchResult(msg))
+	case reorderResultMsg:
+		return refreshDetail(m.handleReorderResult(msg))
+	case undoResultMsg:
+		return refreshDetail(m.handleUndoResult(msg))
+	case historyLoadedMsg:
+		return m.handleHistoryLoaded(msg)
+	case weekLoadedMsg:
+		return m.handleWeekLoaded(msg)
+	case searchLoadedMsg:
+		return m.handleSearchLoaded(msg)
+	case searchProgressMsg:
+		return m.handleSearchProgress(msg)
+	case snippetsLoadedMsg:
+		return m.handleSnippetsLoaded(msg)
+	case statsLoadedMsg:
+		return m.handleStatsLoaded(msg)
+	case upcomingLoadedMsg:
+		return m.handleUpcomingLoaded(msg)
+	case blockedLoadedMsg:
+		return m.handleBlockedLoaded(msg)
+	case linksOpenedMsg:
+		return m.handleLinksOpened(msg)
+	case fileChangedMsg:
+		return m.handleFileChanged(msg)
+	case watchReloadMsg:
+		return m.handleWatchReload(msg)
+	case watchErrorMsg:
+		m.errorLine = fmt.Sprintf("File watch failed: %v", msg.err)
+		return m, m.watcher.listen()
 	default:
 		return m, nil
 	}
@@ -263,15 +554,8 @@ func (m Model) handleWindowSize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	m.width = msg.Width
 	m.height = msg.Height
 
-	width := msg.Width - viewportHorizontalPadding
-	if width < 20 {
-		width = 20
-	}
-
-	height := msg.Height - viewportChromeHeight
-	if height < 5 {
-		height = 5
-	}
+	width := max(msg.Width-viewportHorizontalPadding, viewportMinWidth)
+	height := max(msg.Height-viewportChromeHeight, viewportMinHeight)
 
 	m.viewport.Width = width
 	m.viewport.Height = height
@@ -282,6 +566,22 @@ func (m Model) handleWindowSize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Keys would act on a screen the user cannot see, so only quitting works.
+	if m.tooSmall() {
+		if key.Matches(msg, m.keys.Quit) || msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+	if m.showHelp {
+		return m.handleHelpKey(msg)
+	}
+	if m.showStats {
+		return m.handleStatsKey(msg)
+	}
+	if m.showUpcoming {
+		return m.handleUpcomingKey(msg)
+	}
 	if m.mode != modeNormal {
 		return m.handleInputKey(msg)
 	}
@@ -290,11 +590,27 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, m.keys.Quit):
 		return m, tea.Quit
 	case key.Matches(msg, m.keys.Down):
+		if m.weekView {
+			return m.moveWeekSelection(1), nil
+		}
 		m = m.moveSelection(1)
 		return m, nil
 	case key.Matches(msg, m.keys.Up):
+		if m.weekView {
+			return m.moveWeekSelection(-1), nil
+		}
 		m = m.moveSelection(-1)
 		return m, nil
+	case key.Matches(msg, m.keys.Week):
+		if m.loading {
+			return m, nil
+		}
+		return m.clearMarks().toggleWeekView()
+	case key.Matches(msg, m.keys.Timeline):
+		if m.loading {
+			return m, nil
+		}
+		return m.clearMarks().toggleTimelineView()
 	case key.Matches(msg, m.keys.PrevDay):
 		return m.gotoDate(m.currentDate.AddDate(0, 0, -1))
 	case key.Matches(msg, m.keys.NextDay):
@@ -304,14 +620,30 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, m.keys.Reload):
 		return m.reload()
 	case key.Matches(msg, m.keys.Toggle):
-		if len(m.section.Entries) == 0 || m.loading {
+		if !m.hasSelection() || m.loading {
 			return m, nil
 		}
+		if marked := m.markedIndexes(); len(marked) > 0 {
+			m.statusLine = fmt.Sprintf("Toggling %d entries...", len(marked))
+			m.errorLine = ""
+			return m, m.toggleManyCmd(m.currentDate, m.batchTargets(m.selected))
+		}
 		return m.toggleSelected()
+	case key.Matches(msg, m.keys.Mark):
+		return m.toggleMark()
+	case key.Matches(msg, m.keys.Retag):
+		return m.beginRetag()
+	case key.Matches(msg, m.keys.Pin):
+		if !m.hasSelection() || m.loading {
+			return m, nil
+		}
+		return m, m.pinManyCmd(m.currentDate, m.batchTargets(m.selected))
 	case key.Matches(msg, m.keys.AddTodo):
 		return m.beginAdd(logbook.StatusTodo)
 	case key.Matches(msg, m.keys.AddDone):
 		return m.beginAdd(logbook.StatusDone)
+	case key.Matches(msg, m.keys.Compose):
+		return m.beginCompose()
 	case key.Matches(msg, m.keys.Edit):
 		return m.beginEdit()
 	case key.Matches(msg, m.keys.EditTime):
@@ -320,6 +652,60 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.beginEditStatus()
 	case key.Matches(msg, m.keys.Delete):
 		return m.beginDelete()
+	case key.Matches(msg, m.keys.Duplicate):
+		if !m.hasSelection() || m.loading {
+			return m, nil
+		}
+		return m.duplicateSelected()
+	case key.Matches(msg, m.keys.Move):
+		return m.beginMove()
+	case key.Matches(msg, m.keys.ShiftDown):
+		return m.reorderSelected(1)
+	case key.Matches(msg, m.keys.ShiftUp):
+		return m.reorderSelected(-1)
+	case key.Matches(msg, m.keys.Filter):
+		return m.beginFilter()
+	case key.Matches(msg, m.keys.Focus):
+		return m.toggleFocus(), nil
+	case key.Matches(msg, m.keys.Search):
+		return m.beginSearch()
+	case key.Matches(msg, m.keys.Snippet):
+		return m.beginSnippet()
+	case key.Matches(msg, m.keys.Stats):
+		if m.manager == nil {
+			return m, nil
+		}
+		return m.beginStats()
+	case key.Matches(msg, m.keys.Upcoming):
+		if m.manager == nil {
+			return m, nil
+		}
+		return m.beginUpcoming()
+	case key.Matches(msg, m.keys.OpenLink):
+		if !m.hasSelection() {
+			return m, nil
+		}
+		return m.openSelectedLinks()
+	case key.Matches(msg, m.keys.Detail):
+		return m.toggleDetail()
+	case key.Matches(msg, m.keys.Help):
+		m.showHelp = true
+		return m, nil
+	case msg.Type == tea.KeyEsc && len(m.marked) > 0:
+		m = m.clearMarks()
+		m.statusLine = "Marks cleared."
+		return m, nil
+	case msg.Type == tea.KeyEsc && m.showDetail:
+		return m.toggleDetail()
+	case msg.Type == tea.KeyEsc && m.filter != "":
+		return m.setFilter("", "Filter cleared."), nil
+	case key.Matches(msg, m.keys.Undo):
+		if m.loading {
+			return m, nil
+		}
+		m.statusLine = "Undoing last change..."
+		m.errorLine = ""
+		return m, m.undoCmd()
 	default:
 		var cmd tea.Cmd
 		m.viewport, cmd = m.viewport.Update(msg)
@@ -328,20 +714,24 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) moveSelection(delta int) Model {
-	if len(m.section.Entries) == 0 {
+	if len(m.visible) == 0 {
 		return m
 	}
 
-	next := m.selected + delta
+	next := m.visiblePosition() + delta
 	if next < 0 {
 		next = 0
-	} else if next >= len(m.section.Entries) {
-		next = len(m.section.Entries) - 1
+	} else if next >= len(m.visible) {
+		next = len(m.visible) - 1
 	}
 
-	if next != m.selected {
-		m.selected = next
-		m.statusLine = fmt.Sprintf("Selected entry %d of %d", m.selected+1, len(m.section.Entries))
+	if m.visible[next] != m.selected {
+		m.selected = m.visible[next]
+		if m.filter != "" {
+			m.statusLine = fmt.Sprintf("Selected entry %d (%d of %d matching)", m.selected+1, next+1, len(m.visible))
+		} else {
+			m.statusLine = fmt.Sprintf("Selected entry %d of %d", m.selected+1, len(m.section.Entries))
+		}
 		m.errorLine = ""
 		m = m.scrollSelectionIntoView()
 	}
@@ -350,26 +740,65 @@ func (m Model) moveSelection(delta int) Model {
 }
 
 func (m Model) scrollSelectionIntoView() Model {
-	if !m.viewportReady || m.viewport.Height <= 0 || len(m.section.Entries) == 0 {
+	row := m.visiblePosition()
+	last := row
+	if m.weekView {
+		_, row = m.renderWeek()
+		last = row
+	} else if m.timelineView {
+		_, row = m.renderTimeline()
+		last = row
+	} else if row >= 0 {
+		// A wrapped entry spans several lines; keep all of them in view.
+		_, starts := m.renderEntries()
+		row, last = starts[row], starts[row+1]-1
+	}
+	if !m.viewportReady || row < 0 {
 		return m
 	}
 
-	if m.selected < m.viewport.YOffset {
-		m.viewport.SetYOffset(m.selected)
-	} else if m.selected >= m.viewport.YOffset+m.viewport.Height {
-		m.viewport.SetYOffset(m.selected - m.viewport.Height + 1)
+	m = m.syncViewport()
+	height := m.listHeight()
+	if height <= 0 {
+		return m
+	}
+	if row < m.viewport.YOffset {
+		m.viewport.SetYOffset(row)
+	} else if last >= m.viewport.YOffset+height {
+		m.viewport.SetYOffset(min(row, last-height+1))
 	}
 
 	return m
 }
 
+// syncViewport hands the viewport the current list so scrolling clamps
+// against its real length; View only sets content on its own copy.
+func (m Model) syncViewport() Model {
+	var content string
+	switch {
+	case m.weekView:
+		content, _ = m.renderWeek()
+	case m.timelineView:
+		content, _ = m.renderTimeline()
+	default:
+		content, _ = m.renderEntries()
+	}
+	m.viewport.SetContent(content)
+	return m
+}
+
+// listHeight is the number of list lines shown inside the frame.
+func (m Model) listHeight() int {
+	return m.viewport.Height - m.viewport.Style.GetVerticalFrameSize()
+}
+
 func (m Model) focusTextInput(value, placeholder string) (Model, tea.Cmd) {
 	m.inputBuffer = value
 	m.textInput.SetValue(value)
 	if placeholder != "" {
 		m.textInput.Placeholder = placeholder
 	}
-	m.textInput.Prompt = cursorActiveStyle.Render("› ")
+	m.textInput.Prompt = cursorActiveStyle.Render(glyphs.cursor + " ")
 	m.textInput.CursorEnd()
 	cmd := m.textInput.Focus()
 	return m, cmd
@@ -379,15 +808,19 @@ func (m Model) resetTextInput() Model {
 	m.textInput.Blur()
 	m.textInput.SetValue("")
 	m.textInput.CursorStart()
-	m.textInput.Placeholder = "Describe the entry. Use @HH:MM, !todo|!done, #tags"
+	m.textInput.Placeholder = "Describe the entry. Use @HH:MM or @-15m, !todo|!done, #tags"
 	m.textInput.CharLimit = 512
-	m.textInput.Prompt = cursorPassiveStyle.Render("› ")
+	m.textInput.Prompt = cursorPassiveStyle.Render(glyphs.cursor + " ")
 	return m
 }
 
 func (m Model) handleInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch m.mode {
-	case modeAddTodo, modeAddLog, modeEdit, modeEditTime, modeEditStatus:
+	case modeSearch:
+		return m.handleSearchKey(msg)
+	case modeSnippet:
+		return m.handleSnippetKey(msg)
+	case modeAddTodo, modeAddLog, modeEdit, modeEditTime, modeEditStatus, modeMove, modeRetag:
 		switch msg.Type {
 		case tea.KeyEnter:
 			m.inputBuffer = m.textInput.Value()
@@ -402,6 +835,30 @@ func (m Model) handleInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.textInput, cmd = m.textInput.Update(msg)
 		m.inputBuffer = m.textInput.Value()
 		return m, cmd
+	case modeFilter:
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.mode = modeNormal
+			m = m.resetTextInput()
+			m.inputLabel = ""
+			if m.filter == "" {
+				m.statusLine = "Filter cleared."
+			} else {
+				m.statusLine = fmt.Sprintf("Filtering by %q: %d of %d entries.", m.filter, len(m.visible), len(m.section.Entries))
+			}
+			return m, nil
+		case tea.KeyEsc:
+			m.mode = modeNormal
+			m = m.resetTextInput()
+			m.inputLabel = ""
+			return m.setFilter("", "Filter cleared."), nil
+		case tea.KeyCtrlC:
+			return m, tea.Quit
+		}
+		var cmd tea.Cmd
+		m.textInput, cmd = m.textInput.Update(msg)
+		m = m.setFilter(m.textInput.Value(), "")
+		return m, cmd
 	case modeConfirmDelete:
 		switch msg.String() {
 		case "y", "Y":
@@ -426,20 +883,131 @@ func (m Model) beginAdd(status logbook.Status) (tea.Model, tea.Cmd) {
 	}
 	m.pendingStatus = status
 	if status == logbook.StatusDone {
-		m.inputLabel = "New done entry (text; add @HH:MM, !todo|!done, #tags as needed; Enter to save, Esc to cancel):"
+		m.inputLabel = "New done entry (text; add @HH:MM or @-15m, !todo|!done, #tags as needed; Enter to save, Esc to cancel):"
 	} else {
-		m.inputLabel = "New todo entry (text; add @HH:MM, !todo|!done, #tags as needed; Enter to save, Esc to cancel):"
+		m.inputLabel = "New todo entry (text; add @HH:MM or @-15m, !todo|!done, #tags as needed; Enter to save, Esc to cancel):"
 	}
 	m.statusLine = ""
 	m.errorLine = ""
 	m.editingIndex = -1
 	m.textInput.CharLimit = 512
-	placeholder := "Describe the entry. Use @HH:MM, !todo|!done, #tags"
+	placeholder := "Describe the entry. Use @HH:MM or @-15m, !todo|!done, #tags"
 	return m.focusTextInput("", placeholder)
 }
 
+// beginCompose suspends the TUI and opens a scratch buffer in $EDITOR for a
+// todo too long for the single-line input.
+func (m Model) beginCompose() (tea.Model, tea.Cmd) {
+	path, err := editor.Scratch("")
+	if err != nil {
+		m.errorLine = fmt.Sprintf("Editor failed: %v", err)
+		return m, nil
+	}
+	m.statusLine = ""
+	m.errorLine = ""
+	date := m.currentDate
+	return m, tea.ExecProcess(editor.Command(path), func(err error) tea.Msg {
+		return composeClosedMsg{date: date, path: path, err: err}
+	})
+}
+
+func (m Model) handleComposeClosed(msg composeClosedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		os.Remove(msg.path)
+		m.errorLine = fmt.Sprintf("Editor failed: %v", msg.err)
+		return m, nil
+	}
+	first, notes, err := editor.ReadScratch(msg.path)
+	if errors.Is(err, editor.ErrEmpty) {
+		m.statusLine = "Editor closed without an entry."
+		return m, nil
+	}
+	if err != nil {
+		m.errorLine = fmt.Sprintf("Editor failed: %v", err)
+		return m, nil
+	}
+
+	parsed, err := logbook.ParseTokens(first, msg.date)
+	if err != nil {
+		m.errorLine = err.Error()
+		return m, nil
+	}
+	if parsed.Text == "" && len(parsed.Tags) == 0 {
+		m.statusLine = "Editor closed without an entry."
+		return m, nil
+	}
+	entry := newEntry(msg.date, parsed, logbook.StatusTodo)
+	entry.Notes = notes
+	m.statusLine = "Saving entry..."
+	m.errorLine = ""
+	m.pendingSelectIndex = -1
+	return m, m.appendEntryCmd(msg.date, entry)
+}
+
+// newEntry builds an entry on date from parsed prompt tokens, stamped with the
+// current time unless they carry @HH:MM and using fallback when they carry no
+// !status.
+func newEntry(date time.Time, parsed logbook.TokenInput, fallback logbook.Status) logbook.Entry {
+	now := time.Now().In(date.Location())
+	return parsed.Entry(time.Date(date.Year(), date.Month(), date.Day(), now.Hour(), now.Minute(), 0, 0, now.Location()), fallback)
+}
+
+// duplicateSelected appends a copy of the selected entry to the current day as
+// a todo stamped with the current time.
+func (m Model) duplicateSelected() (tea.Model, tea.Cmd) {
+	entry := m.section.Entries[m.selected]
+	entry.Tags = append([]string(nil), entry.Tags...)
+	entry.Notes = append([]string(nil), entry.Notes...)
+	entry.Status = logbook.StatusTodo
+	now := time.Now().In(m.currentDate.Location())
+	entry.Time = time.Date(m.currentDate.Year(), m.currentDate.Month(), m.currentDate.Day(), now.Hour(), now.Minute(), 0, 0, now.Location())
+
+	m.statusLine = fmt.Sprintf("Duplicating entry %d...", m.selected+1)
+	m.errorLine = ""
+	m.pendingSelectIndex = -1
+	return m, m.appendEntryCmd(m.currentDate, entry)
+}
+
+func (m Model) beginFilter() (tea.Model, tea.Cmd) {
+	m.mode = modeFilter
+	m.inputLabel = "Filter entries (#tag or text; Enter to apply, Esc to clear):"
+	m.statusLine = ""
+	m.errorLine = ""
+	m.textInput.CharLimit = 128
+	return m.focusTextInput(m.filter, "#tag or text")
+}
+
+// toggleFocus shows or hides closed entries, keeping the choice as the
+// view moves between days.
+func (m Model) toggleFocus() Model {
+	m.focus = !m.focus
+	message := "Focus off: showing every entry."
+	if m.focus {
+		message = fmt.Sprintf("Focus on: closed entries hidden (%s to show them).", m.keys.Focus.Help().Key)
+	}
+	m = m.applyFilter()
+	m = m.scrollSelectionIntoView()
+	m.statusLine = message
+	m.errorLine = ""
+	return m
+}
+
+// setFilter narrows the list as the filter changes, leaving the status line
+// alone when message is empty.
+func (m Model) setFilter(term, message string) Model {
+	m.filter = strings.TrimSpace(term)
+	m = m.applyFilter()
+	m.viewport.SetYOffset(0)
+	m = m.scrollSelectionIntoView()
+	if message != "" {
+		m.statusLine = message
+		m.errorLine = ""
+	}
+	return m
+}
+
 func (m Model) beginEdit() (tea.Model, tea.Cmd) {
-	if len(m.section.Entries) == 0 {
+	if !m.hasSelection() {
 		return m, nil
 	}
 
@@ -448,8 +1016,8 @@ func (m Model) beginEdit() (tea.Model, tea.Cmd) {
 
 	m.mode = modeEdit
 	m.editingIndex = index
-	m.inputBuffer = entryToInput(entry)
-	m.inputLabel = fmt.Sprintf("Edit entry %d (adjust text, @HH:MM, !todo|!done, #tags; Enter to save, Esc to cancel):", index+1)
+	m.inputBuffer = entryToInput(entry, m.timeLayout)
+	m.inputLabel = fmt.Sprintf("Edit entry %d (adjust text, @HH:MM or @-15m, !todo|!done, * to pin, #tags; Enter to save, Esc to cancel):", index+1)
 	m.statusLine = ""
 	m.errorLine = ""
 	m.textInput.CharLimit = 512
@@ -457,7 +1025,7 @@ func (m Model) beginEdit() (tea.Model, tea.Cmd) {
 }
 
 func (m Model) beginEditTime() (tea.Model, tea.Cmd) {
-	if len(m.section.Entries) == 0 {
+	if !m.hasSelection() {
 		return m, nil
 	}
 
@@ -467,37 +1035,33 @@ func (m Model) beginEditTime() (tea.Model, tea.Cmd) {
 	if entry.Time.IsZero() {
 		m.inputBuffer = ""
 	} else {
-		m.inputBuffer = entry.Time.Format("15:04")
+		m.inputBuffer = entryClock(entry, m.timeLayout)
 	}
-	m.inputLabel = fmt.Sprintf("Set time for entry %d (HH:MM, Enter to save, Esc to cancel):", m.selected+1)
+	m.inputLabel = fmt.Sprintf("Set time for entry %d (HH:MM, 9:45pm, or a range such as HH:MM-HH:MM; Enter to save, Esc to cancel):", m.selected+1)
 	m.statusLine = ""
 	m.errorLine = ""
-	m.textInput.CharLimit = 5
+	m.textInput.CharLimit = 15
 	return m.focusTextInput(m.inputBuffer, "HH:MM")
 }
 
 func (m Model) beginEditStatus() (tea.Model, tea.Cmd) {
-	if len(m.section.Entries) == 0 {
+	if !m.hasSelection() {
 		return m, nil
 	}
 
 	entry := m.section.Entries[m.selected]
 	m.mode = modeEditStatus
 	m.editingIndex = m.selected
-	if entry.Status == logbook.StatusDone {
-		m.inputBuffer = "done"
-	} else {
-		m.inputBuffer = "todo"
-	}
-	m.inputLabel = fmt.Sprintf("Set status for entry %d (todo|done, Enter to save, Esc to cancel):", m.selected+1)
+	m.inputBuffer = entry.Status.String()
+	m.inputLabel = fmt.Sprintf("Set status for entry %d (%s, Enter to save, Esc to cancel):", m.selected+1, strings.Join(logbook.StatusNames(), "|"))
 	m.statusLine = ""
 	m.errorLine = ""
-	m.textInput.CharLimit = 4
-	return m.focusTextInput(m.inputBuffer, "todo|done")
+	m.textInput.CharLimit = 16
+	return m.focusTextInput(m.inputBuffer, strings.Join(logbook.StatusNames(), "|"))
 }
 
 func (m Model) beginDelete() (tea.Model, tea.Cmd) {
-	if len(m.section.Entries) == 0 {
+	if !m.hasSelection() {
 		return m, nil
 	}
 
@@ -507,10 +1071,44 @@ func (m Model) beginDelete() (tea.Model, tea.Cmd) {
 	m.statusLine = ""
 	m.errorLine = ""
 	m.textInput.Blur()
-	m.textInput.Prompt = cursorPassiveStyle.Render("› ")
+	m.textInput.Prompt = cursorPassiveStyle.Render(glyphs.cursor + " ")
 	return m, nil
 }
 
+func (m Model) beginMove() (tea.Model, tea.Cmd) {
+	if !m.hasSelection() || m.loading {
+		return m, nil
+	}
+
+	m.mode = modeMove
+	m.editingIndex = m.selected
+	target := fmt.Sprintf("entry %d", m.selected+1)
+	if n := len(m.markedIndexes()); n > 0 {
+		target = fmt.Sprintf("%d marked entr%s", n, plural(n))
+	}
+	m.inputLabel = fmt.Sprintf("Move %s to (YYYY-MM-DD or +N/-N days, Enter to move, Esc to cancel):", target)
+	m.statusLine = ""
+	m.errorLine = ""
+	m.textInput.CharLimit = 10
+	return m.focusTextInput(m.currentDate.AddDate(0, 0, 1).Format("2006-01-02"), "YYYY-MM-DD")
+}
+
+// parseMoveTarget accepts an absolute date or a day offset from the current date.
+func (m Model) parseMoveTarget(value string) (time.Time, error) {
+	if strings.HasPrefix(value, "+") || strings.HasPrefix(value, "-") {
+		days, err := strconv.Atoi(value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid offset %q (expected +N or -N)", value)
+		}
+		return m.currentDate.AddDate(0, 0, days), nil
+	}
+	date, err := time.ParseInLocation("2006-01-02", value, m.currentDate.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", value)
+	}
+	return date, nil
+}
+
 func (m Model) submitInput() (tea.Model, tea.Cmd) {
 	input := strings.TrimSpace(m.inputBuffer)
 	if input == "" && m.mode != modeEdit {
@@ -520,30 +1118,16 @@ func (m Model) submitInput() (tea.Model, tea.Cmd) {
 
 	switch m.mode {
 	case modeAddTodo, modeAddLog:
-		parsed, err := parseInputLine(input, m.currentDate)
+		parsed, err := logbook.ParseTokens(input, m.currentDate)
 		if err != nil {
 			m.errorLine = err.Error()
 			return m, nil
 		}
-		if parsed.text == "" && len(parsed.tags) == 0 {
+		if parsed.Text == "" && len(parsed.Tags) == 0 {
 			m.errorLine = "Entry cannot be empty."
 			return m, nil
 		}
-		status := m.pendingStatus
-		if parsed.status != nil {
-			status = *parsed.status
-		}
-		now := time.Now().In(m.currentDate.Location())
-		when := time.Date(m.currentDate.Year(), m.currentDate.Month(), m.currentDate.Day(), now.Hour(), now.Minute(), 0, 0, now.Location())
-		if parsed.when != nil {
-			when = *parsed.when
-		}
-		entry := logbook.Entry{
-			Status: status,
-			Time:   when,
-			Text:   parsed.text,
-			Tags:   parsed.tags,
-		}
+		entry := newEntry(m.currentDate, parsed, m.pendingStatus)
 		cmd := m.appendEntryCmd(m.currentDate, entry)
 		m.mode = modeNormal
 		m = m.resetTextInput()
@@ -563,23 +1147,21 @@ func (m Model) submitInput() (tea.Model, tea.Cmd) {
 		if base.IsZero() {
 			base = m.currentDate
 		}
-		parsed, err := parseInputLine(input, base)
+		parsed, err := logbook.ParseTokens(input, base)
 		if err != nil {
 			m.errorLine = err.Error()
 			return m, nil
 		}
-		if parsed.text == "" && len(parsed.tags) == 0 && parsed.when == nil && parsed.status == nil {
+		if parsed.Text == "" && len(parsed.Tags) == 0 && parsed.Time == nil && parsed.Status == nil {
 			m.errorLine = "Entry cannot be empty."
 			return m, nil
 		}
-		updated := original
-		updated.Text = parsed.text
-		updated.Tags = parsed.tags
-		if parsed.when != nil {
-			updated.Time = *parsed.when
-		}
-		if parsed.status != nil {
-			updated.Status = *parsed.status
+		// The input is prefilled with every token, so dropping one, such
+		// as the ~name, the due: deadline, or the end of a range, clears it.
+		updated := parsed.Entry(original.Time, original.Status)
+		updated.Notes = original.Notes
+		if parsed.Time == nil {
+			updated.End = original.End
 		}
 		cmd := m.editEntryCmd(m.currentDate, m.editingIndex, updated)
 		m.mode = modeNormal
@@ -605,14 +1187,14 @@ func (m Model) submitInput() (tea.Model, tea.Cmd) {
 		if base.IsZero() {
 			base = m.currentDate
 		}
-		parsed, err := time.ParseInLocation("15:04", value, base.Location())
+		when, end, err := logbook.ParseClockRange(value, base)
 		if err != nil {
-			m.errorLine = fmt.Sprintf("Invalid time %q (expected HH:MM)", value)
+			m.errorLine = err.Error()
 			return m, nil
 		}
-		when := time.Date(base.Year(), base.Month(), base.Day(), parsed.Hour(), parsed.Minute(), 0, 0, base.Location())
 		updated := entry
 		updated.Time = when
+		updated.End = end
 		cmd := m.editEntryCmd(m.currentDate, m.editingIndex, updated)
 		m.mode = modeNormal
 		m = m.resetTextInput()
@@ -634,13 +1216,17 @@ func (m Model) submitInput() (tea.Model, tea.Cmd) {
 		}
 		var status logbook.Status
 		switch value {
-		case "todo", "t":
+		case "t":
 			status = logbook.StatusTodo
-		case "done", "d":
+		case "d":
 			status = logbook.StatusDone
 		default:
-			m.errorLine = fmt.Sprintf("Invalid status %q (expected todo or done)", value)
-			return m, nil
+			parsed, err := logbook.ParseStatus(value)
+			if err != nil {
+				m.errorLine = err.Error()
+				return m, nil
+			}
+			status = parsed
 		}
 		entry := m.section.Entries[m.editingIndex]
 		if entry.Status == status {
@@ -652,7 +1238,9 @@ func (m Model) submitInput() (tea.Model, tea.Cmd) {
 			m.errorLine = ""
 			m.pendingSelectIndex = m.editingIndex
 			m.editingIndex = -1
-			return m, nil
+			var cmd tea.Cmd
+			m, cmd = m.reloadIfStale()
+			return m, cmd
 		}
 		updated := entry
 		updated.Status = status
@@ -666,6 +1254,52 @@ func (m Model) submitInput() (tea.Model, tea.Cmd) {
 		m.pendingSelectIndex = m.editingIndex
 		m.editingIndex = -1
 		return m, cmd
+	case modeRetag:
+		if m.editingIndex < 0 || m.editingIndex >= len(m.section.Entries) {
+			return m.cancelInput("No entry selected.")
+		}
+		add, remove, err := parseRetag(input)
+		if err != nil {
+			m.errorLine = err.Error()
+			return m, nil
+		}
+		targets := m.batchTargets(m.editingIndex)
+		cmd := m.retagManyCmd(m.currentDate, targets, add, remove)
+		m.mode = modeNormal
+		m = m.resetTextInput()
+		m.inputBuffer = ""
+		m.inputLabel = ""
+		m.statusLine = fmt.Sprintf("Retagging %d entr%s...", len(targets), plural(len(targets)))
+		m.errorLine = ""
+		m.editingIndex = -1
+		return m, cmd
+	case modeMove:
+		if m.editingIndex < 0 || m.editingIndex >= len(m.section.Entries) {
+			return m.cancelInput("No entry selected.")
+		}
+		to, err := m.parseMoveTarget(input)
+		if err != nil {
+			m.errorLine = err.Error()
+			return m, nil
+		}
+		if sameDay(to, m.currentDate) {
+			m.errorLine = "Entry is already on that date."
+			return m, nil
+		}
+		cmd := m.moveEntryCmd(m.currentDate, m.editingIndex, to)
+		m.statusLine = "Moving entry..."
+		if marked := m.markedIndexes(); len(marked) > 0 {
+			cmd = m.moveManyCmd(m.currentDate, m.batchTargets(m.editingIndex), to)
+			m.statusLine = fmt.Sprintf("Moving %d entries...", len(marked))
+		}
+		m.mode = modeNormal
+		m = m.resetTextInput()
+		m.inputBuffer = ""
+		m.inputLabel = ""
+		m.errorLine = ""
+		m.pendingSelectIndex = m.editingIndex
+		m.editingIndex = -1
+		return m, cmd
 	default:
 		return m, nil
 	}
@@ -684,7 +1318,9 @@ func (m Model) cancelInput(message string) (tea.Model, tea.Cmd) {
 		m.statusLine = message
 	}
 	m.errorLine = ""
-	return m, nil
+	var cmd tea.Cmd
+	m, cmd = m.reloadIfStale()
+	return m, cmd
 }
 
 func (m Model) confirmDelete() (tea.Model, tea.Cmd) {
@@ -693,8 +1329,12 @@ func (m Model) confirmDelete() (tea.Model, tea.Cmd) {
 	}
 	index := m.editingIndex
 	cmd := m.deleteEntryCmd(m.currentDate, index)
-	m.mode = modeNormal
 	m.statusLine = "Deleting entry..."
+	if marked := m.markedIndexes(); len(marked) > 0 {
+		cmd = m.deleteManyCmd(m.currentDate, m.batchTargets(index))
+		m.statusLine = fmt.Sprintf("Deleting %d entries...", len(marked))
+	}
+	m.mode = modeNormal
 	m.errorLine = ""
 	m.inputBuffer = ""
 	m.inputLabel = ""
@@ -708,6 +1348,8 @@ func (m Model) handleSectionLoaded(msg sectionLoadedMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 	m.loading = false
+	m.staleOnDisk = false
+	m.watcher.follow(m.watchedPaths())
 	if msg.err != nil {
 		m.errorLine = fmt.Sprintf("Failed to load %s: %v", msg.date.Format("2006-01-02"), msg.err)
 		m.statusLine = ""
@@ -719,7 +1361,10 @@ func (m Model) handleSectionLoaded(msg sectionLoadedMsg) (tea.Model, tea.Cmd) {
 	if section.Date.IsZero() {
 		section.Date = msg.date
 	}
+	// Indexes may have shifted, so marks do not survive a reload.
+	m = m.clearMarks()
 	m.section = section
+	m.blocked = nil
 	if len(m.section.Entries) == 0 {
 		m.selected = 0
 		m.statusLine = fmt.Sprintf("%s has no entries.", msg.date.Format("2006-01-02"))
@@ -740,8 +1385,13 @@ func (m Model) handleSectionLoaded(msg sectionLoadedMsg) (tea.Model, tea.Cmd) {
 	}
 	m.shouldSelectLast = false
 	m.pendingSelectIndex = -1
+	m = m.applyFilter()
+	m = m.syncWeekSection()
+	if m.filter != "" && len(m.section.Entries) > 0 {
+		m.statusLine = fmt.Sprintf("Loaded %d entr%s, %d matching %q.", len(m.section.Entries), plural(len(m.section.Entries)), len(m.visible), m.filter)
+	}
 	m = m.scrollSelectionIntoView()
-	return m, nil
+	return m, m.loadBlockedCmd(m.section)
 }
 
 func (m Model) handleToggleResult(msg toggleResultMsg) (tea.Model, tea.Cmd) {
@@ -754,10 +1404,15 @@ func (m Model) handleToggleResult(msg toggleResultMsg) (tea.Model, tea.Cmd) {
 	if msg.index >= 0 && msg.index < len(m.section.Entries) {
 		m.section.Entries[msg.index] = msg.entry
 	}
+	// An entry closed under focus mode leaves the list; the selection moves on.
+	m = m.applyFilter()
+	m = m.syncWeekSection()
+	m = m.scrollSelectionIntoView()
 
-	m.statusLine = fmt.Sprintf("Toggled entry %d (%s).", msg.index+1, msg.entry.Time.Format("15:04"))
+	m.statusLine = fmt.Sprintf("Toggled entry %d (%s).", msg.index+1, msg.entry.Time.Format(m.timeLayout))
 	m.errorLine = ""
-	return m, nil
+	// Toggling may settle what other entries of the day wait for.
+	return m, m.loadBlockedCmd(m.section)
 }
 
 func (m Model) handleAppendResult(msg appendResultMsg) (tea.Model, tea.Cmd) {
@@ -769,6 +1424,11 @@ func (m Model) handleAppendResult(msg appendResultMsg) (tea.Model, tea.Cmd) {
 
 	m.errorLine = ""
 	m.statusLine = "Entry added."
+	if msg.entry.Status == logbook.StatusTodo && m.wipLimit > 0 {
+		if open := m.section.OpenCount() + 1; open > m.wipLimit {
+			m.statusLine = fmt.Sprintf("Entry added. WIP limit exceeded: %d open todos (limit %d).", open, m.wipLimit)
+		}
+	}
 	m.loading = true
 	m.shouldSelectLast = true
 	m.pendingSelectIndex = -1
@@ -803,7 +1463,73 @@ func (m Model) handleDeleteResult(msg deleteResultMsg) (tea.Model, tea.Cmd) {
 	return m, m.loadSectionCmd(m.currentDate)
 }
 
+func (m Model) handleMoveResult(msg moveResultMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.errorLine = fmt.Sprintf("Move failed: %v", msg.err)
+		m.statusLine = ""
+		return m, nil
+	}
+
+	m.errorLine = ""
+	m.statusLine = fmt.Sprintf("Moved entry %d to %s.", msg.index+1, formatDate(msg.to, "Mon 2006-01-02"))
+	m.loading = true
+	m.pendingSelectIndex = msg.index
+	return m, m.refreshCmd()
+}
+
+// reorderSelected swaps the focused entry with its neighbour in direction delta.
+func (m Model) reorderSelected(delta int) (tea.Model, tea.Cmd) {
+	if !m.hasSelection() || m.loading {
+		return m, nil
+	}
+	to := m.selected + delta
+	if to < 0 || to >= len(m.section.Entries) {
+		return m, nil
+	}
+	m.loading = true
+	m.statusLine = fmt.Sprintf("Moving entry %d to position %d...", m.selected+1, to+1)
+	m.errorLine = ""
+	return m, m.reorderEntryCmd(m.currentDate, m.selected, to)
+}
+
+func (m Model) handleReorderResult(msg reorderResultMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.loading = false
+		m.errorLine = fmt.Sprintf("Reorder failed: %v", msg.err)
+		m.statusLine = ""
+		return m, nil
+	}
+
+	m.errorLine = ""
+	m.statusLine = fmt.Sprintf("Moved entry to position %d.", msg.to+1)
+	// Week view keeps selected across reloads; day view uses pendingSelectIndex.
+	m.selected = msg.to
+	m.pendingSelectIndex = msg.to
+	return m, m.refreshCmd()
+}
+
+func (m Model) handleUndoResult(msg undoResultMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		if errors.Is(msg.err, logbook.ErrNothingToUndo) {
+			m.statusLine = "Nothing to undo."
+			return m, nil
+		}
+		m.errorLine = fmt.Sprintf("Undo failed: %v", msg.err)
+		m.statusLine = ""
+		return m, nil
+	}
+
+	m.errorLine = ""
+	m.statusLine = fmt.Sprintf("Undid %s.", msg.change.Op)
+	m.loading = true
+	m.pendingSelectIndex = m.selected
+	return m, m.refreshCmd()
+}
+
 func (m Model) gotoDate(date time.Time) (tea.Model, tea.Cmd) {
+	if m.weekView {
+		return m.focusWeekDay(date)
+	}
 	if sameDay(m.currentDate, date) {
 		return m.reload()
 	}
@@ -811,6 +1537,7 @@ func (m Model) gotoDate(date time.Time) (tea.Model, tea.Cmd) {
 	m.currentDate = date
 	m.section = logbook.DateSection{Date: date}
 	m.selected = 0
+	m.visible = nil
 	m.loading = true
 	m.statusLine = fmt.Sprintf("Loading %s...", date.Format("2006-01-02"))
 	m.errorLine = ""
@@ -829,7 +1556,15 @@ func (m Model) reload() (tea.Model, tea.Cmd) {
 	m.loading = true
 	m.statusLine = fmt.Sprintf("Refreshing %s...", m.currentDate.Format("2006-01-02"))
 	m.errorLine = ""
-	return m, m.loadSectionCmd(m.currentDate)
+	return m, m.refreshCmd()
+}
+
+// refreshCmd reloads whatever the current view shows.
+func (m Model) refreshCmd() tea.Cmd {
+	if m.weekView {
+		return m.loadWeekCmd(m.weekEnd)
+	}
+	return m.loadSectionCmd(m.currentDate)
 }
 
 func (m Model) toggleSelected() (tea.Model, tea.Cmd) {
@@ -908,18 +1643,129 @@ func (m Model) deleteEntryCmd(date time.Time, index int) tea.Cmd {
 	}
 }
 
+func (m Model) moveEntryCmd(date time.Time, index int, to time.Time) tea.Cmd {
+	writer := m.writer
+	ctx := m.ctx
+	return func() tea.Msg {
+		if _, err := writer.Move(ctx, date, index+1, to); err != nil {
+			return moveResultMsg{index: index, to: to, err: err}
+		}
+		return moveResultMsg{index: index, to: to}
+	}
+}
+
+func (m Model) reorderEntryCmd(date time.Time, from, to int) tea.Cmd {
+	writer := m.writer
+	ctx := m.ctx
+	return func() tea.Msg {
+		if _, err := writer.Reorder(ctx, date, from+1, to+1); err != nil {
+			return reorderResultMsg{to: to, err: err}
+		}
+		return reorderResultMsg{to: to}
+	}
+}
+
+func (m Model) undoCmd() tea.Cmd {
+	writer := m.writer
+	ctx := m.ctx
+	return func() tea.Msg {
+		change, err := writer.Undo(ctx)
+		return undoResultMsg{change: change, err: err}
+	}
+}
+
+// dayLabel marks today, weekend days, and holidays after a day heading,
+// such as " (Today, Weekend)".
+func (m Model) dayLabel(day time.Time) string {
+	var labels []string
+	if sameDay(day, today()) {
+		labels = append(labels, "Today")
+	}
+	if m.calendar.Holiday(day) {
+		labels = append(labels, "Holiday")
+	} else if m.calendar.Weekend(day) {
+		labels = append(labels, "Weekend")
+	}
+	if len(labels) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(labels, ", ") + ")"
+}
+
+// progressText renders how much of the focused day is done within the
+// context, such as "3/7 done, 43%", or "" when there is nothing to do.
+// Entries hidden by focus still count, and focus adds how many there are.
+func (m Model) progressText() string {
+	scoped := logbook.DateSection{Date: m.section.Date}
+	hidden := 0
+	for _, entry := range m.section.Entries {
+		if m.matches(entry) {
+			scoped.Entries = append(scoped.Entries, entry)
+			if !entry.Status.Open() {
+				hidden++
+			}
+		}
+	}
+	done, total := scoped.Progress()
+	text := ""
+	if total > 0 {
+		text = fmt.Sprintf("%d/%d done, %d%%", done, total, (done*100+total/2)/total)
+	}
+	if m.focus {
+		if text != "" {
+			text += glyphs.sep
+		}
+		text += fmt.Sprintf("focus, %d closed hidden", hidden)
+	}
+	return text
+}
+
 // View renders the frame.
 func (m Model) View() string {
-	var headerText string
-	if sameDay(m.currentDate, today()) {
-		headerText = m.currentDate.Format("Monday, 02 January 2006") + " (Today)"
-	} else {
-		headerText = m.currentDate.Format("Monday, 02 January 2006")
+	if m.tooSmall() {
+		return m.renderTooSmall()
+	}
+	if m.showHelp {
+		return m.renderHelpOverlay()
+	}
+	if m.showStats {
+		return m.renderStats()
+	}
+	if m.showUpcoming {
+		return m.renderUpcoming()
+	}
+
+	headerText := formatDate(m.currentDate, "Monday, 02 January 2006") + m.dayLabel(m.currentDate)
+	if progress := m.progressText(); progress != "" {
+		headerText += glyphs.sep + progress
+	}
+	if m.wipLimit > 0 {
+		headerText = fmt.Sprintf("%s%sWIP %d/%d", headerText, glyphs.sep, m.section.OpenCount(), m.wipLimit)
+	}
+	if tracked := m.section.TrackedDuration(); tracked > 0 {
+		headerText = fmt.Sprintf("%s%s%s tracked", headerText, glyphs.sep, formatDuration(tracked))
+	}
+	if m.weekView {
+		headerText = m.weekHeader()
+	} else if m.timelineView {
+		headerText += glyphs.sep + "timeline"
+	}
+	if len(m.context) > 0 {
+		headerText = fmt.Sprintf("%s%scontext #%s", headerText, glyphs.sep, strings.Join(m.context, " #"))
+	}
+	if m.filter != "" && !m.weekView {
+		headerText = fmt.Sprintf("%s%sfilter %q %d/%d", headerText, glyphs.sep, m.filter, len(m.visible), len(m.section.Entries))
+	} else if m.filter != "" {
+		headerText = fmt.Sprintf("%s%sfilter %q", headerText, glyphs.sep, m.filter)
+	}
+	style := headerStyle
+	if !m.weekView && !m.calendar.Workday(m.currentDate) {
+		style = offDayHeaderStyle
 	}
 	header := lipgloss.JoinVertical(
 		lipgloss.Left,
-		headerStyle.Render(headerText),
-		underlineStyle.Render(strings.Repeat("─", lipgloss.Width(headerText))),
+		style.Render(headerText),
+		underlineStyle.Render(strings.Repeat(glyphs.rule, lipgloss.Width(headerText))),
 	)
 
 	var listView string
@@ -927,12 +1773,44 @@ func (m Model) View() string {
 		loading := strings.TrimSpace(fmt.Sprintf("%s %s", m.spinner.View(), loadingStyle.Render("Loading entries...")))
 		listView = viewportFrameStyle.Render(loading)
 	} else {
-		content := m.renderEntries()
+		content, _ := m.renderEntries()
+		if m.weekView {
+			content, _ = m.renderWeek()
+		} else if m.timelineView {
+			content, _ = m.renderTimeline()
+		}
+		if m.mode == modeSearch {
+			content = m.renderSearch()
+			m.viewport.SetYOffset(max(0, m.searchCursor-m.viewport.Height+1))
+		}
+		if m.mode == modeSnippet {
+			content = m.renderSnippets()
+			m.viewport.SetYOffset(max(0, m.snippetCursor-m.viewport.Height+1))
+		}
 		if strings.TrimSpace(content) == "" {
 			content = placeholderStyle.Render("(no entries yet)")
+			if m.focus && slices.ContainsFunc(m.section.Entries, m.matches) {
+				content = placeholderStyle.Render(fmt.Sprintf("(nothing left to do; press %s to show closed entries)", m.keys.Focus.Help().Key))
+			} else if m.filter != "" && len(m.section.Entries) > 0 {
+				content = placeholderStyle.Render(fmt.Sprintf("(no entries match %q)", m.filter))
+			} else if len(m.context) > 0 && len(m.section.Entries) > 0 {
+				content = placeholderStyle.Render(fmt.Sprintf("(no entries in context #%s)", strings.Join(m.context, " #")))
+			}
+		}
+		if m.detailVisible() {
+			listWidth, paneWidth := m.detailLayout(m.viewport.Width)
+			pane := m.renderDetail(paneWidth, m.viewport.Height)
+			if listWidth == 0 {
+				listView = pane
+			} else {
+				m.viewport.Width = listWidth
+				m.viewport.SetContent(content)
+				listView = lipgloss.JoinHorizontal(lipgloss.Top, m.viewport.View(), " ", pane)
+			}
+		} else {
+			m.viewport.SetContent(content)
+			listView = m.viewport.View()
 		}
-		m.viewport.SetContent(content)
-		listView = m.viewport.View()
 	}
 
 	var status string
@@ -941,14 +1819,24 @@ func (m Model) View() string {
 	} else if m.statusLine != "" {
 		status = statusInfoStyle.Render(m.statusLine)
 	}
+	if n := len(m.markedIndexes()); n > 0 {
+		marks := labelStyle.Render(fmt.Sprintf("%d marked", n))
+		if status != "" {
+			marks += statusInfoStyle.Render(glyphs.sep) + status
+		}
+		status = marks
+	}
 
 	var input string
 	switch m.mode {
-	case modeAddTodo, modeAddLog, modeEdit, modeEditTime, modeEditStatus:
+	case modeAddTodo, modeAddLog, modeEdit, modeEditTime, modeEditStatus, modeFilter, modeMove, modeSearch, modeSnippet, modeRetag:
 		label := labelStyle.Render(m.inputLabel)
 		input = lipgloss.JoinVertical(lipgloss.Left, label, m.textInput.View())
 	case modeConfirmDelete:
 		prompt := fmt.Sprintf("Delete entry %d? (y/n, Esc to cancel)", m.editingIndex+1)
+		if n := len(m.markedIndexes()); n > 0 {
+			prompt = fmt.Sprintf("Delete %d marked entr%s? (y/n, Esc to cancel)", n, plural(n))
+		}
 		input = labelStyle.Render(prompt)
 	}
 
@@ -967,34 +1855,115 @@ func (m Model) View() string {
 	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
 
-func (m Model) renderEntries() string {
-	if len(m.section.Entries) == 0 {
-		return ""
+// tooSmall reports whether the terminal is below the size the layout needs.
+// Before the first resize message the size is unknown and assumed to fit.
+func (m Model) tooSmall() bool {
+	return m.width > 0 && (m.width < minWidth || m.height < minHeight)
+}
+
+// renderTooSmall asks for a larger terminal, wrapped and cut to fit the
+// current one so it never scrolls.
+func (m Model) renderTooSmall() string {
+	notice := fmt.Sprintf("Terminal too small (%dx%d). Resize to at least %dx%d, or press %s to quit.",
+		m.width, m.height, minWidth, minHeight, m.keys.Quit.Help().Key)
+	lines := strings.Split(lipgloss.NewStyle().Width(m.width).Render(notice), "\n")
+	if len(lines) > m.height {
+		lines = lines[:max(m.height, 1)]
 	}
+	return statusInfoStyle.Render(strings.Join(lines, "\n"))
+}
 
-	lines := make([]string, len(m.section.Entries))
-	for i, entry := range m.section.Entries {
-		lines[i] = m.renderEntry(entry, i)
+// renderEntries draws the visible entries and reports the first line of
+// each one, plus the total line count, so wrapped entries can be mapped back
+// to rows.
+func (m Model) renderEntries() (string, []int) {
+	starts := make([]int, len(m.visible)+1)
+	if len(m.visible) == 0 {
+		return "", starts
 	}
-	return strings.Join(lines, "\n")
+
+	var lines []string
+	for row, index := range m.visible {
+		starts[row] = len(lines)
+		lines = append(lines, strings.Split(m.renderEntry(m.section.Entries[index], index), "\n")...)
+	}
+	starts[len(m.visible)] = len(lines)
+	return strings.Join(lines, "\n"), starts
 }
 
 func (m Model) renderEntry(entry logbook.Entry, index int) string {
 	cursor := cursorPassiveStyle.Render(" ")
 	if index == m.selected {
-		cursor = cursorActiveStyle.Render("›")
+		cursor = cursorActiveStyle.Render(glyphs.cursor)
+	}
+	prefix := cursor + " "
+	if len(m.marked) > 0 {
+		// While entries are marked every row gets a mark column.
+		mark := " "
+		if m.marked[index] {
+			mark = tagStyle.Render(glyphs.mark)
+		}
+		prefix = cursor + mark + " "
 	}
+	content := m.renderEntryContent(entry, index == m.selected)
+	if m.blocked[index] && entry.Status.Open() && index != m.selected {
+		content = placeholderStyle.Render(ansi.Strip(content))
+	}
+	return m.fitEntry(prefix, content, m.listWidth(), m.wrapEntries)
+}
 
-	statusBadge := todoBadgeStyle.Render(" TODO ")
-	if entry.Status == logbook.StatusDone {
-		statusBadge = doneBadgeStyle.Render(" DONE ")
+// listWidth is the room for a line inside the list frame, or zero before the
+// terminal size is known.
+func (m Model) listWidth() int {
+	if !m.viewportReady {
+		return 0
+	}
+	width := m.viewport.Width
+	if m.detailVisible() {
+		if list, _ := m.detailLayout(width); list > 0 {
+			width = list
+		}
+	}
+	return max(width-viewportFrameStyle.GetHorizontalFrameSize(), 1)
+}
+
+// fitEntry places content after prefix within width columns. Content that
+// does not fit is cut short with an ellipsis, or with wrap set, continued on
+// lines indented to match prefix. A zero width leaves content whole.
+func (m Model) fitEntry(prefix, content string, width int, wrap bool) string {
+	room := width - lipgloss.Width(prefix)
+	if width <= 0 || room < 1 || lipgloss.Width(content) <= room {
+		return prefix + content
+	}
+	if !wrap {
+		return prefix + ansi.Truncate(content, room, glyphs.ellipsis)
+	}
+
+	lines := strings.Split(lipgloss.NewStyle().Width(room).Render(content), "\n")
+	indent := strings.Repeat(" ", lipgloss.Width(prefix))
+	for i, line := range lines {
+		if i == 0 {
+			lines[i] = prefix + line
+		} else {
+			lines[i] = indent + line
+		}
 	}
+	return strings.Join(lines, "\n")
+}
+
+// renderEntryContent renders the badge, time, text, and tags of an entry.
+func (m Model) renderEntryContent(entry logbook.Entry, selected bool) string {
+	statusBadge := renderStatusBadge(entry.Status)
 
 	timeText := "--:--"
 	if !entry.Time.IsZero() {
-		timeText = entry.Time.Format("15:04")
+		timeText = entry.Time.Format(m.timeLayout)
 	}
 	timeSegment := timeStyle.Render(timeText)
+	if duration := entry.Duration(); duration > 0 {
+		timeSegment = timeStyle.Render(timeText+"-"+entry.End.Format(m.timeLayout)) + " " +
+			placeholderStyle.Render(formatDuration(duration))
+	}
 
 	text := strings.TrimSpace(entry.Text)
 	if text == "" {
@@ -1008,20 +1977,87 @@ func (m Model) renderEntry(entry logbook.Entry, index int) string {
 	}
 
 	contentParts := []string{statusBadge, timeSegment, textSegment}
+	if entry.Pinned {
+		contentParts = []string{statusBadge, timeSegment, tagStyle.Render(glyphs.pin), textSegment}
+	}
+	for _, link := range entry.Links {
+		contentParts = append(contentParts, linkStyle.Render(logbook.LinkPrefix+link))
+	}
+	for _, after := range entry.After {
+		contentParts = append(contentParts, placeholderStyle.Render(logbook.AfterPrefix+logbook.AfterRef(after)))
+	}
+	if entry.Author != "" {
+		contentParts = append(contentParts, placeholderStyle.Render(logbook.AuthorPrefix+entry.Author))
+	}
+	if !entry.Due.IsZero() {
+		contentParts = append(contentParts, renderDue(entry))
+	}
 	if len(tagSegments) > 0 {
 		contentParts = append(contentParts, strings.Join(tagSegments, " "))
 	}
 
 	content := strings.Join(contentParts, " ")
-	if index == m.selected {
+	if selected {
 		content = selectedEntryStyle.Render(content)
 	}
+	return content
+}
+
+// renderDue shows an entry's deadline, flagged in the error color once an
+// open entry is past it.
+func renderDue(entry logbook.Entry) string {
+	due := "due " + formatDate(entry.Due, "02 Jan")
+	if entry.Overdue(time.Now().In(location)) {
+		return statusErrorStyle.Render(glyphs.warn + " " + due)
+	}
+	return placeholderStyle.Render(due)
+}
+
+// renderStatusBadge draws the fixed-width badge for a status.
+func renderStatusBadge(status logbook.Status) string {
+	switch status {
+	case logbook.StatusDone:
+		return doneBadgeStyle.Render(" DONE ")
+	case logbook.StatusInProgress:
+		return progressBadgeStyle.Render(" WIP  ")
+	case logbook.StatusBlocked:
+		return blockedBadgeStyle.Render(" BLKD ")
+	case logbook.StatusCancelled:
+		return cancelledBadgeStyle.Render(" CNCL ")
+	default:
+		return todoBadgeStyle.Render(" TODO ")
+	}
+}
+
+// formatDuration renders d as 1h30m, 2h, or 45m.
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours := int(d / time.Hour)
+	minutes := int((d % time.Hour) / time.Minute)
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%02dm", hours, minutes)
+	}
+}
 
-	return fmt.Sprintf("%s %s", cursor, content)
+// location is the zone today reckons in; NewModel sets it from Options.
+var location = time.Local
+
+// names spells day and month names; NewModel and NewReviewModel set it from
+// Options.
+var names locale.Locale
+
+// formatDate is t.Format(layout) with day and month names in the locale.
+func formatDate(t time.Time, layout string) string {
+	return names.Format(t, layout)
 }
 
 func today() time.Time {
-	now := time.Now().In(time.Local)
+	now := time.Now().In(location)
 	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 }
 
@@ -1036,62 +2072,43 @@ func plural(count int) string {
 	return "ies"
 }
 
-func entryToInput(entry logbook.Entry) string {
-	parts := make([]string, 0, 4+len(entry.Tags))
-	if entry.Status == logbook.StatusDone {
-		parts = append(parts, "!done")
-	} else {
-		parts = append(parts, "!todo")
+// entryClock renders the entry's time as typed in inputs, in layout: HH:MM,
+// or HH:MM-HH:MM for a ranged entry, or 9:45pm and 9:45pm-10:30pm with a
+// 12-hour layout.
+func entryClock(entry logbook.Entry, layout string) string {
+	clock := entry.Time.Format(layout)
+	if entry.Duration() > 0 {
+		clock += "-" + entry.End.Format(layout)
 	}
+	return strings.ToLower(clock)
+}
+
+func entryToInput(entry logbook.Entry, layout string) string {
+	parts := make([]string, 0, 4+len(entry.Tags))
+	parts = append(parts, "!"+entry.Status.String())
 	if !entry.Time.IsZero() {
-		parts = append(parts, "@"+entry.Time.Format("15:04"))
+		parts = append(parts, "@"+entryClock(entry, layout))
+	}
+	if entry.Pinned {
+		parts = append(parts, "*")
 	}
 	if strings.TrimSpace(entry.Text) != "" {
 		parts = append(parts, strings.Fields(entry.Text)...)
 	}
+	for _, link := range entry.Links {
+		parts = append(parts, logbook.LinkPrefix+link)
+	}
+	for _, after := range entry.After {
+		parts = append(parts, logbook.AfterPrefix+after)
+	}
+	if entry.Author != "" {
+		parts = append(parts, logbook.AuthorPrefix+entry.Author)
+	}
+	if !entry.Due.IsZero() {
+		parts = append(parts, logbook.DuePrefix+entry.Due.Format("2006-01-02"))
+	}
 	for _, tag := range entry.Tags {
 		parts = append(parts, "#"+tag)
 	}
 	return strings.Join(parts, " ")
 }
-
-func parseInputLine(input string, base time.Time) (entryInput, error) {
-	result := entryInput{}
-	if strings.TrimSpace(input) == "" {
-		return result, nil
-	}
-
-	var textParts []string
-	var tags []string
-	for _, token := range strings.Fields(input) {
-		switch {
-		case strings.HasPrefix(token, "#") && len(token) > 1:
-			tags = append(tags, strings.TrimPrefix(token, "#"))
-		case strings.HasPrefix(token, "@") && len(token) > 1:
-			parsed, err := time.ParseInLocation("15:04", token[1:], base.Location())
-			if err != nil {
-				return entryInput{}, fmt.Errorf("invalid time %q (expected HH:MM)", token[1:])
-			}
-			when := time.Date(base.Year(), base.Month(), base.Day(), parsed.Hour(), parsed.Minute(), 0, 0, base.Location())
-			result.when = &when
-		case strings.HasPrefix(token, "!") && len(token) > 1:
-			statusToken := strings.ToLower(token[1:])
-			switch statusToken {
-			case "todo":
-				status := logbook.StatusTodo
-				result.status = &status
-			case "done":
-				status := logbook.StatusDone
-				result.status = &status
-			default:
-				return entryInput{}, fmt.Errorf("invalid status %q (expected !todo or !done)", token)
-			}
-		default:
-			textParts = append(textParts, token)
-		}
-	}
-
-	result.text = strings.TrimSpace(strings.Join(textParts, " "))
-	result.tags = tags
-	return result, nil
-}
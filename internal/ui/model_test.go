@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+var testDay = time.Date(2025, time.November, 14, 0, 0, 0, 0, time.Local)
+
+// newTestModel opens the TUI on testDay in a fresh logbook holding one todo
+// per text, at 09:00, 10:00, and so on, with the day loaded.
+func newTestModel(t *testing.T, texts ...string) Model {
+	t.Helper()
+	ctx := context.Background()
+	manager, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	writer := logbook.NewWriter(manager)
+	for i, text := range texts {
+		entry := logbook.Entry{Status: logbook.StatusTodo, Time: testDay.Add(time.Duration(9+i) * time.Hour), Text: text}
+		if err := writer.Append(ctx, testDay, entry); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	m := NewModel(ctx, manager, Options{Date: testDay, Location: time.Local, Plain: true})
+	t.Cleanup(func() { m.Close() })
+	m, _ = send(m, tea.WindowSizeMsg{Width: 100, Height: 40})
+	return settle(t, m, m.loadSectionCmd(testDay))
+}
+
+// send feeds msgs to m in order and returns the command of the last one
+// without running it.
+func send(m Model, msgs ...tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, msg := range msgs {
+		var next tea.Model
+		next, cmd = m.Update(msg)
+		m = next.(Model)
+	}
+	return m, cmd
+}
+
+// settle runs cmd, a load or a write, and feeds its result back into m along
+// with the results of the loads that follow. Anything else, such as cursor
+// blinks, is dropped.
+func settle(t *testing.T, m Model, cmd tea.Cmd) Model {
+	t.Helper()
+	if cmd == nil {
+		return m
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, cmd := range msg {
+			m = settle(t, m, cmd)
+		}
+	case sectionLoadedMsg, toggleResultMsg, appendResultMsg, editResultMsg, deleteResultMsg,
+		moveResultMsg, batchResultMsg, reorderResultMsg, undoResultMsg, blockedLoadedMsg, weekLoadedMsg:
+		m, cmd = send(m, msg)
+		m = settle(t, m, cmd)
+	}
+	return m
+}
+
+// keys turns key names into key presses: esc, enter, and space, or runes.
+func keys(names ...string) []tea.Msg {
+	msgs := make([]tea.Msg, len(names))
+	for i, name := range names {
+		switch name {
+		case "esc":
+			msgs[i] = tea.KeyMsg{Type: tea.KeyEsc}
+		case "enter":
+			msgs[i] = tea.KeyMsg{Type: tea.KeyEnter}
+		case "space":
+			msgs[i] = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+		default:
+			msgs[i] = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name)}
+		}
+	}
+	return msgs
+}
+
+// press sends the named keys one at a time, settling the command of each
+// before the next, as a user waiting for the screen to update would. Keys
+// that leave a prompt open only drive the text input, whose cursor blinks are
+// not worth waiting for.
+func press(t *testing.T, m Model, names ...string) Model {
+	t.Helper()
+	for _, msg := range keys(names...) {
+		var cmd tea.Cmd
+		if m, cmd = send(m, msg); m.mode == modeNormal {
+			m = settle(t, m, cmd)
+		}
+	}
+	return m
+}
+
+// statuses lists the status of every entry of the loaded day.
+func statuses(m Model) []logbook.Status {
+	out := make([]logbook.Status, len(m.section.Entries))
+	for i, entry := range m.section.Entries {
+		out[i] = entry.Status
+	}
+	return out
+}
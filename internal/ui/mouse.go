@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// listTopRow is the screen row of the first list line: below the header,
+	// its rule, a blank line, and the frame's top border.
+	listTopRow = 4
+	// doubleClickWindow is the longest gap between two clicks on the same
+	// entry that still counts as a double-click.
+	doubleClickWindow = 400 * time.Millisecond
+)
+
+// clickNow reports the time of a click; tests replace it.
+var clickNow = time.Now
+
+// handleMouse scrolls the list with the wheel, selects the clicked entry, and
+// toggles it on a double-click. Mouse input is ignored while a prompt,
+// overlay, or load is in progress.
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.showHelp || m.showStats || m.showUpcoming || m.mode != modeNormal || m.loading || !m.viewportReady {
+		return m, nil
+	}
+
+	if tea.MouseEvent(msg).IsWheel() {
+		var cmd tea.Cmd
+		m = m.syncViewport()
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+	if msg.Button != tea.MouseButtonLeft || msg.Action != tea.MouseActionPress || m.weekView || m.timelineView {
+		return m, nil
+	}
+
+	row := msg.Y - listTopRow
+	if row < 0 || row >= m.listHeight() {
+		return m, nil
+	}
+	// Wrapped entries span several lines, so find the entry whose lines
+	// include the clicked one.
+	_, starts := m.renderEntries()
+	position := sort.SearchInts(starts, row+m.viewport.YOffset+1) - 1
+	if position < 0 || position >= len(m.visible) {
+		return m, nil
+	}
+
+	now := clickNow()
+	index := m.visible[position]
+	double := index == m.lastClickIndex && now.Sub(m.lastClick) < doubleClickWindow
+	m = m.moveSelection(position - m.visiblePosition())
+	if double {
+		m.lastClick = time.Time{}
+		return m.toggleSelected()
+	}
+	m.lastClick = now
+	m.lastClickIndex = index
+	return m, nil
+}
@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// PickerItem is one entry offered by the picker. Index is 1-based within the
+// entry's day.
+type PickerItem struct {
+	Date  time.Time
+	Index int
+	Entry logbook.Entry
+}
+
+// PickerModel is the match picker behind kerja search --interactive. Enter
+// chooses the highlighted entry and o chooses it to open in the TUI; read
+// Choice once the program exits.
+type PickerModel struct {
+	list   list.Model
+	chosen bool
+	open   bool
+}
+
+var (
+	pickerChooseKey = key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "show entry"))
+	pickerOpenKey   = key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open in TUI"))
+)
+
+// NewPickerModel lists items under title. Only the Theme, Plain, and
+// TimeLayout options apply.
+func NewPickerModel(title string, items []PickerItem, opts Options) PickerModel {
+	applyTheme(opts.Theme, opts.Plain)
+	timeLayout := opts.TimeLayout
+	if timeLayout == "" {
+		timeLayout = "15:04"
+	}
+
+	listItems := make([]list.Item, len(items))
+	for i, item := range items {
+		listItems[i] = pickerRow{item, timeLayout}
+	}
+	delegate := list.NewDefaultDelegate()
+	if opts.Plain {
+		delegate.Styles = list.DefaultItemStyles{}
+	}
+	l := list.New(listItems, delegate, 0, 0)
+	l.Title = title
+	l.Styles.Title = headerStyle
+	l.SetStatusBarItemName("match", "matches")
+	l.AdditionalShortHelpKeys = func() []key.Binding { return []key.Binding{pickerChooseKey, pickerOpenKey} }
+	return PickerModel{list: l}
+}
+
+// Choice returns the chosen entry and whether it should open in the TUI. The
+// last result is false when the picker was cancelled.
+func (m PickerModel) Choice() (PickerItem, bool, bool) {
+	if !m.chosen {
+		return PickerItem{}, false, false
+	}
+	row, ok := m.list.SelectedItem().(pickerRow)
+	return row.PickerItem, m.open, ok
+}
+
+func (m PickerModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m PickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetSize(msg.Width, msg.Height)
+		return m, nil
+	case tea.KeyMsg:
+		// While the filter is being typed every key belongs to it.
+		if m.list.FilterState() != list.Filtering && m.list.SelectedItem() != nil {
+			switch {
+			case key.Matches(msg, pickerChooseKey):
+				m.chosen = true
+				return m, tea.Quit
+			case key.Matches(msg, pickerOpenKey):
+				m.chosen, m.open = true, true
+				return m, tea.Quit
+			}
+		}
+	}
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m PickerModel) View() string {
+	if m.chosen {
+		return ""
+	}
+	return m.list.View()
+}
+
+// pickerRow is a PickerItem as the list draws it: the entry text as the
+// title and its date, index, status, time, and tags beneath.
+type pickerRow struct {
+	PickerItem
+	timeLayout string
+}
+
+func (r pickerRow) FilterValue() string {
+	parts := []string{r.Entry.Text}
+	for _, tag := range r.Entry.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (r pickerRow) Title() string {
+	text := strings.TrimSpace(r.Entry.Text)
+	if text == "" {
+		text = "(no description)"
+	}
+	return text
+}
+
+func (r pickerRow) Description() string {
+	clock := r.Entry.Time.Format(r.timeLayout)
+	if r.Entry.Duration() > 0 {
+		clock += "-" + r.Entry.End.Format(r.timeLayout)
+	}
+	desc := fmt.Sprintf("%s #%d%s%s %s", r.Date.Format("2006-01-02"), r.Index, glyphs.sep, r.Entry.Status, clock)
+	for _, tag := range r.Entry.Tags {
+		desc += " #" + tag
+	}
+	return desc
+}
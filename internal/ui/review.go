@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// ReviewModel is the end-of-day wizard behind kerja review. It steps through
+// a day's open todos, asks done, carry to the next workday, drop, or keep for
+// each, and then asks for confirmation. It writes nothing itself: read Decisions
+// once the program exits with Confirmed true.
+type ReviewModel struct {
+	date       time.Time
+	items      []reviewItem
+	cursor     int
+	confirmed  bool
+	cancelled  bool
+	timeLayout string
+	calendar   logbook.Calendar
+}
+
+type reviewItem struct {
+	index  int
+	entry  logbook.Entry
+	action logbook.ReviewAction
+}
+
+// NewReviewModel prepares a review of the open entries in section. Only the
+// Theme, Plain, TimeLayout, Calendar, and Locale options apply.
+func NewReviewModel(section logbook.DateSection, opts Options) ReviewModel {
+	applyTheme(opts.Theme, opts.Plain)
+	names = opts.Locale
+	m := ReviewModel{date: section.Date, timeLayout: opts.TimeLayout, calendar: opts.Calendar}
+	if m.timeLayout == "" {
+		m.timeLayout = "15:04"
+	}
+	for i, entry := range section.Entries {
+		if entry.Status.Open() {
+			m.items = append(m.items, reviewItem{index: i + 1, entry: entry})
+		}
+	}
+	return m
+}
+
+// Empty reports whether there are no open todos to review.
+func (m ReviewModel) Empty() bool {
+	return len(m.items) == 0
+}
+
+// Confirmed reports whether the user accepted the summary.
+func (m ReviewModel) Confirmed() bool {
+	return m.confirmed
+}
+
+// Decisions returns the chosen outcome for each reviewed entry, skipping
+// entries that were kept. Carried entries target the next workday.
+func (m ReviewModel) Decisions() []logbook.ReviewDecision {
+	var decisions []logbook.ReviewDecision
+	for _, item := range m.items {
+		if item.action == logbook.ReviewKeep {
+			continue
+		}
+		decision := logbook.ReviewDecision{Index: item.index, Action: item.action}
+		if item.action == logbook.ReviewCarry {
+			decision.Target = m.calendar.NextWorkday(m.date)
+		}
+		decisions = append(decisions, decision)
+	}
+	return decisions
+}
+
+func (m ReviewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ReviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m ReviewModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "esc", "q":
+		m.cancelled = true
+		return m, tea.Quit
+	case "b", "backspace", "left":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+	}
+
+	if m.cursor == len(m.items) {
+		if msg.String() == "enter" || msg.String() == "y" {
+			m.confirmed = true
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	item := &m.items[m.cursor]
+	switch msg.String() {
+	case "d":
+		item.action = logbook.ReviewDone
+	case "c":
+		item.action = logbook.ReviewCarry
+	case "x":
+		item.action = logbook.ReviewDrop
+	case "k", "enter", " ":
+		item.action = logbook.ReviewKeep
+	default:
+		return m, nil
+	}
+	m.cursor++
+	return m, nil
+}
+
+func (m ReviewModel) View() string {
+	if m.confirmed || m.cancelled {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Review %s", formatDate(m.date, "Monday, 2006-01-02"))))
+	b.WriteString("\n\n")
+
+	if m.cursor < len(m.items) {
+		item := m.items[m.cursor]
+		b.WriteString(labelStyle.Render(fmt.Sprintf("Todo %d of %d", m.cursor+1, len(m.items))))
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("%s %s\n\n", cursorActiveStyle.Render(glyphs.cursor), m.renderItem(item)))
+		b.WriteString(statusInfoStyle.Render("d: done  c: carry to next workday  x: drop  k/enter: keep  b: back  esc: cancel"))
+		return b.String()
+	}
+
+	counts := make(map[logbook.ReviewAction]int)
+	for _, item := range m.items {
+		counts[item.action]++
+		b.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render(fmt.Sprintf("%-7s", reviewActionLabel(item.action))), m.renderItem(item)))
+	}
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%d done, %d carried, %d dropped, %d kept\n\n",
+		counts[logbook.ReviewDone], counts[logbook.ReviewCarry], counts[logbook.ReviewDrop], counts[logbook.ReviewKeep]))
+	b.WriteString(statusInfoStyle.Render("enter: save  b: back  esc: cancel"))
+	return b.String()
+}
+
+func (m ReviewModel) renderItem(item reviewItem) string {
+	text := strings.TrimSpace(item.entry.Text)
+	if text == "" {
+		text = "(no description)"
+	}
+	clock := item.entry.Time.Format(m.timeLayout)
+	if item.entry.Duration() > 0 {
+		clock += "-" + item.entry.End.Format(m.timeLayout)
+	}
+	parts := []string{renderStatusBadge(item.entry.Status), timeStyle.Render(clock), entryTextStyle.Render(text)}
+	for _, tag := range item.entry.Tags {
+		parts = append(parts, tagStyle.Render("#"+tag))
+	}
+	return strings.Join(parts, " ")
+}
+
+func reviewActionLabel(action logbook.ReviewAction) string {
+	switch action {
+	case logbook.ReviewDone:
+		return "done"
+	case logbook.ReviewCarry:
+		return "carry"
+	case logbook.ReviewDrop:
+		return "drop"
+	default:
+		return "keep"
+	}
+}
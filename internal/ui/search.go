@@ -0,0 +1,332 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// searchLimit caps how many fuzzy matches are listed.
+const searchLimit = 50
+
+type searchScope uint8
+
+const (
+	searchMonth searchScope = iota
+	searchAll
+)
+
+func (s searchScope) String() string {
+	if s == searchAll {
+		return "all months"
+	}
+	return "this month"
+}
+
+// searchHit is one fuzzy match; index is the entry's position in its section.
+type searchHit struct {
+	date  time.Time
+	index int
+	entry logbook.Entry
+	score int
+}
+
+type searchLoadedMsg struct {
+	scope    searchScope
+	sections []logbook.DateSection
+	err      error
+}
+
+// searchProgressMsg reports a month read by an all-months search; updates
+// delivers the next progress report or the final searchLoadedMsg.
+type searchProgressMsg struct {
+	scope    searchScope
+	progress logbook.Progress
+	updates  <-chan tea.Msg
+}
+
+// fuzzyScore matches the runes of query in order against target, ignoring
+// case. Consecutive runs and matches at word starts score higher, so "rvw doc"
+// ranks "Review design doc" above an incidental match. Every occurrence of the
+// first rune is tried as a starting point and the best run wins. ok is false
+// when some query rune is missing.
+func fuzzyScore(query, target string) (score int, ok bool) {
+	q := []rune(strings.ToLower(strings.Join(strings.Fields(query), "")))
+	if len(q) == 0 {
+		return 0, true
+	}
+	t := []rune(strings.ToLower(target))
+
+	best := -1
+	for start := range t {
+		if t[start] != q[0] {
+			continue
+		}
+		if score, ok := scoreFrom(q, t, start); ok && score > best {
+			best = score
+		}
+	}
+	if best < 0 {
+		return 0, false
+	}
+	// Prefer shorter targets when everything else is equal.
+	return best*100 - len(t), true
+}
+
+// scoreFrom greedily matches q against t beginning at start.
+func scoreFrom(q, t []rune, start int) (int, bool) {
+	score, qi, run := 0, 0, 0
+	for ti := start; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			run = 0
+			continue
+		}
+		score++
+		score += 4 * run
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 3
+		}
+		run++
+		qi++
+	}
+	return score, qi == len(q)
+}
+
+func searchTarget(entry logbook.Entry) string {
+	parts := []string{entry.Text}
+	for _, tag := range entry.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	return strings.Join(parts, " ")
+}
+
+// fuzzySearch ranks every entry in sections against query, best first, with
+// newer dates winning ties.
+func fuzzySearch(sections []logbook.DateSection, query string) []searchHit {
+	var hits []searchHit
+	for _, section := range sections {
+		for index, entry := range section.Entries {
+			score, ok := fuzzyScore(query, searchTarget(entry))
+			if !ok {
+				continue
+			}
+			hits = append(hits, searchHit{date: section.Date, index: index, entry: entry, score: score})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return hits[i].date.After(hits[j].date)
+	})
+	if len(hits) > searchLimit {
+		hits = hits[:searchLimit]
+	}
+	return hits
+}
+
+func (m Model) beginSearch() (tea.Model, tea.Cmd) {
+	if m.loading {
+		return m, nil
+	}
+	m.mode = modeSearch
+	m.searchCursor = 0
+	m.searchHits = nil
+	m.searchSections = nil
+	m.searchLoading = true
+	m.searchProgress = logbook.Progress{}
+	m.statusLine = ""
+	m.errorLine = ""
+	m.textInput.CharLimit = 128
+	m = m.updateSearchLabel()
+	m, focus := m.focusTextInput("", "fuzzy search text and #tags")
+	return m, tea.Batch(focus, m.loadSearchCmd(m.searchScope))
+}
+
+func (m Model) updateSearchLabel() Model {
+	m.inputLabel = fmt.Sprintf("Search %s (Tab: switch scope, %s: choose, Enter: jump, Esc: cancel):", m.searchScope, glyphs.upDown)
+	return m
+}
+
+// loadSearchCmd reads the sections for scope in the background. Progress
+// reports arrive as searchProgressMsg, each waiting for the next, until the
+// searchLoadedMsg.
+func (m Model) loadSearchCmd(scope searchScope) tea.Cmd {
+	manager := m.manager
+	ctx := m.ctx
+	current := m.currentDate
+	reader := m.reader
+	// Every report is answered with another wait, so the final result is
+	// always read; reports are dropped while an earlier one is unread.
+	updates := make(chan tea.Msg, 1)
+	if manager != nil {
+		reader = logbook.NewReader(manager).OnProgress(func(p logbook.Progress) {
+			select {
+			case updates <- searchProgressMsg{scope: scope, progress: p, updates: updates}:
+			default:
+			}
+		})
+	}
+	go func() {
+		// A panic here would bypass Bubble Tea's recovery and leave the
+		// terminal in the alternate screen, so it becomes a search error.
+		defer func() {
+			if r := recover(); r != nil {
+				updates <- searchLoadedMsg{scope: scope, err: fmt.Errorf("search failed: %v", r)}
+			}
+		}()
+		sections, err := loadSearchSections(ctx, reader, manager, scope, current)
+		updates <- searchLoadedMsg{scope: scope, sections: sections, err: err}
+	}()
+	return waitForSearch(updates)
+}
+
+func waitForSearch(updates <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-updates
+	}
+}
+
+func loadSearchSections(ctx context.Context, reader *logbook.Reader, manager *files.Manager, scope searchScope, current time.Time) ([]logbook.DateSection, error) {
+	if scope == searchMonth || manager == nil {
+		return reader.MonthSections(ctx, current)
+	}
+	months, err := manager.Months()
+	if err != nil {
+		return nil, err
+	}
+	var sections []logbook.DateSection
+	err = reader.ScanMonths(ctx, months, func(month time.Time, found []logbook.DateSection) error {
+		sections = append(sections, found...)
+		return nil
+	})
+	return sections, err
+}
+
+// handleSearchProgress shows how many months the current search has read,
+// then waits for the next report. Reports from a search that is no longer
+// shown are still drained so its load can finish.
+func (m Model) handleSearchProgress(msg searchProgressMsg) (tea.Model, tea.Cmd) {
+	if m.mode == modeSearch && msg.scope == m.searchScope && m.searchLoading {
+		m.searchProgress = msg.progress
+	}
+	return m, waitForSearch(msg.updates)
+}
+
+func (m Model) handleSearchLoaded(msg searchLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.mode != modeSearch || msg.scope != m.searchScope {
+		return m, nil
+	}
+	m.searchLoading = false
+	if msg.err != nil {
+		m.errorLine = fmt.Sprintf("Search failed: %v", msg.err)
+		return m, nil
+	}
+	m.searchSections = msg.sections
+	return m.runSearch(), nil
+}
+
+func (m Model) runSearch() Model {
+	m.searchHits = fuzzySearch(m.searchSections, m.textInput.Value())
+	if m.searchCursor >= len(m.searchHits) {
+		m.searchCursor = 0
+	}
+	return m
+}
+
+func (m Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.searchHits = nil
+		m.searchSections = nil
+		return m.cancelInput("Search cancelled.")
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyTab:
+		if m.searchScope == searchMonth {
+			m.searchScope = searchAll
+		} else {
+			m.searchScope = searchMonth
+		}
+		m.searchLoading = true
+		m.searchProgress = logbook.Progress{}
+		m.searchHits = nil
+		m = m.updateSearchLabel()
+		return m, m.loadSearchCmd(m.searchScope)
+	case tea.KeyUp, tea.KeyCtrlP:
+		if m.searchCursor > 0 {
+			m.searchCursor--
+		}
+		return m, nil
+	case tea.KeyDown, tea.KeyCtrlN:
+		if m.searchCursor < len(m.searchHits)-1 {
+			m.searchCursor++
+		}
+		return m, nil
+	case tea.KeyEnter:
+		if len(m.searchHits) == 0 {
+			return m, nil
+		}
+		return m.jumpToHit(m.searchHits[m.searchCursor])
+	}
+
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	m.searchCursor = 0
+	return m.runSearch(), cmd
+}
+
+// jumpToHit closes the search and opens the hit's day with it selected.
+func (m Model) jumpToHit(hit searchHit) (tea.Model, tea.Cmd) {
+	m.mode = modeNormal
+	m = m.resetTextInput()
+	m.inputLabel = ""
+	m.searchHits = nil
+	m.searchSections = nil
+	m = m.setFilter("", "")
+
+	model, cmd := m.gotoDate(hit.date)
+	next := model.(Model)
+	// Day view selects pendingSelectIndex once the section loads; week view
+	// keeps selected across the reload (or applies it now when the day is
+	// already on screen).
+	next.pendingSelectIndex = hit.index
+	next.selected = hit.index
+	if next.weekView && !next.loading {
+		next = next.applyFilter().scrollSelectionIntoView()
+	}
+	next.statusLine = fmt.Sprintf("Jumped to %s entry %d.", hit.date.Format("2006-01-02"), hit.index+1)
+	return next, cmd
+}
+
+func (m Model) renderSearch() string {
+	if m.searchLoading {
+		loading := fmt.Sprintf("Loading %s...", m.searchScope)
+		if p := m.searchProgress; p.Total > 1 {
+			loading += fmt.Sprintf(" %d/%d months (%s)", p.Done, p.Total, p.Month.Format("2006-01"))
+		}
+		return m.spinner.View() + " " + placeholderStyle.Render(loading)
+	}
+	if len(m.searchHits) == 0 {
+		return placeholderStyle.Render("(no matches)")
+	}
+
+	lines := make([]string, len(m.searchHits))
+	for i, hit := range m.searchHits {
+		cursor := cursorPassiveStyle.Render(" ")
+		date := labelStyle.Render(hit.date.Format("2006-01-02"))
+		if i == m.searchCursor {
+			cursor = cursorActiveStyle.Render(glyphs.cursor)
+		}
+		lines[i] = fmt.Sprintf("%s %s %s", cursor, date, m.renderEntryContent(hit.entry, i == m.searchCursor))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+	"github.com/faizmokh/kerja/internal/snippets"
+)
+
+type snippetsLoadedMsg struct {
+	snippets []snippets.Snippet
+	err      error
+}
+
+func (m Model) beginSnippet() (tea.Model, tea.Cmd) {
+	if m.loading || m.weekView || m.manager == nil {
+		return m, nil
+	}
+	m.mode = modeSnippet
+	m.snippets = nil
+	m.snippetHits = nil
+	m.snippetCursor = 0
+	m.snippetsLoading = true
+	m.inputLabel = fmt.Sprintf("Add from snippet (type to narrow, %s: choose, Enter: add, Esc: cancel):", glyphs.upDown)
+	m.statusLine = ""
+	m.errorLine = ""
+	m.textInput.CharLimit = 64
+	m, focus := m.focusTextInput("", "snippet name")
+
+	path := snippets.Path(m.manager.BasePath())
+	return m, tea.Batch(focus, func() tea.Msg {
+		list, err := snippets.Load(path)
+		return snippetsLoadedMsg{snippets: list, err: err}
+	})
+}
+
+func (m Model) handleSnippetsLoaded(msg snippetsLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.mode != modeSnippet {
+		return m, nil
+	}
+	m.snippetsLoading = false
+	if msg.err != nil {
+		m.errorLine = fmt.Sprintf("Snippets failed: %v", msg.err)
+		return m, nil
+	}
+	m.snippets = msg.snippets
+	return m.narrowSnippets(), nil
+}
+
+// narrowSnippets ranks snippets against the typed query by name, then text,
+// keeping file order for ties.
+func (m Model) narrowSnippets() Model {
+	query := m.textInput.Value()
+	type hit struct {
+		snippet snippets.Snippet
+		score   int
+	}
+	var hits []hit
+	for _, snippet := range m.snippets {
+		score, ok := fuzzyScore(query, snippet.Name)
+		if !ok {
+			if score, ok = fuzzyScore(query, snippet.Text); !ok {
+				continue
+			}
+			score -= 1000
+		}
+		hits = append(hits, hit{snippet: snippet, score: score})
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+
+	m.snippetHits = make([]snippets.Snippet, len(hits))
+	for i, h := range hits {
+		m.snippetHits[i] = h.snippet
+	}
+	if m.snippetCursor >= len(m.snippetHits) {
+		m.snippetCursor = 0
+	}
+	return m
+}
+
+func (m Model) handleSnippetKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.snippets = nil
+		m.snippetHits = nil
+		return m.cancelInput("Cancelled.")
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyUp, tea.KeyCtrlP:
+		if m.snippetCursor > 0 {
+			m.snippetCursor--
+		}
+		return m, nil
+	case tea.KeyDown, tea.KeyCtrlN:
+		if m.snippetCursor < len(m.snippetHits)-1 {
+			m.snippetCursor++
+		}
+		return m, nil
+	case tea.KeyEnter:
+		if len(m.snippetHits) == 0 {
+			return m, nil
+		}
+		return m.addSnippet(m.snippetHits[m.snippetCursor])
+	}
+
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	m.snippetCursor = 0
+	return m.narrowSnippets(), cmd
+}
+
+// addSnippet appends the snippet to the current day as a todo unless it
+// carries a !status, stamped now unless it carries @HH:MM.
+func (m Model) addSnippet(snippet snippets.Snippet) (tea.Model, tea.Cmd) {
+	parsed, err := logbook.ParseTokens(snippet.Text, m.currentDate)
+	if err != nil {
+		m.errorLine = fmt.Sprintf("Snippet %q: %v", snippet.Name, err)
+		return m, nil
+	}
+	entry := newEntry(m.currentDate, parsed, logbook.StatusTodo)
+	entry.Notes = append([]string(nil), snippet.Notes...)
+
+	m.mode = modeNormal
+	m = m.resetTextInput()
+	m.inputLabel = ""
+	m.snippets = nil
+	m.snippetHits = nil
+	m.statusLine = fmt.Sprintf("Adding %q...", snippet.Name)
+	m.errorLine = ""
+	m.pendingSelectIndex = -1
+	return m, m.appendEntryCmd(m.currentDate, entry)
+}
+
+func (m Model) renderSnippets() string {
+	if m.snippetsLoading {
+		return placeholderStyle.Render("Loading snippets...")
+	}
+	if len(m.snippets) == 0 {
+		return placeholderStyle.Render(fmt.Sprintf("(no snippets; add \"## name\" sections to %s)", snippets.Path(m.manager.BasePath())))
+	}
+	if len(m.snippetHits) == 0 {
+		return placeholderStyle.Render("(no matches)")
+	}
+
+	width := 0
+	for _, snippet := range m.snippetHits {
+		width = max(width, len(snippet.Name))
+	}
+	lines := make([]string, len(m.snippetHits))
+	for i, snippet := range m.snippetHits {
+		cursor := cursorPassiveStyle.Render(" ")
+		if i == m.snippetCursor {
+			cursor = cursorActiveStyle.Render(glyphs.cursor)
+		}
+		name := labelStyle.Render(fmt.Sprintf("%-*s", width, snippet.Name))
+		line := fmt.Sprintf("%s %s  %s", cursor, name, entryTextStyle.Render(snippet.Text))
+		if len(snippet.Notes) > 0 {
+			line += placeholderStyle.Render(fmt.Sprintf(" (+%d notes)", len(snippet.Notes)))
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,232 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+const (
+	// statsBarWidth is the longest bar in cells.
+	statsBarWidth = 30
+	// statsTopTags caps the tag chart.
+	statsTopTags = 8
+)
+
+// monthStats summarizes a month for the stats screen.
+type monthStats struct {
+	month       time.Time
+	days        []dayCount
+	total, done int
+	tags        []tagCount
+}
+
+type dayCount struct {
+	date        time.Time
+	total, done int
+}
+
+type tagCount struct {
+	tag   string
+	count int
+}
+
+type statsLoadedMsg struct {
+	month time.Time
+	stats monthStats
+	err   error
+}
+
+// buildMonthStats counts entries per day of month, overall completion, and
+// the most used tags (ties in alphabetical order).
+func buildMonthStats(month time.Time, sections []logbook.DateSection) monthStats {
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	stats := monthStats{month: first}
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		stats.days = append(stats.days, dayCount{date: day})
+	}
+
+	tags := make(map[string]int)
+	for _, section := range sections {
+		if section.Date.Year() != first.Year() || section.Date.Month() != first.Month() {
+			continue
+		}
+		day := &stats.days[section.Date.Day()-1]
+		for _, entry := range section.Entries {
+			day.total++
+			if entry.Status == logbook.StatusDone {
+				day.done++
+			}
+			for _, tag := range entry.Tags {
+				tags[tag]++
+			}
+		}
+		stats.total += day.total
+		stats.done += day.done
+	}
+
+	for tag, count := range tags {
+		stats.tags = append(stats.tags, tagCount{tag: tag, count: count})
+	}
+	sort.Slice(stats.tags, func(i, j int) bool {
+		if stats.tags[i].count != stats.tags[j].count {
+			return stats.tags[i].count > stats.tags[j].count
+		}
+		return stats.tags[i].tag < stats.tags[j].tag
+	})
+	if len(stats.tags) > statsTopTags {
+		stats.tags = stats.tags[:statsTopTags]
+	}
+	return stats
+}
+
+func (m Model) beginStats() (tea.Model, tea.Cmd) {
+	m.showStats = true
+	return m.loadStats(m.currentDate)
+}
+
+func (m Model) loadStats(month time.Time) (tea.Model, tea.Cmd) {
+	month = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	m.stats = monthStats{month: month}
+	m.statsLoading = true
+	m.errorLine = ""
+
+	reader := m.reader
+	ctx := m.ctx
+	return m, func() tea.Msg {
+		sections, err := reader.MonthSections(ctx, month)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return statsLoadedMsg{month: month, err: err}
+		}
+		return statsLoadedMsg{month: month, stats: buildMonthStats(month, sections)}
+	}
+}
+
+func (m Model) handleStatsLoaded(msg statsLoadedMsg) (tea.Model, tea.Cmd) {
+	// Ignore months the user has already paged away from.
+	if !m.showStats || !msg.month.Equal(m.stats.month) {
+		return m, nil
+	}
+	m.statsLoading = false
+	if msg.err != nil {
+		m.errorLine = fmt.Sprintf("Stats failed: %v", msg.err)
+		return m, nil
+	}
+	m.stats = msg.stats
+	return m, nil
+}
+
+func (m Model) handleStatsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyCtrlC:
+		return m, tea.Quit
+	case msg.Type == tea.KeyEsc, key.Matches(msg, m.keys.Stats), key.Matches(msg, m.keys.Quit):
+		m.showStats = false
+		m.errorLine = ""
+	case key.Matches(msg, m.keys.PrevDay):
+		return m.loadStats(m.stats.month.AddDate(0, -1, 0))
+	case key.Matches(msg, m.keys.NextDay):
+		return m.loadStats(m.stats.month.AddDate(0, 1, 0))
+	}
+	return m, nil
+}
+
+// statsBar draws value scaled against scale, splitting off the done share in
+// its own color.
+func statsBar(value, done, scale int) string {
+	if value == 0 || scale == 0 {
+		return ""
+	}
+	cells := max((value*statsBarWidth+scale-1)/scale, 1)
+	doneCells := min(cells, done*statsBarWidth/scale)
+	return barDoneStyle.Render(strings.Repeat(glyphs.bar, doneCells)) +
+		barOpenStyle.Render(strings.Repeat(glyphs.bar, cells-doneCells))
+}
+
+// renderStats draws entries per day, the done ratio, and the top tags.
+func (m Model) renderStats() string {
+	title := fmt.Sprintf("Stats%s%s", glyphs.sep, formatDate(m.stats.month, "January 2006"))
+	lines := []string{
+		headerStyle.Render(title),
+		underlineStyle.Render(strings.Repeat(glyphs.rule, lipgloss.Width(title))),
+		"",
+	}
+
+	var body string
+	switch {
+	case m.statsLoading:
+		body = loadingStyle.Render("Loading month...")
+	case m.stats.total == 0:
+		body = placeholderStyle.Render("(no entries this month)")
+	default:
+		body = m.renderStatsCharts()
+	}
+	lines = append(lines, viewportFrameStyle.Render(body))
+
+	if m.errorLine != "" {
+		lines = append(lines, statusErrorStyle.Render(m.errorLine))
+	}
+	lines = append(lines, statusInfoStyle.Render(fmt.Sprintf("%s or %s: change month, %s or Esc: close.",
+		m.keys.PrevDay.Help().Key, m.keys.NextDay.Help().Key, m.keys.Stats.Help().Key)))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func (m Model) renderStatsCharts() string {
+	busiest := 0
+	for _, day := range m.stats.days {
+		busiest = max(busiest, day.total)
+	}
+	// Short day names differ in width between locales, so pad the labels
+	// to keep the bars aligned.
+	labelWidth := 0
+	for _, day := range m.stats.days {
+		labelWidth = max(labelWidth, lipgloss.Width(formatDate(day.date, "Mon 02")))
+	}
+	days := []string{labelStyle.Render("Entries per day")}
+	for _, day := range m.stats.days {
+		label := formatDate(day.date, "Mon 02")
+		label += strings.Repeat(" ", labelWidth-lipgloss.Width(label))
+		if day.total == 0 {
+			days = append(days, placeholderStyle.Render(label))
+			continue
+		}
+		days = append(days, fmt.Sprintf("%s %s %d", timeStyle.Render(label), statsBar(day.total, day.done, busiest), day.total))
+	}
+
+	ratio := float64(m.stats.done) / float64(m.stats.total)
+	summary := []string{
+		labelStyle.Render("Done ratio"),
+		fmt.Sprintf("%s %.0f%%", statsBar(m.stats.total, m.stats.done, m.stats.total), ratio*100),
+		entryTextStyle.Render(fmt.Sprintf("%d of %d entries done", m.stats.done, m.stats.total)),
+		"",
+		labelStyle.Render("Top tags"),
+	}
+	if len(m.stats.tags) == 0 {
+		summary = append(summary, placeholderStyle.Render("(no tags)"))
+	}
+	width := 0
+	for _, tag := range m.stats.tags {
+		width = max(width, lipgloss.Width(tag.tag)+1)
+	}
+	for _, tag := range m.stats.tags {
+		label := tagStyle.Render(fmt.Sprintf("%-*s", width, "#"+tag.tag))
+		bar := barTagStyle.Render(strings.Repeat(glyphs.bar, max(tag.count*statsBarWidth/m.stats.tags[0].count, 1)))
+		summary = append(summary, fmt.Sprintf("%s %s %d", label, bar, tag.count))
+	}
+
+	left, right := strings.Join(days, "\n"), strings.Join(summary, "\n")
+	charts := lipgloss.JoinHorizontal(lipgloss.Top, spaced([]string{left, right})...)
+	if m.width > 0 && lipgloss.Width(charts) > m.width-viewportHorizontalPadding {
+		return right + "\n\n" + left
+	}
+	return charts
+}
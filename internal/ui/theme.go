@@ -0,0 +1,105 @@
+package ui
+
+import (
+	gumstyle "github.com/charmbracelet/gum/style"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// palette lists the colors a theme can override. Empty strings disable color.
+type palette struct {
+	header, muted, placeholder, passive, error, todo, done, progress, blocked, cancelled, badgeBG, time, tag, accent, frame, selectedBG, selectedFG, text string
+}
+
+var themes = map[string]palette{
+	"default": {
+		header: "213", muted: "244", placeholder: "241", passive: "238", error: "196", todo: "51", done: "120", progress: "214", blocked: "203",
+		cancelled: "245", badgeBG: "236", time: "111", tag: "177", accent: "51", frame: "60", selectedBG: "57", selectedFG: "230", text: "252",
+	},
+	"light": {
+		header: "125", muted: "242", placeholder: "245", passive: "250", error: "160", todo: "25", done: "28", progress: "130", blocked: "124",
+		cancelled: "244", badgeBG: "254", time: "25", tag: "90", accent: "25", frame: "248", selectedBG: "153", selectedFG: "16", text: "235",
+	},
+	"mono": {},
+}
+
+// glyphSet holds the non-ASCII characters the TUI decorates with.
+type glyphSet struct {
+	sep, cursor, mark, pin, rule, marker, dash, warn, rail, busyRail, continued, ellipsis, upDown, bar string
+}
+
+var (
+	unicodeGlyphs = glyphSet{
+		sep: " · ", cursor: "›", mark: "•", pin: "★", rule: "─", marker: "▸", dash: "–", warn: "⚠", rail: "│", busyRail: "┃", continued: "⋮", ellipsis: "…", upDown: "↑/↓", bar: "█",
+	}
+	asciiGlyphs = glyphSet{
+		sep: " | ", cursor: ">", mark: "*", pin: "*", rule: "-", marker: ">", dash: "-", warn: "!", rail: ":", busyRail: "|", continued: ":", ellipsis: "...", upDown: "up/down", bar: "#",
+	}
+
+	// glyphs is swapped for asciiGlyphs in plain mode.
+	glyphs = unicodeGlyphs
+)
+
+// applyTheme rebinds the package styles to the named palette, falling back to
+// the default theme for unknown names. Plain mode drops every style and
+// draws with ASCII only.
+func applyTheme(name string, plain bool) {
+	if plain {
+		applyPlain()
+		return
+	}
+	glyphs = unicodeGlyphs
+
+	p, ok := themes[name]
+	if !ok {
+		p = themes["default"]
+	}
+
+	headerStyle = gumstyle.Styles{Foreground: p.header, Bold: true}.ToLipgloss()
+	offDayHeaderStyle = gumstyle.Styles{Foreground: p.muted, Bold: true, Italic: true}.ToLipgloss()
+	loadingStyle = gumstyle.Styles{Foreground: p.time}.ToLipgloss()
+	statusInfoStyle = gumstyle.Styles{Foreground: p.muted}.ToLipgloss()
+	statusErrorStyle = gumstyle.Styles{Foreground: p.error, Bold: true}.ToLipgloss()
+	labelStyle = gumstyle.Styles{Foreground: p.muted, Bold: true}.ToLipgloss()
+	todoBadgeStyle = gumstyle.Styles{Foreground: p.todo, Background: p.badgeBG, Bold: true}.ToLipgloss()
+	doneBadgeStyle = gumstyle.Styles{Foreground: p.done, Background: p.badgeBG, Bold: true}.ToLipgloss()
+	progressBadgeStyle = gumstyle.Styles{Foreground: p.progress, Background: p.badgeBG, Bold: true}.ToLipgloss()
+	blockedBadgeStyle = gumstyle.Styles{Foreground: p.blocked, Background: p.badgeBG, Bold: true}.ToLipgloss()
+	cancelledBadgeStyle = gumstyle.Styles{Foreground: p.cancelled, Background: p.badgeBG, Strikethrough: true}.ToLipgloss()
+	timeStyle = gumstyle.Styles{Foreground: p.time}.ToLipgloss()
+	tagStyle = gumstyle.Styles{Foreground: p.tag}.ToLipgloss()
+	linkStyle = gumstyle.Styles{Foreground: p.time, Underline: true}.ToLipgloss()
+	barDoneStyle = gumstyle.Styles{Foreground: p.done}.ToLipgloss()
+	barOpenStyle = gumstyle.Styles{Foreground: p.todo}.ToLipgloss()
+	barTagStyle = gumstyle.Styles{Foreground: p.tag}.ToLipgloss()
+	placeholderStyle = gumstyle.Styles{Foreground: p.placeholder}.ToLipgloss()
+	cursorActiveStyle = gumstyle.Styles{Foreground: p.accent, Bold: true}.ToLipgloss()
+	cursorPassiveStyle = gumstyle.Styles{Foreground: p.passive}.ToLipgloss()
+
+	viewportFrameStyle = lipgloss.NewStyle().
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color(p.frame)).
+		Padding(0, 1)
+	selectedEntryStyle = lipgloss.NewStyle().
+		Background(lipgloss.Color(p.selectedBG)).
+		Foreground(lipgloss.Color(p.selectedFG)).
+		Bold(true)
+	if p.selectedBG == "" {
+		selectedEntryStyle = selectedEntryStyle.Reverse(true)
+	}
+	entryTextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(p.text))
+	underlineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(p.frame))
+}
+
+func applyPlain() {
+	glyphs = asciiGlyphs
+
+	plain := lipgloss.NewStyle()
+	headerStyle, offDayHeaderStyle, loadingStyle, statusInfoStyle, statusErrorStyle, labelStyle = plain, plain, plain, plain, plain, plain
+	todoBadgeStyle, doneBadgeStyle, progressBadgeStyle, blockedBadgeStyle, cancelledBadgeStyle = plain, plain, plain, plain, plain
+	timeStyle, tagStyle, linkStyle, placeholderStyle, cursorActiveStyle, cursorPassiveStyle = plain, plain, plain, plain, plain, plain
+	selectedEntryStyle, entryTextStyle, underlineStyle = plain, plain, plain
+	barDoneStyle, barOpenStyle, barTagStyle = plain, plain, plain
+	viewportFrameStyle = lipgloss.NewStyle().
+		BorderStyle(lipgloss.ASCIIBorder()).
+		Padding(0, 1)
+}
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/faizmokh/kerja/internal/export"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// Timeline view always draws the working day so gaps show up even on quiet
+// days; it widens to cover entries outside these hours.
+const (
+	timelineStartHour = 8
+	timelineEndHour   = 18
+)
+
+// timelineBlock places an entry on the day in minutes since midnight. end
+// equals start unless the entry has a time range or a `~duration` annotation.
+type timelineBlock struct {
+	index   int
+	entry   logbook.Entry
+	start   int
+	end     int
+	overlap bool
+}
+
+func (m Model) toggleTimelineView() (tea.Model, tea.Cmd) {
+	if m.timelineView {
+		m.timelineView = false
+		m.statusLine = fmt.Sprintf("List view: %s.", m.currentDate.Format("2006-01-02"))
+		m.errorLine = ""
+		m.viewport.SetYOffset(0)
+		return m.scrollSelectionIntoView(), nil
+	}
+
+	if m.weekView {
+		m.weekView = false
+		m.weekSections = nil
+	}
+	m.timelineView = true
+	m.statusLine = fmt.Sprintf("Timeline view: %s.", m.currentDate.Format("2006-01-02"))
+	m.errorLine = ""
+	m.viewport.SetYOffset(0)
+	return m.scrollSelectionIntoView(), nil
+}
+
+// timelineBlocks returns the timed entries accepted by shown ordered by
+// start, flagging any that start before an earlier one has ended, plus the
+// indexes of entries without a time.
+func timelineBlocks(section logbook.DateSection, shown func(logbook.Entry) bool) ([]timelineBlock, []int) {
+	var (
+		blocks  []timelineBlock
+		untimed []int
+	)
+	for index, entry := range section.Entries {
+		if !shown(entry) {
+			continue
+		}
+		if entry.Time.IsZero() {
+			untimed = append(untimed, index)
+			continue
+		}
+		start := entry.Time.Hour()*60 + entry.Time.Minute()
+		end := start
+		duration := entry.Duration()
+		if duration == 0 {
+			duration, _, _ = export.EntryDuration(entry.Text)
+		}
+		if duration > 0 {
+			end = min(start+int(duration/time.Minute), 24*60)
+		}
+		blocks = append(blocks, timelineBlock{index: index, entry: entry, start: start, end: end})
+	}
+	sort.SliceStable(blocks, func(i, j int) bool { return blocks[i].start < blocks[j].start })
+
+	for i := range blocks {
+		for j := 0; j < i; j++ {
+			if blocks[j].start == blocks[i].start || blocks[j].end > blocks[i].start {
+				blocks[i].overlap = true
+				blocks[j].overlap = true
+			}
+		}
+	}
+	return blocks, untimed
+}
+
+// renderTimeline draws the focused day as an hour-by-hour column with each
+// entry on the hour it starts. Hours an entry's duration runs through are
+// marked with a heavy rail, idle hours with a light one. It reports the line
+// holding the selection so the viewport can keep it visible.
+func (m Model) renderTimeline() (string, int) {
+	blocks, untimed := timelineBlocks(m.section, m.shown)
+	if len(blocks) == 0 && len(untimed) == 0 {
+		return "", -1
+	}
+
+	var (
+		lines        []string
+		selectedLine = -1
+	)
+	labelWidth := len(time.Date(2000, 1, 1, 23, 0, 0, 0, time.UTC).Format(m.timeLayout))
+	blank := strings.Repeat(" ", labelWidth)
+	// Timeline rows always truncate: wrapped lines would break the rail.
+	entryLine := func(label, rail string, block timelineBlock) string {
+		cursor := cursorPassiveStyle.Render(" ")
+		if block.index == m.selected {
+			cursor = cursorActiveStyle.Render(glyphs.cursor)
+			selectedLine = len(lines)
+		}
+		content := m.renderEntryContent(block.entry, block.index == m.selected)
+		if block.overlap {
+			content += " " + statusErrorStyle.Render(glyphs.warn+" overlap")
+		}
+		return m.fitEntry(fmt.Sprintf("%s %s %s ", label, rail, cursor), content, m.listWidth(), false)
+	}
+
+	for i, index := range untimed {
+		label := blank
+		if i == 0 {
+			label = placeholderStyle.Render(fmt.Sprintf("%-*s", labelWidth, "--:--"))
+		}
+		lines = append(lines, entryLine(label, placeholderStyle.Render(glyphs.rail), timelineBlock{index: index, entry: m.section.Entries[index]}))
+	}
+
+	first, last := timelineStartHour, timelineEndHour
+	for _, block := range blocks {
+		first = min(first, block.start/60)
+		last = max(last, (max(block.end, block.start+1)-1)/60)
+	}
+
+	date := m.section.Date
+	for hour := first; hour <= last; hour++ {
+		var starting, running []timelineBlock
+		for _, block := range blocks {
+			switch {
+			case block.start/60 == hour:
+				starting = append(starting, block)
+			case block.start/60 < hour && block.end > hour*60:
+				running = append(running, block)
+			}
+		}
+
+		label := timeStyle.Render(time.Date(date.Year(), date.Month(), date.Day(), hour, 0, 0, 0, date.Location()).Format(m.timeLayout))
+		if len(starting)+len(running) == 0 {
+			lines = append(lines, fmt.Sprintf("%s %s", label, placeholderStyle.Render(glyphs.rail)))
+			continue
+		}
+		rail := timeStyle.Render(glyphs.busyRail)
+
+		for _, block := range running {
+			text := strings.TrimSpace(block.entry.Text)
+			lines = append(lines, fmt.Sprintf("%s %s %s", label, rail, placeholderStyle.Render("  "+glyphs.continued+" "+text)))
+			label = blank
+		}
+		for _, block := range starting {
+			lines = append(lines, entryLine(label, rail, block))
+			label = blank
+		}
+	}
+	return strings.Join(lines, "\n"), selectedLine
+}
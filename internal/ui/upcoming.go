@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// upcomingDays is how far ahead, from tomorrow, the upcoming screen looks.
+const upcomingDays = 14
+
+// upcomingItem is an open entry on a future day; index is its position in
+// that day's section.
+type upcomingItem struct {
+	date  time.Time
+	index int
+	entry logbook.Entry
+}
+
+type upcomingLoadedMsg struct {
+	items []upcomingItem
+	err   error
+}
+
+// upcomingTodos lists the open entries in sections that match context, in
+// date order.
+func upcomingTodos(sections []logbook.DateSection, context []string) []upcomingItem {
+	var items []upcomingItem
+	for _, section := range sections {
+		for i, entry := range section.Entries {
+			if entry.Status.Open() && logbook.MatchesContext(entry, context) {
+				items = append(items, upcomingItem{date: section.Date, index: i, entry: entry})
+			}
+		}
+	}
+	return items
+}
+
+func (m Model) beginUpcoming() (tea.Model, tea.Cmd) {
+	m.showUpcoming = true
+	m.upcoming = nil
+	m.upcomingCursor = 0
+	m.upcomingLoading = true
+	m.errorLine = ""
+
+	reader := m.reader
+	ctx := m.ctx
+	scope := m.context
+	start := today().AddDate(0, 0, 1)
+	return m, func() tea.Msg {
+		sections, err := reader.SectionsBetween(ctx, start, start.AddDate(0, 0, upcomingDays-1))
+		if err != nil {
+			return upcomingLoadedMsg{err: err}
+		}
+		return upcomingLoadedMsg{items: upcomingTodos(sections, scope)}
+	}
+}
+
+func (m Model) handleUpcomingLoaded(msg upcomingLoadedMsg) (tea.Model, tea.Cmd) {
+	if !m.showUpcoming {
+		return m, nil
+	}
+	m.upcomingLoading = false
+	if msg.err != nil {
+		m.errorLine = fmt.Sprintf("Upcoming failed: %v", msg.err)
+		return m, nil
+	}
+	m.upcoming = msg.items
+	return m, nil
+}
+
+func (m Model) handleUpcomingKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyCtrlC:
+		return m, tea.Quit
+	case msg.Type == tea.KeyEsc, key.Matches(msg, m.keys.Upcoming), key.Matches(msg, m.keys.Quit):
+		m.showUpcoming = false
+		m.errorLine = ""
+	case key.Matches(msg, m.keys.Up):
+		if m.upcomingCursor > 0 {
+			m.upcomingCursor--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.upcomingCursor < len(m.upcoming)-1 {
+			m.upcomingCursor++
+		}
+	case msg.Type == tea.KeyEnter:
+		if len(m.upcoming) == 0 {
+			return m, nil
+		}
+		item := m.upcoming[m.upcomingCursor]
+		m.showUpcoming = false
+		m.upcoming = nil
+		return m.jumpToHit(searchHit{date: item.date, index: item.index, entry: item.entry})
+	}
+	return m, nil
+}
+
+// renderUpcoming lists the open entries of the coming days under their dates.
+func (m Model) renderUpcoming() string {
+	title := fmt.Sprintf("Upcoming%snext %d days", glyphs.sep, upcomingDays)
+	lines := []string{
+		headerStyle.Render(title),
+		underlineStyle.Render(strings.Repeat(glyphs.rule, lipgloss.Width(title))),
+		"",
+	}
+
+	var body []string
+	switch {
+	case m.upcomingLoading:
+		body = append(body, loadingStyle.Render("Loading upcoming days..."))
+	case len(m.upcoming) == 0:
+		body = append(body, placeholderStyle.Render("(nothing scheduled)"))
+	}
+	for i, item := range m.upcoming {
+		if i == 0 || !sameDay(item.date, m.upcoming[i-1].date) {
+			if i > 0 {
+				body = append(body, "")
+			}
+			body = append(body, labelStyle.Render(formatDate(item.date, "Monday, 02 January")+m.dayLabel(item.date)))
+		}
+		cursor := cursorPassiveStyle.Render(" ")
+		if i == m.upcomingCursor {
+			cursor = cursorActiveStyle.Render(glyphs.cursor)
+		}
+		body = append(body, fmt.Sprintf("%s %s", cursor, m.renderEntryContent(item.entry, i == m.upcomingCursor)))
+	}
+	lines = append(lines, viewportFrameStyle.Render(strings.Join(body, "\n")))
+
+	if m.errorLine != "" {
+		lines = append(lines, statusErrorStyle.Render(m.errorLine))
+	}
+	lines = append(lines, statusInfoStyle.Render(fmt.Sprintf("%s or %s: move, Enter: open the day, %s or Esc: close.",
+		m.keys.Up.Help().Key, m.keys.Down.Help().Key, m.keys.Upcoming.Help().Key)))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,207 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce coalesces the burst of events editors emit for one save.
+const watchDebounce = 150 * time.Millisecond
+
+// fileWatcher follows the year directories holding the displayed month files.
+// Directories are watched rather than files because both kerja and most
+// editors save by renaming a temp file over the original.
+type fileWatcher struct {
+	watcher *fsnotify.Watcher
+	dirs    map[string]bool
+
+	// written holds the modification time each file had right after the
+	// model last wrote it, so the events of that write cause no reload. It
+	// is filled from the goroutines running writes, hence the lock.
+	mu      sync.Mutex
+	written map[string]time.Time
+}
+
+type fileChangedMsg struct {
+	path string
+}
+
+type watchReloadMsg struct {
+	seq int
+}
+
+type watchErrorMsg struct {
+	err error
+}
+
+func newFileWatcher() *fileWatcher {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil
+	}
+	return &fileWatcher{watcher: watcher, dirs: make(map[string]bool), written: make(map[string]time.Time)}
+}
+
+// close stops the watcher and the listen command blocked on it.
+func (w *fileWatcher) close() error {
+	if w == nil {
+		return nil
+	}
+	return w.watcher.Close()
+}
+
+// recordWrite notes the modification time path has after the model wrote it.
+func (w *fileWatcher) recordWrite(path string) {
+	if w == nil {
+		return
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.written[filepath.Clean(path)] = info.ModTime()
+}
+
+// ownWrite reports whether path is still as the model's last write left it.
+func (w *fileWatcher) ownWrite(path string) bool {
+	if w == nil {
+		return false
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	written, ok := w.written[filepath.Clean(path)]
+	return ok && written.Equal(info.ModTime())
+}
+
+// follow makes sure the directory of every path is watched and drops watches
+// that are no longer needed. Missing directories are retried on the next call.
+func (w *fileWatcher) follow(paths []string) {
+	if w == nil {
+		return
+	}
+	wanted := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		wanted[filepath.Dir(path)] = true
+	}
+	for dir := range w.dirs {
+		if !wanted[dir] {
+			_ = w.watcher.Remove(dir)
+			delete(w.dirs, dir)
+		}
+	}
+	for dir := range wanted {
+		if w.dirs[dir] {
+			continue
+		}
+		if _, err := os.Stat(dir); err != nil {
+			continue
+		}
+		if err := w.watcher.Add(dir); err == nil {
+			w.dirs[dir] = true
+		}
+	}
+}
+
+// listen waits for the next filesystem event.
+func (w *fileWatcher) listen() tea.Cmd {
+	if w == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		for {
+			select {
+			case event, ok := <-w.watcher.Events:
+				if !ok {
+					return nil
+				}
+				if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) || event.Has(fsnotify.Rename) || event.Has(fsnotify.Remove) {
+					return fileChangedMsg{path: event.Name}
+				}
+			case err, ok := <-w.watcher.Errors:
+				if !ok {
+					return nil
+				}
+				return watchErrorMsg{err: err}
+			}
+		}
+	}
+}
+
+// watchedPaths lists the month files backing the current view.
+func (m Model) watchedPaths() []string {
+	if m.manager == nil {
+		return nil
+	}
+	if m.weekView {
+		return []string{m.manager.MonthPath(m.weekStart()), m.manager.MonthPath(m.weekEnd)}
+	}
+	return []string{m.manager.MonthPath(m.currentDate)}
+}
+
+func (m Model) handleFileChanged(msg fileChangedMsg) (tea.Model, tea.Cmd) {
+	listen := m.watcher.listen()
+	relevant := false
+	for _, path := range m.watchedPaths() {
+		if filepath.Clean(path) == filepath.Clean(msg.path) {
+			relevant = true
+			break
+		}
+	}
+	if !relevant {
+		return m, listen
+	}
+
+	m.changedPaths = append(m.changedPaths, msg.path)
+	m.watchSeq++
+	seq := m.watchSeq
+	debounce := tea.Tick(watchDebounce, func(time.Time) tea.Msg { return watchReloadMsg{seq: seq} })
+	return m, tea.Batch(listen, debounce)
+}
+
+func (m Model) handleWatchReload(msg watchReloadMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.watchSeq {
+		return m, nil
+	}
+	if m.loading {
+		return m, tea.Tick(watchDebounce, func(time.Time) tea.Msg { return msg })
+	}
+	// The month files kerja itself just wrote are already shown as written;
+	// reloading them would only move the selection.
+	changed := m.changedPaths
+	m.changedPaths = nil
+	if !slices.ContainsFunc(changed, func(path string) bool { return !m.watcher.ownWrite(path) }) {
+		return m, nil
+	}
+	// Reloading under an open prompt could shift the entry being edited, so
+	// wait until the prompt closes.
+	if m.mode != modeNormal && m.mode != modeFilter {
+		m.staleOnDisk = true
+		return m, nil
+	}
+	m.loading = true
+	m.statusLine = "File changed on disk, reloading..."
+	m.errorLine = ""
+	return m, m.refreshCmd()
+}
+
+// reloadIfStale catches up on external edits that arrived while a prompt was open.
+func (m Model) reloadIfStale() (Model, tea.Cmd) {
+	if !m.staleOnDisk || m.loading {
+		return m, nil
+	}
+	m.staleOnDisk = false
+	m.loading = true
+	return m, m.refreshCmd()
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func TestWatchReloadSkipsOwnWrites(t *testing.T) {
+	m := newTestModel(t, "Plan", "Build", "Ship")
+	m = press(t, m, "j", "x")
+	if m.section.Entries[1].Status != logbook.StatusInProgress {
+		t.Fatalf("toggle did not apply: %v", statuses(m))
+	}
+
+	path := m.manager.MonthPath(testDay)
+	m, _ = send(m, fileChangedMsg{path: path})
+	m, cmd := send(m, watchReloadMsg{seq: m.watchSeq})
+	if cmd != nil || m.loading {
+		t.Fatalf("own write triggered a reload")
+	}
+	if m.selected != 1 {
+		t.Fatalf("selected = %d, want 1", m.selected)
+	}
+}
+
+func TestWatchReloadPicksUpExternalEdits(t *testing.T) {
+	m := newTestModel(t, "Plan")
+	m = press(t, m, "x")
+
+	path := m.manager.MonthPath(testDay)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	data = append(data, "- [ ] [11:00] Edited elsewhere\n"...)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	m, _ = send(m, fileChangedMsg{path: path})
+	m, cmd := send(m, watchReloadMsg{seq: m.watchSeq})
+	if cmd == nil || !m.loading {
+		t.Fatalf("external edit did not trigger a reload")
+	}
+	m = settle(t, m, cmd)
+	if len(m.section.Entries) != 2 || m.section.Entries[1].Text != "Edited elsewhere" {
+		t.Fatalf("entries after reload = %+v", m.section.Entries)
+	}
+}
+
+func TestCloseStopsWatching(t *testing.T) {
+	m := newTestModel(t, "Plan")
+	if m.watcher == nil {
+		t.Skip("file watching unavailable")
+	}
+	listen := m.watcher.listen()
+	if err := m.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if msg := listen(); msg != nil {
+		t.Fatalf("listen after Close = %#v, want nil", msg)
+	}
+}
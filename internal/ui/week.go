@@ -0,0 +1,264 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// weekDays is the number of sections stacked in week view.
+const weekDays = 7
+
+type weekLoadedMsg struct {
+	end      time.Time
+	sections []logbook.DateSection
+	err      error
+}
+
+// weekStart returns the first day shown in week view.
+func (m Model) weekStart() time.Time {
+	return m.weekEnd.AddDate(0, 0, -(weekDays - 1))
+}
+
+// weekIndex returns the position of date within the loaded week, or -1.
+func (m Model) weekIndex(date time.Time) int {
+	for i, section := range m.weekSections {
+		if sameDay(section.Date, date) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m Model) toggleWeekView() (tea.Model, tea.Cmd) {
+	if m.weekView {
+		m.weekView = false
+		m.weekSections = nil
+		m.statusLine = fmt.Sprintf("Day view: %s.", m.currentDate.Format("2006-01-02"))
+		m.errorLine = ""
+		m.viewport.SetYOffset(0)
+		m = m.scrollSelectionIntoView()
+		return m, nil
+	}
+
+	m.weekView = true
+	m.timelineView = false
+	m.weekEnd = m.currentDate
+	m.loading = true
+	m.statusLine = fmt.Sprintf("Loading week ending %s...", m.weekEnd.Format("2006-01-02"))
+	m.errorLine = ""
+	return m, m.loadWeekCmd(m.weekEnd)
+}
+
+// focusWeekDay moves the focus to date, shifting the 7-day window when date
+// falls outside it.
+func (m Model) focusWeekDay(date time.Time) (tea.Model, tea.Cmd) {
+	m.selected = 0
+	if i := m.weekIndex(date); i >= 0 {
+		m.currentDate = m.weekSections[i].Date
+		m.section = m.weekSections[i]
+		m = m.applyFilter()
+		m.statusLine = fmt.Sprintf("Focused %s.", formatDate(m.currentDate, "Mon 2006-01-02"))
+		m.errorLine = ""
+		m = m.scrollSelectionIntoView()
+		return m, nil
+	}
+
+	if date.Before(m.weekStart()) {
+		m.weekEnd = date.AddDate(0, 0, weekDays-1)
+	} else {
+		m.weekEnd = date
+	}
+	m.currentDate = date
+	m.section = logbook.DateSection{Date: date}
+	m.visible = nil
+	m.loading = true
+	m.statusLine = fmt.Sprintf("Loading week ending %s...", m.weekEnd.Format("2006-01-02"))
+	m.errorLine = ""
+	m.viewport.SetYOffset(0)
+	return m, m.loadWeekCmd(m.weekEnd)
+}
+
+func (m Model) loadWeekCmd(end time.Time) tea.Cmd {
+	reader := m.reader
+	ctx := m.ctx
+	return func() tea.Msg {
+		start := end.AddDate(0, 0, -(weekDays - 1))
+		found, err := reader.SectionsBetween(ctx, start, end)
+		if err != nil {
+			return weekLoadedMsg{end: end, err: err}
+		}
+
+		sections := make([]logbook.DateSection, weekDays)
+		for i := range sections {
+			sections[i] = logbook.DateSection{Date: start.AddDate(0, 0, i)}
+			for _, section := range found {
+				if sameDay(section.Date, sections[i].Date) {
+					sections[i] = section
+					break
+				}
+			}
+		}
+		return weekLoadedMsg{end: end, sections: sections}
+	}
+}
+
+func (m Model) handleWeekLoaded(msg weekLoadedMsg) (tea.Model, tea.Cmd) {
+	if !m.weekView || !sameDay(m.weekEnd, msg.end) {
+		return m, nil
+	}
+	m.loading = false
+	m.staleOnDisk = false
+	m.watcher.follow(m.watchedPaths())
+	if msg.err != nil {
+		m.errorLine = fmt.Sprintf("Failed to load week ending %s: %v", msg.end.Format("2006-01-02"), msg.err)
+		m.statusLine = ""
+		return m, nil
+	}
+
+	m.weekSections = msg.sections
+	if i := m.weekIndex(m.currentDate); i >= 0 {
+		m.section = m.weekSections[i]
+	}
+	if m.selected >= len(m.section.Entries) {
+		m.selected = 0
+	}
+	m.pendingSelectIndex = -1
+	m.shouldSelectLast = false
+	m = m.applyFilter()
+
+	total := 0
+	for _, section := range m.weekSections {
+		total += len(section.Entries)
+	}
+	m.errorLine = ""
+	m.statusLine = fmt.Sprintf("Loaded %d entr%s across %d days.", total, plural(total), weekDays)
+	m = m.scrollSelectionIntoView()
+	return m, nil
+}
+
+// syncWeekSection copies a freshly loaded day back into the week view.
+func (m Model) syncWeekSection() Model {
+	if !m.weekView {
+		return m
+	}
+	if i := m.weekIndex(m.section.Date); i >= 0 {
+		m.weekSections[i] = m.section
+	}
+	return m
+}
+
+type weekRow struct {
+	day   int
+	index int
+}
+
+// weekRows lists every entry visible under the current filter in display order.
+func (m Model) weekRows() []weekRow {
+	var rows []weekRow
+	for day, section := range m.weekSections {
+		for index, entry := range section.Entries {
+			if m.shown(entry) {
+				rows = append(rows, weekRow{day: day, index: index})
+			}
+		}
+	}
+	return rows
+}
+
+// moveWeekSelection steps through entries across day boundaries.
+func (m Model) moveWeekSelection(delta int) Model {
+	rows := m.weekRows()
+	if len(rows) == 0 {
+		return m
+	}
+	focus := m.weekIndex(m.currentDate)
+
+	target := -1
+	for pos, row := range rows {
+		if row.day == focus && row.index == m.selected && m.hasSelection() {
+			target = pos + delta
+			break
+		}
+	}
+	if target == -1 {
+		// Nothing selected on the focused day: jump to the nearest entry in
+		// the direction of travel.
+		for pos, row := range rows {
+			if delta > 0 && row.day > focus {
+				target = pos
+				break
+			}
+			if delta < 0 && row.day < focus {
+				target = pos
+			}
+		}
+		if target == -1 {
+			return m
+		}
+	}
+	if target < 0 {
+		target = 0
+	} else if target >= len(rows) {
+		target = len(rows) - 1
+	}
+
+	row := rows[target]
+	m.currentDate = m.weekSections[row.day].Date
+	m.section = m.weekSections[row.day]
+	m.selected = row.index
+	m = m.applyFilter()
+	m.statusLine = fmt.Sprintf("Selected %s entry %d", formatDate(m.currentDate, "Mon 2006-01-02"), m.selected+1)
+	m.errorLine = ""
+	return m.scrollSelectionIntoView()
+}
+
+// renderWeek stacks every day of the week and reports the line holding the
+// selection so the viewport can keep it visible.
+func (m Model) renderWeek() (string, int) {
+	var (
+		lines        []string
+		selectedLine = -1
+	)
+	for _, section := range m.weekSections {
+		focused := sameDay(section.Date, m.currentDate)
+		heading := formatDate(section.Date, "Mon 2006-01-02") + m.dayLabel(section.Date)
+		switch {
+		case focused:
+			lines = append(lines, headerStyle.Render(glyphs.marker+" "+heading))
+		case !m.calendar.Workday(section.Date):
+			lines = append(lines, offDayHeaderStyle.Render("  "+heading))
+		default:
+			lines = append(lines, labelStyle.Render("  "+heading))
+		}
+		if focused && !m.hasSelection() {
+			selectedLine = len(lines) - 1
+		}
+
+		shown := 0
+		for index, entry := range section.Entries {
+			if !m.shown(entry) {
+				continue
+			}
+			shown++
+			if focused && index == m.selected {
+				selectedLine = len(lines)
+				lines = append(lines, strings.Split(m.renderEntry(entry, index), "\n")...)
+				continue
+			}
+			lines = append(lines, strings.Split(m.renderEntry(entry, -1), "\n")...)
+		}
+		if shown == 0 {
+			lines = append(lines, placeholderStyle.Render("    (no entries)"))
+		}
+	}
+	return strings.Join(lines, "\n"), selectedLine
+}
+
+func (m Model) weekHeader() string {
+	return fmt.Sprintf("Week %s %s %s", formatDate(m.weekStart(), "Mon 02 Jan"), glyphs.dash, formatDate(m.weekEnd, "Mon 02 Jan 2006"))
+}
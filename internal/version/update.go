@@ -0,0 +1,88 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ReleasesURL is the GitHub API endpoint describing the latest release.
+// Tests point it at a local server.
+var ReleasesURL = "https://api.github.com/repos/faizmokh/kerja/releases/latest"
+
+// Release describes a published release.
+type Release struct {
+	Tag string `json:"tag_name"`
+	URL string `json:"html_url"`
+}
+
+// Latest fetches the most recent published release from GitHub.
+func Latest(ctx context.Context, client *http.Client) (Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ReleasesURL, nil)
+	if err != nil {
+		return Release{}, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return Release{}, fmt.Errorf("check for updates: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Release{}, fmt.Errorf("check for updates: GitHub returned %s", resp.Status)
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return Release{}, fmt.Errorf("check for updates: %w", err)
+	}
+	if release.Tag == "" {
+		return Release{}, fmt.Errorf("check for updates: release has no tag")
+	}
+	return release, nil
+}
+
+// Newer reports whether latest is a higher version than current. Both may
+// carry a leading "v"; pre-release and build suffixes are ignored. A current
+// version that does not parse, such as "dev", is never considered outdated.
+func Newer(current, latest string) bool {
+	have, ok := parse(current)
+	if !ok {
+		return false
+	}
+	want, ok := parse(latest)
+	if !ok {
+		return false
+	}
+	for i := range have {
+		if want[i] != have[i] {
+			return want[i] > have[i]
+		}
+	}
+	return false
+}
+
+// parse reads MAJOR.MINOR.PATCH, allowing the minor and patch parts to be
+// omitted.
+func parse(value string) ([3]int, bool) {
+	var parts [3]int
+	value = strings.TrimPrefix(strings.TrimSpace(value), "v")
+	if i := strings.IndexAny(value, "-+"); i >= 0 {
+		value = value[:i]
+	}
+	fields := strings.Split(value, ".")
+	if len(fields) > 3 {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
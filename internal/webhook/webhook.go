@@ -0,0 +1,182 @@
+// Package webhook posts entry events to a user-configured URL, so kerja can
+// drive automation platforms such as n8n or Zapier.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+// Kind names what happened to an entry.
+type Kind string
+
+const (
+	// Added is sent for every entry created, logged or planned.
+	Added Kind = "added"
+	// Completed is sent when an existing entry is marked done.
+	Completed Kind = "completed"
+	// Deleted is sent for every entry removed.
+	Deleted Kind = "deleted"
+)
+
+// Kinds lists every event kind.
+var Kinds = []Kind{Added, Completed, Deleted}
+
+// Event is one entry event. Without a template it is posted as JSON.
+type Event struct {
+	Event Kind `json:"event"`
+	// Date is the entry's day as YYYY-MM-DD and Index its 1-based position
+	// there: the new one, or the old one for a deletion.
+	Date  string        `json:"date"`
+	Index int           `json:"index"`
+	Entry logbook.Entry `json:"entry"`
+}
+
+// Events turns the entry changes of one write into events. Entries moved
+// between days arrive as a deletion and an addition; moves within a day, such
+// as kerja reorder makes, are not changes and send nothing.
+func Events(changes []logbook.EntryChange) []Event {
+	var events []Event
+	for _, change := range changes {
+		event := Event{Date: change.Date.Format("2006-01-02"), Index: change.Index}
+		switch {
+		case change.Kind == logbook.ChangeCreate:
+			event.Event, event.Entry = Added, *change.After
+		case change.Kind == logbook.ChangeDelete:
+			event.Event, event.Entry = Deleted, *change.Before
+		case change.After.Status == logbook.StatusDone && change.Before.Status != logbook.StatusDone:
+			event.Event, event.Entry = Completed, *change.After
+		default:
+			continue
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// ParseEvents reads a comma-separated list of event kinds, such as
+// "added,completed". An empty value means every kind.
+func ParseEvents(value string) ([]Kind, error) {
+	var kinds []Kind
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(Kinds, Kind(name)) {
+			return nil, fmt.Errorf("invalid webhook event %q (expected added, completed, or deleted)", name)
+		}
+		kinds = append(kinds, Kind(name))
+	}
+	return kinds, nil
+}
+
+// ParseTemplate compiles a request body template. It is executed with an
+// Event, so {{.Event}}, {{.Date}}, and {{.Entry.Text}} are available, and
+// {{json .Entry.Text}} quotes a value for a JSON body.
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("webhook").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// Hook posts events to URL.
+type Hook struct {
+	url      string
+	kinds    []Kind
+	template *template.Template
+	client   *http.Client
+}
+
+// New returns a hook posting the kinds of event listed in events (all when
+// empty) to url, with a body rendered from tmpl or, when tmpl is empty, the
+// event as JSON. A nil client uses http.DefaultClient.
+func New(client *http.Client, url, tmpl, events string) (*Hook, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	kinds, err := ParseEvents(events)
+	if err != nil {
+		return nil, err
+	}
+	hook := &Hook{url: url, kinds: kinds, client: client}
+	if tmpl != "" {
+		if hook.template, err = ParseTemplate(tmpl); err != nil {
+			return nil, err
+		}
+	}
+	return hook, nil
+}
+
+// Wants reports whether the hook is configured to send kind.
+func (h *Hook) Wants(kind Kind) bool {
+	return len(h.kinds) == 0 || slices.Contains(h.kinds, kind)
+}
+
+// Send posts each wanted event in order, stopping at the first failure.
+func (h *Hook) Send(ctx context.Context, events []Event) error {
+	for _, event := range events {
+		if !h.Wants(event.Event) {
+			continue
+		}
+		if err := h.post(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *Hook) post(ctx context.Context, event Event) error {
+	body, err := h.body(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	// Templates may render plain text or form data as well as JSON.
+	contentType := "text/plain; charset=utf-8"
+	if json.Valid(body) {
+		contentType = "application/json"
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post %s webhook: %w", event.Event, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		reason, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s webhook returned %s: %s", event.Event, resp.Status, strings.TrimSpace(string(reason)))
+	}
+	return nil
+}
+
+func (h *Hook) body(event Event) ([]byte, error) {
+	if h.template == nil {
+		return json.Marshal(event)
+	}
+	var b bytes.Buffer
+	if err := h.template.Execute(&b, event); err != nil {
+		return nil, fmt.Errorf("render webhook template: %w", err)
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,114 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+func TestEventsReportsAddedCompletedAndDeleted(t *testing.T) {
+	day := time.Date(2025, 11, 4, 0, 0, 0, 0, time.UTC)
+	todo := logbook.Entry{Status: logbook.StatusTodo, Text: "Ship login"}
+	done := logbook.Entry{Status: logbook.StatusDone, Text: "Ship login"}
+	retitled := logbook.Entry{Status: logbook.StatusTodo, Text: "Ship signup"}
+	changes := []logbook.EntryChange{
+		{Kind: logbook.ChangeCreate, Date: day, Index: 1, After: &todo},
+		{Kind: logbook.ChangeEdit, Date: day, Index: 1, Before: &todo, After: &retitled},
+		{Kind: logbook.ChangeToggle, Date: day, Index: 2, Before: &todo, After: &done},
+		{Kind: logbook.ChangeToggle, Date: day, Index: 3, Before: &done, After: &done},
+		{Kind: logbook.ChangeDelete, Date: day, Index: 4, Before: &done},
+	}
+
+	events := Events(changes)
+	want := []struct {
+		kind  Kind
+		index int
+	}{{Added, 1}, {Completed, 2}, {Deleted, 4}}
+	if len(events) != len(want) {
+		t.Fatalf("Events = %+v", events)
+	}
+	for i, w := range want {
+		if events[i].Event != w.kind || events[i].Index != w.index || events[i].Date != "2025-11-04" {
+			t.Fatalf("event %d = %+v, want %s at %d", i, events[i], w.kind, w.index)
+		}
+	}
+}
+
+func TestEventsSkipEntriesMovedWithinTheirDay(t *testing.T) {
+	manager, err := files.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	var events []Event
+	writer := logbook.NewWriter(manager).OnChange(func(op string, changes []logbook.EntryChange) {
+		events = append(events, Events(changes)...)
+	})
+	ctx := context.Background()
+	day := time.Date(2025, 11, 4, 0, 0, 0, 0, time.UTC)
+	for i, text := range []string{"a", "b", "c"} {
+		if err := writer.Append(ctx, day, logbook.Entry{Status: logbook.StatusTodo, Time: day.Add(time.Duration(9+i) * time.Hour), Text: text}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	events = nil
+
+	if _, err := writer.Reorder(ctx, day, 3, 1); err != nil {
+		t.Fatalf("Reorder: %v", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("reorder events = %+v, want none", events)
+	}
+}
+
+func TestSendPostsJSONWithoutTemplate(t *testing.T) {
+	var got Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	hook, err := New(srv.Client(), srv.URL, "", "")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	event := Event{Event: Added, Date: "2025-11-04", Index: 1, Entry: logbook.Entry{Text: "Ship login", Tags: []string{"work"}}}
+	if err := hook.Send(context.Background(), []Event{event}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if got.Event != Added || got.Entry.Text != "Ship login" || len(got.Entry.Tags) != 1 {
+		t.Fatalf("posted %+v", got)
+	}
+}
+
+func TestSendRendersPlainTextTemplate(t *testing.T) {
+	var contentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+	}))
+	defer srv.Close()
+
+	hook, err := New(srv.Client(), srv.URL, "{{.Event}}: {{.Entry.Text}}", "deleted")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if hook.Wants(Added) || !hook.Wants(Deleted) {
+		t.Fatalf("Wants does not follow the configured events")
+	}
+	if err := hook.Send(context.Background(), []Event{{Event: Deleted, Entry: logbook.Entry{Text: "Dentist"}}}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if contentType != "text/plain; charset=utf-8" {
+		t.Fatalf("Content-Type = %q", contentType)
+	}
+}
@@ -0,0 +1,162 @@
+// Package kerja reads and writes a kerja logbook from other Go programs
+// without shelling out to the CLI.
+//
+// Open uses the same logbook and settings as the kerja command; OpenDir
+// opens a plain monthly logbook anywhere on disk. Every change goes through
+// the same writer as the CLI, so it can be undone with kerja undo and shows
+// up in kerja history.
+//
+//	book, err := kerja.Open()
+//	if err != nil {
+//		return err
+//	}
+//	entry, err := kerja.ParseEntry("Ship release #work @09:30 !done", time.Now())
+//	if err != nil {
+//		return err
+//	}
+//	return book.Append(ctx, entry)
+package kerja
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/faizmokh/kerja/internal/config"
+	"github.com/faizmokh/kerja/internal/files"
+	"github.com/faizmokh/kerja/internal/logbook"
+)
+
+type (
+	// Entry is one line of the logbook with its notes.
+	Entry = logbook.Entry
+	// Status is an entry's checkbox state.
+	Status = logbook.Status
+	// Day holds the entries under one date heading, in file order.
+	Day = logbook.DateSection
+)
+
+const (
+	StatusTodo       = logbook.StatusTodo
+	StatusDone       = logbook.StatusDone
+	StatusInProgress = logbook.StatusInProgress
+	StatusCancelled  = logbook.StatusCancelled
+	StatusBlocked    = logbook.StatusBlocked
+)
+
+// ParseStatus converts a status name such as "done" or "in-progress".
+func ParseStatus(name string) (Status, error) {
+	return logbook.ParseStatus(name)
+}
+
+// ParseEntry reads a free-form line the way kerja log and kerja todo do:
+// #tags, an @HH:MM time or @HH:MM-HH:MM range, a !status, a standalone * to
+// pin, a ~name author, a due:YYYY-MM-DD deadline, ref: links, and after:
+// dependencies are picked out and the rest is the text. The entry falls on
+// at's day, at at's time unless the line sets one, and is a todo unless the
+// line sets a status.
+func ParseEntry(line string, at time.Time) (Entry, error) {
+	parsed, err := logbook.ParseTokens(line, at)
+	if err != nil {
+		return Entry{}, err
+	}
+	entry := parsed.Entry(at, StatusTodo)
+	if entry.Text == "" && len(entry.Tags) == 0 {
+		return Entry{}, fmt.Errorf("entry has no text")
+	}
+	return entry, nil
+}
+
+// Logbook reads and changes one logbook directory. It is not safe for
+// concurrent use.
+type Logbook struct {
+	manager *files.Manager
+	reader  *logbook.Reader
+	writer  *logbook.Writer
+}
+
+// Open opens the logbook the kerja command uses, honoring KERJA_HOME,
+// KERJA_CONFIG, and the config file's base_path, layout, encryption,
+// backups, time_zone, and sort_entries settings.
+func Open() (*Logbook, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	manager, err := cfg.Manager()
+	if err != nil {
+		return nil, err
+	}
+	return newLogbook(manager, cfg.SortEntries == "time"), nil
+}
+
+// OpenDir opens the monthly logbook rooted at dir with default settings,
+// ignoring the config file. The directory is created on the first write.
+func OpenDir(dir string) (*Logbook, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("logbook directory is required")
+	}
+	manager, err := files.NewManager(dir)
+	if err != nil {
+		return nil, err
+	}
+	return newLogbook(manager, false), nil
+}
+
+func newLogbook(manager *files.Manager, sortByTime bool) *Logbook {
+	return &Logbook{
+		manager: manager,
+		reader:  logbook.NewReader(manager),
+		writer:  logbook.NewWriter(manager).SortByTime(sortByTime),
+	}
+}
+
+// Dir returns the logbook's root directory.
+func (l *Logbook) Dir() string {
+	return l.manager.BasePath()
+}
+
+// Day returns the entries logged on date. A day without a heading has none.
+func (l *Logbook) Day(ctx context.Context, date time.Time) (Day, error) {
+	return l.reader.Section(ctx, date)
+}
+
+// Days returns the days from start to end inclusive that have a heading,
+// oldest first.
+func (l *Logbook) Days(ctx context.Context, start, end time.Time) ([]Day, error) {
+	return l.reader.SectionsBetween(ctx, start, end)
+}
+
+// Append adds entry to the end of the day of its Time, creating the day's
+// heading and month file when needed.
+func (l *Logbook) Append(ctx context.Context, entry Entry) error {
+	return l.writer.Append(ctx, entry.Time, entry)
+}
+
+// AppendAll adds entries to their days, writing each month file once.
+func (l *Logbook) AppendAll(ctx context.Context, entries []Entry) error {
+	return l.writer.AppendBatch(ctx, entries)
+}
+
+// Toggle advances the status of the entry at index (1-based) on date, as
+// kerja toggle does, and returns the updated entry.
+func (l *Logbook) Toggle(ctx context.Context, date time.Time, index int) (Entry, error) {
+	return l.writer.Toggle(ctx, date, index)
+}
+
+// Edit replaces the entry at index (1-based) on date.
+func (l *Logbook) Edit(ctx context.Context, date time.Time, index int, entry Entry) error {
+	return l.writer.Edit(ctx, date, index, entry)
+}
+
+// Delete removes the entry at index (1-based) on date and returns it.
+func (l *Logbook) Delete(ctx context.Context, date time.Time, index int) (Entry, error) {
+	return l.writer.Delete(ctx, date, index)
+}
+
+// Undo reverts the most recent change to the logbook, whoever made it, and
+// returns a description of it such as "append to 2025-11-04".
+func (l *Logbook) Undo(ctx context.Context) (string, error) {
+	change, err := l.writer.Undo(ctx)
+	return change.Op, err
+}
@@ -0,0 +1,76 @@
+package kerja_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faizmokh/kerja/pkg/kerja"
+)
+
+func TestLogbookAppendsAndReadsEntries(t *testing.T) {
+	ctx := context.Background()
+	book, err := kerja.OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatalf("OpenDir: %v", err)
+	}
+
+	day := time.Date(2025, time.November, 4, 8, 0, 0, 0, time.Local)
+	entry, err := kerja.ParseEntry("Ship release #work @09:30 !done", day)
+	if err != nil {
+		t.Fatalf("ParseEntry: %v", err)
+	}
+	if err := book.Append(ctx, entry); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := book.AppendAll(ctx, []kerja.Entry{{Status: kerja.StatusTodo, Time: day, Text: "Write notes"}}); err != nil {
+		t.Fatalf("AppendAll: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(book.Dir(), "2025", "2025-11.md"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !strings.Contains(string(data), "- [x] [09:30] Ship release #work\n- [ ] [08:00] Write notes\n") {
+		t.Fatalf("month file:\n%s", data)
+	}
+
+	got, err := book.Day(ctx, day)
+	if err != nil {
+		t.Fatalf("Day: %v", err)
+	}
+	if len(got.Entries) != 2 || got.Entries[0].Status != kerja.StatusDone || got.Entries[0].Tags[0] != "work" {
+		t.Fatalf("Day = %+v", got)
+	}
+
+	toggled, err := book.Toggle(ctx, day, 2)
+	if err != nil || toggled.Status != kerja.StatusInProgress {
+		t.Fatalf("Toggle = %+v, %v", toggled, err)
+	}
+	if op, err := book.Undo(ctx); err != nil || op != "toggle 2025-11-04 #2" {
+		t.Fatalf("Undo = %q, %v", op, err)
+	}
+	days, err := book.Days(ctx, day.AddDate(0, 0, -1), day.AddDate(0, 0, 1))
+	if err != nil || len(days) != 1 || days[0].Entries[1].Status != kerja.StatusTodo {
+		t.Fatalf("Days = %+v, %v", days, err)
+	}
+}
+
+func TestParseEntryRequiresText(t *testing.T) {
+	if _, err := kerja.ParseEntry("@09:00 !done", time.Now()); err == nil {
+		t.Fatal("expected an error for an entry without text")
+	}
+}
+
+func TestParseEntryKeepsPinAndAuthor(t *testing.T) {
+	entry, err := kerja.ParseEntry("* Ship release ~bob #work", time.Now())
+	if err != nil {
+		t.Fatalf("ParseEntry: %v", err)
+	}
+	if entry.Text != "Ship release" || !entry.Pinned || entry.Author != "bob" {
+		t.Fatalf("entry = %+v", entry)
+	}
+}